@@ -1,13 +1,18 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/devbydaniel/tt/config"
 	"github.com/devbydaniel/tt/internal/app"
 	"github.com/devbydaniel/tt/internal/cli"
 	"github.com/devbydaniel/tt/internal/database"
+	"github.com/devbydaniel/tt/internal/filestore"
+	"github.com/devbydaniel/tt/internal/logging"
 	"github.com/devbydaniel/tt/internal/output"
 )
 
@@ -24,25 +29,95 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
+	// An unknown language is reported with the other config problems
+	_ = output.SetLanguage(cfg.Language)
+	if path := cli.DBPathRequested(os.Args[1:]); path != "" {
+		cfg.Database = path
+	}
+
+	deps := &cli.Dependencies{
+		Config:   cfg,
+		Theme:    output.NewTheme(&cfg.Theme),
+		ReadOnly: cfg.ReadOnly || cli.ReadOnlyRequested(os.Args[1:]),
+		OpenDB:   openDB,
+	}
+	defer func() {
+		if deps.DB != nil {
+			deps.DB.Close()
+		}
+	}()
+	defer logging.Stop()
+
+	start := time.Now()
+	err = cli.NewRootCmd(deps).Execute()
+	logging.Debug("exit", "duration", time.Since(start), "err", err)
+	return err
+}
+
+// openDB opens and migrates the database and wires up the application.
+// It runs only for commands that use the database.
+func openDB(deps *cli.Dependencies) error {
+	cfg := deps.Config
+	if cfg.Storage == config.StorageFiles {
+		return openFiles(deps)
+	}
 
-	db, err := database.Open(cfg.Database)
+	source := cfg.DatabaseSource()
+	var db *database.DB
+	var err error
+	if deps.ReadOnly {
+		db, err = database.OpenReadOnly(source)
+	} else {
+		db, err = database.Open(source)
+	}
 	if err != nil {
-		return fmt.Errorf("opening database: %w", err)
+		return databaseError(source, fmt.Errorf("opening database: %w", err))
 	}
-	defer db.Close()
 
 	if err := db.Migrate(); err != nil {
-		return fmt.Errorf("running migrations: %w", err)
+		db.Close()
+		return databaseError(source, fmt.Errorf("running migrations: %w", err))
 	}
 
-	application := app.New(db)
-	theme := output.NewTheme(&cfg.Theme)
+	deps.DB = db
+	deps.App = app.NewWithOptions(db, app.ConfigOptions(cfg))
+	return nil
+}
 
-	deps := &cli.Dependencies{
-		App:    application,
-		Config: cfg,
-		Theme:  theme,
+// openFiles wires up the application on top of the task files directory.
+// There is no database, so DB stays nil.
+func openFiles(deps *cli.Dependencies) error {
+	var store *filestore.Store
+	if deps.ReadOnly {
+		store = filestore.OpenReadOnly(deps.Config.Files)
+	} else {
+		var err error
+		store, err = filestore.Open(deps.Config.Files)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", deps.Config.Files, err)
+		}
 	}
+	deps.App = app.NewWithStores(app.Stores{
+		Tasks:    store.Tasks,
+		Areas:    store.Areas,
+		Holidays: store.Holidays,
+		Comments: store.Comments,
+	}, app.ConfigOptions(deps.Config))
+	return nil
+}
 
-	return cli.NewRootCmd(deps).Execute()
+// databaseError adds recovery hints to errors about a corrupted or locked
+// database file
+func databaseError(path string, err error) error {
+	if database.IsPostgres(path) {
+		return fmt.Errorf("%s: %w", database.Redact(path), err)
+	}
+	switch {
+	case errors.Is(err, database.ErrCorrupt):
+		backups := filepath.Join(filepath.Dir(path), "backups")
+		return fmt.Errorf("%w\n%s can't be read. Inspect it with `tt db check`, restore a copy from %s (see tt maintain), or use another file with --db <path>", err, path, backups)
+	case errors.Is(err, database.ErrLocked):
+		return fmt.Errorf("%w\n%s is in use. Close other tt instances such as the TUI and retry, or use another file with --db <path>", err, path)
+	}
+	return err
 }
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -10,27 +13,124 @@ import (
 
 // ListSettings holds per-list configuration options
 type ListSettings struct {
-	Sort      string `toml:"sort"`
-	Group     string `toml:"group"`
-	HideScope bool   `toml:"hide_scope"`
+	Sort      string         `toml:"sort"`
+	Group     string         `toml:"group"`
+	GroupSort string         `toml:"group_sort"` // sort within each group of the scope grouping
+	HideScope bool           `toml:"hide_scope"`
+	Columns   []string       `toml:"columns"`  // columns to show, in order (empty = default layout)
+	Widths    map[string]int `toml:"widths"`   // maximum width per column; longer values are truncated
+	Overflow  string         `toml:"overflow"` // long titles: truncate, wrap or none
+	Details   bool           `toml:"details"`  // show description excerpt and checklist progress
+}
+
+// TaskDefaults are the [defaults] for tasks added with tt add or the TUI's
+// add form, used unless the command's flags or the form say otherwise
+type TaskDefaults struct {
+	Tags    []string `toml:"tags"`
+	Planned string   `toml:"planned"` // planned date of tasks without one, e.g. "today"
+	State   string   `toml:"state"`   // "active" (default) or "someday" for tasks without a date
+	Project string   `toml:"project"` // project of tasks added without a project or area
+}
+
+// AreaSettings are the [areas.<name>] settings of one area: how its view
+// is listed, overriding [area], and defaults for tasks added to it
+type AreaSettings struct {
+	Sort      string   `toml:"sort"`
+	Group     string   `toml:"group"`
+	HideScope bool     `toml:"hide_scope"`
+	Tags      []string `toml:"tags"`       // tags new tasks in the area get
+	PlannedIn *int     `toml:"planned_in"` // plan new tasks this many days ahead (0 = today, unset = unplanned)
+	WIPLimit  int      `toml:"wip_limit"`  // open active tasks the area should hold, with its projects (0 = no limit)
+}
+
+// ProjectSettings are the [projects.<name>] settings of one project
+type ProjectSettings struct {
+	WIPLimit int `toml:"wip_limit"` // open active tasks the project should hold (0 = no limit)
+}
+
+// MaintainSettings controls the chores run by tt maintain. Zero turns a
+// chore off.
+type MaintainSettings struct {
+	ArchiveAfterDays int `toml:"archive_after_days"` // archive tasks completed more than this many days ago
+	SomedayStaleDays int `toml:"someday_stale_days"` // archive someday tasks created more than this many days ago
+	Backups          int `toml:"backups"`            // daily backups to keep
+}
+
+// Storage backends, selected with the `storage` setting
+const (
+	StorageSQLite   = "sqlite"   // a SQLite database (default)
+	StorageFiles    = "files"    // a directory of Markdown files, one per task
+	StoragePostgres = "postgres" // a Postgres server at database_url, shared by several clients
+)
+
+// StorageModes returns the accepted values of the `storage` setting
+func StorageModes() []string {
+	return []string{StorageSQLite, StorageFiles, StoragePostgres}
 }
 
 type Config struct {
-	Database string
-	Sort     string // global default sort
-	Group    string // global default group
-	Today         ListSettings
-	Upcoming      ListSettings
-	Anytime       ListSettings
-	Someday       ListSettings
-	Log           ListSettings
+	Database    string
+	DatabaseURL string         // postgres:// URL when Storage is StoragePostgres
+	Storage     string         // StorageSQLite (default), StorageFiles or StoragePostgres
+	Files       string         // directory of task files when Storage is StorageFiles
+	Sort        string         // global default sort
+	Group       string         // global default group
+	GroupSort   string         // global default sort within scope groups (empty = the list's sort)
+	Columns     []string       // global default column layout
+	Widths      map[string]int // global default column widths
+	Overflow    string         // global default for titles wider than the terminal
+	Details     bool           // global default for showing task details under rows
+	DateGroups  []string       // headers of date grouping, in order; left-out ones merge into a neighbour (empty = all)
+
+	UpcomingDays    int    // how many days ahead Upcoming reaches (0 = unbounded)
+	DayRolloverHour int    // hour at which a new day starts (0 = midnight)
+	DailyCapacity   string // estimated work per day before warning, e.g. "6h" (empty = no limit)
+	ReadOnly        bool   // open the database read-only and reject mutating commands
+	RecurAhead      bool   // create the next occurrence of fixed recurrences up front
+	HolidayMode     string // occurrences on holidays: "skip" (default) or "shift"
+	ExpireAction    string // tasks past their expires date: "someday" (default) or "delete"
+	Language        string // language of messages and dates, e.g. "de" (empty = English)
+	Accessible      bool   // screen-reader friendly output: labeled lines, no colors or icons
+	Offline         bool   // never contact the network, e.g. for update checks
+	User            string // your name in a shared database, recorded as creator (default: login name)
+	Debug           bool   // write a debug log to tt.log in DataDir (same as --verbose)
+	DataDir         string // where tasks.db, files/ and the debug log live
+	StartView       string // view the TUI opens at, e.g. "today" or "project:Work" (empty = where it was left)
+	YankTemplate    string // Go template Y in the TUI copies a task with (empty = "#ID Title — due date — link")
+	StandupTemplate string // Go template tt standup prints its report with (empty = markdown lists)
+
+	// ScheduleSort sorts the Today, Upcoming, Anytime and Someday groups of
+	// views grouped by schedule, keyed by schedule. Groups without an entry
+	// use the view's sort.
+	ScheduleSort map[string]string
+
+	Today       ListSettings
+	Upcoming    ListSettings
+	Anytime     ListSettings
+	Someday     ListSettings
+	Log         ListSettings
 	ProjectList ListSettings
 	Project     ListSettings
 	Area        ListSettings
 	Tag         ListSettings
 	List        ListSettings // for "all" view
-	Inbox         ListSettings
+	Inbox       ListSettings
 	Theme       ThemeConfig
+	Maintain    MaintainSettings
+
+	// Defaults fills in new tasks added from the CLI and the TUI
+	Defaults TaskDefaults
+
+	// Areas holds per-area settings by area name. An area's view is looked
+	// up as "area:<name>", falling back to the [area] settings.
+	Areas map[string]AreaSettings
+
+	// Projects holds per-project settings by project name
+	Projects map[string]ProjectSettings
+
+	// Warnings collects problems found while reading the config file,
+	// such as syntax errors and unknown keys. Loading never fails on them.
+	Warnings []string
 }
 
 // ThemeConfig holds color and icon settings for output formatting
@@ -45,19 +145,58 @@ type ThemeConfig struct {
 	ID      string     `toml:"id"`      // color for task IDs (empty = inherit from muted)
 	Scope   string     `toml:"scope"`   // color for project/area column
 	Icons   IconConfig `toml:"icons"`
+	NoColor bool       `toml:"no_color"` // disable all colors (also set by NO_COLOR or --no-color)
+
+	// Presets defines additional named themes, selectable via Name
+	Presets map[string]PresetConfig `toml:"presets"`
+
+	TUI TUIStyleConfig `toml:"tui"`
+}
+
+// TUIStyleConfig holds style settings that only apply to the TUI.
+// Colors left empty fall back to the theme's accent, muted and header colors.
+type TUIStyleConfig struct {
+	Border          string `toml:"border"`           // rounded (default), normal, thick, double, or none
+	FocusedBorder   string `toml:"focused_border"`   // border color of the focused panel (default: accent)
+	UnfocusedBorder string `toml:"unfocused_border"` // border color of other panels (default: muted)
+	Selected        string `toml:"selected"`         // color of the selected item (default: accent)
+	ModalBorder     string `toml:"modal_border"`     // modal border color (default: accent)
+	ModalTitle      string `toml:"modal_title"`      // modal title color (default: header)
+}
+
+// TUIBorders lists the accepted values of TUIStyleConfig.Border
+func TUIBorders() []string {
+	return []string{"rounded", "normal", "thick", "double", "none"}
+}
+
+// PresetConfig holds the colors of a user-defined theme preset
+type PresetConfig struct {
+	Muted   string `toml:"muted"`
+	Accent  string `toml:"accent"`
+	Warning string `toml:"warning"`
+	Success string `toml:"success"`
+	Error   string `toml:"error"`
+	Header  string `toml:"header"`
+	ID      string `toml:"id"`
+	Scope   string `toml:"scope"`
 }
 
 // IconConfig holds customizable icon characters
 type IconConfig struct {
-	Planned string `toml:"planned"` // indicator for tasks planned today or earlier (default: ★)
-	Due     string `toml:"due"`     // indicator for due/overdue tasks (default: ⚑)
-	Date    string `toml:"date"`    // prefix for planned dates (default: 📅)
-	Done    string `toml:"done"`    // indicator for completed tasks (default: ✓)
+	Planned  string `toml:"planned"`  // indicator for tasks planned today or earlier (default: ★)
+	Due      string `toml:"due"`      // indicator for due/overdue tasks (default: ⚑)
+	Date     string `toml:"date"`     // prefix for planned dates (default: 📅)
+	Done     string `toml:"done"`     // indicator for completed tasks (default: ✓)
+	Canceled string `toml:"canceled"` // indicator for canceled tasks (default: ✗)
 }
 
 // GetSort returns the sort setting for a list view.
 // Priority: list-specific > global default > "" (code default)
 func (c *Config) GetSort(listName string) string {
+	if a, ok := c.areaSettings(listName); ok && a.Sort != "" {
+		return a.Sort
+	}
+	listName = listKind(listName)
 	var listSetting string
 	switch listName {
 	case "today":
@@ -89,9 +228,23 @@ func (c *Config) GetSort(listName string) string {
 	return c.Sort // global default (empty means code default)
 }
 
+// GetScheduleSort returns the sort setting for a schedule group (today,
+// upcoming, anytime or someday) of a view grouped by schedule.
+// Priority: schedule_sort entry > fallback, usually the view's sort.
+func (c *Config) GetScheduleSort(schedule, fallback string) string {
+	if s := c.ScheduleSort[schedule]; s != "" {
+		return s
+	}
+	return fallback
+}
+
 // GetGroup returns the group setting for a list view.
 // Priority: list-specific > global default > "none"
 func (c *Config) GetGroup(listName string) string {
+	if a, ok := c.areaSettings(listName); ok && a.Group != "" {
+		return a.Group
+	}
+	listName = listKind(listName)
 	var listSetting string
 	switch listName {
 	case "today":
@@ -134,9 +287,107 @@ func (c *Config) GetGroup(listName string) string {
 	return "none"
 }
 
+// GetGroupSort returns the sort within each group of a list view grouped
+// by scope. Priority: list-specific > global default > "" (the list's sort).
+func (c *Config) GetGroupSort(listName string) string {
+	if list := c.listSettings(listName); list != nil && list.GroupSort != "" {
+		return list.GroupSort
+	}
+	return c.GroupSort
+}
+
+// GetColumns returns the column layout and widths for a list view.
+// Priority: list-specific > global default > nil (code default).
+// Columns and widths are resolved independently.
+func (c *Config) GetColumns(listName string) ([]string, map[string]int) {
+	columns, widths := c.Columns, c.Widths
+	if list := c.listSettings(listName); list != nil {
+		if len(list.Columns) > 0 {
+			columns = list.Columns
+		}
+		if len(list.Widths) > 0 {
+			widths = list.Widths
+		}
+	}
+	return columns, widths
+}
+
+// GetOverflow returns how a list view handles titles wider than the terminal.
+// Priority: list-specific > global default > "truncate".
+func (c *Config) GetOverflow(listName string) string {
+	if list := c.listSettings(listName); list != nil && list.Overflow != "" {
+		return list.Overflow
+	}
+	if c.Overflow != "" {
+		return c.Overflow
+	}
+	return "truncate"
+}
+
+// GetDetails returns whether a list view shows description excerpts and
+// checklist progress under each task. Enabled globally or for the list.
+func (c *Config) GetDetails(listName string) bool {
+	if list := c.listSettings(listName); list != nil && list.Details {
+		return true
+	}
+	return c.Details
+}
+
+// areaSettings returns the [areas.<name>] block for an "area:<name>" list
+// view, if there is one
+func (c *Config) areaSettings(listName string) (AreaSettings, bool) {
+	name, ok := strings.CutPrefix(listName, "area:")
+	if !ok {
+		return AreaSettings{}, false
+	}
+	a, ok := c.Areas[name]
+	return a, ok
+}
+
+// listKind returns the list view whose settings apply to listName: "area"
+// for "area:<name>", otherwise listName itself
+func listKind(listName string) string {
+	if strings.HasPrefix(listName, "area:") {
+		return "area"
+	}
+	return listName
+}
+
+// listSettings returns the settings block for a list view, or nil if unknown
+func (c *Config) listSettings(listName string) *ListSettings {
+	switch listKind(listName) {
+	case "today":
+		return &c.Today
+	case "upcoming":
+		return &c.Upcoming
+	case "anytime":
+		return &c.Anytime
+	case "someday":
+		return &c.Someday
+	case "log":
+		return &c.Log
+	case "project-list":
+		return &c.ProjectList
+	case "project":
+		return &c.Project
+	case "area":
+		return &c.Area
+	case "tag":
+		return &c.Tag
+	case "list", "all":
+		return &c.List
+	case "inbox":
+		return &c.Inbox
+	}
+	return nil
+}
+
 // GetHideScope returns the hide_scope setting for a list view.
 func (c *Config) GetHideScope(listName string) bool {
-	switch listName {
+	if a, ok := c.areaSettings(listName); ok && a.HideScope {
+		return true
+	}
+	switch listKind(listName) {
 	case "today":
 		return c.Today.HideScope
 	case "upcoming":
@@ -163,93 +414,241 @@ func (c *Config) GetHideScope(listName string) bool {
 	return false
 }
 
+// DatabaseSource returns what the database is opened from: the Postgres URL
+// with storage = "postgres", otherwise the SQLite file
+func (c *Config) DatabaseSource() string {
+	if c.Storage == StoragePostgres {
+		return c.DatabaseURL
+	}
+	return c.Database
+}
+
 // fileConfig represents the TOML config file structure
 type fileConfig struct {
-	DataDir string `toml:"data_dir"`
-	Sort    string `toml:"sort"`
-	Group   string `toml:"group"`
-	Today         ListSettings `toml:"today"`
-	Upcoming      ListSettings `toml:"upcoming"`
-	Anytime       ListSettings `toml:"anytime"`
-	Someday       ListSettings `toml:"someday"`
-	Log           ListSettings `toml:"log"`
-	ProjectList ListSettings `toml:"project_list"`
-	Project     ListSettings `toml:"project"`
-	Area        ListSettings `toml:"area"`
-	Tag         ListSettings `toml:"tag"`
-	List        ListSettings `toml:"list"`
-	Inbox         ListSettings `toml:"inbox"`
-	Theme       ThemeConfig  `toml:"theme"`
+	DataDir     string         `toml:"data_dir"`
+	Storage     string         `toml:"storage"`
+	DatabaseURL string         `toml:"database_url"`
+	Sort        string         `toml:"sort"`
+	Group       string         `toml:"group"`
+	GroupSort   string         `toml:"group_sort"`
+	Columns     []string       `toml:"columns"`
+	Widths      map[string]int `toml:"widths"`
+	Overflow    string         `toml:"overflow"`
+	Details     bool           `toml:"details"`
+	DateGroups  []string       `toml:"date_groups"`
+
+	UpcomingDays    int    `toml:"upcoming_days"`
+	DayRolloverHour int    `toml:"day_rollover_hour"`
+	DailyCapacity   string `toml:"daily_capacity"`
+	ReadOnly        bool   `toml:"read_only"`
+	RecurAhead      bool   `toml:"recur_ahead"`
+	HolidayMode     string `toml:"holiday_mode"`
+	ExpireAction    string `toml:"expire_action"`
+	Language        string `toml:"language"`
+	Accessible      bool   `toml:"accessible"`
+	Offline         bool   `toml:"offline"`
+	User            string `toml:"user"`
+	Debug           bool   `toml:"debug"`
+	StartView       string `toml:"start_view"`
+	YankTemplate    string `toml:"yank_template"`
+	StandupTemplate string `toml:"standup_template"`
+
+	ScheduleSort map[string]string `toml:"schedule_sort"`
+
+	Today       ListSettings     `toml:"today"`
+	Upcoming    ListSettings     `toml:"upcoming"`
+	Anytime     ListSettings     `toml:"anytime"`
+	Someday     ListSettings     `toml:"someday"`
+	Log         ListSettings     `toml:"log"`
+	ProjectList ListSettings     `toml:"project_list"`
+	Project     ListSettings     `toml:"project"`
+	Area        ListSettings     `toml:"area"`
+	Tag         ListSettings     `toml:"tag"`
+	List        ListSettings     `toml:"list"`
+	Inbox       ListSettings     `toml:"inbox"`
+	Theme       ThemeConfig      `toml:"theme"`
+	Maintain    MaintainSettings `toml:"maintain"`
+
+	Defaults TaskDefaults               `toml:"defaults"`
+	Areas    map[string]AreaSettings    `toml:"areas"`
+	Projects map[string]ProjectSettings `toml:"projects"`
 }
 
+// Load reads the config file and layers TT_* environment variables on top.
+// Precedence, highest first: environment variable > config file > default.
 func Load() (*Config, error) {
-	dataDir := resolveDataDir()
+	profile := Profile()
+	if strings.ContainsAny(profile, `/\`) || profile == "." || profile == ".." {
+		return nil, fmt.Errorf("invalid TT_PROFILE %q: must be a plain name", profile)
+	}
+
+	var fc fileConfig
+	var warnings []string
+
+	if configPath := configFilePath(); configPath != "" {
+		md, err := toml.DecodeFile(configPath, &fc)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v (using defaults)", configPath, err))
+			fc = fileConfig{}
+		} else {
+			for _, key := range md.Undecoded() {
+				warnings = append(warnings, fmt.Sprintf("%s: unknown key %q", configPath, key.String()))
+			}
+		}
+	}
 
+	warnings = append(warnings, applyEnv(&fc)...)
+
+	dataDir := defaultDataDir()
+	if fc.DataDir != "" {
+		dataDir = expandTilde(fc.DataDir)
+	}
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
 		return nil, err
 	}
 
-	cfg := &Config{
-		Database: filepath.Join(dataDir, "tasks.db"),
+	return &Config{
+		Database:        filepath.Join(dataDir, "tasks.db"),
+		DatabaseURL:     fc.DatabaseURL,
+		Storage:         fc.Storage,
+		Files:           filepath.Join(dataDir, "files"),
+		Sort:            fc.Sort,
+		Group:           fc.Group,
+		GroupSort:       fc.GroupSort,
+		Columns:         fc.Columns,
+		Widths:          fc.Widths,
+		Overflow:        fc.Overflow,
+		Details:         fc.Details,
+		DateGroups:      fc.DateGroups,
+		UpcomingDays:    fc.UpcomingDays,
+		DayRolloverHour: fc.DayRolloverHour,
+		DailyCapacity:   fc.DailyCapacity,
+		ReadOnly:        fc.ReadOnly,
+		RecurAhead:      fc.RecurAhead,
+		HolidayMode:     fc.HolidayMode,
+		ExpireAction:    fc.ExpireAction,
+		Language:        fc.Language,
+		Accessible:      fc.Accessible,
+		Offline:         fc.Offline,
+		User:            userName(fc.User),
+		Debug:           fc.Debug,
+		DataDir:         dataDir,
+		StartView:       fc.StartView,
+		YankTemplate:    fc.YankTemplate,
+		StandupTemplate: fc.StandupTemplate,
+		ScheduleSort:    fc.ScheduleSort,
+		Today:           fc.Today,
+		Upcoming:        fc.Upcoming,
+		Anytime:         fc.Anytime,
+		Someday:         fc.Someday,
+		Log:             fc.Log,
+		ProjectList:     fc.ProjectList,
+		Project:         fc.Project,
+		Area:            fc.Area,
+		Tag:             fc.Tag,
+		List:            fc.List,
+		Inbox:           fc.Inbox,
+		Theme:           fc.Theme,
+		Maintain:        fc.Maintain,
+		Defaults:        fc.Defaults,
+		Areas:           fc.Areas,
+		Projects:        fc.Projects,
+		Warnings:        warnings,
+	}, nil
+}
+
+// userName returns the configured user name, or the login name if there
+// is none
+func userName(configured string) string {
+	if configured = strings.TrimSpace(configured); configured != "" {
+		return configured
 	}
+	for _, env := range []string{"USER", "USERNAME"} {
+		if name := os.Getenv(env); name != "" {
+			return name
+		}
+	}
+	return ""
+}
 
-	if configPath := configFilePath(); configPath != "" {
-		var fc fileConfig
-		if _, err := toml.DecodeFile(configPath, &fc); err == nil {
-			cfg.Sort = fc.Sort
-			cfg.Group = fc.Group
-			cfg.Today = fc.Today
-			cfg.Upcoming = fc.Upcoming
-			cfg.Anytime = fc.Anytime
-			cfg.Someday = fc.Someday
-			cfg.Log = fc.Log
-			cfg.ProjectList = fc.ProjectList
-			cfg.Project = fc.Project
-			cfg.Area = fc.Area
-			cfg.Tag = fc.Tag
-			cfg.List = fc.List
-			cfg.Inbox = fc.Inbox
-			cfg.Theme = fc.Theme
+// Profile returns the active profile name from TT_PROFILE ("" = default).
+// A profile has its own config file and data directory.
+func Profile() string {
+	return strings.TrimSpace(os.Getenv("TT_PROFILE"))
+}
+
+// Dir returns the directory holding config.toml:
+// $XDG_CONFIG_HOME/tt, %APPDATA%\tt on Windows, otherwise ~/.config/tt.
+// Returns "" if no home directory can be determined.
+func Dir() string {
+	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
+		return filepath.Join(xdgConfig, "tt")
+	}
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "tt")
 		}
 	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "tt")
+	}
+	return ""
+}
 
-	return cfg, nil
+// Path returns where the config file lives, whether or not it exists.
+// Profiles use profiles/<name>.toml next to the default config.toml.
+func Path() string {
+	dir := Dir()
+	if dir == "" {
+		return ""
+	}
+	if profile := Profile(); profile != "" {
+		return filepath.Join(dir, "profiles", profile+".toml")
+	}
+	return filepath.Join(dir, "config.toml")
 }
 
-// resolveDataDir determines the data directory with priority:
-// 1. TT_DATA_DIR environment variable
-// 2. Config file (~/.config/tt/config.toml)
-// 3. Default (~/.local/share/tt)
-func resolveDataDir() string {
-	// Priority 1: Environment variable
-	if envDir := os.Getenv("TT_DATA_DIR"); envDir != "" {
-		return expandTilde(envDir)
+// Init writes the commented Template to Path. An existing file is only
+// replaced when force is set.
+func Init(force bool) (string, error) {
+	return write(Template, force)
+}
+
+// InitWithTheme writes the Template to Path like Init, with the theme
+// setting enabled and set to the given preset. It never replaces a file.
+func InitWithTheme(theme string) (string, error) {
+	return write(StarterTemplate(theme), false)
+}
+
+// StarterTemplate returns the Template with [theme] enabled and its name set
+func StarterTemplate(theme string) string {
+	return strings.Replace(Template, "# [theme]\n# name = \"dracula\"", fmt.Sprintf("[theme]\nname = %q", theme), 1)
+}
+
+// write writes contents to Path, replacing an existing file only when force
+// is set
+func write(contents string, force bool) (string, error) {
+	path := Path()
+	if path == "" {
+		return "", errors.New("cannot determine config directory")
 	}
 
-	// Priority 2: Config file
-	if configPath := configFilePath(); configPath != "" {
-		var fc fileConfig
-		if _, err := toml.DecodeFile(configPath, &fc); err == nil && fc.DataDir != "" {
-			return expandTilde(fc.DataDir)
-		}
+	if _, err := os.Stat(path); err == nil && !force {
+		return path, fmt.Errorf("config file already exists: %s (use --force to overwrite)", path)
 	}
 
-	// Priority 3: Default
-	return defaultDataDir()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return path, err
+	}
+	return path, os.WriteFile(path, []byte(contents), 0644)
 }
 
 // configFilePath returns the config file path if it exists
 func configFilePath() string {
-	var configDir string
-	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
-		configDir = filepath.Join(xdgConfig, "tt")
-	} else if home, err := os.UserHomeDir(); err == nil {
-		configDir = filepath.Join(home, ".config", "tt")
-	} else {
+	configPath := Path()
+	if configPath == "" {
 		return ""
 	}
-
-	configPath := filepath.Join(configDir, "config.toml")
 	if _, err := os.Stat(configPath); err == nil {
 		return configPath
 	}
@@ -258,7 +657,7 @@ func configFilePath() string {
 
 // expandTilde expands ~ to the user's home directory
 func expandTilde(path string) string {
-	if strings.HasPrefix(path, "~/") {
+	if strings.HasPrefix(path, "~/") || (runtime.GOOS == "windows" && strings.HasPrefix(path, `~\`)) {
 		if home, err := os.UserHomeDir(); err == nil {
 			return filepath.Join(home, path[2:])
 		}
@@ -266,11 +665,27 @@ func expandTilde(path string) string {
 	return path
 }
 
+// defaultDataDir returns $XDG_DATA_HOME/tt, %APPDATA%\tt on Windows, or
+// ~/.local/share/tt, with profiles/<name> appended for a non-default profile
 func defaultDataDir() string {
+	dir := baseDataDir()
+	if profile := Profile(); profile != "" {
+		dir = filepath.Join(dir, "profiles", profile)
+	}
+	return dir
+}
+
+func baseDataDir() string {
 	if xdgData := os.Getenv("XDG_DATA_HOME"); xdgData != "" {
 		return filepath.Join(xdgData, "tt")
 	}
 
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "tt")
+		}
+	}
+
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return filepath.Join(".", ".tt")
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/BurntSushi/toml"
 )
 
 func TestConfig_GetSort(t *testing.T) {
@@ -161,3 +166,351 @@ func TestConfig_GetGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_GetColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		listName    string
+		wantColumns []string
+		wantWidths  map[string]int
+	}{
+		{
+			name:     "empty config returns nil",
+			config:   Config{},
+			listName: "today",
+		},
+		{
+			name:        "global default used",
+			config:      Config{Columns: []string{"id", "title"}, Widths: map[string]int{"title": 30}},
+			listName:    "today",
+			wantColumns: []string{"id", "title"},
+			wantWidths:  map[string]int{"title": 30},
+		},
+		{
+			name: "list override takes precedence",
+			config: Config{
+				Columns: []string{"id", "title"},
+				Today:   ListSettings{Columns: []string{"title", "due"}},
+			},
+			listName:    "today",
+			wantColumns: []string{"title", "due"},
+		},
+		{
+			name: "widths resolved independently of columns",
+			config: Config{
+				Widths: map[string]int{"title": 30},
+				Today:  ListSettings{Columns: []string{"title", "due"}},
+			},
+			listName:    "today",
+			wantColumns: []string{"title", "due"},
+			wantWidths:  map[string]int{"title": 30},
+		},
+		{
+			name:        "unknown list falls back to global",
+			config:      Config{Columns: []string{"title"}},
+			listName:    "week",
+			wantColumns: []string{"title"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, widths := tt.config.GetColumns(tt.listName)
+			if !reflect.DeepEqual(columns, tt.wantColumns) {
+				t.Errorf("GetColumns(%q) columns = %v, want %v", tt.listName, columns, tt.wantColumns)
+			}
+			if !reflect.DeepEqual(widths, tt.wantWidths) {
+				t.Errorf("GetColumns(%q) widths = %v, want %v", tt.listName, widths, tt.wantWidths)
+			}
+		})
+	}
+}
+
+func TestConfig_GetOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		listName string
+		want     string
+	}{
+		{"empty config returns truncate", Config{}, "today", "truncate"},
+		{"global default used", Config{Overflow: "wrap"}, "today", "wrap"},
+		{"list override takes precedence", Config{Overflow: "wrap", Today: ListSettings{Overflow: "none"}}, "today", "none"},
+		{"other lists keep global", Config{Overflow: "wrap", Today: ListSettings{Overflow: "none"}}, "upcoming", "wrap"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.GetOverflow(tt.listName); got != tt.want {
+				t.Errorf("GetOverflow(%q) = %q, want %q", tt.listName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_GetScheduleSort(t *testing.T) {
+	cfg := &Config{ScheduleSort: map[string]string{"today": "due"}}
+	if got := cfg.GetScheduleSort("today", "created"); got != "due" {
+		t.Errorf("GetScheduleSort(today) = %q, want %q", got, "due")
+	}
+	if got := cfg.GetScheduleSort("upcoming", "created"); got != "created" {
+		t.Errorf("GetScheduleSort(upcoming) = %q, want the fallback %q", got, "created")
+	}
+}
+
+func TestConfig_GetGroupSort(t *testing.T) {
+	cfg := &Config{GroupSort: "due", Project: ListSettings{GroupSort: "title"}}
+	if got := cfg.GetGroupSort("project"); got != "title" {
+		t.Errorf("GetGroupSort(project) = %q, want the list's %q", got, "title")
+	}
+	if got := cfg.GetGroupSort("today"); got != "due" {
+		t.Errorf("GetGroupSort(today) = %q, want the global %q", got, "due")
+	}
+}
+
+func TestConfig_AreaSettings(t *testing.T) {
+	cfg := &Config{
+		Sort:  "title",
+		Area:  ListSettings{Group: "scope", HideScope: true},
+		Areas: map[string]AreaSettings{"Work": {Sort: "due", Group: "date"}},
+	}
+	if got := cfg.GetSort("area:Work"); got != "due" {
+		t.Errorf("GetSort(area:Work) = %q, want the area's %q", got, "due")
+	}
+	if got := cfg.GetGroup("area:Work"); got != "date" {
+		t.Errorf("GetGroup(area:Work) = %q, want the area's %q", got, "date")
+	}
+	if !cfg.GetHideScope("area:Work") {
+		t.Error("GetHideScope(area:Work) = false, want [area]'s true")
+	}
+	// Areas without a block use [area], then the global default
+	if got := cfg.GetGroup("area:Home"); got != "scope" {
+		t.Errorf("GetGroup(area:Home) = %q, want %q", got, "scope")
+	}
+	if got := cfg.GetSort("area:Home"); got != "title" {
+		t.Errorf("GetSort(area:Home) = %q, want %q", got, "title")
+	}
+}
+
+func TestConfig_GetDetails(t *testing.T) {
+	if (&Config{}).GetDetails("today") {
+		t.Error("GetDetails() = true for empty config")
+	}
+	cfg := &Config{Today: ListSettings{Details: true}}
+	if !cfg.GetDetails("today") || cfg.GetDetails("upcoming") {
+		t.Error("list setting should only enable details for that list")
+	}
+	cfg = &Config{Details: true}
+	if !cfg.GetDetails("upcoming") {
+		t.Error("global setting should enable details for every list")
+	}
+}
+
+func TestPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	want := filepath.Join(dir, "tt", "config.toml")
+	if got := Path(); got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+}
+
+func TestInit(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	path, err := Init(false)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	// The template must be valid TOML that leaves every setting at its default
+	var fc fileConfig
+	if _, err := toml.DecodeFile(path, &fc); err != nil {
+		t.Fatalf("template does not parse: %v", err)
+	}
+	if !reflect.DeepEqual(fc, fileConfig{}) {
+		t.Errorf("template sets values: %+v", fc)
+	}
+
+	if _, err := Init(false); err == nil {
+		t.Error("expected error when config already exists")
+	}
+	if _, err := Init(true); err != nil {
+		t.Errorf("Init(force) error = %v", err)
+	}
+}
+
+func TestInitWithTheme(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("TT_DATA_DIR", t.TempDir())
+
+	if _, err := InitWithTheme("nord"); err != nil {
+		t.Fatalf("InitWithTheme() error = %v", err)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Theme.Name != "nord" || len(cfg.Warnings) > 0 {
+		t.Errorf("Theme.Name = %q, warnings %v; want nord and none", cfg.Theme.Name, cfg.Warnings)
+	}
+
+	if _, err := InitWithTheme("gruvbox"); err == nil {
+		t.Error("expected error when config already exists")
+	}
+}
+
+func TestLoadWarnsOnUnknownKeys(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("TT_DATA_DIR", t.TempDir())
+
+	if err := os.MkdirAll(filepath.Join(configHome, "tt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "sort = \"title\"\ncolour = \"red\"\n\n[today]\ngroupp = \"date\"\n"
+	if err := os.WriteFile(Path(), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Sort != "title" {
+		t.Errorf("Sort = %q, want title", cfg.Sort)
+	}
+	if len(cfg.Warnings) != 2 {
+		t.Errorf("got %d warnings, want 2: %v", len(cfg.Warnings), cfg.Warnings)
+	}
+}
+
+func TestLoadWarnsOnSyntaxError(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("TT_DATA_DIR", t.TempDir())
+
+	if err := os.MkdirAll(filepath.Join(configHome, "tt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(Path(), []byte("sort = \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Warnings) != 1 {
+		t.Errorf("got %d warnings, want 1: %v", len(cfg.Warnings), cfg.Warnings)
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	configHome := t.TempDir()
+	dataDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	if err := os.MkdirAll(filepath.Join(configHome, "tt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "sort = \"title\"\ngroup = \"scope\"\n\n[today]\ngroup = \"scope\"\n"
+	if err := os.WriteFile(Path(), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("TT_DATA_DIR", dataDir)
+	t.Setenv("TT_SORT", "due")
+	t.Setenv("TT_TODAY_GROUP", "date")
+	t.Setenv("TT_THEME", "nord")
+	t.Setenv("TT_UPCOMING_DAYS", "7")
+	t.Setenv("TT_READ_ONLY", "true")
+	t.Setenv("TT_DAY_ROLLOVER_HOUR", "late")
+	t.Setenv("TT_TODAY_COLUMNS", "id, title,due")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Sort != "due" {
+		t.Errorf("Sort = %q, want env override due", cfg.Sort)
+	}
+	if cfg.Group != "scope" {
+		t.Errorf("Group = %q, want file value scope", cfg.Group)
+	}
+	if cfg.Today.Group != "date" {
+		t.Errorf("Today.Group = %q, want date", cfg.Today.Group)
+	}
+	if want := []string{"id", "title", "due"}; !reflect.DeepEqual(cfg.Today.Columns, want) {
+		t.Errorf("Today.Columns = %v, want %v", cfg.Today.Columns, want)
+	}
+	if cfg.Theme.Name != "nord" {
+		t.Errorf("Theme.Name = %q, want nord", cfg.Theme.Name)
+	}
+	if cfg.UpcomingDays != 7 || !cfg.ReadOnly {
+		t.Errorf("UpcomingDays = %d, ReadOnly = %v, want 7, true", cfg.UpcomingDays, cfg.ReadOnly)
+	}
+	if cfg.Database != filepath.Join(dataDir, "tasks.db") {
+		t.Errorf("Database = %q, want under %q", cfg.Database, dataDir)
+	}
+	if len(cfg.Warnings) != 1 {
+		t.Errorf("got warnings %v, want one for TT_DAY_ROLLOVER_HOUR", cfg.Warnings)
+	}
+}
+
+func TestProfile(t *testing.T) {
+	configHome := t.TempDir()
+	dataHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	t.Setenv("TT_PROFILE", "work")
+
+	if want := filepath.Join(configHome, "tt", "profiles", "work.toml"); Path() != want {
+		t.Errorf("Path() = %q, want %q", Path(), want)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := filepath.Join(dataHome, "tt", "profiles", "work", "tasks.db"); cfg.Database != want {
+		t.Errorf("Database = %q, want %q", cfg.Database, want)
+	}
+
+	t.Setenv("TT_PROFILE", "../escape")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for profile with path separator")
+	}
+}
+
+func TestLoadThemePresets(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("TT_DATA_DIR", t.TempDir())
+
+	if err := os.MkdirAll(filepath.Join(configHome, "tt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "[theme]\nname = \"mine\"\n\n[theme.presets.mine]\naccent = \"#ff00ff\"\nmuted = \"8\"\n"
+	if err := os.WriteFile(Path(), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", cfg.Warnings)
+	}
+	preset, ok := cfg.Theme.Presets["mine"]
+	if !ok {
+		t.Fatal("preset mine not loaded")
+	}
+	if preset.Accent != "#ff00ff" || preset.Muted != "8" {
+		t.Errorf("preset = %+v", preset)
+	}
+}
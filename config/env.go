@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// envAliases maps short environment variable names to the generic ones
+// derived from the TOML keys
+var envAliases = map[string]string{
+	"TT_THEME": "TT_THEME_NAME",
+}
+
+// EnvName returns the environment variable that overrides a TOML key path,
+// e.g. "today.group" -> "TT_TODAY_GROUP"
+func EnvName(keyPath string) string {
+	return "TT_" + strings.ToUpper(strings.ReplaceAll(keyPath, ".", "_"))
+}
+
+// applyEnv overrides fc with TT_* environment variables. Every TOML key has
+// one, derived from its path (see EnvName), so new settings get an override
+// without extra code. Lists are comma-separated; tables of arbitrary keys
+// (presets, widths) can only be set in the file. Values that don't parse
+// are reported and skipped.
+func applyEnv(fc *fileConfig) []string {
+	return applyEnvFields(reflect.ValueOf(fc).Elem(), "")
+}
+
+func applyEnvFields(v reflect.Value, keyPrefix string) []string {
+	var warnings []string
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("toml")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		keyPath := tag
+		if keyPrefix != "" {
+			keyPath = keyPrefix + "." + tag
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			warnings = append(warnings, applyEnvFields(field, keyPath)...)
+			continue
+		}
+
+		name := EnvName(keyPath)
+		value, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s: invalid boolean %q", name, value))
+				continue
+			}
+			field.SetBool(b)
+		case reflect.Slice:
+			if field.Type().Elem().Kind() != reflect.String {
+				continue
+			}
+			var items []string
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			field.Set(reflect.ValueOf(items))
+		case reflect.Int:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s: invalid number %q", name, value))
+				continue
+			}
+			field.SetInt(int64(n))
+		}
+	}
+
+	return warnings
+}
+
+// lookupEnv reads an environment variable, falling back to its alias
+func lookupEnv(name string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+	for alias, target := range envAliases {
+		if target == name {
+			if value, ok := os.LookupEnv(alias); ok {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
@@ -1,14 +1,32 @@
 package app
 
 import (
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/database"
 	"github.com/devbydaniel/tt/internal/domain/area"
 	areausecases "github.com/devbydaniel/tt/internal/domain/area/usecases"
+	"github.com/devbydaniel/tt/internal/domain/comment"
+	commentusecases "github.com/devbydaniel/tt/internal/domain/comment/usecases"
+	"github.com/devbydaniel/tt/internal/domain/holiday"
+	holidayusecases "github.com/devbydaniel/tt/internal/domain/holiday/usecases"
 	"github.com/devbydaniel/tt/internal/domain/task"
 	taskusecases "github.com/devbydaniel/tt/internal/domain/task/usecases"
+	"github.com/devbydaniel/tt/internal/domain/token"
+	tokenusecases "github.com/devbydaniel/tt/internal/domain/token/usecases"
 )
 
 type App struct {
+	// Clock is the clock the use cases read the current time from
+	Clock clock.Clock
+	// Schedule decides what counts as today and upcoming
+	Schedule task.ScheduleSettings
+
+	// Services for the CLI and TUI. They wrap the use cases below by
+	// default; tests can replace them with fakes.
+	Tasks    task.Service
+	Projects task.ProjectService
+	Areas    area.Service
+
 	// Area use cases
 	CreateArea    *areausecases.CreateArea
 	ListAreas     *areausecases.ListAreas
@@ -16,6 +34,22 @@ type App struct {
 	DeleteArea    *areausecases.DeleteArea
 	RenameArea    *areausecases.RenameArea
 
+	// Holiday use cases
+	ListHolidays   *holidayusecases.ListHolidays
+	AddHoliday     *holidayusecases.AddHoliday
+	RemoveHoliday  *holidayusecases.RemoveHoliday
+	ImportHolidays *holidayusecases.ImportHolidays
+
+	// Comment use cases
+	AddComment   *commentusecases.AddComment
+	ListComments *commentusecases.ListComments
+
+	// API token use cases (nil without a database)
+	CreateToken *tokenusecases.CreateToken
+	ListTokens  *tokenusecases.ListTokens
+	RevokeToken *tokenusecases.RevokeToken
+	VerifyToken *tokenusecases.VerifyToken
+
 	// Project use cases (projects are now tasks with task_type='project')
 	CreateProject        *taskusecases.CreateProject
 	ListProjects         *taskusecases.ListProjects
@@ -25,16 +59,36 @@ type App struct {
 
 	// Task use cases
 	CreateTask         *taskusecases.CreateTask
+	UpsertTask         *taskusecases.UpsertTask
 	ListTasks          *taskusecases.ListTasks
+	ListWeek           *taskusecases.ListWeek
+	ListDue            *taskusecases.ListDue
+	SuggestNext        *taskusecases.SuggestNext
 	GetTask            *taskusecases.GetTask
 	CompleteTasks      *taskusecases.CompleteTasks
 	UncompleteTasks    *taskusecases.UncompleteTasks
+	CancelTasks        *taskusecases.CancelTasks
+	SetCompletionNote  *taskusecases.SetCompletionNote
+	SplitTask          *taskusecases.SplitTask
 	DeleteTasks        *taskusecases.DeleteTasks
 	ListCompletedTasks *taskusecases.ListCompletedTasks
 	DeferTask          *taskusecases.DeferTask
 	ActivateTask       *taskusecases.ActivateTask
 	SetPlannedDate     *taskusecases.SetPlannedDate
 	SetDueDate         *taskusecases.SetDueDate
+	SetEstimate        *taskusecases.SetEstimate
+	SetContext         *taskusecases.SetContext
+	SetAssignee        *taskusecases.SetAssignee
+	SetLocation        *taskusecases.SetLocation
+	SetFlagged         *taskusecases.SetFlagged
+	OrderToday         *taskusecases.OrderToday
+	SetExpires         *taskusecases.SetExpires
+	SetTimeBlock       *taskusecases.SetTimeBlock
+	PauseForVacation   *taskusecases.PauseForVacation
+	SetDependencies    *taskusecases.SetDependencies
+	PlanProject        *taskusecases.PlanProject
+	ExpireTasks        *taskusecases.ExpireTasks
+	ArchiveTasks       *taskusecases.ArchiveTasks
 	SetTaskProject     *taskusecases.SetTaskProject
 	SetTaskArea        *taskusecases.SetTaskArea
 	SetTaskTitle       *taskusecases.SetTaskTitle
@@ -43,16 +97,91 @@ type App struct {
 	PauseRecurrence    *taskusecases.PauseRecurrence
 	ResumeRecurrence   *taskusecases.ResumeRecurrence
 	SetRecurrenceEnd   *taskusecases.SetRecurrenceEnd
+	SetRecurrenceCount *taskusecases.SetRecurrenceCount
+	KeepRecurTemplate  *taskusecases.KeepRecurTemplate
+	PropagateRecurEdit *taskusecases.PropagateRecurEdit
 	AddTag             *taskusecases.AddTag
 	RemoveTag          *taskusecases.RemoveTag
 	ListTags           *taskusecases.ListTags
+	ListLocations      *taskusecases.ListLocations
+	SetTagColor        *taskusecases.SetTagColor
+	ListTagColors      *taskusecases.ListTagColors
 	SetTags            *taskusecases.SetTags
+	CountTasks         *taskusecases.CountTasks
+	CountWIP           *taskusecases.CountWIP
+	LintTasks          *taskusecases.LintTasks
+
+	stores Stores // kept for Reconfigure
+}
+
+// Options holds settings that tune use case behavior
+type Options struct {
+	Schedule   task.ScheduleSettings
+	Recurrence task.RecurrenceSettings
+	// ExpireAction is what happens to tasks past their expires date:
+	// task.ExpireSomeday (default) or task.ExpireDelete
+	ExpireAction string
+	// Clock tells the current time; nil means the system clock
+	Clock clock.Clock
+	// User is recorded as the creator of new tasks (empty = unknown)
+	User string
+	// AreaDefaults are applied to new tasks in an area, keyed by area name
+	AreaDefaults map[string]task.AreaDefaults
+	// WIPLimits are the soft limits on open active tasks of areas and
+	// projects
+	WIPLimits task.WIPLimits
+}
+
+// Reconfigure rewires the use cases with new settings on the same stores,
+// e.g. after the config file is reloaded. a is updated in place, so
+// everything holding it sees the new settings. A nil opts.Clock keeps the
+// current clock.
+func (a *App) Reconfigure(opts Options) {
+	if opts.Clock == nil {
+		opts.Clock = a.Clock
+	}
+	*a = *NewWithStores(a.stores, opts)
 }
 
 func New(db *database.DB) *App {
-	// Create repositories
-	areaRepo := area.NewRepository(db)
-	taskRepo := task.NewRepository(db)
+	return NewWithOptions(db, Options{})
+}
+
+// Stores are where the app keeps its data
+type Stores struct {
+	Tasks    task.Store
+	Areas    area.Store
+	Holidays holiday.Store
+	Comments comment.Store
+	Tokens   token.Store // nil if the backend can't keep API tokens
+}
+
+// NewWithOptions wires up the app on top of the SQLite database
+func NewWithOptions(db *database.DB, opts Options) *App {
+	return NewWithStores(Stores{
+		Tasks:    task.NewRepository(db),
+		Areas:    area.NewRepository(db),
+		Holidays: holiday.NewRepository(db),
+		Comments: comment.NewRepository(db),
+		Tokens:   token.NewRepository(db),
+	}, opts)
+}
+
+// NewWithStores wires up the app on top of the given stores, e.g. another
+// storage backend
+func NewWithStores(stores Stores, opts Options) *App {
+	clk := opts.Clock
+	if clk == nil {
+		clk = clock.System
+	}
+
+	areaRepo := stores.Areas
+	taskRepo := stores.Tasks
+	holidayRepo := stores.Holidays
+	// The task store's schedule filters need the clock too
+	if c, ok := taskRepo.(interface{ SetClock(clock.Clock) }); ok {
+		c.SetClock(clk)
+	}
 
 	// Create area use cases (no cross-domain dependencies)
 	createArea := &areausecases.CreateArea{Repo: areaRepo}
@@ -61,36 +190,108 @@ func New(db *database.DB) *App {
 	deleteArea := &areausecases.DeleteArea{Repo: areaRepo}
 	renameArea := &areausecases.RenameArea{Repo: areaRepo}
 
+	// Create holiday use cases (no cross-domain dependencies)
+	listHolidays := &holidayusecases.ListHolidays{Repo: holidayRepo}
+	addHoliday := &holidayusecases.AddHoliday{Repo: holidayRepo}
+	removeHoliday := &holidayusecases.RemoveHoliday{Repo: holidayRepo}
+	importHolidays := &holidayusecases.ImportHolidays{Repo: holidayRepo}
+
 	// Create project use cases (projects are now tasks with task_type='project')
 	getProjectByName := &taskusecases.GetProjectByName{Repo: taskRepo}
 	createProject := &taskusecases.CreateProject{
 		Repo:       taskRepo,
 		AreaLookup: getAreaByName,
+		Clock:      clk,
 	}
 	listProjects := &taskusecases.ListProjects{Repo: taskRepo}
 	listAllProjects := &taskusecases.ListAllProjects{Repo: taskRepo}
 	listProjectsWithArea := &taskusecases.ListProjectsWithArea{Repo: taskRepo}
 
 	// Create task use cases
+	countWIP := &taskusecases.CountWIP{
+		Repo:          taskRepo,
+		ProjectLookup: getProjectByName,
+		AreaLookup:    getAreaByName,
+		Limits:        opts.WIPLimits,
+	}
 	createTask := &taskusecases.CreateTask{
 		Repo:          taskRepo,
 		ProjectLookup: getProjectByName,
 		AreaLookup:    getAreaByName,
+		Recurrence:    opts.Recurrence,
+		Holidays:      listHolidays,
+		Clock:         clk,
+		User:          opts.User,
+		AreaDefaults:  opts.AreaDefaults,
+		Schedule:      opts.Schedule,
+		WIP:           countWIP,
+	}
+	upsertTask := &taskusecases.UpsertTask{
+		Repo:          taskRepo,
+		Create:        createTask,
+		ProjectLookup: getProjectByName,
+		AreaLookup:    getAreaByName,
 	}
 	listTasks := &taskusecases.ListTasks{
 		Repo:          taskRepo,
 		ProjectLookup: getProjectByName,
 		AreaLookup:    getAreaByName,
+		Schedule:      opts.Schedule,
+		Clock:         clk,
 	}
+	listWeek := &taskusecases.ListWeek{Repo: taskRepo, Schedule: opts.Schedule, Clock: clk}
+	listDue := &taskusecases.ListDue{Repo: taskRepo}
+	suggestNext := &taskusecases.SuggestNext{Repo: taskRepo, Schedule: opts.Schedule, Clock: clk}
 	getTask := &taskusecases.GetTask{Repo: taskRepo}
-	completeTasks := &taskusecases.CompleteTasks{Repo: taskRepo}
+	completeTasks := &taskusecases.CompleteTasks{
+		Repo:       taskRepo,
+		Recurrence: opts.Recurrence,
+		Holidays:   listHolidays,
+		Clock:      clk,
+	}
 	uncompleteTasks := &taskusecases.UncompleteTasks{Repo: taskRepo}
+	cancelTasks := &taskusecases.CancelTasks{Repo: taskRepo, Complete: completeTasks, Clock: clk}
+	setCompletionNote := &taskusecases.SetCompletionNote{Repo: taskRepo}
+	splitTask := &taskusecases.SplitTask{
+		Repo:     taskRepo,
+		Complete: completeTasks,
+		Clock:    clk,
+		User:     opts.User,
+	}
 	deleteTasks := &taskusecases.DeleteTasks{Repo: taskRepo}
-	listCompletedTasks := &taskusecases.ListCompletedTasks{Repo: taskRepo}
+	listCompletedTasks := &taskusecases.ListCompletedTasks{
+		Repo:          taskRepo,
+		ProjectLookup: getProjectByName,
+		AreaLookup:    getAreaByName,
+	}
 	deferTask := &taskusecases.DeferTask{Repo: taskRepo}
 	activateTask := &taskusecases.ActivateTask{Repo: taskRepo}
 	setPlannedDate := &taskusecases.SetPlannedDate{Repo: taskRepo}
 	setDueDate := &taskusecases.SetDueDate{Repo: taskRepo}
+	setEstimate := &taskusecases.SetEstimate{Repo: taskRepo}
+	setContext := &taskusecases.SetContext{Repo: taskRepo}
+	setAssignee := &taskusecases.SetAssignee{Repo: taskRepo}
+	setLocation := &taskusecases.SetLocation{Repo: taskRepo}
+	setFlagged := &taskusecases.SetFlagged{Repo: taskRepo}
+	orderToday := &taskusecases.OrderToday{Repo: taskRepo, Schedule: opts.Schedule, Clock: clk}
+	archiveTasks := &taskusecases.ArchiveTasks{Repo: taskRepo}
+	setExpires := &taskusecases.SetExpires{Repo: taskRepo}
+	setTimeBlock := &taskusecases.SetTimeBlock{Repo: taskRepo}
+	pauseForVacation := &taskusecases.PauseForVacation{Repo: taskRepo, Schedule: opts.Schedule, Clock: clk}
+	setDependencies := &taskusecases.SetDependencies{Repo: taskRepo}
+	planProject := &taskusecases.PlanProject{
+		Repo:          taskRepo,
+		ProjectLookup: getProjectByName,
+		Holidays:      listHolidays,
+		Schedule:      opts.Schedule,
+		Clock:         clk,
+	}
+	expireTasks := &taskusecases.ExpireTasks{
+		Repo:     taskRepo,
+		Schedule: opts.Schedule,
+		Action:   opts.ExpireAction,
+		Clock:    clk,
+	}
 	setTaskProject := &taskusecases.SetTaskProject{
 		Repo:          taskRepo,
 		ProjectLookup: getProjectByName,
@@ -101,16 +302,42 @@ func New(db *database.DB) *App {
 	}
 	setTaskTitle := &taskusecases.SetTaskTitle{Repo: taskRepo}
 	setTaskDescription := &taskusecases.SetTaskDescription{Repo: taskRepo}
-	setRecurrence := &taskusecases.SetRecurrence{Repo: taskRepo}
+	setRecurrence := &taskusecases.SetRecurrence{
+		Repo:       taskRepo,
+		Recurrence: opts.Recurrence,
+		Holidays:   listHolidays,
+		Clock:      clk,
+	}
 	pauseRecurrence := &taskusecases.PauseRecurrence{Repo: taskRepo}
 	resumeRecurrence := &taskusecases.ResumeRecurrence{Repo: taskRepo}
 	setRecurrenceEnd := &taskusecases.SetRecurrenceEnd{Repo: taskRepo}
+	setRecurrenceCount := &taskusecases.SetRecurrenceCount{Repo: taskRepo}
+	keepRecurTemplate := &taskusecases.KeepRecurTemplate{Repo: taskRepo}
+	propagateRecurEdit := &taskusecases.PropagateRecurEdit{Repo: taskRepo}
 	addTag := &taskusecases.AddTag{Repo: taskRepo}
 	removeTag := &taskusecases.RemoveTag{Repo: taskRepo}
 	listTagsUC := &taskusecases.ListTags{Repo: taskRepo}
+	listLocations := &taskusecases.ListLocations{Repo: taskRepo}
+	setTagColor := &taskusecases.SetTagColor{Repo: taskRepo}
+	listTagColors := &taskusecases.ListTagColors{Repo: taskRepo}
+	countTasks := &taskusecases.CountTasks{Repo: taskRepo}
+	lintTasks := &taskusecases.LintTasks{Repo: taskRepo, Areas: listAreas, Clock: clk}
+
+	// Create comment use cases
+	addComment := &commentusecases.AddComment{
+		Repo:  stores.Comments,
+		Tasks: getTask,
+		Clock: clk,
+		User:  opts.User,
+	}
+	listComments := &commentusecases.ListComments{Repo: stores.Comments}
 	setTags := &taskusecases.SetTags{Repo: taskRepo}
 
-	return &App{
+	a := &App{
+		Clock:    clk,
+		Schedule: opts.Schedule,
+		stores:   stores,
+
 		// Area
 		CreateArea:    createArea,
 		ListAreas:     listAreas,
@@ -118,6 +345,16 @@ func New(db *database.DB) *App {
 		DeleteArea:    deleteArea,
 		RenameArea:    renameArea,
 
+		// Holiday
+		ListHolidays:   listHolidays,
+		AddHoliday:     addHoliday,
+		RemoveHoliday:  removeHoliday,
+		ImportHolidays: importHolidays,
+
+		// Comment
+		AddComment:   addComment,
+		ListComments: listComments,
+
 		// Project (tasks with task_type='project')
 		CreateProject:        createProject,
 		ListProjects:         listProjects,
@@ -127,16 +364,36 @@ func New(db *database.DB) *App {
 
 		// Task
 		CreateTask:         createTask,
+		UpsertTask:         upsertTask,
 		ListTasks:          listTasks,
+		ListWeek:           listWeek,
+		ListDue:            listDue,
+		SuggestNext:        suggestNext,
 		GetTask:            getTask,
 		CompleteTasks:      completeTasks,
 		UncompleteTasks:    uncompleteTasks,
+		CancelTasks:        cancelTasks,
+		SetCompletionNote:  setCompletionNote,
+		SplitTask:          splitTask,
 		DeleteTasks:        deleteTasks,
 		ListCompletedTasks: listCompletedTasks,
 		DeferTask:          deferTask,
 		ActivateTask:       activateTask,
 		SetPlannedDate:     setPlannedDate,
 		SetDueDate:         setDueDate,
+		SetEstimate:        setEstimate,
+		SetContext:         setContext,
+		SetAssignee:        setAssignee,
+		SetLocation:        setLocation,
+		SetFlagged:         setFlagged,
+		OrderToday:         orderToday,
+		SetExpires:         setExpires,
+		SetTimeBlock:       setTimeBlock,
+		PauseForVacation:   pauseForVacation,
+		SetDependencies:    setDependencies,
+		PlanProject:        planProject,
+		ExpireTasks:        expireTasks,
+		ArchiveTasks:       archiveTasks,
 		SetTaskProject:     setTaskProject,
 		SetTaskArea:        setTaskArea,
 		SetTaskTitle:       setTaskTitle,
@@ -145,9 +402,28 @@ func New(db *database.DB) *App {
 		PauseRecurrence:    pauseRecurrence,
 		ResumeRecurrence:   resumeRecurrence,
 		SetRecurrenceEnd:   setRecurrenceEnd,
+		SetRecurrenceCount: setRecurrenceCount,
+		KeepRecurTemplate:  keepRecurTemplate,
+		PropagateRecurEdit: propagateRecurEdit,
 		AddTag:             addTag,
 		RemoveTag:          removeTag,
 		ListTags:           listTagsUC,
+		ListLocations:      listLocations,
+		SetTagColor:        setTagColor,
+		ListTagColors:      listTagColors,
+		CountTasks:         countTasks,
+		CountWIP:           countWIP,
+		LintTasks:          lintTasks,
 		SetTags:            setTags,
 	}
+	if stores.Tokens != nil {
+		a.CreateToken = &tokenusecases.CreateToken{Repo: stores.Tokens, Clock: clk}
+		a.ListTokens = &tokenusecases.ListTokens{Repo: stores.Tokens}
+		a.RevokeToken = &tokenusecases.RevokeToken{Repo: stores.Tokens}
+		a.VerifyToken = &tokenusecases.VerifyToken{Repo: stores.Tokens, Clock: clk}
+	}
+	a.Tasks = TaskService{a}
+	a.Projects = ProjectService{a}
+	a.Areas = AreaService{a}
+	return a
 }
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// ConfigOptions returns the use case settings from the config file
+func ConfigOptions(cfg *config.Config) Options {
+	return Options{
+		Schedule: task.ScheduleSettings{
+			UpcomingDays:    cfg.UpcomingDays,
+			DayRolloverHour: cfg.DayRolloverHour,
+		},
+		Recurrence: task.RecurrenceSettings{
+			Ahead:       cfg.RecurAhead,
+			HolidayMode: cfg.HolidayMode,
+		},
+		ExpireAction: cfg.ExpireAction,
+		User:         cfg.User,
+		AreaDefaults: areaDefaults(cfg.Areas),
+		WIPLimits:    wipLimits(cfg),
+	}
+}
+
+// areaDefaults returns the new-task defaults of the [areas.*] blocks that
+// have any
+func areaDefaults(areas map[string]config.AreaSettings) map[string]task.AreaDefaults {
+	defaults := make(map[string]task.AreaDefaults)
+	for name, a := range areas {
+		if len(a.Tags) > 0 || a.PlannedIn != nil {
+			defaults[name] = task.AreaDefaults{Tags: a.Tags, PlannedIn: a.PlannedIn}
+		}
+	}
+	return defaults
+}
+
+// wipLimits returns the WIP limits set in [areas.*] and [projects.*]
+func wipLimits(cfg *config.Config) task.WIPLimits {
+	limits := task.WIPLimits{Areas: make(map[string]int), Projects: make(map[string]int)}
+	for name, a := range cfg.Areas {
+		if a.WIPLimit > 0 {
+			limits.Areas[name] = a.WIPLimit
+		}
+	}
+	for name, p := range cfg.Projects {
+		if p.WIPLimit > 0 {
+			limits.Projects[name] = p.WIPLimit
+		}
+	}
+	return limits
+}
@@ -0,0 +1,298 @@
+package app
+
+import (
+	"io"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/area"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+var (
+	_ task.Service        = TaskService{}
+	_ task.ProjectService = ProjectService{}
+	_ area.Service        = AreaService{}
+)
+
+// TaskService implements task.Service with the app's task use cases
+type TaskService struct {
+	app *App
+}
+
+func (s TaskService) Create(title string, opts *task.CreateOptions) (*task.Task, error) {
+	return s.app.CreateTask.Execute(title, opts)
+}
+
+func (s TaskService) Upsert(title string, opts *task.CreateOptions) (*task.Task, bool, error) {
+	return s.app.UpsertTask.Execute(title, opts)
+}
+
+func (s TaskService) Get(id int64) (*task.Task, error) {
+	return s.app.GetTask.Execute(id)
+}
+
+func (s TaskService) List(opts *task.ListOptions) ([]task.Task, error) {
+	return s.app.ListTasks.Execute(opts)
+}
+
+func (s TaskService) Each(opts *task.ListOptions, fn func(task.Task) error) error {
+	return s.app.ListTasks.Each(opts, fn)
+}
+
+func (s TaskService) Week(offset int, sort []task.SortOption) (*task.Week, error) {
+	return s.app.ListWeek.Execute(offset, sort)
+}
+
+func (s TaskService) WeekFrom(start time.Time, sort []task.SortOption) (*task.Week, error) {
+	return s.app.ListWeek.ExecuteFrom(start, sort)
+}
+
+func (s TaskService) Due(until *time.Time) ([]task.Task, error) {
+	return s.app.ListDue.Execute(until)
+}
+
+func (s TaskService) SuggestNext(opts task.SuggestOptions) (*task.Suggestion, error) {
+	return s.app.SuggestNext.Execute(opts)
+}
+
+func (s TaskService) Count() (task.Counts, error) {
+	return s.app.CountTasks.Execute()
+}
+
+func (s TaskService) WIP() ([]task.WIPCount, error) {
+	return s.app.CountWIP.Execute()
+}
+
+func (s TaskService) WIPFor(id int64) ([]task.WIPCount, error) {
+	t, err := s.app.GetTask.Execute(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.app.CountWIP.For(t)
+}
+
+func (s TaskService) Lint(emptyDays int) (*task.LintReport, error) {
+	return s.app.LintTasks.Execute(emptyDays)
+}
+
+func (s TaskService) Complete(ids []int64) ([]task.CompleteResult, error) {
+	return s.app.CompleteTasks.Execute(ids)
+}
+
+func (s TaskService) Uncomplete(ids []int64) ([]task.UncompleteResult, error) {
+	return s.app.UncompleteTasks.Execute(ids)
+}
+
+func (s TaskService) Cancel(ids []int64) ([]task.CompleteResult, error) {
+	return s.app.CancelTasks.Execute(ids)
+}
+
+func (s TaskService) SetCompletionNote(id int64, note *string) (*task.Task, error) {
+	return s.app.SetCompletionNote.Execute(id, note)
+}
+
+func (s TaskService) Split(id int64, titles []string, keep bool) ([]task.Task, error) {
+	return s.app.SplitTask.Execute(id, titles, keep)
+}
+
+func (s TaskService) Delete(ids []int64) ([]task.Task, error) {
+	return s.app.DeleteTasks.Execute(ids)
+}
+
+func (s TaskService) ListCompleted(opts *task.CompletedOptions) ([]task.Task, error) {
+	return s.app.ListCompletedTasks.Execute(opts)
+}
+
+func (s TaskService) EachCompleted(opts *task.CompletedOptions, fn func(task.Task) error) error {
+	return s.app.ListCompletedTasks.Each(opts, fn)
+}
+
+func (s TaskService) Defer(id int64) (*task.Task, error) {
+	return s.app.DeferTask.Execute(id)
+}
+
+func (s TaskService) Activate(id int64) (*task.Task, error) {
+	return s.app.ActivateTask.Execute(id)
+}
+
+func (s TaskService) Expire() ([]task.Task, error) {
+	return s.app.ExpireTasks.Execute()
+}
+
+func (s TaskService) Archive(w io.Writer, completedBefore, somedayBefore *time.Time) ([]task.Task, error) {
+	return s.app.ArchiveTasks.Execute(w, completedBefore, somedayBefore)
+}
+
+func (s TaskService) SetTitle(id int64, title string) (*task.Task, error) {
+	return s.app.SetTaskTitle.Execute(id, title)
+}
+
+func (s TaskService) SetDescription(id int64, description *string) (*task.Task, error) {
+	return s.app.SetTaskDescription.Execute(id, description)
+}
+
+func (s TaskService) SetProject(id int64, projectName string) (*task.Task, error) {
+	return s.app.SetTaskProject.Execute(id, projectName)
+}
+
+func (s TaskService) SetArea(id int64, areaName string) (*task.Task, error) {
+	return s.app.SetTaskArea.Execute(id, areaName)
+}
+
+func (s TaskService) SetPlannedDate(id int64, date *time.Time) (*task.Task, error) {
+	return s.app.SetPlannedDate.Execute(id, date)
+}
+
+func (s TaskService) SetDueDate(id int64, date *time.Time) (*task.Task, error) {
+	return s.app.SetDueDate.Execute(id, date)
+}
+
+func (s TaskService) SetEstimate(id int64, minutes *int) (*task.Task, error) {
+	return s.app.SetEstimate.Execute(id, minutes)
+}
+
+func (s TaskService) SetContext(id int64, context string) (*task.Task, error) {
+	return s.app.SetContext.Execute(id, context)
+}
+
+func (s TaskService) SetAssignee(id int64, assignee string) (*task.Task, error) {
+	return s.app.SetAssignee.Execute(id, assignee)
+}
+
+func (s TaskService) SetLocation(id int64, location string) (*task.Task, error) {
+	return s.app.SetLocation.Execute(id, location)
+}
+
+func (s TaskService) SetFlagged(ids []int64, flagged bool) ([]task.Task, error) {
+	return s.app.SetFlagged.Execute(ids, flagged)
+}
+
+func (s TaskService) OrderToday(ids []int64) error {
+	return s.app.OrderToday.Execute(ids)
+}
+
+func (s TaskService) SetExpires(id int64, date *time.Time) (*task.Task, error) {
+	return s.app.SetExpires.Execute(id, date)
+}
+
+func (s TaskService) SetTimeBlock(id int64, start *time.Time, minutes int) (*task.Task, error) {
+	return s.app.SetTimeBlock.Execute(id, start, minutes)
+}
+
+func (s TaskService) PauseForVacation(until time.Time, dryRun bool) (*task.VacationPlan, error) {
+	return s.app.PauseForVacation.Execute(until, dryRun)
+}
+
+func (s TaskService) SetDependencies(id int64, dependsOn []int64) (*task.Task, error) {
+	return s.app.SetDependencies.Execute(id, dependsOn)
+}
+
+func (s TaskService) PlanProject(name string, capacity int, apply bool) (*task.ProjectPlan, error) {
+	return s.app.PlanProject.Execute(name, capacity, apply)
+}
+
+func (s TaskService) SetRecurrence(id int64, recurType, recurRule *string, recurEnd *time.Time, recurCount *int) (*task.Task, error) {
+	return s.app.SetRecurrence.Execute(id, recurType, recurRule, recurEnd, recurCount)
+}
+
+func (s TaskService) PauseRecurrence(id int64) (*task.Task, error) {
+	return s.app.PauseRecurrence.Execute(id)
+}
+
+func (s TaskService) ResumeRecurrence(id int64) (*task.Task, error) {
+	return s.app.ResumeRecurrence.Execute(id)
+}
+
+func (s TaskService) SetRecurrenceEnd(id int64, endDate *time.Time) (*task.Task, error) {
+	return s.app.SetRecurrenceEnd.Execute(id, endDate)
+}
+
+func (s TaskService) SetRecurrenceCount(id int64, count *int) (*task.Task, error) {
+	return s.app.SetRecurrenceCount.Execute(id, count)
+}
+
+func (s TaskService) KeepRecurTemplate(id int64) (*task.Task, error) {
+	return s.app.KeepRecurTemplate.Execute(id)
+}
+
+func (s TaskService) PropagateRecurEdit(id int64, fields []string) (int, error) {
+	return s.app.PropagateRecurEdit.Execute(id, fields)
+}
+
+func (s TaskService) AddTag(id int64, tagName string) (*task.Task, error) {
+	return s.app.AddTag.Execute(id, tagName)
+}
+
+func (s TaskService) RemoveTag(id int64, tagName string) (*task.Task, error) {
+	return s.app.RemoveTag.Execute(id, tagName)
+}
+
+func (s TaskService) SetTags(id int64, tags []string) (*task.Task, error) {
+	return s.app.SetTags.Execute(id, tags)
+}
+
+func (s TaskService) Tags() ([]string, error) {
+	return s.app.ListTags.Execute()
+}
+
+func (s TaskService) Locations() ([]string, error) {
+	return s.app.ListLocations.Execute()
+}
+
+func (s TaskService) SetTagColor(name, color string) error {
+	return s.app.SetTagColor.Execute(name, color)
+}
+
+func (s TaskService) TagColors() (map[string]string, error) {
+	return s.app.ListTagColors.Execute()
+}
+
+// ProjectService implements task.ProjectService with the app's project use cases
+type ProjectService struct {
+	app *App
+}
+
+func (s ProjectService) Create(name string, opts *task.CreateProjectOptions) (*task.Task, error) {
+	return s.app.CreateProject.Execute(name, opts)
+}
+
+func (s ProjectService) Get(name string) (*task.Task, error) {
+	return s.app.GetProjectByName.Execute(name)
+}
+
+func (s ProjectService) List() ([]task.Task, error) {
+	return s.app.ListProjects.Execute()
+}
+
+func (s ProjectService) ListAll() ([]task.Task, error) {
+	return s.app.ListAllProjects.Execute()
+}
+
+func (s ProjectService) ListWithArea() ([]task.Task, error) {
+	return s.app.ListProjectsWithArea.Execute()
+}
+
+// AreaService implements area.Service with the app's area use cases
+type AreaService struct {
+	app *App
+}
+
+func (s AreaService) Create(name string) (*area.Area, error) {
+	return s.app.CreateArea.Execute(name)
+}
+
+func (s AreaService) Get(name string) (*area.Area, error) {
+	return s.app.GetAreaByName.Execute(name)
+}
+
+func (s AreaService) List() ([]area.Area, error) {
+	return s.app.ListAreas.Execute()
+}
+
+func (s AreaService) Delete(name string) (*area.Area, error) {
+	return s.app.DeleteArea.Execute(name)
+}
+
+func (s AreaService) Rename(oldName, newName string) (*area.Area, error) {
+	return s.app.RenameArea.Execute(oldName, newName)
+}
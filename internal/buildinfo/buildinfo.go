@@ -0,0 +1,149 @@
+// Package buildinfo describes the running build of tt and checks GitHub for
+// newer releases.
+//
+// Release builds set the variables with -ldflags, e.g.
+//
+//	go build -ldflags "-X github.com/devbydaniel/tt/internal/buildinfo.version=v1.2.0" ./cmd/tt
+//
+// (see the Makefile). Without them, the details Go records in every binary
+// are used: the module version for go install, the VCS revision otherwise.
+package buildinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Set with -ldflags -X
+var (
+	version = ""
+	commit  = ""
+	date    = ""
+)
+
+// ReleasesURL is the GitHub API endpoint for the latest release
+const ReleasesURL = "https://api.github.com/repos/devbydaniel/tt/releases/latest"
+
+// Info identifies a build
+type Info struct {
+	Version   string // e.g. "v1.2.0", or "dev" for local builds
+	Commit    string // VCS revision ("" if unknown)
+	Date      string // build or commit time ("" if unknown)
+	GoVersion string
+	Modified  bool // built from a working tree with uncommitted changes
+}
+
+// Get returns the build info, preferring values set with -ldflags
+func Get() Info {
+	info := Info{Version: version, Commit: commit, Date: date, GoVersion: runtime.Version()}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = s.Value
+				}
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	if len(info.Commit) > 12 {
+		info.Commit = info.Commit[:12]
+	}
+	return info
+}
+
+// Release is a published release of tt
+type Release struct {
+	Version string `json:"tag_name"`
+	URL     string `json:"html_url"`
+}
+
+// LatestRelease asks the GitHub API at url (normally ReleasesURL) for the
+// newest release
+func LatestRelease(ctx context.Context, client *http.Client, url string) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("checking for updates: %s", resp.Status)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("checking for updates: %w", err)
+	}
+	if release.Version == "" {
+		return nil, fmt.Errorf("checking for updates: release has no version")
+	}
+	return &release, nil
+}
+
+// Newer reports whether version latest is newer than current. Versions are
+// compared as semantic versions with an optional "v" prefix; pre-release and
+// build suffixes are ignored. A current version that isn't a release, such
+// as "dev", is never considered up to date.
+func Newer(latest, current string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+// parseVersion parses "v1.2.3" into its major, minor and patch numbers
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+	s = strings.TrimPrefix(s, "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
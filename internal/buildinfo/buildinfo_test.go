@@ -0,0 +1,55 @@
+package buildinfo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v1.2.0", "v1.1.9", true},
+		{"v1.2.0", "v1.2.0", false},
+		{"v1.10.0", "v1.9.0", true},
+		{"v1.2.0", "v2.0.0", false},
+		{"1.2.1", "v1.2.0", true},
+		{"v1.2.0", "v1.2.0-rc.1", false},
+		{"v1.2.0", "dev", true},
+		{"nightly", "v1.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestLatestRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v1.4.0", "html_url": "https://github.com/devbydaniel/tt/releases/tag/v1.4.0", "name": "ignored"}`))
+	}))
+	defer server.Close()
+
+	release, err := LatestRelease(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("LatestRelease() error = %v", err)
+	}
+	if release.Version != "v1.4.0" || release.URL != "https://github.com/devbydaniel/tt/releases/tag/v1.4.0" {
+		t.Errorf("LatestRelease() = %+v", release)
+	}
+}
+
+func TestLatestReleaseHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if _, err := LatestRelease(context.Background(), server.Client(), server.URL); err == nil {
+		t.Error("LatestRelease() succeeded, want error")
+	}
+}
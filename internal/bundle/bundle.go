@@ -0,0 +1,289 @@
+// Package bundle packs tt's data into a single file for backups and moving
+// machines: a gzipped tar archive with a manifest, the database or the task
+// files, and the archive of old tasks. A bundle can be encrypted with a
+// passphrase, so it's safe to email or keep in cloud storage.
+//
+// Encrypted bundles start with a header line, followed by a random salt and
+// nonce and the archive sealed with AES-256-GCM. The key is derived from the
+// passphrase with PBKDF2-SHA256.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Version is the bundle format written by Write
+const Version = 1
+
+// ManifestName is the name of the manifest in the archive
+const ManifestName = "manifest.json"
+
+// header starts an encrypted bundle
+const header = "tt-bundle-encrypted-v1\n"
+
+const (
+	saltSize   = 16
+	iterations = 600_000
+)
+
+var (
+	// ErrWrongPassphrase is returned when an encrypted bundle can't be
+	// opened, because the passphrase is wrong or the file was changed
+	ErrWrongPassphrase = errors.New("wrong passphrase, or the bundle is damaged")
+	// ErrPassphraseRequired is returned when an encrypted bundle is opened
+	// without a passphrase
+	ErrPassphraseRequired = errors.New("bundle is encrypted; a passphrase is required")
+)
+
+// Manifest describes a bundle
+type Manifest struct {
+	Version int       `json:"version"`
+	Storage string    `json:"storage"` // storage the data came from, see config.Storage
+	Created time.Time `json:"created"`
+	Files   []string  `json:"files"`
+}
+
+// File is a file to pack: its name in the bundle and where to read it from
+type File struct {
+	Name string // slash-separated, relative
+	Path string
+}
+
+// Write packs files into a bundle on w, encrypted if passphrase isn't empty
+func Write(w io.Writer, m Manifest, files []File, passphrase string) error {
+	m.Version = Version
+	m.Files = m.Files[:0]
+	for _, f := range files {
+		if !validName(f.Name) {
+			return fmt.Errorf("bundle: invalid file name %q", f.Name)
+		}
+		m.Files = append(m.Files, f.Name)
+	}
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	if err := addFile(tw, ManifestName, manifest, m.Created); err != nil {
+		return err
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f.Path)
+		if err != nil {
+			return err
+		}
+		if err := addFile(tw, f.Name, data, m.Created); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	if passphrase == "" {
+		_, err = w.Write(archive.Bytes())
+		return err
+	}
+	sealed, err := encrypt(archive.Bytes(), passphrase)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(sealed)
+	return err
+}
+
+func addFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// Encrypted reports whether data is an encrypted bundle
+func Encrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(header))
+}
+
+// Bundle is an opened bundle
+type Bundle struct {
+	Manifest Manifest
+	files    map[string][]byte
+}
+
+// Open reads a bundle, decrypting it with passphrase if it's encrypted
+func Open(data []byte, passphrase string) (*Bundle, error) {
+	if Encrypted(data) {
+		if passphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		var err error
+		if data, err = decrypt(data, passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("not a tt bundle: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	b := &Bundle{files: map[string][]byte{}}
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading bundle: %w", err)
+		}
+		if h.Typeflag != tar.TypeReg || !validName(h.Name) {
+			return nil, fmt.Errorf("bundle: unexpected entry %q", h.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("reading bundle: %w", err)
+		}
+		b.files[h.Name] = data
+	}
+
+	manifest, ok := b.files[ManifestName]
+	if !ok {
+		return nil, errors.New("not a tt bundle: no manifest")
+	}
+	delete(b.files, ManifestName)
+	if err := json.Unmarshal(manifest, &b.Manifest); err != nil {
+		return nil, fmt.Errorf("bundle manifest: %w", err)
+	}
+	if b.Manifest.Version > Version {
+		return nil, fmt.Errorf("bundle format %d is newer than this tt supports (%d); upgrade tt", b.Manifest.Version, Version)
+	}
+	for _, name := range b.Manifest.Files {
+		if _, ok := b.files[name]; !ok {
+			return nil, fmt.Errorf("bundle is incomplete: %s is missing", name)
+		}
+	}
+	return b, nil
+}
+
+// Has reports whether the bundle holds the file name, or files under it if
+// name ends with a slash
+func (b *Bundle) Has(name string) bool {
+	for _, f := range b.Manifest.Files {
+		if f == name || (strings.HasSuffix(name, "/") && strings.HasPrefix(f, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Extract writes the file name to dest, or with a name ending in a slash
+// all files under it into the directory dest
+func (b *Bundle) Extract(name, dest string) error {
+	if !strings.HasSuffix(name, "/") {
+		data, ok := b.files[name]
+		if !ok {
+			return fmt.Errorf("bundle has no %s", name)
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(dest, data, 0644)
+	}
+
+	for _, f := range b.Manifest.Files {
+		rel, ok := strings.CutPrefix(f, name)
+		if !ok {
+			continue
+		}
+		path := filepath.Join(dest, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, b.files[f], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validName accepts relative, clean, slash-separated names, so extracting
+// can't write outside the target
+func validName(name string) bool {
+	return name != "" && !strings.Contains(name, `\`) && !path.IsAbs(name) &&
+		path.Clean(name) == name && name != ".." && !strings.HasPrefix(name, "../")
+}
+
+func encrypt(plain []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append([]byte(header), salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plain, []byte(header)), nil
+}
+
+func decrypt(data []byte, passphrase string) ([]byte, error) {
+	data = data[len(header):]
+	if len(data) < saltSize {
+		return nil, ErrWrongPassphrase
+	}
+	salt, data := data[:saltSize], data[saltSize:]
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	nonce, sealed := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, []byte(header))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plain, nil
+}
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
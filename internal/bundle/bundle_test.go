@@ -0,0 +1,121 @@
+package bundle
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFiles(t *testing.T) []File {
+	t.Helper()
+	dir := t.TempDir()
+	var files []File
+	for name, content := range map[string]string{"tasks.db": "database", "archive.jsonl": "{}\n"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, File{Name: name, Path: path})
+	}
+	return files
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, passphrase := range []string{"", "correct horse"} {
+		var buf bytes.Buffer
+		manifest := Manifest{Storage: "sqlite", Created: time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)}
+		if err := Write(&buf, manifest, writeFiles(t), passphrase); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if got := Encrypted(buf.Bytes()); got != (passphrase != "") {
+			t.Errorf("Encrypted = %v with passphrase %q", got, passphrase)
+		}
+		if passphrase != "" && bytes.Contains(buf.Bytes(), []byte("database")) {
+			t.Error("encrypted bundle contains plain text")
+		}
+
+		b, err := Open(buf.Bytes(), passphrase)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		if b.Manifest.Storage != "sqlite" || b.Manifest.Version != Version || len(b.Manifest.Files) != 2 {
+			t.Errorf("manifest = %+v", b.Manifest)
+		}
+		dest := filepath.Join(t.TempDir(), "restored.db")
+		if err := b.Extract("tasks.db", dest); err != nil {
+			t.Fatalf("Extract: %v", err)
+		}
+		if data, _ := os.ReadFile(dest); string(data) != "database" {
+			t.Errorf("restored %q, want %q", data, "database")
+		}
+	}
+}
+
+func TestOpenEncrypted(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Manifest{Storage: "sqlite"}, writeFiles(t), "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(buf.Bytes(), ""); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("without passphrase: err = %v, want ErrPassphraseRequired", err)
+	}
+	if _, err := Open(buf.Bytes(), "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("wrong passphrase: err = %v, want ErrWrongPassphrase", err)
+	}
+
+	tampered := bytes.Clone(buf.Bytes())
+	tampered[len(tampered)-1] ^= 1
+	if _, err := Open(tampered, "secret"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("tampered: err = %v, want ErrWrongPassphrase", err)
+	}
+}
+
+func TestExtractDirectory(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "tasks"), 0755)
+	os.WriteFile(filepath.Join(dir, "tasks", "1.md"), []byte("# one"), 0644)
+	os.WriteFile(filepath.Join(dir, "areas.txt"), []byte("work"), 0644)
+
+	var buf bytes.Buffer
+	files := []File{
+		{Name: "files/tasks/1.md", Path: filepath.Join(dir, "tasks", "1.md")},
+		{Name: "files/areas.txt", Path: filepath.Join(dir, "areas.txt")},
+	}
+	if err := Write(&buf, Manifest{Storage: "files"}, files, ""); err != nil {
+		t.Fatal(err)
+	}
+	b, err := Open(buf.Bytes(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.Has("files/") || b.Has("tasks.db") {
+		t.Error("Has reports the wrong files")
+	}
+
+	dest := t.TempDir()
+	if err := b.Extract("files/", dest); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dest, "tasks", "1.md")); string(data) != "# one" {
+		t.Errorf("tasks/1.md = %q", data)
+	}
+}
+
+func TestWriteRejectsUnsafeNames(t *testing.T) {
+	for _, name := range []string{"", "../etc/passwd", "/abs", "a/../../b", `a\b`} {
+		err := Write(&bytes.Buffer{}, Manifest{}, []File{{Name: name, Path: "x"}}, "")
+		if err == nil {
+			t.Errorf("name %q accepted", name)
+		}
+	}
+}
+
+func TestOpenRejectsOtherFiles(t *testing.T) {
+	if _, err := Open([]byte("not a bundle"), ""); err == nil {
+		t.Error("opened a file that isn't a bundle")
+	}
+}
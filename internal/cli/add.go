@@ -5,9 +5,9 @@ import (
 	"os"
 	"strings"
 
+	"github.com/devbydaniel/tt/config"
 	"github.com/devbydaniel/tt/internal/dateparse"
 	"github.com/devbydaniel/tt/internal/domain/task"
-	"github.com/devbydaniel/tt/internal/output"
 	"github.com/devbydaniel/tt/internal/recurparse"
 	"github.com/spf13/cobra"
 )
@@ -22,7 +22,16 @@ func NewAddCmd(deps *Dependencies) *cobra.Command {
 	var someday bool
 	var recurStr string
 	var recurEndStr string
+	var recurCount int
 	var tags []string
+	var estimateStr string
+	var contextName string
+	var strictDates bool
+	var strictWIP bool
+	var expiresStr string
+	var assignee string
+	var location string
+	var externalID string
 
 	cmd := &cobra.Command{
 		Use:   "add [title]",
@@ -45,16 +54,42 @@ func NewAddCmd(deps *Dependencies) *cobra.Command {
 				plannedStr = "today"
 			}
 
+			// Fill in the [defaults] the flags leave open. Syncs by external
+			// ID set their own fields, and would move updated tasks otherwise.
+			var defaults config.TaskDefaults
+			if externalID == "" {
+				defaults = deps.Config.Defaults
+			}
+			if !cmd.Flags().Changed("tag") {
+				tags = defaults.Tags
+			}
+			if projectName == "" && areaName == "" {
+				projectName = defaults.Project
+			}
+			if plannedStr == "" && dueStr == "" {
+				if !cmd.Flags().Changed("someday") {
+					someday = defaults.State == string(task.StateSomeday)
+				}
+				if !someday {
+					plannedStr = defaults.Planned
+				}
+			}
+
 			opts := &task.CreateOptions{
 				ProjectName: projectName,
 				AreaName:    areaName,
 				Description: description,
 				Someday:     someday,
 				Tags:        tags,
+				Context:     contextName,
+				Assignee:    assignee,
+				Location:    location,
+				ExternalID:  externalID,
+				StrictWIP:   strictWIP,
 			}
 
 			if plannedStr != "" {
-				planned, err := dateparse.Parse(plannedStr)
+				planned, err := dateparse.ParseFrom(plannedStr, deps.today())
 				if err != nil {
 					return err
 				}
@@ -62,13 +97,29 @@ func NewAddCmd(deps *Dependencies) *cobra.Command {
 			}
 
 			if dueStr != "" {
-				due, err := dateparse.Parse(dueStr)
+				due, err := dateparse.ParseFrom(dueStr, deps.today())
 				if err != nil {
 					return err
 				}
 				opts.DueDate = &due
 			}
 
+			if expiresStr != "" {
+				expires, err := dateparse.ParseFrom(expiresStr, deps.today())
+				if err != nil {
+					return err
+				}
+				opts.Expires = &expires
+			}
+
+			if estimateStr != "" {
+				estimate, err := task.ParseEstimate(estimateStr)
+				if err != nil {
+					return err
+				}
+				opts.Estimate = &estimate
+			}
+
 			// Parse recurrence if provided
 			if recurStr != "" {
 				result, err := recurparse.Parse(recurStr)
@@ -82,24 +133,51 @@ func NewAddCmd(deps *Dependencies) *cobra.Command {
 				recurType := string(result.Type)
 				opts.RecurType = &recurType
 				opts.RecurRule = &ruleJSON
+
+				if recurCount > 0 && result.Count > 0 {
+					return errors.New("cannot specify both --recur-count and a \"for N times\" pattern")
+				}
+				if recurCount == 0 {
+					recurCount = result.Count
+				}
+			}
+			if recurCount < 0 {
+				return errors.New("--recur-count must be positive")
+			}
+			if recurCount > 0 {
+				if recurStr == "" {
+					return errors.New("--recur-count requires --recur")
+				}
+				opts.RecurCount = &recurCount
 			}
 
 			// Parse recurrence end date if provided
 			if recurEndStr != "" {
-				recurEnd, err := dateparse.Parse(recurEndStr)
+				recurEnd, err := dateparse.ParseFrom(recurEndStr, deps.today())
 				if err != nil {
 					return err
 				}
 				opts.RecurEnd = &recurEnd
 			}
 
-			t, err := deps.App.CreateTask.Execute(title, opts)
+			formatter := deps.formatter(os.Stdout)
+			if err := checkDates(deps, formatter, strictDates, "", opts.PlannedDate, opts.DueDate); err != nil {
+				return err
+			}
+
+			t, created, err := deps.App.Tasks.Upsert(title, opts)
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			if !created {
+				formatter.TaskUpserted(t)
+				return nil
+			}
 			formatter.TaskCreated(t)
+			if t.State == task.StateActive {
+				return warnWIP(deps, formatter, []int64{t.ID})
+			}
 			return nil
 		},
 	}
@@ -107,17 +185,28 @@ func NewAddCmd(deps *Dependencies) *cobra.Command {
 	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Assign to project")
 	cmd.Flags().StringVarP(&areaName, "area", "a", "", "Assign to area")
 	cmd.Flags().StringVarP(&description, "description", "d", "", "Task description")
-	cmd.Flags().StringVarP(&plannedStr, "planned", "P", "", "Planned date (e.g., today, tomorrow, +3d, 2025-01-15)")
+	cmd.Flags().StringVarP(&plannedStr, "planned", "P", "", "Planned date (e.g., tomorrow, fri, next week, jul 15, +3d, 2025-01-15)")
 	cmd.Flags().BoolVarP(&today, "today", "T", false, "Set planned date to today")
-	cmd.Flags().StringVarP(&dueStr, "due", "D", "", "Due date (e.g., today, tomorrow, +3d, 2025-01-15)")
+	cmd.Flags().StringVarP(&dueStr, "due", "D", "", "Due date (e.g., tomorrow, fri, end of month, jul 15, +3d, 2025-01-15)")
 	cmd.Flags().BoolVar(&someday, "someday", false, "Create task in someday state")
 	cmd.Flags().StringVarP(&recurStr, "recur", "r", "", "Recurrence pattern (e.g., daily, every monday, 3d after done)")
 	cmd.Flags().StringVar(&recurEndStr, "recur-end", "", "Recurrence end date")
+	cmd.Flags().IntVar(&recurCount, "recur-count", 0, "End recurrence after this many occurrences")
 	cmd.Flags().StringArrayVarP(&tags, "tag", "t", nil, "Add tag (repeatable)")
+	cmd.Flags().StringVarP(&estimateStr, "estimate", "e", "", "Effort estimate (e.g., 30m, 2h, 1h30m)")
+	cmd.Flags().StringVarP(&contextName, "context", "c", "", "Context: "+strings.Join(task.ValidContexts(), ", "))
+	cmd.Flags().StringVar(&expiresStr, "expires", "", "Expire the task after this date (moved to someday or deleted, see expire_action)")
+	cmd.Flags().StringVar(&assignee, "assignee", "", "Assign to a person, in a shared database")
+	cmd.Flags().StringVar(&location, "location", "", "Where it can be done (e.g. home, @office, a shop)")
+	cmd.Flags().StringVar(&externalID, "external-id", "", "ID in another system; adding it again updates the task instead")
+	addStrictDatesFlag(cmd, &strictDates)
+	addStrictWIPFlag(cmd, &strictWIP)
 
 	// Register completions
 	registry := NewCompletionRegistry(deps)
 	registry.RegisterAll(cmd)
+	registry.RegisterLocationFlag(cmd)
+	_ = cmd.RegisterFlagCompletionFunc("context", contextCompletion)
 
 	return cmd
 }
@@ -2,11 +2,14 @@ package cli_test
 
 import (
 	"bytes"
+	"slices"
+	"strconv"
 	"testing"
 
 	"github.com/devbydaniel/tt/config"
 	"github.com/devbydaniel/tt/internal/app"
 	"github.com/devbydaniel/tt/internal/cli"
+	"github.com/devbydaniel/tt/internal/domain/task"
 	"github.com/devbydaniel/tt/internal/testutil"
 )
 
@@ -181,3 +184,123 @@ func TestAddCannotSpecifyBothTodayAndPlanned(t *testing.T) {
 		t.Error("expected error when specifying both --today and --planned")
 	}
 }
+
+func TestAddStrictDates(t *testing.T) {
+	deps := setupCLI(t)
+
+	cmd := cli.NewRootCmd(deps)
+	cmd.SetArgs([]string{"add", "Task", "-P", "2020-01-05", "-D", "2020-01-03", "--strict-dates"})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected error for a past due date with --strict-dates")
+	}
+	tasks, err := deps.App.ListTasks.Execute(nil)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Errorf("expected no task to be created, got %d", len(tasks))
+	}
+
+	// Without --strict-dates the same dates are only a warning
+	cmd = cli.NewRootCmd(deps)
+	cmd.SetArgs([]string{"add", "Task", "-P", "2020-01-05", "-D", "2020-01-03"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("add command failed: %v", err)
+	}
+}
+
+func TestAddStrictWIP(t *testing.T) {
+	deps := setupCLI(t)
+	deps.App.Reconfigure(app.Options{WIPLimits: task.WIPLimits{Areas: map[string]int{"work": 1}}})
+	if _, err := deps.App.CreateArea.Execute("work"); err != nil {
+		t.Fatalf("failed to create area: %v", err)
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := cli.NewRootCmd(deps)
+		cmd.SetArgs(args)
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("add", "First", "-a", "work", "--strict"); err != nil {
+		t.Fatalf("add under the limit failed: %v", err)
+	}
+	if _, err := run("add", "Second", "-a", "work", "--strict"); err == nil {
+		t.Fatal("expected error for going over the WIP limit with --strict")
+	}
+
+	// Without --strict it's only a warning, and so is activating
+	if _, err := run("add", "Second", "-a", "work"); err != nil {
+		t.Fatalf("add over the limit failed: %v", err)
+	}
+	later, err := deps.App.CreateTask.Execute("Later", &task.CreateOptions{AreaName: "work", Someday: true})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	id := strconv.FormatInt(later.ID, 10)
+	if _, err := run("edit", id, "--active", "--strict"); err == nil {
+		t.Fatal("expected error for activating over the WIP limit with --strict")
+	}
+	if got, _ := deps.App.Tasks.Get(later.ID); got.State != task.StateSomeday {
+		t.Errorf("State = %s after a refused activation, want someday", got.State)
+	}
+	if _, err := run("edit", id, "--active"); err != nil {
+		t.Fatalf("edit --active over the limit failed: %v", err)
+	}
+}
+
+func TestAddDefaults(t *testing.T) {
+	deps := setupCLI(t)
+	deps.Config.Defaults = config.TaskDefaults{Tags: []string{"new"}, State: "someday", Project: "Chores"}
+
+	if _, err := deps.App.CreateProject.Execute("Chores", nil); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if _, err := deps.App.CreateArea.Execute("work"); err != nil {
+		t.Fatalf("failed to create area: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"add", "Water plants"},
+		{"add", "Call bank", "-t", "phone", "-P", "today"},
+		{"add", "Write report", "-a", "work", "--someday=false"},
+	} {
+		cmd := cli.NewRootCmd(deps)
+		cmd.SetArgs(args)
+		cmd.SetOut(&bytes.Buffer{})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+	}
+
+	get := func(id int64) *task.Task {
+		t.Helper()
+		got, err := deps.App.GetTask.Execute(id)
+		if err != nil {
+			t.Fatalf("get #%d failed: %v", id, err)
+		}
+		return got
+	}
+
+	// Nothing given: all defaults apply
+	if got := get(2); got.State != task.StateSomeday || got.ParentID == nil || *got.ParentID != 1 || !slices.Equal(got.Tags, []string{"new"}) {
+		t.Errorf("defaults not applied: state %q, project %v, tags %v", got.State, got.ParentID, got.Tags)
+	}
+	// Flags win over the defaults they overlap with
+	if got := get(3); got.State != task.StateActive || got.PlannedDate == nil || !slices.Equal(got.Tags, []string{"phone"}) {
+		t.Errorf("flags not preferred: state %q, planned %v, tags %v", got.State, got.PlannedDate, got.Tags)
+	}
+	// An area given takes the place of the default project
+	if got := get(4); got.ParentID != nil || got.AreaID == nil || got.State != task.StateActive {
+		t.Errorf("area not preferred: project %v, area %v, state %q", got.ParentID, got.AreaID, got.State)
+	}
+}
@@ -14,9 +14,9 @@ func NewAreaCmd(deps *Dependencies) *cobra.Command {
 	}
 
 	cmd.AddCommand(newAreaListCmd(deps))
-	cmd.AddCommand(newAreaAddCmd(deps))
-	cmd.AddCommand(newAreaDeleteCmd(deps))
-	cmd.AddCommand(newAreaRenameCmd(deps))
+	cmd.AddCommand(mutating(newAreaAddCmd(deps)))
+	cmd.AddCommand(mutating(newAreaDeleteCmd(deps)))
+	cmd.AddCommand(mutating(newAreaRenameCmd(deps)))
 
 	return cmd
 }
@@ -29,7 +29,7 @@ func newAreaListCmd(deps *Dependencies) *cobra.Command {
 		Short: "List all areas",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			areas, err := deps.App.ListAreas.Execute()
+			areas, err := deps.App.Areas.List()
 			if err != nil {
 				return err
 			}
@@ -38,7 +38,7 @@ func newAreaListCmd(deps *Dependencies) *cobra.Command {
 				return output.WriteJSON(os.Stdout, areas)
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.AreaList(areas)
 			return nil
 		},
@@ -54,12 +54,12 @@ func newAreaAddCmd(deps *Dependencies) *cobra.Command {
 		Short: "Create a new area",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			area, err := deps.App.CreateArea.Execute(args[0])
+			area, err := deps.App.Areas.Create(args[0])
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.AreaCreated(area)
 			return nil
 		},
@@ -72,12 +72,12 @@ func newAreaDeleteCmd(deps *Dependencies) *cobra.Command {
 		Short: "Delete an area",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			area, err := deps.App.DeleteArea.Execute(args[0])
+			area, err := deps.App.Areas.Delete(args[0])
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.AreaDeleted(area)
 			return nil
 		},
@@ -99,12 +99,12 @@ func newAreaRenameCmd(deps *Dependencies) *cobra.Command {
 			oldName := args[0]
 			newName := args[1]
 
-			_, err := deps.App.RenameArea.Execute(oldName, newName)
+			_, err := deps.App.Areas.Rename(oldName, newName)
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.AreaRenamed(oldName, newName)
 			return nil
 		},
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/dateparse"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/spf13/cobra"
+)
+
+func NewBlockCmd(deps *Dependencies) *cobra.Command {
+	var atStr, forStr, onStr string
+	var clear bool
+
+	cmd := &cobra.Command{
+		Use:   "block <task-id>",
+		Short: "Set aside a time slot to work on a task",
+		Long: `Set aside a time slot to work on a task, and plan it for that day.
+
+The block lasts the task's estimate unless --for is given, or an hour
+without either. It's on the task's planned date, or today if that has
+passed, unless --on is given. Export blocks to a calendar with
+tt export blocks --ics.
+
+Examples:
+  t block 12 --at 14:00 --for 1h
+  t block 12 --at 9:30 --on friday
+  t block 12 --clear`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			formatter := deps.formatter(os.Stdout)
+
+			if clear {
+				t, err := deps.App.Tasks.SetTimeBlock(id, nil, 0)
+				if err != nil {
+					return err
+				}
+				formatter.TaskTimeBlockSet(t)
+				return nil
+			}
+
+			if atStr == "" {
+				return errors.New("--at required (or use --clear to remove)")
+			}
+			at, err := time.Parse("15:04", strings.TrimSpace(atStr))
+			if err != nil {
+				return fmt.Errorf("invalid --at time %q (expected HH:MM)", atStr)
+			}
+
+			current, err := deps.App.Tasks.Get(id)
+			if err != nil {
+				return err
+			}
+
+			minutes := 60
+			if current.Estimate != nil {
+				minutes = *current.Estimate
+			}
+			if forStr != "" {
+				if minutes, err = task.ParseEstimate(forStr); err != nil {
+					return fmt.Errorf("invalid --for: %w", err)
+				}
+			}
+
+			today := deps.today()
+			day := today
+			if current.PlannedDate != nil && current.PlannedDate.Format("2006-01-02") > today.Format("2006-01-02") {
+				day = *current.PlannedDate
+			}
+			if onStr != "" {
+				if day, err = dateparse.ParseFrom(onStr, today); err != nil {
+					return err
+				}
+			}
+			start := time.Date(day.Year(), day.Month(), day.Day(), at.Hour(), at.Minute(), 0, 0, time.Local)
+
+			t, err := deps.App.Tasks.SetTimeBlock(id, &start, minutes)
+			if err != nil {
+				return err
+			}
+			formatter.TaskTimeBlockSet(t)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&atStr, "at", "", "Start time (HH:MM)")
+	cmd.Flags().StringVar(&forStr, "for", "", "Length, e.g. 30m, 1h, 1h30m (default: the estimate, or 1h)")
+	cmd.Flags().StringVar(&onStr, "on", "", "Day of the block (default: the planned date, or today)")
+	cmd.Flags().BoolVar(&clear, "clear", false, "Clear the time block")
+	cmd.MarkFlagsMutuallyExclusive("clear", "at")
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCancelCmd(deps *Dependencies) *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "cancel <id> [id...]",
+		Short: "Mark task(s) as won't do",
+		Long: `Mark task(s) as canceled: closed without being done. Canceled tasks are
+listed in the logbook, marked with their own icon, but aren't counted as
+completed. Canceling a project cancels its open tasks; a recurring task
+still gets its next occurrence. tt undo reopens a canceled task.
+
+IDs can be given as ranges and comma-separated lists, e.g. 4-9,12. If any
+of them doesn't exist, nothing is changed.
+
+Example:
+  tt cancel 12 --reason "superseded by #15"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := parseIDs(args)
+			if err != nil {
+				return err
+			}
+
+			canceled, err := deps.App.Tasks.Cancel(ids)
+			if err != nil {
+				return err
+			}
+
+			// The reason is kept as the completion note
+			if reason = strings.TrimSpace(reason); reason != "" {
+				for i, r := range canceled {
+					t, err := deps.App.Tasks.SetCompletionNote(r.Completed.ID, &reason)
+					if err != nil {
+						return err
+					}
+					canceled[i].Completed = *t
+				}
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.TasksCanceled(canceled)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the task won't be done")
+
+	return cmd
+}
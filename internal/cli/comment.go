@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommentCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "comment <task-id> <text>",
+		Short: "Add a dated comment to a task",
+		Long: `Add a dated comment to a task, e.g. to note progress without rewriting the
+description. Comments are listed oldest first by tt show and in the TUI
+detail pane, with their author (the user setting) in a shared database.
+
+Example:
+  tt comment 12 "waiting on legal"`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			if _, err := deps.App.AddComment.Execute(id, strings.Join(args[1:], " ")); err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.CommentAdded(id)
+			return nil
+		},
+	}
+}
+
+func NewShowCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <task-id>",
+		Short: "Show a task's details and comments",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			return showTask(deps, id)
+		},
+	}
+}
+
+// showTask prints a task's details followed by its comments
+func showTask(deps *Dependencies, id int64) error {
+	t, err := deps.App.Tasks.Get(id)
+	if err != nil {
+		return err
+	}
+	comments, err := deps.App.ListComments.Execute(id)
+	if err != nil {
+		return err
+	}
+
+	formatter := deps.formatter(os.Stdout)
+	formatter.TaskDetails(t)
+	formatter.Comments(comments)
+	return nil
+}
@@ -3,9 +3,11 @@ package cli
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -22,7 +24,10 @@ func NewCompletionRegistry(deps *Dependencies) *CompletionRegistry {
 // ProjectCompletion returns a completion function for project names (active projects only)
 func (r *CompletionRegistry) ProjectCompletion() func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
 	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		projects, err := r.deps.App.ListProjects.Execute()
+		if err := r.deps.open(); err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		projects, err := r.deps.App.Projects.List()
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
@@ -41,7 +46,10 @@ func (r *CompletionRegistry) ProjectCompletion() func(*cobra.Command, []string,
 // AllProjectCompletion returns a completion function for all project names (active and someday)
 func (r *CompletionRegistry) AllProjectCompletion() func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
 	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		projects, err := r.deps.App.ListAllProjects.Execute()
+		if err := r.deps.open(); err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		projects, err := r.deps.App.Projects.ListAll()
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
@@ -60,7 +68,10 @@ func (r *CompletionRegistry) AllProjectCompletion() func(*cobra.Command, []strin
 // AreaCompletion returns a completion function for area names
 func (r *CompletionRegistry) AreaCompletion() func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
 	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		areas, err := r.deps.App.ListAreas.Execute()
+		if err := r.deps.open(); err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		areas, err := r.deps.App.Areas.List()
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
@@ -119,7 +130,10 @@ func (r *CompletionRegistry) RegisterSortFlag(cmd *cobra.Command) {
 // TagCompletion returns a completion function for tag names
 func (r *CompletionRegistry) TagCompletion() func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
 	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		tags, err := r.deps.App.ListTags.Execute()
+		if err := r.deps.open(); err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		tags, err := r.deps.App.Tasks.Tags()
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
@@ -140,6 +154,51 @@ func (r *CompletionRegistry) RegisterTagFlag(cmd *cobra.Command) {
 	_ = cmd.RegisterFlagCompletionFunc("tag", r.TagCompletion())
 }
 
+// LocationCompletion returns a completion function for the locations of open tasks
+func (r *CompletionRegistry) LocationCompletion() func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if err := r.deps.open(); err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		locations, err := r.deps.App.Tasks.Locations()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		var completions []string
+		for _, l := range locations {
+			if strings.HasPrefix(strings.ToLower(l), strings.ToLower(strings.TrimPrefix(toComplete, "@"))) {
+				completions = append(completions, l)
+			}
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// RegisterLocationFlag registers location completion on a command's --location flag
+func (r *CompletionRegistry) RegisterLocationFlag(cmd *cobra.Command) {
+	_ = cmd.RegisterFlagCompletionFunc("location", r.LocationCompletion())
+}
+
+// ThemeCompletion returns a completion function for theme names, including custom presets
+func (r *CompletionRegistry) ThemeCompletion() func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var completions []string
+		for _, info := range output.Themes(&r.deps.Config.Theme) {
+			if strings.HasPrefix(info.Name, toComplete) && !slices.Contains(args, info.Name) {
+				completions = append(completions, info.Name)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// contextCompletion completes context labels
+func contextCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return task.ValidContexts(), cobra.ShellCompDirectiveNoFileComp
+}
+
 // RegisterAll registers project, area, sort, and tag completion on a command
 func (r *CompletionRegistry) RegisterAll(cmd *cobra.Command) {
 	r.RegisterProjectFlag(cmd)
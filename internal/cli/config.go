@@ -0,0 +1,392 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/database"
+	"github.com/devbydaniel/tt/internal/dateparse"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/i18n"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/devbydaniel/tt/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigCmd(deps *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Locate, create, or edit the config file",
+	}
+
+	cmd.AddCommand(newConfigPathCmd(deps))
+	cmd.AddCommand(newConfigInitCmd(deps))
+	cmd.AddCommand(newConfigEditCmd(deps))
+	cmd.AddCommand(newConfigCheckCmd(deps))
+
+	return cmd
+}
+
+func newConfigPathCmd(deps *Dependencies) *cobra.Command {
+	var showDB bool
+
+	cmd := &cobra.Command{
+		Use:   "path",
+		Short: "Print the config file path",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if showDB {
+				fmt.Fprintln(cmd.OutOrStdout(), database.Redact(deps.Config.DatabaseSource()))
+				return nil
+			}
+			path := config.Path()
+			if path == "" {
+				return errors.New("cannot determine config directory")
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), path)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&showDB, "db", false, "Print the database path instead")
+	return cmd
+}
+
+func newConfigInitCmd(deps *Dependencies) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Write a commented config file template",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.Init(force)
+			if err != nil {
+				return err
+			}
+			formatter := deps.formatter(os.Stdout)
+			formatter.ConfigCreated(path)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing config file")
+	return cmd
+}
+
+func newConfigEditCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit",
+		Short: "Open the config file in $EDITOR",
+		Long: `Open the config file in $VISUAL or $EDITOR, creating it from the
+template first if it doesn't exist yet.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := config.Path()
+			if path == "" {
+				return errors.New("cannot determine config directory")
+			}
+			if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+				if _, err := config.Init(false); err != nil {
+					return err
+				}
+			}
+
+			editor := strings.Fields(editorCommand())
+			editCmd := exec.Command(editor[0], append(editor[1:], path)...)
+			editCmd.Stdin = os.Stdin
+			editCmd.Stdout = os.Stdout
+			editCmd.Stderr = os.Stderr
+			return editCmd.Run()
+		},
+	}
+}
+
+// editorCommand returns the user's editor, falling back to a platform default
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+func newConfigCheckCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Validate the config file",
+		Args:  cobra.NoArgs,
+		// Problems are already printed on every run; skip that here to avoid duplicates
+		Annotations: map[string]string{skipConfigWarningsAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			problems := ConfigProblems(deps.Config)
+			formatter := deps.formatter(os.Stdout)
+			formatter.ConfigCheck(config.Path(), problems)
+			if len(problems) > 0 {
+				return fmt.Errorf("found %d problem(s) in config", len(problems))
+			}
+			return nil
+		},
+	}
+}
+
+// skipConfigWarningsAnnotation marks commands that report config problems themselves
+const skipConfigWarningsAnnotation = "skip-config-warnings"
+
+// ConfigProblems returns actionable descriptions of everything wrong with cfg:
+// file-level problems found while loading, plus values tt can't use.
+func ConfigProblems(cfg *config.Config) []string {
+	problems := append([]string(nil), cfg.Warnings...)
+
+	if cfg.Sort != "" {
+		if _, err := task.ParseSort(cfg.Sort); err != nil {
+			problems = append(problems, fmt.Sprintf("sort: %v", err))
+		}
+	}
+	if cfg.GroupSort != "" {
+		if _, err := task.ParseSort(cfg.GroupSort); err != nil {
+			problems = append(problems, fmt.Sprintf("group_sort: %v", err))
+		}
+	}
+	if cfg.Group != "" && !slices.Contains(validGroups("list"), cfg.Group) {
+		problems = append(problems, fmt.Sprintf("group: invalid value %q (valid: %s)", cfg.Group, strings.Join(validGroups("list"), ", ")))
+	}
+	problems = append(problems, columnProblems("", cfg.Columns, cfg.Widths)...)
+	if cfg.StartView != "" {
+		if _, err := tui.ParseView(cfg.StartView); err != nil {
+			problems = append(problems, fmt.Sprintf("start_view: %v", err))
+		}
+	}
+	if _, err := tui.ParseYankTemplate(cfg.YankTemplate); err != nil {
+		problems = append(problems, fmt.Sprintf("yank_template: %v", err))
+	}
+	if _, err := output.ParseStandupTemplate(cfg.StandupTemplate); err != nil {
+		problems = append(problems, fmt.Sprintf("standup_template: %v", err))
+	}
+	if err := output.ValidateDateGroups(cfg.DateGroups); err != nil {
+		problems = append(problems, fmt.Sprintf("date_groups: %v", err))
+	}
+	if cfg.Overflow != "" && !slices.Contains(output.OverflowModes(), cfg.Overflow) {
+		problems = append(problems, fmt.Sprintf("overflow: invalid value %q (valid: %s)", cfg.Overflow, strings.Join(output.OverflowModes(), ", ")))
+	}
+
+	lists := []struct {
+		key      string
+		settings config.ListSettings
+	}{
+		{"today", cfg.Today}, {"upcoming", cfg.Upcoming}, {"anytime", cfg.Anytime},
+		{"someday", cfg.Someday}, {"log", cfg.Log}, {"project_list", cfg.ProjectList},
+		{"project", cfg.Project}, {"area", cfg.Area}, {"tag", cfg.Tag},
+		{"list", cfg.List}, {"inbox", cfg.Inbox},
+	}
+	for _, l := range lists {
+		if l.settings.Sort != "" {
+			if _, err := task.ParseSort(l.settings.Sort); err != nil {
+				problems = append(problems, fmt.Sprintf("[%s] sort: %v", l.key, err))
+			}
+		}
+		if l.settings.GroupSort != "" {
+			if _, err := task.ParseSort(l.settings.GroupSort); err != nil {
+				problems = append(problems, fmt.Sprintf("[%s] group_sort: %v", l.key, err))
+			}
+		}
+		valid := validGroups(l.key)
+		if l.settings.Group != "" && !slices.Contains(valid, l.settings.Group) {
+			problems = append(problems, fmt.Sprintf("[%s] group: invalid value %q (valid: %s)", l.key, l.settings.Group, strings.Join(valid, ", ")))
+		}
+		problems = append(problems, columnProblems("["+l.key+"] ", l.settings.Columns, l.settings.Widths)...)
+		if l.settings.Overflow != "" && !slices.Contains(output.OverflowModes(), l.settings.Overflow) {
+			problems = append(problems, fmt.Sprintf("[%s] overflow: invalid value %q (valid: %s)", l.key, l.settings.Overflow, strings.Join(output.OverflowModes(), ", ")))
+		}
+	}
+
+	scheduleGroups := []string{"today", "upcoming", "anytime", "someday"}
+	for _, schedule := range slices.Sorted(maps.Keys(cfg.ScheduleSort)) {
+		if !slices.Contains(scheduleGroups, schedule) {
+			problems = append(problems, fmt.Sprintf("[schedule_sort] unknown group %q (valid: %s)", schedule, strings.Join(scheduleGroups, ", ")))
+			continue
+		}
+		if _, err := task.ParseSort(cfg.ScheduleSort[schedule]); err != nil {
+			problems = append(problems, fmt.Sprintf("[schedule_sort] %s: %v", schedule, err))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.Areas)) {
+		a := cfg.Areas[name]
+		if a.Sort != "" {
+			if _, err := task.ParseSort(a.Sort); err != nil {
+				problems = append(problems, fmt.Sprintf("[areas.%s] sort: %v", name, err))
+			}
+		}
+		if valid := validGroups("area"); a.Group != "" && !slices.Contains(valid, a.Group) {
+			problems = append(problems, fmt.Sprintf("[areas.%s] group: invalid value %q (valid: %s)", name, a.Group, strings.Join(valid, ", ")))
+		}
+		if a.PlannedIn != nil && *a.PlannedIn < 0 {
+			problems = append(problems, fmt.Sprintf("[areas.%s] planned_in: must be 0 or more, got %d", name, *a.PlannedIn))
+		}
+		if a.WIPLimit < 0 {
+			problems = append(problems, fmt.Sprintf("[areas.%s] wip_limit: must be 0 or more, got %d", name, a.WIPLimit))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Projects)) {
+		if p := cfg.Projects[name]; p.WIPLimit < 0 {
+			problems = append(problems, fmt.Sprintf("[projects.%s] wip_limit: must be 0 or more, got %d", name, p.WIPLimit))
+		}
+	}
+
+	if d := cfg.Defaults; d.Planned != "" {
+		if _, err := dateparse.Parse(d.Planned); err != nil {
+			problems = append(problems, fmt.Sprintf("[defaults] planned: %v", err))
+		}
+		if d.State == string(task.StateSomeday) {
+			problems = append(problems, `[defaults] planned: can't be combined with state = "someday"`)
+		}
+	}
+	if state := cfg.Defaults.State; state != "" && state != string(task.StateActive) && state != string(task.StateSomeday) {
+		problems = append(problems, fmt.Sprintf("[defaults] state: invalid value %q (valid: %s, %s)", state, task.StateActive, task.StateSomeday))
+	}
+
+	if cfg.UpcomingDays < 0 {
+		problems = append(problems, fmt.Sprintf("upcoming_days: must be 0 or more, got %d", cfg.UpcomingDays))
+	}
+	if cfg.DayRolloverHour < 0 || cfg.DayRolloverHour > 23 {
+		problems = append(problems, fmt.Sprintf("day_rollover_hour: must be between 0 and 23, got %d", cfg.DayRolloverHour))
+	}
+	if cfg.Storage != "" && !slices.Contains(config.StorageModes(), cfg.Storage) {
+		problems = append(problems, fmt.Sprintf("storage: invalid value %q (valid: %s)", cfg.Storage, strings.Join(config.StorageModes(), ", ")))
+	}
+	if cfg.Storage == config.StoragePostgres && !database.IsPostgres(cfg.DatabaseURL) {
+		problems = append(problems, `database_url: storage = "postgres" needs a postgres:// URL`)
+	}
+	if cfg.HolidayMode != "" && !slices.Contains(task.HolidayModes(), cfg.HolidayMode) {
+		problems = append(problems, fmt.Sprintf("holiday_mode: invalid value %q (valid: %s)", cfg.HolidayMode, strings.Join(task.HolidayModes(), ", ")))
+	}
+	if cfg.ExpireAction != "" && !slices.Contains(task.ExpireActions(), cfg.ExpireAction) {
+		problems = append(problems, fmt.Sprintf("expire_action: invalid value %q (valid: %s)", cfg.ExpireAction, strings.Join(task.ExpireActions(), ", ")))
+	}
+	if cfg.Language != "" && !slices.Contains(i18n.Languages(), cfg.Language) {
+		problems = append(problems, fmt.Sprintf("language: invalid value %q (valid: %s)", cfg.Language, strings.Join(i18n.Languages(), ", ")))
+	}
+	for _, setting := range []struct {
+		key   string
+		value int
+	}{
+		{"archive_after_days", cfg.Maintain.ArchiveAfterDays},
+		{"someday_stale_days", cfg.Maintain.SomedayStaleDays},
+		{"backups", cfg.Maintain.Backups},
+	} {
+		if setting.value < 0 {
+			problems = append(problems, fmt.Sprintf("[maintain] %s: must be 0 or more, got %d", setting.key, setting.value))
+		}
+	}
+	if cfg.DailyCapacity != "" {
+		if _, err := task.ParseEstimate(cfg.DailyCapacity); err != nil {
+			problems = append(problems, fmt.Sprintf("daily_capacity: %v", err))
+		}
+	}
+
+	theme := cfg.Theme
+	var themeNames []string
+	for _, info := range output.Themes(&theme) {
+		themeNames = append(themeNames, info.Name)
+	}
+	if theme.Name != "" && !slices.Contains(themeNames, theme.Name) {
+		problems = append(problems, fmt.Sprintf("[theme] name: unknown theme %q (available: %s)", theme.Name, strings.Join(themeNames, ", ")))
+	}
+	problems = append(problems, colorProblems("[theme]", config.PresetConfig{
+		Muted: theme.Muted, Accent: theme.Accent, Warning: theme.Warning, Success: theme.Success,
+		Error: theme.Error, Header: theme.Header, ID: theme.ID, Scope: theme.Scope,
+	})...)
+	if theme.TUI.Border != "" && !slices.Contains(config.TUIBorders(), theme.TUI.Border) {
+		problems = append(problems, fmt.Sprintf("[theme.tui] border: invalid value %q (valid: %s)", theme.TUI.Border, strings.Join(config.TUIBorders(), ", ")))
+	}
+	tuiColors := []struct{ key, value string }{
+		{"focused_border", theme.TUI.FocusedBorder}, {"unfocused_border", theme.TUI.UnfocusedBorder},
+		{"selected", theme.TUI.Selected}, {"modal_border", theme.TUI.ModalBorder}, {"modal_title", theme.TUI.ModalTitle},
+	}
+	for _, c := range tuiColors {
+		if c.value == "" {
+			continue
+		}
+		if err := output.ValidateColor(c.value); err != nil {
+			problems = append(problems, fmt.Sprintf("[theme.tui] %s: %v", c.key, err))
+		}
+	}
+	presetNames := slices.Sorted(maps.Keys(theme.Presets))
+	for _, name := range presetNames {
+		problems = append(problems, colorProblems(fmt.Sprintf("[theme.presets.%s]", name), theme.Presets[name])...)
+	}
+
+	return problems
+}
+
+// columnProblems reports unknown column names and invalid widths.
+// prefix is prepended to each key, e.g. "[today] ".
+func columnProblems(prefix string, columns []string, widths map[string]int) []string {
+	var problems []string
+	valid := strings.Join(output.Columns(), ", ")
+	for _, name := range columns {
+		if !output.IsColumn(name) {
+			problems = append(problems, fmt.Sprintf("%scolumns: unknown column %q (valid: %s)", prefix, name, valid))
+		}
+	}
+
+	names := make([]string, 0, len(widths))
+	for name := range widths {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if !output.IsColumn(name) {
+			problems = append(problems, fmt.Sprintf("%swidths: unknown column %q (valid: %s)", prefix, name, valid))
+		} else if widths[name] < 0 {
+			problems = append(problems, fmt.Sprintf("%swidths.%s: must be 0 or more, got %d", prefix, name, widths[name]))
+		}
+	}
+	return problems
+}
+
+// colorProblems validates every color set in a preset, prefixing each problem with section
+func colorProblems(section string, colors config.PresetConfig) []string {
+	fields := []struct{ key, value string }{
+		{"muted", colors.Muted}, {"accent", colors.Accent}, {"warning", colors.Warning},
+		{"success", colors.Success}, {"error", colors.Error}, {"header", colors.Header},
+		{"id", colors.ID}, {"scope", colors.Scope},
+	}
+
+	var problems []string
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if err := output.ValidateColor(f.value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s %s: %v", section, f.key, err))
+		}
+	}
+	return problems
+}
+
+// validGroups returns the group values a list accepts
+func validGroups(list string) []string {
+	switch list {
+	case "project_list":
+		return []string{"area", "none"}
+	case "log":
+		return []string{"scope", "date", "week", "none"}
+	case "list", "project", "area", "tag":
+		// tt list and its filters can also split tasks by schedule
+		return []string{"scope", "date", "schedule", "tag", "state", "none"}
+	}
+	return []string{"scope", "date", "tag", "state", "none"}
+}
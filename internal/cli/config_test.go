@@ -0,0 +1,87 @@
+package cli_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/cli"
+)
+
+func TestConfigProblems(t *testing.T) {
+	valid := &config.Config{
+		Sort:            "planned,title",
+		Group:           "date",
+		DayRolloverHour: 4,
+		DailyCapacity:   "6h",
+		HolidayMode:     "shift",
+		ExpireAction:    "delete",
+		Maintain:        config.MaintainSettings{ArchiveAfterDays: 365, Backups: 7},
+		Columns:         []string{"id", "title", "due"},
+		Widths:          map[string]int{"title": 40},
+		Overflow:        "wrap",
+		ProjectList:     config.ListSettings{Group: "area"},
+		Area:            config.ListSettings{GroupSort: "due:asc,planned"},
+		ScheduleSort:    map[string]string{"today": "due", "upcoming": "planned:desc"},
+		Areas:           map[string]config.AreaSettings{"Work": {Sort: "due", Group: "date", Tags: []string{"work"}}},
+		Defaults:        config.TaskDefaults{Tags: []string{"new"}, Planned: "today", State: "active", Project: "Chores"},
+		Theme:           config.ThemeConfig{Name: "nord", Muted: "245", Accent: "#f1fa8c", Warning: "red|bright-red", Success: "Green"},
+	}
+	if problems := cli.ConfigProblems(valid); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+
+	negative := -1
+	invalid := &config.Config{
+		Sort:            "priority",
+		Today:           config.ListSettings{Group: "area", Columns: []string{"id", "priority"}},
+		Upcoming:        config.ListSettings{Widths: map[string]int{"title": -1}, Overflow: "scroll", GroupSort: "priority"},
+		DayRolloverHour: 25,
+		DailyCapacity:   "lots",
+		HolidayMode:     "ignore",
+		ExpireAction:    "archive",
+		Maintain:        config.MaintainSettings{Backups: -1},
+		ScheduleSort:    map[string]string{"today": "urgency", "later": "due"},
+		DateGroups:      []string{"today", "someday"},
+		Areas:           map[string]config.AreaSettings{"Work": {Group: "week", PlannedIn: &negative, WIPLimit: -1}},
+		Projects:        map[string]config.ProjectSettings{"Website": {WIPLimit: -3}},
+		Defaults:        config.TaskDefaults{Planned: "whenever", State: "later"},
+		StandupTemplate: "{{range .Today}}",
+		Theme: config.ThemeConfig{
+			Name: "solarized", Header: "purple", Error: "300",
+			TUI: config.TUIStyleConfig{Border: "dotted", Selected: "#zzz"},
+		},
+		Warnings: []string{`config.toml: unknown key "colour"`},
+	}
+	problems := cli.ConfigProblems(invalid)
+
+	for _, want := range []string{"unknown key", "sort:", "[today] group", "[today] columns", "[upcoming] widths.title", "[upcoming] overflow", "[upcoming] group_sort", "day_rollover_hour", "daily_capacity", "holiday_mode", "expire_action", "[maintain] backups", "[schedule_sort] today", "[schedule_sort] unknown group \"later\"", "date_groups", "[areas.Work] group", "[areas.Work] planned_in", "[areas.Work] wip_limit", "[projects.Website] wip_limit", "[defaults] planned", "[defaults] state", "standup_template", "[theme] name", "[theme] header", "[theme] error", "[theme.tui] border", "[theme.tui] selected"} {
+		found := false
+		for _, p := range problems {
+			if strings.Contains(p, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing problem containing %q in %v", want, problems)
+		}
+	}
+}
+
+func TestConfigProblemsCustomPreset(t *testing.T) {
+	cfg := &config.Config{
+		Theme: config.ThemeConfig{
+			Name: "mine",
+			Presets: map[string]config.PresetConfig{
+				"mine":   {Accent: "magenta", Muted: "8"},
+				"broken": {Scope: "#12"},
+			},
+		},
+	}
+
+	problems := cli.ConfigProblems(cfg)
+	if len(problems) != 1 || !strings.Contains(problems[0], "[theme.presets.broken] scope") {
+		t.Errorf("got %v, want one problem for the broken preset", problems)
+	}
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// addStrictDatesFlag adds --strict-dates, which turns date warnings into errors
+func addStrictDatesFlag(cmd *cobra.Command, strict *bool) {
+	cmd.Flags().BoolVar(strict, "strict-dates", false, "Refuse past due dates and planned dates after the due date instead of warning")
+}
+
+// checkDates reports suspicious planned and due dates (see task.DateWarnings)
+// before they are saved. Normally they are printed as warnings; when strict,
+// they are returned as an error so nothing gets changed. A non-empty label
+// such as "task 3" prefixes each message.
+func checkDates(deps *Dependencies, formatter *output.Formatter, strict bool, label string, planned, due *time.Time) error {
+	today := deps.today()
+	warnings := task.DateWarnings(planned, due, today)
+	if label != "" {
+		for i, w := range warnings {
+			warnings[i] = label + ": " + w
+		}
+	}
+
+	if strict && len(warnings) > 0 {
+		return errors.New(strings.Join(warnings, "; "))
+	}
+	for _, w := range warnings {
+		formatter.Warning(w)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/database"
+	"github.com/spf13/cobra"
+)
+
+func NewDBCmd(deps *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Inspect the database file",
+	}
+
+	cmd.AddCommand(newDBCheckCmd(deps))
+
+	return cmd
+}
+
+func newDBCheckCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Check the database file for corruption",
+		Long: `Check the database file for corruption with SQLite's integrity check.
+
+The file is opened read-only and nothing is migrated, so this also works on
+a database tt refuses to start with. Use --db to check another file, e.g. a
+backup written by tt maintain.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			if deps.Config.Storage == config.StorageFiles {
+				return fmt.Errorf("storage is %q: there is no database to check; files are checked as they're read", config.StorageFiles)
+			}
+			if deps.Config.Storage == config.StoragePostgres {
+				return fmt.Errorf("storage is %q: the server keeps the database consistent; check it with the server's tools", config.StoragePostgres)
+			}
+			path := deps.Config.Database
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("checking %s: %w", path, err)
+			}
+			db, err := database.OpenReadOnly(path)
+			if err != nil {
+				return fmt.Errorf("checking %s: %w", path, err)
+			}
+			defer db.Close()
+
+			problems, err := db.Check()
+			if err != nil {
+				return fmt.Errorf("checking %s: %w", path, err)
+			}
+			formatter := deps.formatter(os.Stdout)
+			formatter.DatabaseCheck(path, problems)
+			if len(problems) > 0 {
+				return fmt.Errorf("found %d problem(s) in database", len(problems))
+			}
+			return nil
+		},
+	}
+}
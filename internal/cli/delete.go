@@ -1,11 +1,8 @@
 package cli
 
 import (
-	"errors"
 	"os"
-	"strconv"
 
-	"github.com/devbydaniel/tt/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -13,23 +10,23 @@ func NewDeleteCmd(deps *Dependencies) *cobra.Command {
 	return &cobra.Command{
 		Use:   "delete <id> [id...]",
 		Short: "Delete task(s)",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `Delete task(s).
+
+IDs can be given as ranges and comma-separated lists, e.g. 4-9,12. If any
+of them doesn't exist, nothing is changed.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ids := make([]int64, 0, len(args))
-			for _, arg := range args {
-				id, err := strconv.ParseInt(arg, 10, 64)
-				if err != nil {
-					return errors.New("invalid task ID: " + arg)
-				}
-				ids = append(ids, id)
+			ids, err := parseIDs(args)
+			if err != nil {
+				return err
 			}
 
-			deleted, err := deps.App.DeleteTasks.Execute(ids)
+			deleted, err := deps.App.Tasks.Delete(ids)
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.TasksDeleted(deleted)
 			return nil
 		},
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func NewDependCmd(deps *Dependencies) *cobra.Command {
+	var clear bool
+
+	cmd := &cobra.Command{
+		Use:   "depend <task-id> <depends-on-id>...",
+		Short: "Set the tasks that have to be done before a task",
+		Long: `Set the tasks that have to be done before a task can start, replacing
+any set before. tt project plan schedules tasks after the ones they depend on.
+
+Examples:
+  t depend 12 10 11
+  t depend 12 4-6,9
+  t depend 12 --clear`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			if !clear && len(args) == 1 {
+				return errors.New("task IDs to depend on required (or use --clear to remove)")
+			}
+			if clear && len(args) > 1 {
+				return errors.New("--clear takes no task IDs to depend on")
+			}
+
+			dependsOn, err := parseIDs(args[1:])
+			if err != nil {
+				return err
+			}
+
+			t, err := deps.App.Tasks.SetDependencies(id, dependsOn)
+			if err != nil {
+				return err
+			}
+			deps.formatter(os.Stdout).TaskDependenciesSet(t, dependsOn)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&clear, "clear", false, "Clear the dependencies")
+
+	return cmd
+}
@@ -1,37 +1,54 @@
 package cli
 
 import (
-	"errors"
 	"os"
-	"strconv"
+	"strings"
 
-	"github.com/devbydaniel/tt/internal/output"
 	"github.com/spf13/cobra"
 )
 
 func NewDoCmd(deps *Dependencies) *cobra.Command {
-	return &cobra.Command{
+	var note string
+
+	cmd := &cobra.Command{
 		Use:   "do <id> [id...]",
 		Short: "Mark task(s) as complete",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `Mark task(s) as complete.
+
+IDs can be given as ranges and comma-separated lists, e.g. 4-9,12. If any
+of them doesn't exist, nothing is changed.
+
+--note records how the task turned out, e.g. what was shipped or where it
+was filed. It's shown in the logbook and by tt show.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ids := make([]int64, 0, len(args))
-			for _, arg := range args {
-				id, err := strconv.ParseInt(arg, 10, 64)
-				if err != nil {
-					return errors.New("invalid task ID: " + arg)
-				}
-				ids = append(ids, id)
+			ids, err := parseIDs(args)
+			if err != nil {
+				return err
 			}
 
-			completed, err := deps.App.CompleteTasks.Execute(ids)
+			completed, err := deps.App.Tasks.Complete(ids)
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			if note = strings.TrimSpace(note); note != "" {
+				for i, r := range completed {
+					t, err := deps.App.Tasks.SetCompletionNote(r.Completed.ID, &note)
+					if err != nil {
+						return err
+					}
+					completed[i].Completed = *t
+				}
+			}
+
+			formatter := deps.formatter(os.Stdout)
 			formatter.TasksCompleted(completed)
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&note, "note", "", "Note on how the task turned out")
+
+	return cmd
 }
@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/database"
+	"github.com/devbydaniel/tt/internal/filestore"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewDoctorCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the environment, e.g. for bug reports",
+		Long: `Diagnose the environment tt runs in: config file and its problems, data
+directory, database size, schema version and task counts, terminal
+capabilities and locale. Anything that looks wrong is listed at the end.
+
+The database is opened read-only and never created or migrated, so doctor
+also works when other commands fail. Paste the output into bug reports.`,
+		Args: cobra.NoArgs,
+		// Problems are part of the report; skip the startup warnings
+		Annotations: map[string]string{skipConfigWarningsAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			report := diagnose(deps)
+			formatter := deps.formatter(os.Stdout)
+			formatter.DoctorSummary(report)
+			if len(report.Issues) > 0 {
+				return fmt.Errorf("found %d issue(s)", len(report.Issues))
+			}
+			return nil
+		},
+	}
+}
+
+// diagnose gathers the doctor report. Failures become issues rather than
+// errors, so as much as possible is reported.
+func diagnose(deps *Dependencies) output.DoctorReport {
+	cfg := deps.Config
+	r := output.DoctorReport{
+		ConfigPath:   config.Path(),
+		DataDir:      filepath.Dir(cfg.Database),
+		Database:     cfg.Database,
+		DatabaseSize: -1,
+		ColorProfile: colorProfile(),
+		Width:        terminalWidth(),
+		Hyperlinks:   hyperlinks(),
+		Language:     cfg.Language,
+		SystemLocale: systemLocale(),
+	}
+	if r.Language == "" {
+		r.Language = "en"
+	}
+
+	if _, err := os.Stat(r.ConfigPath); err == nil {
+		r.ConfigExists = true
+	}
+	for _, problem := range ConfigProblems(cfg) {
+		r.Issues = append(r.Issues, "config: "+problem)
+	}
+
+	if cfg.Storage == config.StorageFiles {
+		r.Database = cfg.Files
+		r.Issues = append(r.Issues, diagnoseFiles(cfg.Files, &r)...)
+		return r
+	}
+
+	if cfg.Storage == config.StoragePostgres {
+		r.Database = database.Redact(cfg.DatabaseURL)
+		r.Issues = append(r.Issues, diagnoseDatabase(cfg.DatabaseURL, &r)...)
+		return r
+	}
+
+	info, err := os.Stat(cfg.Database)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			r.Issues = append(r.Issues, fmt.Sprintf("database: %v", err))
+		}
+		return r
+	}
+	r.DatabaseSize = info.Size()
+	r.Issues = append(r.Issues, diagnoseDatabase(cfg.Database, &r)...)
+	return r
+}
+
+// diagnoseFiles fills in the task counts of the files directory at dir and
+// returns the issues found. Every task file is read, so malformed ones show up.
+func diagnoseFiles(dir string, r *output.DoctorReport) []string {
+	if _, err := os.Stat(dir); err != nil {
+		if !os.IsNotExist(err) {
+			return []string{fmt.Sprintf("files: %v", err)}
+		}
+		return nil
+	}
+	store := filestore.OpenReadOnly(dir)
+	a := app.NewWithStores(app.Stores{Tasks: store.Tasks, Areas: store.Areas, Holidays: store.Holidays, Comments: store.Comments}, app.Options{})
+	counts, err := a.CountTasks.Execute()
+	if err != nil {
+		return []string{fmt.Sprintf("files: counting tasks: %v", err)}
+	}
+	r.Counts = &counts
+	return nil
+}
+
+// diagnoseDatabase fills in the schema and task counts of the database at
+// path, a file or a Postgres URL, and returns the issues found
+func diagnoseDatabase(path string, r *output.DoctorReport) []string {
+	db, err := database.OpenReadOnly(path)
+	if err != nil {
+		return []string{fmt.Sprintf("database: %v", err)}
+	}
+	defer db.Close()
+
+	var issues []string
+	version, err := db.Version()
+	if err != nil {
+		return []string{fmt.Sprintf("database: %v", err)}
+	}
+	latest := database.SchemaVersion()
+	r.Schema = fmt.Sprintf("%d of %d", version, latest)
+	switch {
+	case version < latest:
+		issues = append(issues, fmt.Sprintf("database: %d migration(s) pending; they are applied on the next command that opens it writable", latest-version))
+	case version > latest:
+		issues = append(issues, "database: schema is newer than this build of tt; upgrade tt")
+	}
+
+	// A Postgres server checks its own files, and there's no file to write
+	local := db.Driver != database.Postgres
+	if !local {
+		if size, err := db.Size(); err == nil {
+			r.DatabaseSize = size
+		}
+	} else if problems, err := db.Check(); err != nil {
+		issues = append(issues, fmt.Sprintf("database: %v", err))
+	} else if len(problems) > 0 {
+		issues = append(issues, fmt.Sprintf("database: integrity check found %d problem(s); see tt db check", len(problems)))
+	}
+
+	counts, err := app.New(db).CountTasks.Execute()
+	if err != nil {
+		return append(issues, fmt.Sprintf("database: counting tasks: %v", err))
+	}
+	r.Counts = &counts
+
+	if !local {
+		return issues
+	}
+	if f, err := os.OpenFile(path, os.O_WRONLY, 0); err != nil {
+		issues = append(issues, fmt.Sprintf("database: not writable: %v", err))
+	} else {
+		f.Close()
+	}
+	return issues
+}
+
+// colorProfile describes the colors the terminal on stdout supports
+func colorProfile() string {
+	profile := lipgloss.ColorProfile().Name()
+	if output.NoColorRequested() {
+		profile += " (NO_COLOR set)"
+	}
+	return profile
+}
+
+// terminalWidth describes the width of stdout
+func terminalWidth() string {
+	if width := output.TerminalWidth(os.Stdout); width > 0 {
+		return strconv.Itoa(width)
+	}
+	return "not a terminal"
+}
+
+// hyperlinks guesses whether the terminal renders OSC 8 hyperlinks. There is
+// no way to ask, so this goes by the terminal programs known to support them.
+func hyperlinks() string {
+	known := map[string]string{
+		"iTerm.app":      "iTerm2",
+		"WezTerm":        "WezTerm",
+		"vscode":         "VS Code",
+		"ghostty":        "Ghostty",
+		"Hyper":          "Hyper",
+		"Apple_Terminal": "",
+	}
+	if name, ok := known[os.Getenv("TERM_PROGRAM")]; ok {
+		if name == "" {
+			return "unsupported (Terminal.app)"
+		}
+		return "likely supported (" + name + ")"
+	}
+	switch {
+	case os.Getenv("WT_SESSION") != "":
+		return "likely supported (Windows Terminal)"
+	case os.Getenv("KITTY_WINDOW_ID") != "":
+		return "likely supported (kitty)"
+	case os.Getenv("VTE_VERSION") != "":
+		if v, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && v >= 5000 {
+			return "likely supported (VTE " + os.Getenv("VTE_VERSION") + ")"
+		}
+	}
+	return "unknown"
+}
+
+// systemLocale returns the locale from the environment, like setlocale does
+func systemLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return fmt.Sprintf("%s (%s)", value, name)
+		}
+	}
+	return ""
+}
@@ -2,8 +2,12 @@ package cli
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/devbydaniel/tt/internal/dateparse"
 	"github.com/devbydaniel/tt/internal/output"
@@ -12,33 +16,51 @@ import (
 
 func NewDueCmd(deps *Dependencies) *cobra.Command {
 	var clear bool
+	var strictDates bool
+	var within string
+	var jsonOutput bool
 
 	cmd := &cobra.Command{
-		Use:     "due <task-id> [date]",
+		Use:     "due [<task-id> [date]]",
 		Aliases: []string{"d"},
-		Short:   "Set the due date of a task",
-		Long: `Set the due date of a task.
+		Short:   "List deadlines, or set the due date of a task",
+		Long: `Without arguments, list tasks with a due date, soonest first, with a
+countdown to each date. Overdue tasks come first; planned dates are ignored.
+Use --within to only list what is due within a period.
+
+With a task ID, set the due date of that task.
 
 Examples:
+  t due
+  t due --within 7d
+  t due --within "end of month"
   t due 1 today
   t due 1 tomorrow
   t due 1 friday
   t due 1 +1w
   t due 1 2025-01-15
   t due 1 --clear`,
-		Args: cobra.RangeArgs(1, 2),
+		Args: cobra.RangeArgs(0, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return listDue(deps, within, jsonOutput)
+			}
+			// Only setting writes, so the command isn't marked mutating
+			if err := deps.requireWritable(); err != nil {
+				return err
+			}
+
 			id, err := strconv.ParseInt(args[0], 10, 64)
 			if err != nil {
 				return errors.New("invalid task ID")
 			}
 
 			if clear {
-				t, err := deps.App.SetDueDate.Execute(id, nil)
+				t, err := deps.App.Tasks.SetDueDate(id, nil)
 				if err != nil {
 					return err
 				}
-				formatter := output.NewFormatter(os.Stdout, deps.Theme)
+				formatter := deps.formatter(os.Stdout)
 				formatter.TaskDueDateSet(t)
 				return nil
 			}
@@ -47,23 +69,86 @@ Examples:
 				return errors.New("date required (or use --clear to remove)")
 			}
 
-			date, err := dateparse.Parse(args[1])
+			date, err := dateparse.ParseFrom(args[1], deps.today())
 			if err != nil {
 				return err
 			}
 
-			t, err := deps.App.SetDueDate.Execute(id, &date)
+			current, err := deps.App.Tasks.Get(id)
+			if err != nil {
+				return err
+			}
+			formatter := deps.formatter(os.Stdout)
+			if err := checkDates(deps, formatter, strictDates, "", current.PlannedDate, &date); err != nil {
+				return err
+			}
+
+			t, err := deps.App.Tasks.SetDueDate(id, &date)
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
 			formatter.TaskDueDateSet(t)
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&clear, "clear", false, "Clear the due date")
+	cmd.Flags().StringVar(&within, "within", "", "When listing, only tasks due within a period (e.g. 7d, 2w) or by a date")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "When listing, output as JSON")
+	addStrictDatesFlag(cmd, &strictDates)
 
 	return cmd
 }
+
+// listDue prints the tasks with due dates, limited to those due within the
+// --within period if given
+func listDue(deps *Dependencies, within string, jsonOutput bool) error {
+	today := deps.today()
+
+	var until *time.Time
+	if within != "" {
+		date, err := parseWithin(within, today)
+		if err != nil {
+			return err
+		}
+		until = &date
+	}
+
+	tasks, err := deps.App.Tasks.Due(until)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		return output.WriteJSON(os.Stdout, tasks)
+	}
+
+	formatter := deps.formatter(os.Stdout)
+	applyListLayout(formatter, deps.Config, "")
+	formatter.DueList(tasks, today)
+	return nil
+}
+
+// withinPeriod matches bare periods like "7d" or "2w"
+var withinPeriod = regexp.MustCompile(`^\d+[dwmy]$`)
+
+// parseWithin turns a --within value into the last date it includes: a
+// period counted from today ("7d", "2w", or just "7" for days) or any date
+// dateparse understands
+func parseWithin(s string, today time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 {
+			return time.Time{}, fmt.Errorf("invalid --within %q: must not be negative", s)
+		}
+		return today.AddDate(0, 0, n), nil
+	}
+	if withinPeriod.MatchString(s) {
+		s = "+" + s
+	}
+	date, err := dateparse.ParseFrom(s, today)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --within: %w", err)
+	}
+	return date, nil
+}
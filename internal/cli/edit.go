@@ -2,11 +2,14 @@ package cli
 
 import (
 	"errors"
+	"fmt"
 	"os"
-	"strconv"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/devbydaniel/tt/internal/dateparse"
-	"github.com/devbydaniel/tt/internal/output"
+	"github.com/devbydaniel/tt/internal/domain/task"
 	"github.com/spf13/cobra"
 )
 
@@ -27,6 +30,20 @@ func NewEditCmd(deps *Dependencies) *cobra.Command {
 	var clearDescription bool
 	var someday bool
 	var active bool
+	var estimateStr string
+	var clearEstimate bool
+	var contextName string
+	var clearContext bool
+	var thisOnly bool
+	var allFuture bool
+	var strictDates bool
+	var strictWIP bool
+	var expiresStr string
+	var clearExpires bool
+	var assignee string
+	var clearAssignee bool
+	var location string
+	var clearLocation bool
 
 	cmd := &cobra.Command{
 		Use:     "edit <task-id>...",
@@ -38,25 +55,31 @@ Examples:
   t edit 1 --title "New title"
   t edit 1 --project Work
   t edit 1 2 3 --project Work
+  t edit 4-9,12 --tag imported
   t edit 1 --area Health
   t edit 1 --due tomorrow
   t edit 1 --planned +3d
+  t edit 1 --estimate 45m
+  t edit 1 --context deep
+  t edit 1 --assignee bob
+  t edit 1 --location @home
   t edit 1 --tag urgent --tag priority
   t edit 1 --untag old-tag
   t edit 1 --clear-project
   t edit 1 --clear-due
   t edit 1 --someday
-  t edit 1 --active`,
+  t edit 1 --active
+
+Edits to the title, description, project, area, tags, estimate or context of
+a recurring task also apply to its future occurrences (--all-future, the
+default). Use --this-only to change just this occurrence:
+  t edit 1 --title "Water plants (balcony)" --this-only`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Parse all task IDs first
-			var ids []int64
-			for _, arg := range args {
-				id, err := strconv.ParseInt(arg, 10, 64)
-				if err != nil {
-					return errors.New("invalid task ID: " + arg)
-				}
-				ids = append(ids, id)
+			ids, err := parseIDs(args)
+			if err != nil {
+				return err
 			}
 
 			// Validate mutual exclusivity
@@ -84,29 +107,79 @@ Examples:
 			if dueStr != "" && clearDue {
 				return errors.New("cannot specify both --due and --clear-due")
 			}
+			if expiresStr != "" && clearExpires {
+				return errors.New("cannot specify both --expires and --clear-expires")
+			}
 			if description != "" && clearDescription {
 				return errors.New("cannot specify both --description and --clear-description")
 			}
+			if estimateStr != "" && clearEstimate {
+				return errors.New("cannot specify both --estimate and --clear-estimate")
+			}
+
+			if contextName != "" && clearContext {
+				return errors.New("cannot specify both --context and --clear-context")
+			}
+			if assignee != "" && clearAssignee {
+				return errors.New("cannot specify both --assignee and --clear-assignee")
+			}
+			if location != "" && clearLocation {
+				return errors.New("cannot specify both --location and --clear-location")
+			}
+			if contextName != "" {
+				if _, err := task.ParseContext(contextName); err != nil {
+					return err
+				}
+			}
+
+			var estimate *int
+			if estimateStr != "" {
+				minutes, err := task.ParseEstimate(estimateStr)
+				if err != nil {
+					return err
+				}
+				estimate = &minutes
+			}
+
+			var planned, due *time.Time
+			if plannedStr != "" {
+				parsed, err := dateparse.ParseFrom(plannedStr, deps.today())
+				if err != nil {
+					return err
+				}
+				planned = &parsed
+			}
+			if dueStr != "" {
+				parsed, err := dateparse.ParseFrom(dueStr, deps.today())
+				if err != nil {
+					return err
+				}
+				due = &parsed
+			}
+			var expires *time.Time
+			if expiresStr != "" {
+				parsed, err := dateparse.ParseFrom(expiresStr, deps.today())
+				if err != nil {
+					return err
+				}
+				expires = &parsed
+			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 
 			// If no changes specified and single task, show details
 			hasChanges := title != "" || description != "" || projectName != "" || areaName != "" ||
 				plannedStr != "" || dueStr != "" || today || clearPlanned || clearDue ||
 				clearProject || clearArea || clearDescription || len(addTags) > 0 || len(removeTags) > 0 ||
-				someday || active
+				someday || active || estimateStr != "" || clearEstimate ||
+				contextName != "" || clearContext || expiresStr != "" || clearExpires ||
+				assignee != "" || clearAssignee || location != "" || clearLocation
 
 			if !hasChanges {
 				if len(ids) == 1 {
-					t, err := deps.App.GetTask.Execute(ids[0])
-					if err != nil {
-						return err
-					}
-					formatter.TaskDetails(t)
-				} else {
-					return errors.New("no changes specified")
+					return showTask(deps, ids[0])
 				}
-				return nil
+				return errors.New("no changes specified")
 			}
 
 			// Build changes list once (same for all tasks)
@@ -139,6 +212,31 @@ Examples:
 			} else if clearDue {
 				changes = append(changes, "due date cleared")
 			}
+			if expiresStr != "" {
+				changes = append(changes, "expiry date")
+			} else if clearExpires {
+				changes = append(changes, "expiry date cleared")
+			}
+			if estimateStr != "" {
+				changes = append(changes, "estimate")
+			} else if clearEstimate {
+				changes = append(changes, "estimate cleared")
+			}
+			if contextName != "" {
+				changes = append(changes, "context")
+			} else if clearContext {
+				changes = append(changes, "context cleared")
+			}
+			if assignee != "" {
+				changes = append(changes, "assignee")
+			} else if clearAssignee {
+				changes = append(changes, "assignee cleared")
+			}
+			if location != "" {
+				changes = append(changes, "location")
+			} else if clearLocation {
+				changes = append(changes, "location cleared")
+			}
 			if len(addTags) > 0 {
 				changes = append(changes, "tags added")
 			}
@@ -152,99 +250,198 @@ Examples:
 				changes = append(changes, "moved to active")
 			}
 
+			// Fields that carry over to future occurrences of recurring tasks
+			var recurFields []string
+			if title != "" {
+				recurFields = append(recurFields, task.FieldTitle)
+			}
+			if description != "" || clearDescription {
+				recurFields = append(recurFields, task.FieldDescription)
+			}
+			if projectName != "" || clearProject {
+				recurFields = append(recurFields, task.FieldProject)
+			}
+			if areaName != "" || clearArea {
+				recurFields = append(recurFields, task.FieldArea)
+			}
+			if len(addTags) > 0 || len(removeTags) > 0 {
+				recurFields = append(recurFields, task.FieldTags)
+			}
+			if estimateStr != "" || clearEstimate {
+				recurFields = append(recurFields, task.FieldEstimate)
+			}
+			if contextName != "" || clearContext {
+				recurFields = append(recurFields, task.FieldContext)
+			}
+
+			// Check the resulting dates of every task before changing any
+			if planned != nil || due != nil {
+				for _, id := range ids {
+					t, err := deps.App.Tasks.Get(id)
+					if err != nil {
+						return err
+					}
+					newPlanned, newDue := planned, due
+					if newPlanned == nil && !clearPlanned {
+						newPlanned = t.PlannedDate
+					}
+					if newDue == nil && !clearDue {
+						newDue = t.DueDate
+					}
+					label := ""
+					if len(ids) > 1 {
+						label = fmt.Sprintf("task %d", id)
+					}
+					if err := checkDates(deps, formatter, strictDates, label, newPlanned, newDue); err != nil {
+						return err
+					}
+				}
+			}
+
+			if active && strictWIP {
+				if err := checkWIP(deps, ids); err != nil {
+					return err
+				}
+			}
+
 			// Apply changes to all tasks
 			for _, id := range ids {
+				if thisOnly && len(recurFields) > 0 {
+					// Pin the current fields for later occurrences before editing
+					if _, err := deps.App.Tasks.KeepRecurTemplate(id); err != nil {
+						return err
+					}
+				}
+
 				if title != "" {
-					if _, err := deps.App.SetTaskTitle.Execute(id, title); err != nil {
+					if _, err := deps.App.Tasks.SetTitle(id, title); err != nil {
 						return err
 					}
 				}
 
 				if description != "" {
-					if _, err := deps.App.SetTaskDescription.Execute(id, &description); err != nil {
+					if _, err := deps.App.Tasks.SetDescription(id, &description); err != nil {
 						return err
 					}
 				} else if clearDescription {
-					if _, err := deps.App.SetTaskDescription.Execute(id, nil); err != nil {
+					if _, err := deps.App.Tasks.SetDescription(id, nil); err != nil {
 						return err
 					}
 				}
 
 				if projectName != "" {
-					if _, err := deps.App.SetTaskProject.Execute(id, projectName); err != nil {
+					if _, err := deps.App.Tasks.SetProject(id, projectName); err != nil {
 						return err
 					}
 				} else if clearProject {
-					if _, err := deps.App.SetTaskProject.Execute(id, ""); err != nil {
+					if _, err := deps.App.Tasks.SetProject(id, ""); err != nil {
 						return err
 					}
 				}
 
 				if areaName != "" {
-					if _, err := deps.App.SetTaskArea.Execute(id, areaName); err != nil {
+					if _, err := deps.App.Tasks.SetArea(id, areaName); err != nil {
 						return err
 					}
 				} else if clearArea {
-					if _, err := deps.App.SetTaskArea.Execute(id, ""); err != nil {
+					if _, err := deps.App.Tasks.SetArea(id, ""); err != nil {
 						return err
 					}
 				}
 
-				if plannedStr != "" {
-					planned, err := dateparse.Parse(plannedStr)
-					if err != nil {
+				if planned != nil {
+					if _, err := deps.App.Tasks.SetPlannedDate(id, planned); err != nil {
 						return err
 					}
-					if _, err := deps.App.SetPlannedDate.Execute(id, &planned); err != nil {
+				} else if clearPlanned {
+					if _, err := deps.App.Tasks.SetPlannedDate(id, nil); err != nil {
 						return err
 					}
-				} else if clearPlanned {
-					if _, err := deps.App.SetPlannedDate.Execute(id, nil); err != nil {
+				}
+
+				if due != nil {
+					if _, err := deps.App.Tasks.SetDueDate(id, due); err != nil {
+						return err
+					}
+				} else if clearDue {
+					if _, err := deps.App.Tasks.SetDueDate(id, nil); err != nil {
 						return err
 					}
 				}
 
-				if dueStr != "" {
-					due, err := dateparse.Parse(dueStr)
-					if err != nil {
+				if expires != nil || clearExpires {
+					if _, err := deps.App.Tasks.SetExpires(id, expires); err != nil {
 						return err
 					}
-					if _, err := deps.App.SetDueDate.Execute(id, &due); err != nil {
+				}
+
+				if estimate != nil || clearEstimate {
+					if _, err := deps.App.Tasks.SetEstimate(id, estimate); err != nil {
 						return err
 					}
-				} else if clearDue {
-					if _, err := deps.App.SetDueDate.Execute(id, nil); err != nil {
+				}
+
+				if contextName != "" || clearContext {
+					if _, err := deps.App.Tasks.SetContext(id, contextName); err != nil {
+						return err
+					}
+				}
+
+				if assignee != "" || clearAssignee {
+					if _, err := deps.App.Tasks.SetAssignee(id, assignee); err != nil {
+						return err
+					}
+				}
+
+				if location != "" || clearLocation {
+					if _, err := deps.App.Tasks.SetLocation(id, location); err != nil {
 						return err
 					}
 				}
 
 				for _, tag := range addTags {
-					if _, err := deps.App.AddTag.Execute(id, tag); err != nil {
+					if _, err := deps.App.Tasks.AddTag(id, tag); err != nil {
 						return err
 					}
 				}
 
 				for _, tag := range removeTags {
-					if _, err := deps.App.RemoveTag.Execute(id, tag); err != nil {
+					if _, err := deps.App.Tasks.RemoveTag(id, tag); err != nil {
 						return err
 					}
 				}
 
 				if someday {
-					if _, err := deps.App.DeferTask.Execute(id); err != nil {
+					if _, err := deps.App.Tasks.Defer(id); err != nil {
 						return err
 					}
 				}
 
 				if active {
-					if _, err := deps.App.ActivateTask.Execute(id); err != nil {
+					if _, err := deps.App.Tasks.Activate(id); err != nil {
 						return err
 					}
 				}
 
-				formatter.TaskEdited(id, changes)
+				taskChanges := changes
+				if !thisOnly && len(recurFields) > 0 {
+					n, err := deps.App.Tasks.PropagateRecurEdit(id, recurFields)
+					if err != nil {
+						return err
+					}
+					if n == 1 {
+						taskChanges = append(slices.Clone(changes), "1 other occurrence updated")
+					} else if n > 1 {
+						taskChanges = append(slices.Clone(changes), fmt.Sprintf("%d other occurrences updated", n))
+					}
+				}
+
+				formatter.TaskEdited(id, taskChanges)
 			}
 
+			if active {
+				return warnWIP(deps, formatter, ids)
+			}
 			return nil
 		},
 	}
@@ -260,16 +457,33 @@ Examples:
 	cmd.Flags().StringArrayVar(&removeTags, "untag", nil, "Remove tag (repeatable)")
 	cmd.Flags().BoolVar(&clearPlanned, "clear-planned", false, "Clear planned date")
 	cmd.Flags().BoolVar(&clearDue, "clear-due", false, "Clear due date")
+	cmd.Flags().StringVar(&expiresStr, "expires", "", "Set the date after which the task expires")
+	cmd.Flags().BoolVar(&clearExpires, "clear-expires", false, "Clear the expiry date")
 	cmd.Flags().BoolVar(&clearProject, "clear-project", false, "Remove from project")
 	cmd.Flags().BoolVar(&clearArea, "clear-area", false, "Remove from area")
 	cmd.Flags().BoolVar(&clearDescription, "clear-description", false, "Clear description")
+	cmd.Flags().StringVarP(&estimateStr, "estimate", "e", "", "Set effort estimate (e.g., 30m, 2h, 1h30m)")
+	cmd.Flags().BoolVar(&clearEstimate, "clear-estimate", false, "Clear effort estimate")
+	cmd.Flags().StringVarP(&contextName, "context", "c", "", "Set context: "+strings.Join(task.ValidContexts(), ", "))
+	cmd.Flags().BoolVar(&clearContext, "clear-context", false, "Clear context")
+	cmd.Flags().StringVar(&assignee, "assignee", "", "Assign to a person, in a shared database")
+	cmd.Flags().BoolVar(&clearAssignee, "clear-assignee", false, "Clear the assignee")
+	cmd.Flags().StringVar(&location, "location", "", "Set where it can be done (e.g. home, @office, a shop)")
+	cmd.Flags().BoolVar(&clearLocation, "clear-location", false, "Clear the location")
 	cmd.Flags().BoolVarP(&someday, "someday", "s", false, "Move to someday")
 	cmd.Flags().BoolVarP(&active, "active", "A", false, "Move to active")
 	cmd.MarkFlagsMutuallyExclusive("someday", "active")
+	cmd.Flags().BoolVar(&thisOnly, "this-only", false, "For recurring tasks, edit only this occurrence")
+	cmd.Flags().BoolVar(&allFuture, "all-future", false, "For recurring tasks, apply edits to future occurrences too (default)")
+	cmd.MarkFlagsMutuallyExclusive("this-only", "all-future")
+	addStrictDatesFlag(cmd, &strictDates)
+	addStrictWIPFlag(cmd, &strictWIP)
 
 	// Register completions
 	registry := NewCompletionRegistry(deps)
 	registry.RegisterAll(cmd)
+	registry.RegisterLocationFlag(cmd)
+	_ = cmd.RegisterFlagCompletionFunc("context", contextCompletion)
 
 	return cmd
 }
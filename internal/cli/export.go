@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/bundle"
+	"github.com/devbydaniel/tt/internal/database"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewExportCmd(deps *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export tasks for other tools, or all data as a bundle",
+	}
+
+	cmd.AddCommand(newExportBlocksCmd(deps))
+	cmd.AddCommand(newExportBundleCmd(deps))
+
+	return cmd
+}
+
+func newExportBlocksCmd(deps *Dependencies) *cobra.Command {
+	var ics bool
+	var outPath string
+
+	cmd := &cobra.Command{
+		Use:   "blocks",
+		Short: "Export the time blocks of open tasks as calendar events",
+		Long: `Export the time blocks of open tasks, set with tt block, as calendar
+events, so planned work shows up next to meetings.
+
+--ics writes an iCalendar file that calendar apps can import or subscribe
+to. Events keep their ID across exports, so importing again updates them.
+
+Examples:
+  t export blocks --ics > blocks.ics
+  t export blocks --ics -o ~/Calendars/tt.ics`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tasks, err := deps.App.Tasks.List(nil)
+			if err != nil {
+				return err
+			}
+
+			if outPath == "" {
+				return output.WriteBlocksICS(os.Stdout, tasks, deps.now())
+			}
+			f, err := os.Create(outPath)
+			if err != nil {
+				return err
+			}
+			if err := output.WriteBlocksICS(f, tasks, deps.now()); err != nil {
+				f.Close()
+				return err
+			}
+			return f.Close()
+		},
+	}
+
+	cmd.Flags().BoolVar(&ics, "ics", false, "Write an iCalendar (.ics) file")
+	cmd.Flags().StringVarP(&outPath, "output", "o", "", "Write to this file instead of stdout")
+	_ = cmd.MarkFlagRequired("ics")
+
+	return cmd
+}
+
+// Names of the data in a bundle
+const (
+	bundleDatabase = "tasks.db"  // the SQLite database
+	bundleFiles    = "files/"    // the task files, with storage = "files"
+	bundleArchive  = archiveFile // tasks archived by tt maintain
+)
+
+// passphraseEnv holds the passphrase of encrypted bundles, for scripts
+const passphraseEnv = "TT_PASSPHRASE"
+
+func newExportBundleCmd(deps *Dependencies) *cobra.Command {
+	var encrypt, anonymize bool
+	var outPath string
+
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Export all data as one file, to back up or move machines",
+		Long: `Export all data as one file: every task, including completed ones, with
+areas, holidays, comments and tags, plus the tasks archived by tt maintain.
+Restore it with tt import bundle, e.g. on a new machine.
+
+With --encrypt the bundle is encrypted with a passphrase (AES-256-GCM), so
+it's safe to email to yourself or keep in cloud storage. The passphrase is
+asked for, or read from $TT_PASSPHRASE. Without it the bundle can't be
+restored, so keep it somewhere safe.
+
+To attach a database to a bug report, --anonymize replaces titles,
+descriptions, names, tags and comments with placeholders, keeping dates,
+states and how tasks relate. Equal texts get equal placeholders. The
+archive and API tokens are left out.
+
+The bundle is written to tt-YYYY-MM-DD.bundle in the current directory
+unless -o is given; -o - writes it to stdout. With storage = "postgres",
+back up with pg_dump on the server instead.
+
+Examples:
+  t export bundle --encrypt
+  t export bundle --anonymize -o reproducer.bundle
+  t export bundle --encrypt -o ~/Dropbox/tt.bundle
+  TT_PASSPHRASE=... t export bundle --encrypt -o - | ssh laptop 'cat > tt.bundle'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			if deps.Config.Storage == config.StoragePostgres {
+				return fmt.Errorf("storage is %q: back up with pg_dump on the server", config.StoragePostgres)
+			}
+			if anonymize && deps.Config.Storage == config.StorageFiles {
+				return fmt.Errorf("--anonymize needs a database; with storage = %q, copy the files directory and edit it instead", config.StorageFiles)
+			}
+
+			var passphrase string
+			if encrypt {
+				var err error
+				if passphrase, err = readPassphrase(deps, true); err != nil {
+					return err
+				}
+			}
+
+			tmp, err := os.MkdirTemp("", "tt-bundle-")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmp)
+			files, err := bundleFileList(deps, tmp, anonymize)
+			if err != nil {
+				return err
+			}
+
+			storage := deps.Config.Storage
+			if storage == "" {
+				storage = config.StorageSQLite
+			}
+			manifest := bundle.Manifest{Storage: storage, Created: deps.now()}
+
+			if outPath == "-" {
+				return bundle.Write(os.Stdout, manifest, files, passphrase)
+			}
+			if outPath == "" {
+				outPath = "tt-" + deps.today().Format("2006-01-02") + ".bundle"
+			}
+			// Only the owner gets to read a backup of everything
+			f, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+			if err != nil {
+				return err
+			}
+			if err := bundle.Write(f, manifest, files, passphrase); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			deps.formatter(os.Stdout).BundleExported(outPath, len(files), encrypt)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&encrypt, "encrypt", false, "Encrypt the bundle with a passphrase")
+	cmd.Flags().BoolVar(&anonymize, "anonymize", false, "Replace private text with placeholders, e.g. for a bug report")
+	cmd.Flags().StringVarP(&outPath, "output", "o", "", "Write to this file (- for stdout)")
+
+	return cmd
+}
+
+// bundleFileList lists the files to bundle: a snapshot of the database,
+// written to tmp, or the task files, and the archive if there is one.
+// Anonymized bundles hold an anonymized snapshot and no archive.
+func bundleFileList(deps *Dependencies, tmp string, anonymize bool) ([]bundle.File, error) {
+	var files []bundle.File
+	if deps.Config.Storage == config.StorageFiles {
+		root := deps.Config.Files
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// Skip .git and temporary files
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, bundle.File{Name: bundleFiles + filepath.ToSlash(rel), Path: path})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		snapshot := filepath.Join(tmp, bundleDatabase)
+		if err := deps.DB.Snapshot(snapshot); err != nil {
+			return nil, err
+		}
+		if anonymize {
+			if err := anonymizeSnapshot(snapshot); err != nil {
+				return nil, err
+			}
+			return []bundle.File{{Name: bundleDatabase, Path: snapshot}}, nil
+		}
+		files = append(files, bundle.File{Name: bundleDatabase, Path: snapshot})
+	}
+
+	archive := filepath.Join(filepath.Dir(deps.Config.Database), archiveFile)
+	if _, err := os.Stat(archive); err == nil {
+		files = append(files, bundle.File{Name: bundleArchive, Path: archive})
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return files, nil
+}
+
+// anonymizeSnapshot anonymizes the database copy at path
+func anonymizeSnapshot(path string) error {
+	db, err := database.Open(path)
+	if err != nil {
+		return err
+	}
+	if err := db.Anonymize(); err != nil {
+		db.Close()
+		return fmt.Errorf("anonymizing: %w", err)
+	}
+	return db.Close()
+}
@@ -0,0 +1,96 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/cli"
+	"github.com/devbydaniel/tt/internal/filestore"
+)
+
+// setupFilesCLI sets up tt on a task files directory in a data directory
+func setupFilesCLI(t *testing.T) *cli.Dependencies {
+	t.Helper()
+	dataDir := t.TempDir()
+	cfg := &config.Config{
+		Storage:  config.StorageFiles,
+		Files:    filepath.Join(dataDir, "files"),
+		Database: filepath.Join(dataDir, "tasks.db"),
+		DataDir:  dataDir,
+	}
+	store, err := filestore.Open(cfg.Files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &cli.Dependencies{
+		App: app.NewWithStores(app.Stores{
+			Tasks:    store.Tasks,
+			Areas:    store.Areas,
+			Holidays: store.Holidays,
+			Comments: store.Comments,
+		}, app.ConfigOptions(cfg)),
+		Config: cfg,
+	}
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	t.Setenv("TT_PASSPHRASE", "correct horse")
+	run := func(deps *cli.Dependencies, args ...string) error {
+		t.Helper()
+		cmd := cli.NewRootCmd(deps)
+		cmd.SetArgs(args)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		return cmd.Execute()
+	}
+
+	from := setupFilesCLI(t)
+	if _, err := from.App.Tasks.Create("Renew passport", nil); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(from.Config.DataDir, "archive.jsonl"), []byte(`{"title":"Old"}`+"\n"), 0644)
+	path := filepath.Join(t.TempDir(), "tt.bundle")
+	if err := run(from, "export", "bundle", "--encrypt", "-o", path); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if data, _ := os.ReadFile(path); bytes.Contains(data, []byte("Renew passport")) {
+		t.Error("encrypted bundle contains the task title")
+	}
+
+	to := setupFilesCLI(t)
+	if err := run(to, "import", "bundle", path); err != nil {
+		t.Fatalf("import into empty directory: %v", err)
+	}
+	tasks, err := to.App.Tasks.List(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Renew passport" {
+		t.Fatalf("imported tasks = %v, want Renew passport", tasks)
+	}
+	if _, err := os.Stat(filepath.Join(to.Config.DataDir, "archive.jsonl")); err != nil {
+		t.Errorf("archive not restored: %v", err)
+	}
+
+	// Existing data is only replaced with --force, and kept
+	err = run(to, "import", "bundle", path)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("import over existing data: err = %v, want a hint at --force", err)
+	}
+	if err := run(to, "import", "bundle", "--force", path); err != nil {
+		t.Fatalf("import --force: %v", err)
+	}
+	if _, err := os.Stat(to.Config.Files + ".bak"); err != nil {
+		t.Errorf("replaced data not kept: %v", err)
+	}
+
+	t.Setenv("TT_PASSPHRASE", "wrong")
+	if err := run(setupFilesCLI(t), "import", "bundle", path); err == nil {
+		t.Error("import with the wrong passphrase succeeded")
+	}
+}
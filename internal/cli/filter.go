@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/dateparse"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// taskFilter is a parsed filter expression: list options for the store,
+// and date comparisons checked on the listed tasks
+type taskFilter struct {
+	opts  task.ListOptions
+	dates []dateCondition
+}
+
+// dateCondition compares a task's planned or due date with a day
+type dateCondition struct {
+	field string // "due" or "planned"
+	op    string // "<", "<=", "=", ">=" or ">"
+	day   string // YYYY-MM-DD
+}
+
+// filterOps are the comparisons of a date term, longest first so "<=" isn't
+// read as "<"
+var filterOps = []string{"<=", ">=", "<", ">", "="}
+
+// filterSchedules are the values of is: besides flagged
+var filterSchedules = []string{"today", "upcoming", "anytime", "inbox", "someday"}
+
+// parseFilter parses a filter expression: terms joined by spaces or "and",
+// all of which a task has to match. Terms are key:value for project, area,
+// tag, context, assignee and location; is:flagged or is:<schedule>; date
+// comparisons such as due<=friday or planned>today; and words, which
+// search titles. Values with spaces are quoted, e.g. project:"Q1 Goals".
+func parseFilter(expr string, today time.Time) (*taskFilter, error) {
+	terms, err := splitFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &taskFilter{}
+	var words []string
+	set := func(key string, field *string, value string) error {
+		if *field != "" {
+			return fmt.Errorf("filter: %s given twice", key)
+		}
+		*field = value
+		return nil
+	}
+	for _, term := range terms {
+		if strings.EqualFold(term, "and") {
+			continue
+		}
+
+		if field, op, value, ok := splitDateTerm(term); ok {
+			d, err := dateparse.ParseFrom(value, today)
+			if err != nil {
+				return nil, fmt.Errorf("filter %q: %w", term, err)
+			}
+			f.dates = append(f.dates, dateCondition{field: field, op: op, day: d.Format("2006-01-02")})
+			continue
+		}
+
+		key, value, ok := strings.Cut(term, ":")
+		if !ok || value == "" {
+			words = append(words, term)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "project":
+			err = set(key, &f.opts.ProjectName, value)
+		case "area":
+			err = set(key, &f.opts.AreaName, value)
+		case "tag":
+			err = set(key, &f.opts.TagName, value)
+		case "context":
+			err = set(key, &f.opts.Context, value)
+		case "assignee":
+			err = set(key, &f.opts.Assignee, value)
+		case "location":
+			err = set(key, &f.opts.Location, value)
+		case "is":
+			switch value = strings.ToLower(value); {
+			case value == "flagged":
+				f.opts.Flagged = true
+			case slices.Contains(filterSchedules, value):
+				err = set(key, &f.opts.Schedule, value)
+			default:
+				err = fmt.Errorf("filter: unknown is:%s (use flagged, %s)", value, strings.Join(filterSchedules, ", "))
+			}
+		default:
+			err = fmt.Errorf("filter: unknown key %q (use project, area, tag, context, assignee, location, is, due or planned)", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	f.opts.Search = strings.Join(words, " ")
+	return f, nil
+}
+
+// splitDateTerm splits a term such as due<=friday
+func splitDateTerm(term string) (field, op, value string, ok bool) {
+	for _, field := range []string{"due", "planned"} {
+		rest, found := strings.CutPrefix(strings.ToLower(term), field)
+		if !found {
+			continue
+		}
+		for _, op := range filterOps {
+			if strings.HasPrefix(rest, op) && len(rest) > len(op) {
+				return field, op, term[len(field)+len(op):], true
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+// splitFilter splits an expression at spaces outside double quotes, and
+// drops the quotes
+func splitFilter(expr string) ([]string, error) {
+	var terms []string
+	var term strings.Builder
+	quoted, inTerm := false, false
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			quoted, inTerm = !quoted, true
+		case r == ' ' && !quoted:
+			if inTerm {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+			inTerm = false
+		default:
+			term.WriteRune(r)
+			inTerm = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("filter: unclosed quote in %q", expr)
+	}
+	if inTerm {
+		terms = append(terms, term.String())
+	}
+	return terms, nil
+}
+
+// match reports whether t passes the date conditions; tasks without the
+// date don't
+func (f *taskFilter) match(t task.Task) bool {
+	for _, c := range f.dates {
+		date := t.DueDate
+		if c.field == "planned" {
+			date = t.PlannedDate
+		}
+		if date == nil {
+			return false
+		}
+		cmp := strings.Compare(date.Format("2006-01-02"), c.day)
+		ok := false
+		switch c.op {
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case "=":
+			ok = cmp == 0
+		case ">=":
+			ok = cmp >= 0
+		case ">":
+			ok = cmp > 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// list lists the tasks matching the filter
+func (f *taskFilter) list(deps *Dependencies) ([]task.Task, error) {
+	tasks, err := deps.App.Tasks.List(&f.opts)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(tasks, func(t task.Task) bool { return !f.match(t) }), nil
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func NewFlagCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "flag <id> [id...]",
+		Short: "Flag task(s) so they stay at the top of every list",
+		Long: `Flag task(s) so they stay at the top of every list, whatever their dates.
+List the flagged tasks with tt flagged and unflag them with tt unflag.
+
+IDs can be given as ranges and comma-separated lists, e.g. 4-9,12.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSetFlagged(deps, args, true)
+		},
+	}
+}
+
+func NewUnflagCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unflag <id> [id...]",
+		Short: "Remove the flag from task(s)",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSetFlagged(deps, args, false)
+		},
+	}
+}
+
+func runSetFlagged(deps *Dependencies, args []string, flagged bool) error {
+	ids, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+
+	changed, err := deps.App.Tasks.SetFlagged(ids, flagged)
+	if err != nil {
+		return err
+	}
+
+	formatter := deps.formatter(os.Stdout)
+	formatter.TasksFlagged(changed, flagged)
+	return nil
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/dateparse"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewHolidaysCmd(deps *Dependencies) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "holidays",
+		Short: "Manage holidays that recurring tasks skip",
+		Long: `Manage holidays. Recurring tasks never land on a holiday: their
+occurrence moves to the next one of the pattern, or with holiday_mode = "shift"
+in the config, to the next day that isn't a holiday.
+
+Examples:
+  t holidays                          List holidays
+  t holidays add 2025-12-25 Christmas
+  t holidays remove 2025-12-25
+  t holidays import holidays.ics      Import the dates of a calendar file`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			holidays, err := deps.App.ListHolidays.Execute()
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.WriteJSON(os.Stdout, holidays)
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.HolidayList(holidays)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	cmd.AddCommand(mutating(newHolidaysAddCmd(deps)))
+	cmd.AddCommand(mutating(newHolidaysRemoveCmd(deps)))
+	cmd.AddCommand(mutating(newHolidaysImportCmd(deps)))
+
+	return cmd
+}
+
+func newHolidaysAddCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <date> [name]",
+		Short: "Add a holiday",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			date, err := dateparse.ParseFrom(args[0], deps.today())
+			if err != nil {
+				return err
+			}
+
+			h, err := deps.App.AddHoliday.Execute(date, strings.Join(args[1:], " "))
+			if err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.HolidayAdded(h)
+			return nil
+		},
+	}
+}
+
+func newHolidaysRemoveCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <date>",
+		Short: "Remove a holiday",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			date, err := dateparse.ParseFrom(args[0], deps.today())
+			if err != nil {
+				return err
+			}
+
+			if err := deps.App.RemoveHoliday.Execute(date); err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.HolidayRemoved(date)
+			return nil
+		},
+	}
+}
+
+func newHolidaysImportCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <file.ics>",
+		Short: "Import holidays from an iCalendar file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			n, err := deps.App.ImportHolidays.Execute(f)
+			if err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.HolidaysImported(n, args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxRangeSize caps how many IDs one range may expand to, so a typo like
+// 4-9000 doesn't act on thousands of tasks
+const maxRangeSize = 1000
+
+// parseIDs parses task ID arguments. Each argument is an ID, a range such
+// as 4-9, or a comma-separated list of both, e.g. "4-9,12". IDs are
+// returned in the order given, without duplicates.
+func parseIDs(args []string) ([]int64, error) {
+	var ids []int64
+	seen := map[int64]bool{}
+	add := func(id int64) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				return nil, fmt.Errorf("invalid task ID list %q: empty entry", arg)
+			}
+
+			start, end, isRange := strings.Cut(part, "-")
+			if !isRange {
+				id, err := parseID(part)
+				if err != nil {
+					return nil, err
+				}
+				add(id)
+				continue
+			}
+
+			from, err := parseID(start)
+			if err != nil {
+				return nil, fmt.Errorf("invalid range %q: %w", part, err)
+			}
+			to, err := parseID(end)
+			if err != nil {
+				return nil, fmt.Errorf("invalid range %q: %w", part, err)
+			}
+			if from > to {
+				return nil, fmt.Errorf("invalid range %q: start is after end", part)
+			}
+			if to-from >= maxRangeSize {
+				return nil, fmt.Errorf("invalid range %q: more than %d IDs", part, maxRangeSize)
+			}
+			for id := from; id <= to; id++ {
+				add(id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// parseID parses a single positive task ID
+func parseID(s string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid task ID: %s", s)
+	}
+	return id, nil
+}
@@ -0,0 +1,60 @@
+package cli_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/devbydaniel/tt/internal/cli"
+)
+
+func TestDoWithIDRanges(t *testing.T) {
+	deps := setupCLI(t)
+	for i := 1; i <= 6; i++ {
+		if _, err := deps.App.CreateTask.Execute(fmt.Sprintf("Task %d", i), nil); err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+	}
+
+	cmd := cli.NewRootCmd(deps)
+	cmd.SetArgs([]string{"do", "1-3,5", "2"})
+	cmd.SetOut(&bytes.Buffer{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("do command failed: %v", err)
+	}
+
+	tasks, err := deps.App.ListTasks.Execute(nil)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	var open []string
+	for _, task := range tasks {
+		open = append(open, task.Title)
+	}
+	if got := strings.Join(open, ", "); got != "Task 4, Task 6" {
+		t.Errorf("open tasks = %s, want Task 4, Task 6", got)
+	}
+}
+
+func TestMalformedIDRanges(t *testing.T) {
+	tests := map[string]string{
+		"9-4":    "start is after end",
+		"4-":     "invalid range",
+		"a-3":    "invalid range",
+		"3,,4":   "empty entry",
+		"0":      "invalid task ID",
+		"1-5000": "more than 1000 IDs",
+	}
+	for arg, want := range tests {
+		deps := setupCLI(t)
+		cmd := cli.NewRootCmd(deps)
+		cmd.SetArgs([]string{"delete", arg})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		err := cmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("delete %s: error = %v, want it to mention %q", arg, err, want)
+		}
+	}
+}
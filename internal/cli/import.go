@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/bundle"
+	"github.com/spf13/cobra"
+)
+
+func NewImportCmd(deps *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import data exported with tt export",
+	}
+
+	cmd.AddCommand(newImportBundleCmd(deps))
+
+	return cmd
+}
+
+func newImportBundleCmd(deps *Dependencies) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "bundle <file>",
+		Short: "Restore all data from a bundle written by tt export bundle",
+		Long: `Restore all data from a bundle written by tt export bundle, e.g. on a new
+machine. Encrypted bundles ask for their passphrase, or read it from
+$TT_PASSPHRASE.
+
+The bundle replaces the database (or the task files with storage =
+"files") and the archive. If there is data already, nothing is changed
+unless --force is given; then the current data is kept next to it with a
+.bak suffix. A bundle from the other storage can't be imported; switch the
+storage setting first.
+
+Examples:
+  t import bundle tt-2025-08-01.bundle
+  t import bundle --force ~/Dropbox/tt.bundle`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			if err := deps.requireWritable(); err != nil {
+				return err
+			}
+			if deps.Config.Storage == config.StoragePostgres {
+				return fmt.Errorf("storage is %q: restore with pg_restore on the server", config.StoragePostgres)
+			}
+
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var passphrase string
+			if bundle.Encrypted(data) {
+				if passphrase, err = readPassphrase(deps, false); err != nil {
+					return err
+				}
+			}
+			b, err := bundle.Open(data, passphrase)
+			if err != nil {
+				return fmt.Errorf("%s: %w", args[0], err)
+			}
+
+			targets, err := bundleTargets(deps.Config, b)
+			if err != nil {
+				return err
+			}
+			var existing []string
+			for _, dest := range targets {
+				ok, err := hasData(dest)
+				if err != nil {
+					return err
+				}
+				if ok {
+					existing = append(existing, dest)
+				}
+			}
+			if len(existing) > 0 && !force {
+				return fmt.Errorf("there is data in %s already; rerun with --force to replace it (it's kept with a .bak suffix)", strings.Join(existing, ", "))
+			}
+			for _, dest := range existing {
+				if _, err := os.Stat(dest + ".bak"); err == nil {
+					return fmt.Errorf("%s.bak is in the way; move or remove it first", dest)
+				}
+			}
+
+			for _, dest := range existing {
+				if err := os.Rename(dest, dest+".bak"); err != nil {
+					return err
+				}
+			}
+			for name, dest := range targets {
+				if err := b.Extract(name, dest); err != nil {
+					return err
+				}
+			}
+			deps.formatter(os.Stdout).BundleImported(args[0], len(b.Manifest.Files), existing)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Replace existing data, keeping it with a .bak suffix")
+
+	return cmd
+}
+
+// bundleTargets maps the data in a bundle to where it's restored
+func bundleTargets(cfg *config.Config, b *bundle.Bundle) (map[string]string, error) {
+	storage := cfg.Storage
+	if storage == "" {
+		storage = config.StorageSQLite
+	}
+	if b.Manifest.Storage != storage {
+		return nil, fmt.Errorf("bundle holds data from storage = %q but storage is %q; change the storage setting to import it", b.Manifest.Storage, storage)
+	}
+
+	targets := map[string]string{}
+	if storage == config.StorageFiles {
+		targets[bundleFiles] = cfg.Files
+	} else {
+		targets[bundleDatabase] = cfg.Database
+	}
+	if b.Has(bundleArchive) {
+		targets[bundleArchive] = filepath.Join(filepath.Dir(cfg.Database), archiveFile)
+	}
+	return targets, nil
+}
+
+// hasData reports whether path is a non-empty file, or a directory with
+// files in it
+func hasData(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !info.IsDir() {
+		return info.Size() > 0, nil
+	}
+
+	found := false
+	err = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found, err
+}
+
+// readPassphrase reads the passphrase of a bundle from $TT_PASSPHRASE, or
+// asks for it on the terminal, twice with confirm set
+func readPassphrase(deps *Dependencies, confirm bool) (string, error) {
+	if p := os.Getenv(passphraseEnv); p != "" {
+		return p, nil
+	}
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		// Piped in, e.g. from a password manager
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if p := strings.TrimRight(line, "\r\n"); p != "" {
+			return p, nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		return "", fmt.Errorf("no passphrase: set $%s or pipe it in", passphraseEnv)
+	}
+
+	prompts := deps.formatter(os.Stderr)
+	ask := func(prompt string) (string, error) {
+		prompts.Prompt(prompt)
+		p, err := term.ReadPassword(os.Stdin.Fd())
+		prompts.PromptAbandoned()
+		return string(p), err
+	}
+	p, err := ask("Passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", errors.New("empty passphrase")
+	}
+	if confirm {
+		again, err := ask("Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != p {
+			return "", errors.New("passphrases don't match")
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewLintCmd(deps *Dependencies) *cobra.Command {
+	var emptyDays int
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Find tasks, projects and tags that are probably mistakes",
+		Long: `Find likely mistakes in how tasks are organized:
+
+  - tasks in a project of one area that have another area of their own,
+    which only task files (storage = "files") allow
+  - open projects without an area
+  - open projects without open tasks, created over --empty-days ago
+  - tags used on a single task, open or done, often typos
+
+Nothing is changed. tt lint exits with an error if it finds anything, so it
+can run from scripts.
+
+Examples:
+  t lint
+  t lint --empty-days 90`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			if emptyDays < 0 {
+				return errors.New("--empty-days must be 0 or more")
+			}
+
+			report, err := deps.App.Tasks.Lint(emptyDays)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				if err := output.WriteJSON(os.Stdout, report); err != nil {
+					return err
+				}
+			} else {
+				deps.formatter(os.Stdout).LintReport(report, emptyDays)
+			}
+			if n := report.Len(); n > 0 {
+				return fmt.Errorf("found %d issue(s)", n)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&emptyDays, "empty-days", 30, "Report projects without open tasks once they're older than this")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
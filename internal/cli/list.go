@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/devbydaniel/tt/internal/domain/task"
 	"github.com/devbydaniel/tt/internal/output"
@@ -14,6 +15,9 @@ func NewListCmd(deps *Dependencies) *cobra.Command {
 	var areaName string
 	var tagName string
 	var search string
+	var contextName string
+	var assignee string
+	var location string
 	var sortStr string
 	var today bool
 	var upcoming bool
@@ -22,6 +26,8 @@ func NewListCmd(deps *Dependencies) *cobra.Command {
 	var inbox bool
 	var group string
 	var hideScope bool
+	var details bool
+	var quiet bool
 	var jsonOutput bool
 
 	cmd := &cobra.Command{
@@ -53,7 +59,7 @@ func NewListCmd(deps *Dependencies) *cobra.Command {
 			if projectName != "" {
 				configKey = "project"
 			} else if areaName != "" {
-				configKey = "area"
+				configKey = "area:" + areaName
 			} else if tagName != "" {
 				configKey = "tag"
 			}
@@ -80,7 +86,11 @@ func NewListCmd(deps *Dependencies) *cobra.Command {
 				hideScopeToUse = deps.Config.GetHideScope(configKey)
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
+			applyListLayout(formatter, deps.Config, configKey)
+			if details {
+				formatter.SetDetails(true)
+			}
 			if schedule == "today" {
 				formatter.SetHidePlannedDate(true)
 			}
@@ -88,60 +98,56 @@ func NewListCmd(deps *Dependencies) *cobra.Command {
 				formatter.SetHideScope(true)
 			}
 
-			// JSON output: single call, all tasks
+			// JSON output: single call, all tasks, streamed
 			if jsonOutput {
-				tasks, err := deps.App.ListTasks.Execute(&task.ListOptions{
+				out := output.NewJSONArrayWriter(os.Stdout)
+				err := deps.App.Tasks.Each(&task.ListOptions{
 					ProjectName: projectName,
 					AreaName:    areaName,
 					TagName:     tagName,
 					Search:      search,
+					Context:     contextName,
+					Assignee:    assignee,
+					Location:    location,
 					Sort:        sortOpts,
 					Schedule:    schedule,
-				})
+				}, func(t task.Task) error { return out.Write(t) })
 				if err != nil {
 					return err
 				}
-				return output.WriteJSON(os.Stdout, tasks)
+				return out.Close()
 			}
 
 			// Schedule grouping: 4 separate queries
 			if groupBy == "schedule" {
-				schedules := []struct {
-					name     string
-					schedule string
-				}{
-					{"Today", "today"},
-					{"Upcoming", "upcoming"},
-					{"Anytime", "anytime"},
-					{"Someday", "someday"},
+				all, err := listBySchedule(deps, formatter, task.ListOptions{
+					ProjectName: projectName,
+					AreaName:    areaName,
+					TagName:     tagName,
+					Search:      search,
+					Context:     contextName,
+					Assignee:    assignee,
+					Location:    location,
+					Sort:        sortOpts,
+				}, sortStr)
+				if err != nil {
+					return err
 				}
-
-				for _, sched := range schedules {
-					tasks, err := deps.App.ListTasks.Execute(&task.ListOptions{
-						ProjectName: projectName,
-						AreaName:    areaName,
-						TagName:     tagName,
-						Search:      search,
-						Sort:        sortOpts,
-						Schedule:    sched.schedule,
-					})
-					if err != nil {
-						return err
-					}
-					if len(tasks) > 0 {
-						fmt.Fprintln(os.Stdout, deps.Theme.Header.Render(sched.name))
-						formatter.TaskList(tasks)
-					}
+				if !quiet {
+					formatter.ListFooter(all)
 				}
 				return nil
 			}
 
 			// Other groupings: single call, client-side grouping
-			tasks, err := deps.App.ListTasks.Execute(&task.ListOptions{
+			tasks, err := deps.App.Tasks.List(&task.ListOptions{
 				ProjectName: projectName,
 				AreaName:    areaName,
 				TagName:     tagName,
 				Search:      search,
+				Context:     contextName,
+				Assignee:    assignee,
+				Location:    location,
 				Sort:        sortOpts,
 				Schedule:    schedule,
 			})
@@ -149,6 +155,9 @@ func NewListCmd(deps *Dependencies) *cobra.Command {
 				return err
 			}
 			formatter.GroupedTaskList(tasks, groupBy)
+			if !quiet {
+				formatter.ListFooter(tasks)
+			}
 			return nil
 		},
 	}
@@ -157,19 +166,68 @@ func NewListCmd(deps *Dependencies) *cobra.Command {
 	cmd.Flags().StringVarP(&areaName, "area", "a", "", "Filter by area name")
 	cmd.Flags().StringVar(&tagName, "tag", "", "Filter by tag")
 	cmd.Flags().StringVarP(&search, "search", "S", "", "Search task titles")
+	cmd.Flags().StringVarP(&contextName, "context", "c", "", "Filter by context: "+strings.Join(task.ValidContexts(), ", "))
+	cmd.Flags().StringVar(&assignee, "assignee", "", "Filter by assignee (see also tt mine)")
+	cmd.Flags().StringVar(&location, "location", "", "Filter by location (e.g. home, @office)")
 	cmd.Flags().StringVarP(&sortStr, "sort", "s", "", "Sort by field(s): id, title, planned, due, created, project, area (e.g. due,title:desc)")
 	cmd.Flags().BoolVar(&today, "today", false, "Show tasks planned for today or overdue")
 	cmd.Flags().BoolVar(&upcoming, "upcoming", false, "Show tasks with future dates")
 	cmd.Flags().BoolVar(&someday, "someday", false, "Show someday tasks")
 	cmd.Flags().BoolVar(&anytime, "anytime", false, "Show active tasks with no dates")
 	cmd.Flags().BoolVar(&inbox, "inbox", false, "Show tasks with no project, area, or dates")
-	cmd.Flags().StringVarP(&group, "group", "g", "", "Group tasks by: schedule, scope, date, none")
+	cmd.Flags().StringVarP(&group, "group", "g", "", "Group tasks by: schedule, scope, date, tag, state, none")
 	cmd.Flags().BoolVar(&hideScope, "hide-scope", false, "Hide project/area columns")
+	cmd.Flags().BoolVar(&details, "details", false, "Show description excerpts and checklist progress")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Omit the summary footer")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 
 	// Register completions
 	registry := NewCompletionRegistry(deps)
 	registry.RegisterAll(cmd)
+	registry.RegisterLocationFlag(cmd)
+	_ = cmd.RegisterFlagCompletionFunc("context", contextCompletion)
 
 	return cmd
 }
+
+// listBySchedule prints the tasks matching opts under Today, Upcoming,
+// Anytime and Someday headers, one query each, skipping empty schedules.
+// Unless sorted with --sort (sortFlag), a group with a schedule_sort entry
+// is sorted by it instead of opts.Sort. It returns all tasks printed, e.g.
+// for the footer.
+func listBySchedule(deps *Dependencies, formatter *output.Formatter, opts task.ListOptions, sortFlag string) ([]task.Task, error) {
+	schedules := []struct {
+		name     string
+		schedule string
+	}{
+		{"Today", "today"},
+		{"Upcoming", "upcoming"},
+		{"Anytime", "anytime"},
+		{"Someday", "someday"},
+	}
+
+	var all []task.Task
+	opts.AllUpcoming = true
+	viewSort := opts.Sort
+	for _, sched := range schedules {
+		opts.Schedule = sched.schedule
+		opts.Sort = viewSort
+		if groupSort := deps.Config.GetScheduleSort(sched.schedule, ""); groupSort != "" && sortFlag == "" {
+			sortOpts, err := task.ParseSort(groupSort)
+			if err != nil {
+				return nil, fmt.Errorf("schedule_sort %s: %w", sched.schedule, err)
+			}
+			opts.Sort = sortOpts
+		}
+		tasks, err := deps.App.Tasks.List(&opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(tasks) > 0 {
+			formatter.ScheduleHeader(sched.name)
+			formatter.TaskList(tasks)
+		}
+		all = append(all, tasks...)
+	}
+	return all, nil
+}
@@ -1,54 +1,138 @@
 package cli
 
 import (
+	"fmt"
 	"os"
 	"time"
 
+	"github.com/devbydaniel/tt/internal/domain/task"
 	"github.com/devbydaniel/tt/internal/output"
 	"github.com/spf13/cobra"
 )
 
 func NewLogCmd(deps *Dependencies) *cobra.Command {
 	var sinceStr string
+	var untilStr string
+	var opts task.CompletedOptions
 	var group string
+	var format string
 	var jsonOutput bool
+	var subtotals bool
+	var summary bool
+	var days int
 
 	cmd := &cobra.Command{
 		Use:   "log",
 		Short: "Show completed tasks (logbook)",
+		Long: `Show completed tasks (logbook).
+
+Grouped by date, each day shows how many tasks were completed and their
+summed estimate; --subtotals adds a count per project. --summary prints
+only the counts for each of the last --days days.
+
+--format csv writes one row per task with its completion timestamp, for
+timesheets and invoices. tt doesn't track time spent: the estimate_minutes
+and estimate_hours columns are the task's estimate, not time worked.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var since *time.Time
-			if sinceStr != "" {
-				parsed, err := time.Parse("2006-01-02", sinceStr)
+			if jsonOutput {
+				format = "json"
+			}
+			switch format {
+			case "text", "json", "csv":
+			default:
+				return fmt.Errorf("invalid format %q (valid: text, json, csv)", format)
+			}
+
+			var err error
+			if opts.Since, err = parseLogDate("since", sinceStr); err != nil {
+				return err
+			}
+			if opts.Until, err = parseLogDate("until", untilStr); err != nil {
+				return err
+			}
+			if summary {
+				if format != "text" {
+					return fmt.Errorf("--summary only works with text output")
+				}
+				if days < 1 {
+					return fmt.Errorf("--days must be at least 1, got %d", days)
+				}
+				since := deps.today().AddDate(0, 0, -(days - 1))
+				opts.Since = &since
+			}
+
+			// Exports stream rows instead of loading the whole logbook
+			switch format {
+			case "json":
+				out := output.NewJSONArrayWriter(os.Stdout)
+				if err := deps.App.Tasks.EachCompleted(&opts, func(t task.Task) error { return out.Write(t) }); err != nil {
+					return err
+				}
+				return out.Close()
+			case "csv":
+				out, err := output.NewLogbookCSVWriter(os.Stdout)
 				if err != nil {
 					return err
 				}
-				since = &parsed
+				if err := deps.App.Tasks.EachCompleted(&opts, out.Write); err != nil {
+					return err
+				}
+				return out.Flush()
 			}
 
-			tasks, err := deps.App.ListCompletedTasks.Execute(since)
+			tasks, err := deps.App.Tasks.ListCompleted(&opts)
 			if err != nil {
 				return err
 			}
 
-			if jsonOutput {
-				return output.WriteJSON(os.Stdout, tasks)
-			}
-
 			groupBy := group
 			if groupBy == "" {
 				groupBy = deps.Config.GetGroup("log")
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
+			if summary {
+				formatter.LogbookSummary(tasks, days)
+				return nil
+			}
+			formatter.SetLogSubtotals(subtotals)
 			formatter.GroupedLogbook(tasks, groupBy)
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&sinceStr, "since", "", "Show tasks completed since date (YYYY-MM-DD)")
-	cmd.Flags().StringVarP(&group, "group", "g", "", "Group tasks by: scope, date, none")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().StringVar(&sinceStr, "since", "", "Show tasks completed on or after date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&untilStr, "until", "", "Show tasks completed on or before date (YYYY-MM-DD)")
+	cmd.Flags().StringVarP(&opts.ProjectName, "project", "p", "", "Filter by project name")
+	cmd.Flags().StringVarP(&opts.AreaName, "area", "a", "", "Filter by area name (includes its projects)")
+	cmd.Flags().StringVar(&opts.TagName, "tag", "", "Filter by tag")
+	cmd.Flags().StringVarP(&opts.Search, "search", "S", "", "Search task titles")
+	cmd.Flags().StringVarP(&group, "group", "g", "", "Group tasks by: scope, date, week, none")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format: text, json, csv")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON (same as --format json)")
+	cmd.Flags().BoolVar(&subtotals, "subtotals", false, "Show a count per project under each day (with --group date)")
+	cmd.Flags().BoolVar(&summary, "summary", false, "Only print how many tasks were completed per day")
+	cmd.Flags().IntVar(&days, "days", 7, "Days covered by --summary, up to today")
+	cmd.MarkFlagsMutuallyExclusive("summary", "since")
+	cmd.MarkFlagsMutuallyExclusive("summary", "until")
+
+	registry := NewCompletionRegistry(deps)
+	registry.RegisterProjectFlag(cmd)
+	registry.RegisterAreaFlag(cmd)
+	registry.RegisterTagFlag(cmd)
+	_ = cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"text", "json", "csv"}, cobra.ShellCompDirectiveNoFileComp))
 
 	return cmd
 }
+
+// parseLogDate parses a --since/--until value, returning nil when unset
+func parseLogDate(flag, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s date %q (expected YYYY-MM-DD)", flag, value)
+	}
+	return &parsed, nil
+}
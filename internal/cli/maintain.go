@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/database"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// skipExpireAnnotation marks commands that expire tasks themselves, so the
+// startup run doesn't get there first
+const skipExpireAnnotation = "skip-expire"
+
+func NewMaintainCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "maintain",
+		Short: "Run housekeeping chores on the database",
+		Long: `Run housekeeping chores on the database, e.g. daily from cron:
+
+  - Tasks past their expires date (see tt add --expires) are moved to
+    someday, or deleted with expire_action = "delete". This also happens
+    on startup of any other command.
+  - Tasks completed more than [maintain] archive_after_days ago, and
+    someday tasks created more than someday_stale_days ago, are moved to
+    archive.jsonl in the data directory, one JSON task per line.
+  - A copy of the database is written to backups/ in the data directory,
+    keeping the newest [maintain] backups of them.
+  - The database is compacted and its statistics refreshed.
+
+Archiving and backups are off until configured. With storage = "files"
+there is no database to back up or compact; version the files directory
+with git instead. With storage = "postgres" back up with pg_dump on the
+server; only the statistics are refreshed. A summary is printed.
+
+Example crontab entry:
+  0 3 * * * tt maintain`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{skipExpireAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings := deps.Config.Maintain
+			dataDir := filepath.Dir(deps.Config.Database)
+			now := deps.now()
+			report := output.MaintenanceReport{ExpireAction: deps.Config.ExpireAction}
+
+			var err error
+			if report.Expired, err = deps.App.Tasks.Expire(); err != nil {
+				return err
+			}
+
+			if settings.ArchiveAfterDays > 0 || settings.SomedayStaleDays > 0 {
+				if report.Archived, report.ArchivePath, err = archive(deps, dataDir, now); err != nil {
+					return err
+				}
+			}
+
+			if deps.DB != nil {
+				if err := maintainDB(deps, dataDir, now, &report); err != nil {
+					return err
+				}
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.MaintenanceSummary(report)
+			return nil
+		},
+	}
+}
+
+// maintainDB backs up and compacts the SQLite database. Postgres is backed
+// up on the server.
+func maintainDB(deps *Dependencies, dataDir string, now time.Time, report *output.MaintenanceReport) error {
+	var err error
+	if settings := deps.Config.Maintain; settings.Backups > 0 && deps.DB.Driver != database.Postgres {
+		if report.Backup, report.Pruned, err = deps.DB.Backup(filepath.Join(dataDir, "backups"), settings.Backups, now); err != nil {
+			return err
+		}
+	}
+
+	if report.SizeBefore, err = deps.DB.Size(); err != nil {
+		return err
+	}
+	if err := deps.DB.Optimize(); err != nil {
+		return err
+	}
+	if report.SizeAfter, err = deps.DB.Size(); err != nil {
+		return err
+	}
+	report.Optimized = true
+	return nil
+}
+
+// archiveFile holds archived tasks, in the data directory
+const archiveFile = "archive.jsonl"
+
+// archive appends tasks past the configured ages to archive.jsonl in dataDir
+func archive(deps *Dependencies, dataDir string, now time.Time) (archived []task.Task, path string, err error) {
+	var completedBefore, somedayBefore *time.Time
+	if days := deps.Config.Maintain.ArchiveAfterDays; days > 0 {
+		cutoff := now.AddDate(0, 0, -days)
+		completedBefore = &cutoff
+	}
+	if days := deps.Config.Maintain.SomedayStaleDays; days > 0 {
+		cutoff := now.AddDate(0, 0, -days)
+		somedayBefore = &cutoff
+	}
+
+	path = filepath.Join(dataDir, archiveFile)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, path, err
+	}
+	archived, err = deps.App.Tasks.Archive(f, completedBefore, somedayBefore)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return archived, path, err
+}
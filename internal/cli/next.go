@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewNextCmd(deps *Dependencies) *cobra.Command {
+	var random bool
+	var contextFilter string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "next",
+		Short: "Suggest one task to work on next",
+		Long: `Suggest one actionable task to work on next.
+
+Tasks are scored by how soon they are due, whether they are planned,
+how long they have been waiting, and whether their estimate fits in
+what is left of daily_capacity. Use --random to pick at random instead.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			suggestion, err := deps.App.Tasks.SuggestNext(task.SuggestOptions{
+				Random:   random,
+				Context:  contextFilter,
+				Capacity: dailyCapacity(deps.Config),
+			})
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.WriteJSON(os.Stdout, suggestion)
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			applyListLayout(formatter, deps.Config, "")
+			formatter.Suggestion(suggestion)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&random, "random", false, "Pick a random actionable task")
+	cmd.Flags().StringVarP(&contextFilter, "context", "c", "", "Only consider tasks with this context")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	_ = cmd.RegisterFlagCompletionFunc("context", contextCompletion)
+
+	return cmd
+}
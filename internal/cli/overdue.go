@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/devbydaniel/tt/internal/notify"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewOverdueCmd(deps *Dependencies) *cobra.Command {
+	var notifyDesktop bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "overdue",
+		Short: "List tasks past their due date, by how late they are",
+		Long: `List tasks whose due date has passed, grouped by how late they are:
+more than a week, up to a week, and up to 3 days. Planned dates don't count.
+
+With --notify, also show the summary as a desktop notification (when
+anything is overdue), e.g. from a cron job or login script. There is none
+during a tt pause.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			today := deps.today()
+			yesterday := today.AddDate(0, 0, -1)
+			tasks, err := deps.App.Tasks.Due(&yesterday)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				if err := output.WriteJSON(os.Stdout, tasks); err != nil {
+					return err
+				}
+			} else {
+				formatter := deps.formatter(os.Stdout)
+				applyListLayout(formatter, deps.Config, "")
+				formatter.OverdueList(tasks, today)
+			}
+
+			// No reminders while away
+			if notifyDesktop && len(tasks) > 0 && !onVacation(deps) {
+				cmd.SilenceUsage = true
+				return notify.Send("tt", output.OverdueSummary(tasks, today))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&notifyDesktop, "notify", false, "Also send the summary as a desktop notification")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
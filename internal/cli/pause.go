@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/devbydaniel/tt/internal/dateparse"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/spf13/cobra"
+)
+
+// vacationFile keeps a running vacation pause, in the data directory
+const vacationFile = "vacation.json"
+
+// vacation is a running pause: until when, and which recurrences it paused,
+// so ending it resumes those and leaves ones paused by hand alone
+type vacation struct {
+	Until  string  `json:"until"` // last day, YYYY-MM-DD
+	Paused []int64 `json:"paused,omitempty"`
+}
+
+func NewPauseCmd(deps *Dependencies) *cobra.Command {
+	var untilStr string
+	var dryRun, end bool
+
+	cmd := &cobra.Command{
+		Use:   "pause",
+		Short: "Pause everything while you're away",
+		Long: `Pause everything while you're away, e.g. on vacation.
+
+Until the given day, fixed recurrences are paused, tasks planned from today
+through that day move to the day after, and tt overdue --notify stays
+quiet. The first command run after that day resumes the recurrences. Use
+--dry-run to see what would change first, and --end to come back early.
+
+Examples:
+  t pause --until 2025-08-15 --dry-run
+  t pause --until 2025-08-15
+  t pause --end`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := deps.formatter(os.Stdout)
+
+			if end {
+				v, err := loadVacation(deps.Config.DataDir)
+				if err != nil {
+					return err
+				}
+				if v == nil {
+					return errors.New("not paused")
+				}
+				return endVacation(deps, v, os.Stdout)
+			}
+
+			if untilStr == "" {
+				return errors.New("--until required (or use --end to come back early)")
+			}
+			until, err := dateparse.ParseFrom(untilStr, deps.today())
+			if err != nil {
+				return err
+			}
+
+			plan, err := deps.App.Tasks.PauseForVacation(until, dryRun)
+			if err != nil {
+				return err
+			}
+			if !dryRun {
+				// Pausing again extends the running pause
+				v, err := loadVacation(deps.Config.DataDir)
+				if err != nil {
+					return err
+				}
+				if v == nil {
+					v = &vacation{}
+				}
+				v.Until = until.Format("2006-01-02")
+				for _, t := range plan.Paused {
+					v.Paused = append(v.Paused, t.ID)
+				}
+				if err := saveVacation(deps.Config.DataDir, v); err != nil {
+					return err
+				}
+			}
+			formatter.VacationPlanned(plan, dryRun)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&untilStr, "until", "", "Last day away")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without changing it")
+	cmd.Flags().BoolVar(&end, "end", false, "End the pause now and resume recurrences")
+	cmd.MarkFlagsMutuallyExclusive("end", "until")
+
+	return cmd
+}
+
+// loadVacation reads the running pause, nil if there is none
+func loadVacation(dir string) (*vacation, error) {
+	data, err := os.ReadFile(filepath.Join(dir, vacationFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var v vacation
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("%s: %w", vacationFile, err)
+	}
+	return &v, nil
+}
+
+// saveVacation writes the running pause to dir
+func saveVacation(dir string, v *vacation) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, vacationFile), append(data, '\n'), 0o644)
+}
+
+// onVacation reports whether a pause runs today
+func onVacation(deps *Dependencies) bool {
+	v, err := loadVacation(deps.Config.DataDir)
+	return err == nil && v != nil && v.Until >= deps.today().Format("2006-01-02")
+}
+
+// endVacationIfOver resumes the recurrences of a pause whose last day has
+// passed, reporting it to w. It runs on startup of every command.
+func endVacationIfOver(deps *Dependencies, w io.Writer) error {
+	v, err := loadVacation(deps.Config.DataDir)
+	if err != nil || v == nil || v.Until >= deps.today().Format("2006-01-02") {
+		return err
+	}
+	return endVacation(deps, v, w)
+}
+
+// endVacation resumes the recurrences v paused that are still paused, and
+// removes it
+func endVacation(deps *Dependencies, v *vacation, w io.Writer) error {
+	var resumed []task.Task
+	for _, id := range slices.Compact(slices.Sorted(slices.Values(v.Paused))) {
+		t, err := deps.App.Tasks.Get(id)
+		if errors.Is(err, task.ErrTaskNotFound) {
+			continue // deleted while away
+		}
+		if err != nil {
+			return err
+		}
+		if !t.RecurPaused {
+			continue
+		}
+		if t, err = deps.App.Tasks.ResumeRecurrence(id); err != nil {
+			return err
+		}
+		resumed = append(resumed, *t)
+	}
+	if err := os.Remove(filepath.Join(deps.Config.DataDir, vacationFile)); err != nil {
+		return err
+	}
+	deps.formatter(w).VacationEnded(resumed)
+	return nil
+}
@@ -6,16 +6,16 @@ import (
 	"strconv"
 
 	"github.com/devbydaniel/tt/internal/dateparse"
-	"github.com/devbydaniel/tt/internal/output"
 	"github.com/spf13/cobra"
 )
 
 func NewPlanCmd(deps *Dependencies) *cobra.Command {
 	var clear bool
+	var strictDates bool
 
 	cmd := &cobra.Command{
 		Use:     "plan <task-id> [date]",
-		Aliases: []string{"p"},
+		Aliases: []string{"pl"},
 		Short:   "Set the planned date of a task",
 		Long: `Set the planned date of a task.
 
@@ -34,11 +34,11 @@ Examples:
 			}
 
 			if clear {
-				t, err := deps.App.SetPlannedDate.Execute(id, nil)
+				t, err := deps.App.Tasks.SetPlannedDate(id, nil)
 				if err != nil {
 					return err
 				}
-				formatter := output.NewFormatter(os.Stdout, deps.Theme)
+				formatter := deps.formatter(os.Stdout)
 				formatter.TaskPlannedDateSet(t)
 				return nil
 			}
@@ -47,23 +47,32 @@ Examples:
 				return errors.New("date required (or use --clear to remove)")
 			}
 
-			date, err := dateparse.Parse(args[1])
+			date, err := dateparse.ParseFrom(args[1], deps.today())
 			if err != nil {
 				return err
 			}
 
-			t, err := deps.App.SetPlannedDate.Execute(id, &date)
+			current, err := deps.App.Tasks.Get(id)
+			if err != nil {
+				return err
+			}
+			formatter := deps.formatter(os.Stdout)
+			if err := checkDates(deps, formatter, strictDates, "", &date, current.DueDate); err != nil {
+				return err
+			}
+
+			t, err := deps.App.Tasks.SetPlannedDate(id, &date)
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
 			formatter.TaskPlannedDateSet(t)
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&clear, "clear", false, "Clear the planned date")
+	addStrictDatesFlag(cmd, &strictDates)
 
 	return cmd
 }
@@ -2,12 +2,12 @@ package cli
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"time"
 
 	"github.com/devbydaniel/tt/internal/dateparse"
 	"github.com/devbydaniel/tt/internal/domain/task"
-	"github.com/devbydaniel/tt/internal/domain/task/usecases"
 	"github.com/devbydaniel/tt/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -19,13 +19,15 @@ func NewProjectCmd(deps *Dependencies) *cobra.Command {
 	}
 
 	cmd.AddCommand(newProjectListCmd(deps))
-	cmd.AddCommand(newProjectAddCmd(deps))
-	cmd.AddCommand(newProjectDeleteCmd(deps))
-	cmd.AddCommand(newProjectRenameCmd(deps))
-	cmd.AddCommand(newProjectMoveCmd(deps))
-	cmd.AddCommand(newProjectDoCmd(deps))
-	cmd.AddCommand(newProjectUndoCmd(deps))
-	cmd.AddCommand(newProjectEditCmd(deps))
+	cmd.AddCommand(mutating(newProjectAddCmd(deps)))
+	cmd.AddCommand(mutating(newProjectDeleteCmd(deps)))
+	cmd.AddCommand(mutating(newProjectRenameCmd(deps)))
+	cmd.AddCommand(mutating(newProjectMoveCmd(deps)))
+	cmd.AddCommand(mutating(newProjectDoCmd(deps)))
+	cmd.AddCommand(mutating(newProjectUndoCmd(deps)))
+	cmd.AddCommand(mutating(newProjectEditCmd(deps)))
+	cmd.AddCommand(newProjectPlanCmd(deps))
+	cmd.AddCommand(newProjectGanttCmd(deps))
 
 	return cmd
 }
@@ -64,7 +66,7 @@ func newProjectListCmd(deps *Dependencies) *cobra.Command {
 			}
 
 			// List only active projects
-			projects, err := deps.App.ListTasks.Execute(&task.ListOptions{
+			projects, err := deps.App.Tasks.List(&task.ListOptions{
 				TaskType: task.TaskTypeProject,
 				State:    task.StateActive,
 				Sort:     sortOpts,
@@ -77,7 +79,8 @@ func newProjectListCmd(deps *Dependencies) *cobra.Command {
 				return output.WriteJSON(os.Stdout, projects)
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
+			applyListLayout(formatter, deps.Config, "project-list")
 			if hideScopeToUse {
 				formatter.SetHideScope(true)
 			}
@@ -107,33 +110,33 @@ func newProjectAddCmd(deps *Dependencies) *cobra.Command {
 		Short: "Create a new project",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts := &usecases.CreateProjectOptions{
+			opts := &task.CreateProjectOptions{
 				AreaName:    areaName,
 				Description: description,
 				Someday:     someday,
 			}
 
 			if plannedStr != "" {
-				t, err := parseDate(plannedStr)
+				t, err := parseDate(plannedStr, deps.today())
 				if err != nil {
 					return err
 				}
 				opts.PlannedDate = &t
 			}
 			if dueStr != "" {
-				t, err := parseDate(dueStr)
+				t, err := parseDate(dueStr, deps.today())
 				if err != nil {
 					return err
 				}
 				opts.DueDate = &t
 			}
 
-			project, err := deps.App.CreateProject.Execute(args[0], opts)
+			project, err := deps.App.Projects.Create(args[0], opts)
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.ProjectCreated(project)
 			return nil
 		},
@@ -159,18 +162,18 @@ func newProjectDeleteCmd(deps *Dependencies) *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Look up project by name
-			project, err := deps.App.GetProjectByName.Execute(args[0])
+			project, err := deps.App.Projects.Get(args[0])
 			if err != nil {
 				return err
 			}
 
 			// Delete the project (and its children via cascade)
-			_, err = deps.App.DeleteTasks.Execute([]int64{project.ID})
+			_, err = deps.App.Tasks.Delete([]int64{project.ID})
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.ProjectDeleted(project)
 			return nil
 		},
@@ -193,18 +196,18 @@ func newProjectRenameCmd(deps *Dependencies) *cobra.Command {
 			newName := args[1]
 
 			// Look up project by name
-			project, err := deps.App.GetProjectByName.Execute(oldName)
+			project, err := deps.App.Projects.Get(oldName)
 			if err != nil {
 				return err
 			}
 
 			// Rename using SetTaskTitle
-			_, err = deps.App.SetTaskTitle.Execute(project.ID, newName)
+			_, err = deps.App.Tasks.SetTitle(project.ID, newName)
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.ProjectRenamed(oldName, newName)
 			return nil
 		},
@@ -227,17 +230,17 @@ func newProjectMoveCmd(deps *Dependencies) *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			projectName := args[0]
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 
 			// Look up project by name
-			project, err := deps.App.GetProjectByName.Execute(projectName)
+			project, err := deps.App.Projects.Get(projectName)
 			if err != nil {
 				return err
 			}
 
 			if clearArea {
 				// Clear area using SetTaskArea with empty string
-				project, err = deps.App.SetTaskArea.Execute(project.ID, "")
+				project, err = deps.App.Tasks.SetArea(project.ID, "")
 				if err != nil {
 					return err
 				}
@@ -250,7 +253,7 @@ func newProjectMoveCmd(deps *Dependencies) *cobra.Command {
 			}
 
 			// Set area using SetTaskArea
-			project, err = deps.App.SetTaskArea.Execute(project.ID, areaName)
+			project, err = deps.App.Tasks.SetArea(project.ID, areaName)
 			if err != nil {
 				return err
 			}
@@ -271,9 +274,8 @@ func newProjectMoveCmd(deps *Dependencies) *cobra.Command {
 	return cmd
 }
 
-// parseDate parses a date string in various formats
-func parseDate(s string) (time.Time, error) {
-	now := time.Now()
+// parseDate parses a date string in various formats, relative to now
+func parseDate(s string, now time.Time) (time.Time, error) {
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
 
 	switch s {
@@ -293,18 +295,18 @@ func newProjectDoCmd(deps *Dependencies) *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Look up project by name
-			project, err := deps.App.GetProjectByName.Execute(args[0])
+			project, err := deps.App.Projects.Get(args[0])
 			if err != nil {
 				return err
 			}
 
 			// Complete the project (and its children)
-			completed, err := deps.App.CompleteTasks.Execute([]int64{project.ID})
+			completed, err := deps.App.Tasks.Complete([]int64{project.ID})
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.ProjectsCompleted(completed)
 			return nil
 		},
@@ -324,18 +326,18 @@ func newProjectUndoCmd(deps *Dependencies) *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Look up project by name
-			project, err := deps.App.GetProjectByName.Execute(args[0])
+			project, err := deps.App.Projects.Get(args[0])
 			if err != nil {
 				return err
 			}
 
 			// Uncomplete the project
-			uncompleted, err := deps.App.UncompleteTasks.Execute([]int64{project.ID})
+			uncompleted, err := deps.App.Tasks.Uncomplete([]int64{project.ID})
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.ProjectsUncompleted(uncompleted)
 			return nil
 		},
@@ -384,7 +386,7 @@ Examples:
 			projectName := args[0]
 
 			// Look up project by name
-			project, err := deps.App.GetProjectByName.Execute(projectName)
+			project, err := deps.App.Projects.Get(projectName)
 			if err != nil {
 				return err
 			}
@@ -412,7 +414,7 @@ Examples:
 				return errors.New("cannot specify both --description and --clear-description")
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 
 			// If no changes specified, show details
 			hasChanges := title != "" || description != "" || areaName != "" ||
@@ -465,79 +467,79 @@ Examples:
 
 			// Apply changes
 			if title != "" {
-				if _, err := deps.App.SetTaskTitle.Execute(project.ID, title); err != nil {
+				if _, err := deps.App.Tasks.SetTitle(project.ID, title); err != nil {
 					return err
 				}
 			}
 
 			if description != "" {
-				if _, err := deps.App.SetTaskDescription.Execute(project.ID, &description); err != nil {
+				if _, err := deps.App.Tasks.SetDescription(project.ID, &description); err != nil {
 					return err
 				}
 			} else if clearDescription {
-				if _, err := deps.App.SetTaskDescription.Execute(project.ID, nil); err != nil {
+				if _, err := deps.App.Tasks.SetDescription(project.ID, nil); err != nil {
 					return err
 				}
 			}
 
 			if areaName != "" {
-				if _, err := deps.App.SetTaskArea.Execute(project.ID, areaName); err != nil {
+				if _, err := deps.App.Tasks.SetArea(project.ID, areaName); err != nil {
 					return err
 				}
 			} else if clearArea {
-				if _, err := deps.App.SetTaskArea.Execute(project.ID, ""); err != nil {
+				if _, err := deps.App.Tasks.SetArea(project.ID, ""); err != nil {
 					return err
 				}
 			}
 
 			if plannedStr != "" {
-				planned, err := dateparse.Parse(plannedStr)
+				planned, err := dateparse.ParseFrom(plannedStr, deps.today())
 				if err != nil {
 					return err
 				}
-				if _, err := deps.App.SetPlannedDate.Execute(project.ID, &planned); err != nil {
+				if _, err := deps.App.Tasks.SetPlannedDate(project.ID, &planned); err != nil {
 					return err
 				}
 			} else if clearPlanned {
-				if _, err := deps.App.SetPlannedDate.Execute(project.ID, nil); err != nil {
+				if _, err := deps.App.Tasks.SetPlannedDate(project.ID, nil); err != nil {
 					return err
 				}
 			}
 
 			if dueStr != "" {
-				due, err := dateparse.Parse(dueStr)
+				due, err := dateparse.ParseFrom(dueStr, deps.today())
 				if err != nil {
 					return err
 				}
-				if _, err := deps.App.SetDueDate.Execute(project.ID, &due); err != nil {
+				if _, err := deps.App.Tasks.SetDueDate(project.ID, &due); err != nil {
 					return err
 				}
 			} else if clearDue {
-				if _, err := deps.App.SetDueDate.Execute(project.ID, nil); err != nil {
+				if _, err := deps.App.Tasks.SetDueDate(project.ID, nil); err != nil {
 					return err
 				}
 			}
 
 			for _, tag := range addTags {
-				if _, err := deps.App.AddTag.Execute(project.ID, tag); err != nil {
+				if _, err := deps.App.Tasks.AddTag(project.ID, tag); err != nil {
 					return err
 				}
 			}
 
 			for _, tag := range removeTags {
-				if _, err := deps.App.RemoveTag.Execute(project.ID, tag); err != nil {
+				if _, err := deps.App.Tasks.RemoveTag(project.ID, tag); err != nil {
 					return err
 				}
 			}
 
 			if someday {
-				if _, err := deps.App.DeferTask.Execute(project.ID); err != nil {
+				if _, err := deps.App.Tasks.Defer(project.ID); err != nil {
 					return err
 				}
 			}
 
 			if active {
-				if _, err := deps.App.ActivateTask.Execute(project.ID); err != nil {
+				if _, err := deps.App.Tasks.Activate(project.ID); err != nil {
 					return err
 				}
 			}
@@ -570,3 +572,100 @@ Examples:
 
 	return cmd
 }
+
+func newProjectPlanCmd(deps *Dependencies) *cobra.Command {
+	var capacityStr string
+	var apply bool
+
+	cmd := &cobra.Command{
+		Use:   "plan <name>",
+		Short: "Propose planned dates for a project's tasks",
+		Long: `Propose planned dates for the open tasks of a project, from today on.
+
+A task starts once the tasks it depends on (see tt depend) are done, and no
+day gets more estimated work than daily_capacity, or --capacity. Tasks due
+first go first; tasks longer than a day run over several. Weekends and
+holidays are skipped. Nothing changes unless --apply is given.
+
+Examples:
+  t project plan Launch
+  t project plan Launch --capacity 4h
+  t project plan Launch --apply`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Only applying writes, so the command isn't marked mutating
+			if apply {
+				if err := deps.requireWritable(); err != nil {
+					return err
+				}
+			}
+
+			capacity := dailyCapacity(deps.Config)
+			if capacityStr != "" {
+				minutes, err := task.ParseEstimate(capacityStr)
+				if err != nil {
+					return fmt.Errorf("invalid --capacity: %w", err)
+				}
+				capacity = minutes
+			}
+			if capacity == 0 {
+				return errors.New("no daily capacity: set daily_capacity in the config or pass --capacity")
+			}
+
+			plan, err := deps.App.Tasks.PlanProject(args[0], capacity, apply)
+			if errors.Is(err, task.ErrTaskNotFound) {
+				return fmt.Errorf("project %q not found", args[0])
+			}
+			if err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.ProjectPlan(plan, apply)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&capacityStr, "capacity", "", "Work per day, e.g. 4h (default: daily_capacity)")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Plan the tasks for the proposed days")
+
+	registry := NewCompletionRegistry(deps)
+	cmd.ValidArgsFunction = registry.ProjectCompletion()
+
+	return cmd
+}
+
+func newProjectGanttCmd(deps *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gantt <name>",
+		Short: "Show a project's tasks on a timeline",
+		Long: `Show the open tasks of a project as a Gantt chart: a bar for each task
+from its planned date (or the day it was added) to its due date.
+
+Each column is a day, or a week when the chart wouldn't fit the terminal.
+Overdue bars are highlighted and today's column is marked.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, err := deps.App.Projects.Get(args[0])
+			if errors.Is(err, task.ErrTaskNotFound) {
+				return fmt.Errorf("project %q not found", args[0])
+			}
+			if err != nil {
+				return err
+			}
+			tasks, err := deps.App.Tasks.List(&task.ListOptions{ProjectName: project.Title})
+			if err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.ProjectGantt(project, tasks)
+			return nil
+		},
+	}
+
+	registry := NewCompletionRegistry(deps)
+	cmd.ValidArgsFunction = registry.ProjectCompletion()
+
+	return cmd
+}
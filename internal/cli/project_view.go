@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/spf13/cobra"
+)
+
+func NewProjectViewCmd(deps *Dependencies) *cobra.Command {
+	var sortStr string
+	var details bool
+	var quiet bool
+
+	cmd := &cobra.Command{
+		Use:   "p <project>",
+		Short: "Show a project with its tasks grouped by schedule",
+		Long: `Show a project: its area, dates and description, then its tasks under
+Today, Upcoming, Anytime and Someday. Short for
+
+  tt list --project <project> --group schedule
+
+plus the project header.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, err := deps.App.Projects.Get(args[0])
+			if errors.Is(err, task.ErrTaskNotFound) {
+				return fmt.Errorf("project %q not found", args[0])
+			}
+			if err != nil {
+				return err
+			}
+			if project.AreaID != nil {
+				// Looked up by name, the project comes without its area name
+				areas, err := deps.App.Areas.List()
+				if err != nil {
+					return err
+				}
+				for _, a := range areas {
+					if a.ID == *project.AreaID {
+						project.AreaName = &a.Name
+					}
+				}
+			}
+
+			sortToUse := sortStr
+			if sortToUse == "" {
+				sortToUse = deps.Config.GetSort("project")
+			}
+			sortOpts, err := task.ParseSort(sortToUse)
+			if err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			applyListLayout(formatter, deps.Config, "project")
+			formatter.SetHideScope(true)
+			if details {
+				formatter.SetDetails(true)
+			}
+
+			formatter.ProjectOverview(project)
+			tasks, err := listBySchedule(deps, formatter, task.ListOptions{
+				ProjectName: project.Title,
+				Sort:        sortOpts,
+			}, sortStr)
+			if err != nil {
+				return err
+			}
+			if len(tasks) == 0 {
+				formatter.TaskList(nil)
+			}
+			if !quiet {
+				formatter.ListFooter(tasks)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&sortStr, "sort", "s", "", "Sort by field(s): id, title, planned, due, created, project, area")
+	cmd.Flags().BoolVar(&details, "details", false, "Show description excerpts and checklist progress")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Omit the summary footer")
+	cmd.ValidArgsFunction = NewCompletionRegistry(deps).AllProjectCompletion()
+
+	return cmd
+}
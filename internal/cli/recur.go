@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/devbydaniel/tt/internal/dateparse"
-	"github.com/devbydaniel/tt/internal/output"
 	"github.com/devbydaniel/tt/internal/recurparse"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +17,7 @@ func NewRecurCmd(deps *Dependencies) *cobra.Command {
 	var pause bool
 	var resume bool
 	var endStr string
+	var count int
 	var show bool
 
 	cmd := &cobra.Command{
@@ -28,11 +28,21 @@ func NewRecurCmd(deps *Dependencies) *cobra.Command {
 Examples:
   t recur 5 "every monday"      Set weekly recurrence on Mondays
   t recur 5 "daily"             Set daily recurrence
+  t recur 5 "every weekday"     Recur Monday through Friday
+  t recur 5 "every 2 weeks on friday"
+                                Recur on Fridays every other week
   t recur 5 "3d after done"     Recur 3 days after completion
   t recur 5 --clear             Clear recurrence
   t recur 5 --pause             Pause recurrence
   t recur 5 --resume            Resume paused recurrence
   t recur 5 --end 2025-12-31    Set recurrence end date
+  t recur 5 "weekly for 10 times"
+                                Stop after 10 occurrences
+  t recur 5 --count 10          Set the number of occurrences (0 = no limit)
+  t recur 5 "weekly rotate anna,ben,cara"
+                                Tag each occurrence with the next one in turn
+  t recur 5 "weekly rotate assignee anna,ben"
+                                Assign each occurrence to the next one in turn
   t recur 5 --show              Show current recurrence info`,
 		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -41,11 +51,11 @@ Examples:
 				return errors.New("invalid task ID: " + args[0])
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 
 			// Handle --show
 			if show {
-				t, err := deps.App.GetTask.Execute(id)
+				t, err := deps.App.Tasks.Get(id)
 				if err != nil {
 					return err
 				}
@@ -53,9 +63,14 @@ Examples:
 				return nil
 			}
 
+			// Everything past --show changes the task
+			if err := deps.requireWritable(); err != nil {
+				return err
+			}
+
 			// Handle --clear
 			if clear {
-				t, err := deps.App.SetRecurrence.Execute(id, nil, nil, nil)
+				t, err := deps.App.Tasks.SetRecurrence(id, nil, nil, nil, nil)
 				if err != nil {
 					return err
 				}
@@ -65,7 +80,7 @@ Examples:
 
 			// Handle --pause
 			if pause {
-				t, err := deps.App.PauseRecurrence.Execute(id)
+				t, err := deps.App.Tasks.PauseRecurrence(id)
 				if err != nil {
 					return err
 				}
@@ -75,7 +90,7 @@ Examples:
 
 			// Handle --resume
 			if resume {
-				t, err := deps.App.ResumeRecurrence.Execute(id)
+				t, err := deps.App.Tasks.ResumeRecurrence(id)
 				if err != nil {
 					return err
 				}
@@ -83,19 +98,33 @@ Examples:
 				return nil
 			}
 
-			// Handle --end (without pattern)
-			if endStr != "" && len(args) == 1 {
-				var endDate *time.Time
-				end, err := dateparse.Parse(endStr)
-				if err != nil {
-					return err
+			countSet := cmd.Flags().Changed("count")
+			if countSet && count < 0 {
+				return errors.New("--count must be 0 (no limit) or more")
+			}
+
+			// Handle --end and --count (without pattern)
+			if (endStr != "" || countSet) && len(args) == 1 {
+				if endStr != "" {
+					var endDate *time.Time
+					end, err := dateparse.ParseFrom(endStr, deps.today())
+					if err != nil {
+						return err
+					}
+					endDate = &end
+					t, err := deps.App.Tasks.SetRecurrenceEnd(id, endDate)
+					if err != nil {
+						return err
+					}
+					formatter.TaskRecurrenceEndSet(t)
 				}
-				endDate = &end
-				t, err := deps.App.SetRecurrenceEnd.Execute(id, endDate)
-				if err != nil {
-					return err
+				if countSet {
+					t, err := deps.App.Tasks.SetRecurrenceCount(id, occurrenceCount(count))
+					if err != nil {
+						return err
+					}
+					formatter.TaskRecurrenceCountSet(t)
 				}
-				formatter.TaskRecurrenceEndSet(t)
 				return nil
 			}
 
@@ -117,17 +146,24 @@ Examples:
 
 			recurType := string(result.Type)
 
+			if countSet && result.Count > 0 {
+				return errors.New("cannot specify both --count and a \"for N times\" pattern")
+			}
+			if countSet {
+				result.Count = count
+			}
+
 			// Parse end date if provided
 			var endDate *time.Time
 			if endStr != "" {
-				end, err := dateparse.Parse(endStr)
+				end, err := dateparse.ParseFrom(endStr, deps.today())
 				if err != nil {
 					return err
 				}
 				endDate = &end
 			}
 
-			t, err := deps.App.SetRecurrence.Execute(id, &recurType, &ruleJSON, endDate)
+			t, err := deps.App.Tasks.SetRecurrence(id, &recurType, &ruleJSON, endDate, occurrenceCount(result.Count))
 			if err != nil {
 				return err
 			}
@@ -141,7 +177,16 @@ Examples:
 	cmd.Flags().BoolVar(&pause, "pause", false, "Pause recurrence (keeps rule)")
 	cmd.Flags().BoolVar(&resume, "resume", false, "Resume paused recurrence")
 	cmd.Flags().StringVar(&endStr, "end", "", "Set recurrence end date")
+	cmd.Flags().IntVar(&count, "count", 0, "End recurrence after this many occurrences (0 = no limit)")
 	cmd.Flags().BoolVar(&show, "show", false, "Show current recurrence info")
 
 	return cmd
 }
+
+// occurrenceCount converts a --count value to a recurrence count, where 0 means no limit
+func occurrenceCount(n int) *int {
+	if n <= 0 {
+		return nil
+	}
+	return &n
+}
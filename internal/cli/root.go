@@ -1,46 +1,280 @@
 package cli
 
 import (
+	"errors"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/devbydaniel/tt/config"
 	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/buildinfo"
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/database"
 	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/logging"
 	"github.com/devbydaniel/tt/internal/output"
-	"github.com/devbydaniel/tt/internal/tui"
 	"github.com/spf13/cobra"
 )
 
 type Dependencies struct {
-	App    *app.App
-	Config *config.Config
-	Theme  *output.Theme
+	App      *app.App
+	DB       *database.DB // for maintenance below the domain level
+	Config   *config.Config
+	Theme    *output.Theme
+	ReadOnly bool // reject commands that write to the database
+
+	// OpenDB opens the database and sets App and DB. It is called only once
+	// a command needs them, so commands like completion and --help never
+	// touch the database. Nil when App is set up front, as in tests.
+	OpenDB func(d *Dependencies) error
+}
+
+// noDatabaseAnnotation marks commands (with their subcommands) that don't use the database
+const noDatabaseAnnotation = "no-database"
+
+// withoutDatabase marks cmd and its subcommands as not using the database
+func withoutDatabase(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[noDatabaseAnnotation] = "true"
+	return cmd
+}
+
+// open runs OpenDB unless the database is already open
+func (d *Dependencies) open() error {
+	if d.App != nil || d.OpenDB == nil {
+		return nil
+	}
+	return d.OpenDB(d)
+}
+
+// usesDatabase reports whether cmd needs the database opened before it runs.
+// Shell completion requests open it themselves, only when completing names.
+func usesDatabase(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return false
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[noDatabaseAnnotation] == "true" {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrReadOnly is returned when a mutating command runs in read-only mode
+var ErrReadOnly = errors.New("tt is in read-only mode; rerun without --read-only (or unset read_only in the config) to make changes")
+
+// mutatingAnnotation marks commands that write to the database
+const mutatingAnnotation = "mutating"
+
+// mutating marks cmd as writing to the database, so it is rejected in read-only mode
+func mutating(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[mutatingAnnotation] = "true"
+	return cmd
+}
+
+// requireWritable returns ErrReadOnly when the database was opened read-only
+func (d *Dependencies) requireWritable() error {
+	if d.ReadOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// now returns the current time from the app's clock, so one command sees
+// the same moment everywhere. Before the database is open, it's the system
+// time.
+func (d *Dependencies) now() time.Time {
+	if d.App == nil {
+		return time.Now()
+	}
+	return clock.Now(d.App.Clock)
+}
+
+// today returns the date considered today, after the configured day rollover
+func (d *Dependencies) today() time.Time {
+	return d.schedule().Today(d.now())
+}
+
+func (d *Dependencies) schedule() task.ScheduleSettings {
+	return task.ScheduleSettings{UpcomingDays: d.Config.UpcomingDays, DayRolloverHour: d.Config.DayRolloverHour}
+}
+
+// formatter returns a formatter for w with the configured theme, reading
+// the time from the app's clock and today from the day rollover
+func (d *Dependencies) formatter(w io.Writer) *output.Formatter {
+	f := output.NewFormatter(w, d.Theme)
+	if d.App != nil && d.App.Clock != nil {
+		f.SetClock(d.App.Clock)
+	}
+	f.SetSchedule(d.schedule())
+	f.SetDateGroups(d.Config.DateGroups)
+	return f
+}
+
+// ReadOnlyRequested reports whether --read-only appears in args. Shell
+// completion requests can open the database without cobra parsing the flag,
+// so main needs to know up front.
+func ReadOnlyRequested(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "--read-only", "--read-only=true":
+			return true
+		}
+	}
+	return false
+}
+
+// DBPathRequested returns the path given with --db in args, or "" if there
+// is none. Like ReadOnlyRequested, it lets main honour the flag for shell
+// completion requests.
+func DBPathRequested(args []string) string {
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return ""
+		case arg == "--db" && i+1 < len(args):
+			return args[i+1]
+		case strings.HasPrefix(arg, "--db="):
+			return strings.TrimPrefix(arg, "--db=")
+		}
+	}
+	return ""
 }
 
 func NewRootCmd(deps *Dependencies) *cobra.Command {
+	var readOnly, noColor, accessible, verbose bool
+	var dbPath string
+
 	rootCmd := &cobra.Command{
-		Use:   "tt",
-		Short: "A CLI task manager",
+		Use:     "tt",
+		Version: buildinfo.Get().Version,
+		Short:   "A CLI task manager",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if readOnly {
+				deps.ReadOnly = true
+			}
+			if dbPath != "" {
+				deps.Config.Database = dbPath
+			}
+			if noColor {
+				deps.Config.Theme.NoColor = true
+				deps.Theme = output.NewTheme(&deps.Config.Theme)
+			}
+			if accessible || deps.Config.Accessible {
+				deps.Config.Accessible = true
+				deps.Theme.SetAccessible()
+			}
+			if verbose {
+				deps.Config.Debug = true
+			}
+			if deps.Config.Debug && !logging.Enabled() {
+				if _, err := logging.Start(deps.Config.DataDir); err != nil {
+					deps.formatter(os.Stderr).Warning("debug log: " + err.Error())
+				}
+				logging.Debug("command", "path", cmd.CommandPath(), "args", args)
+			}
+			if cmd.Annotations[skipConfigWarningsAnnotation] != "true" {
+				warnings := deps.formatter(os.Stderr)
+				for _, problem := range ConfigProblems(deps.Config) {
+					warnings.Warning("config: " + problem)
+				}
+			}
+			if !usesDatabase(cmd) {
+				return nil
+			}
+			if err := deps.open(); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			if deps.Theme != nil {
+				if colors, err := deps.App.Tasks.TagColors(); err == nil {
+					deps.Theme.SetTagColors(colors)
+				} else {
+					deps.formatter(os.Stderr).Warning("tag colors: " + err.Error())
+				}
+			}
+			if !deps.ReadOnly && cmd.Annotations[skipExpireAnnotation] != "true" {
+				// Expire tasks lazily so they're gone without running tt maintain
+				expired, err := deps.App.Tasks.Expire()
+				notes := deps.formatter(os.Stderr)
+				if err != nil {
+					notes.Warning("expiring tasks: " + err.Error())
+				} else if len(expired) > 0 {
+					notes.ExpiredNotice(len(expired), deps.Config.ExpireAction)
+				}
+				// Likewise resume what tt pause paused once it's over
+				if err := endVacationIfOver(deps, os.Stderr); err != nil {
+					notes.Warning("ending pause: " + err.Error())
+				}
+			}
+			if cmd.Annotations[mutatingAnnotation] == "true" {
+				return deps.requireWritable()
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return tui.Run(deps.App, deps.Theme, deps.Config)
+			if needsSetup(deps) {
+				if err := runSetup(deps, os.Stdin, os.Stdout); err != nil {
+					return err
+				}
+				pause(deps, os.Stdin, os.Stdout)
+			}
+			return runTUI(deps, "")
 		},
 	}
 
-	rootCmd.AddCommand(NewAddCmd(deps))
+	rootCmd.PersistentFlags().BoolVar(&readOnly, "read-only", false, "Open the database read-only and reject changes")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	rootCmd.PersistentFlags().BoolVar(&accessible, "accessible", false, "Screen-reader friendly output: labeled lines, no colors or icons")
+	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "", "Use this database file instead of the configured one")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Append a debug log to tt.log in the data directory")
+
+	rootCmd.AddCommand(mutating(NewAddCmd(deps)))
 	rootCmd.AddCommand(NewListCmd(deps))
-	rootCmd.AddCommand(NewEditCmd(deps))
-	rootCmd.AddCommand(NewDoCmd(deps))
-	rootCmd.AddCommand(NewUndoCmd(deps))
-	rootCmd.AddCommand(NewDeleteCmd(deps))
+	rootCmd.AddCommand(mutating(NewEditCmd(deps)))
+	rootCmd.AddCommand(mutating(NewDoCmd(deps)))
+	rootCmd.AddCommand(mutating(NewUndoCmd(deps)))
+	rootCmd.AddCommand(mutating(NewCancelCmd(deps)))
+	rootCmd.AddCommand(mutating(NewDeleteCmd(deps)))
 	rootCmd.AddCommand(NewLogCmd(deps))
+	rootCmd.AddCommand(NewExportCmd(deps))
+	rootCmd.AddCommand(withoutDatabase(NewImportCmd(deps)))
 	rootCmd.AddCommand(NewAreaCmd(deps))
 	rootCmd.AddCommand(NewProjectCmd(deps))
-	rootCmd.AddCommand(NewPlanCmd(deps))
+	rootCmd.AddCommand(mutating(NewPlanCmd(deps)))
+	rootCmd.AddCommand(mutating(NewBlockCmd(deps)))
+	rootCmd.AddCommand(mutating(NewPauseCmd(deps)))
+	rootCmd.AddCommand(mutating(NewDependCmd(deps)))
 	rootCmd.AddCommand(NewDueCmd(deps))
 	rootCmd.AddCommand(NewRecurCmd(deps))
+	rootCmd.AddCommand(NewHolidaysCmd(deps))
+	rootCmd.AddCommand(NewTokenCmd(deps))
+	rootCmd.AddCommand(mutating(NewMaintainCmd(deps)))
+	rootCmd.AddCommand(withoutDatabase(NewDBCmd(deps)))
+	rootCmd.AddCommand(withoutDatabase(NewDoctorCmd(deps)))
+	rootCmd.AddCommand(withoutDatabase(NewVersionCmd(deps)))
+	rootCmd.AddCommand(mutating(NewSetupCmd(deps)))
 	rootCmd.AddCommand(NewTagCmd(deps))
+	rootCmd.AddCommand(mutating(NewCommentCmd(deps)))
+	rootCmd.AddCommand(mutating(NewFlagCmd(deps)))
+	rootCmd.AddCommand(mutating(NewSplitCmd(deps)))
+	rootCmd.AddCommand(mutating(NewUnflagCmd(deps)))
+	rootCmd.AddCommand(NewShowCmd(deps))
 	rootCmd.AddCommand(NewSearchCmd(deps))
-	rootCmd.AddCommand(NewCompletionCmd())
+	rootCmd.AddCommand(withoutDatabase(NewCompletionCmd()))
+	rootCmd.AddCommand(withoutDatabase(NewConfigCmd(deps)))
+	rootCmd.AddCommand(withoutDatabase(NewThemeCmd(deps)))
 
 	// Shorthand list commands
 	rootCmd.AddCommand(NewInboxCmd(deps))
@@ -48,10 +282,20 @@ func NewRootCmd(deps *Dependencies) *cobra.Command {
 	rootCmd.AddCommand(NewUpcomingCmd(deps))
 	rootCmd.AddCommand(NewAnytimeCmd(deps))
 	rootCmd.AddCommand(NewSomedayCmd(deps))
+	rootCmd.AddCommand(NewMineCmd(deps))
+	rootCmd.AddCommand(NewFlaggedCmd(deps))
+	rootCmd.AddCommand(NewWeekCmd(deps))
+	rootCmd.AddCommand(NewStandupCmd(deps))
+	rootCmd.AddCommand(NewShareCmd(deps))
+	rootCmd.AddCommand(NewOverdueCmd(deps))
+	rootCmd.AddCommand(NewTreeCmd(deps))
+	rootCmd.AddCommand(NewProjectViewCmd(deps))
+	rootCmd.AddCommand(NewNextCmd(deps))
 	rootCmd.AddCommand(NewTagsCmd(deps))
+	rootCmd.AddCommand(NewLintCmd(deps))
 
 	// Shorthand task commands
-	rootCmd.AddCommand(NewRenameCmd(deps))
+	rootCmd.AddCommand(mutating(NewRenameCmd(deps)))
 
 	// Interactive TUI
 	rootCmd.AddCommand(NewTUICmd(deps))
@@ -59,11 +303,23 @@ func NewRootCmd(deps *Dependencies) *cobra.Command {
 	return rootCmd
 }
 
-// RunListView runs a list view with the given view name, optional sort and group overrides.
-// This is used by all shortcut commands (today, upcoming, etc.) and the list command.
-func RunListView(deps *Dependencies, viewCmd, sortOverride, groupOverride string, jsonOutput bool) error {
+// ListViewOptions holds the per-invocation overrides accepted by list views
+type ListViewOptions struct {
+	Sort     string // overrides the configured sort
+	Group    string // overrides the configured grouping
+	Context  string // only show tasks with this context label
+	Assignee string // only show tasks assigned to this person
+	Location string // only show tasks at this location
+	Details  bool   // show description excerpts and checklist progress
+	Quiet    bool   // omit the summary footer
+	JSON     bool
+}
+
+// RunListView runs a list view with the given view name and overrides.
+// This is used by all shortcut commands (today, upcoming, etc.).
+func RunListView(deps *Dependencies, viewCmd string, viewOpts ListViewOptions) error {
 	// Build list options based on view command
-	opts := &task.ListOptions{}
+	opts := &task.ListOptions{Context: viewOpts.Context, Assignee: viewOpts.Assignee, Location: viewOpts.Location}
 	switch viewCmd {
 	case "today":
 		opts.Schedule = "today"
@@ -75,12 +331,14 @@ func RunListView(deps *Dependencies, viewCmd, sortOverride, groupOverride string
 		opts.Schedule = "someday"
 	case "inbox":
 		opts.Schedule = "inbox"
+	case "flagged":
+		opts.Flagged = true
 	case "all":
 		// no schedule filter
 	}
 
 	// Resolve sorting: override > config > code default
-	sortToUse := sortOverride
+	sortToUse := viewOpts.Sort
 	if sortToUse == "" {
 		sortToUse = deps.Config.GetSort(viewCmd)
 	}
@@ -90,25 +348,63 @@ func RunListView(deps *Dependencies, viewCmd, sortOverride, groupOverride string
 	}
 	opts.Sort = sortOpts
 
-	tasks, err := deps.App.ListTasks.Execute(opts)
+	tasks, err := deps.App.Tasks.List(opts)
 	if err != nil {
 		return err
 	}
 
-	if jsonOutput {
+	if viewOpts.JSON {
 		return output.WriteJSON(os.Stdout, tasks)
 	}
 
 	// Resolve grouping: override > config > none
-	groupBy := groupOverride
+	groupBy := viewOpts.Group
 	if groupBy == "" {
 		groupBy = deps.Config.GetGroup(viewCmd)
 	}
 
-	formatter := output.NewFormatter(os.Stdout, deps.Theme)
+	formatter := deps.formatter(os.Stdout)
+	formatter.SetCapacity(dailyCapacity(deps.Config))
+	applyListLayout(formatter, deps.Config, viewCmd)
+	if viewOpts.Details {
+		formatter.SetDetails(true)
+	}
 	if viewCmd == "today" {
 		formatter.SetHidePlannedDate(true)
 	}
 	formatter.GroupedTaskList(tasks, groupBy)
+	if viewCmd == "today" || viewCmd == "upcoming" {
+		formatter.LoadSummary(tasks)
+	}
+	if !viewOpts.Quiet {
+		formatter.ListFooter(tasks)
+	}
 	return nil
 }
+
+// applyListLayout configures the formatter's columns, title overflow and
+// details for a list view
+func applyListLayout(f *output.Formatter, cfg *config.Config, listName string) {
+	columns, widths := cfg.GetColumns(listName)
+	f.SetColumns(output.ColumnLayout{Columns: columns, Widths: widths})
+	f.SetOverflow(cfg.GetOverflow(listName))
+	f.SetDetails(cfg.GetDetails(listName))
+	if groupSort := cfg.GetGroupSort(listName); groupSort != "" {
+		// Invalid values are reported as config problems
+		if opts, err := task.ParseSort(groupSort); err == nil {
+			f.SetGroupSort(opts)
+		}
+	}
+}
+
+// dailyCapacity returns the configured daily capacity in minutes (0 if unset or invalid)
+func dailyCapacity(cfg *config.Config) int {
+	if cfg.DailyCapacity == "" {
+		return 0
+	}
+	minutes, err := task.ParseEstimate(cfg.DailyCapacity)
+	if err != nil {
+		return 0
+	}
+	return minutes
+}
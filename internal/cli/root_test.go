@@ -0,0 +1,194 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/cli"
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/testutil"
+)
+
+func TestReadOnlyRejectsMutations(t *testing.T) {
+	deps := setupCLI(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{"add", []string{"--read-only", "add", "Task"}, cli.ErrReadOnly},
+		{"area add", []string{"--read-only", "area", "add", "work"}, cli.ErrReadOnly},
+		{"recur", []string{"--read-only", "recur", "1", "daily"}, cli.ErrReadOnly},
+		{"list", []string{"--read-only", "list"}, nil},
+		{"area list", []string{"--read-only", "area", "list"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := cli.NewRootCmd(deps)
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	tasks, err := deps.App.ListTasks.Execute(nil)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Errorf("expected no tasks to be created, got %d", len(tasks))
+	}
+}
+
+func TestReadOnlyRequested(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"list"}, false},
+		{[]string{"--read-only", "list"}, true},
+		{[]string{"list", "--read-only=true"}, true},
+		{[]string{"add", "--", "--read-only"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := cli.ReadOnlyRequested(tt.args); got != tt.want {
+			t.Errorf("ReadOnlyRequested(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestDatabaseOpenedLazily(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantOpen bool
+	}{
+		{[]string{"completion", "bash"}, false},
+		{[]string{"config", "path"}, false},
+		{[]string{"theme", "list"}, false},
+		{[]string{"add", "--help"}, false},
+		{[]string{"__complete", "a"}, false},
+		{[]string{"__complete", "add", "--project", ""}, true},
+		{[]string{"list"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args[0], func(t *testing.T) {
+			opened := false
+			deps := &cli.Dependencies{
+				Config: &config.Config{},
+				OpenDB: func(d *cli.Dependencies) error {
+					opened = true
+					d.App = app.New(testutil.NewTestDB(t))
+					return nil
+				},
+			}
+
+			cmd := cli.NewRootCmd(deps)
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute(%v) error = %v", tt.args, err)
+			}
+			if opened != tt.wantOpen {
+				t.Errorf("Execute(%v) opened database = %v, want %v", tt.args, opened, tt.wantOpen)
+			}
+		})
+	}
+}
+
+// unavailableTasks is a task service whose Complete always fails, standing
+// in for a backend that can't be reached
+type unavailableTasks struct {
+	task.Service
+}
+
+var errUnavailable = errors.New("backend unavailable")
+
+func (unavailableTasks) Complete([]int64) ([]task.CompleteResult, error) {
+	return nil, errUnavailable
+}
+
+func TestCommandsUseTaskService(t *testing.T) {
+	deps := setupCLI(t)
+	if _, err := deps.App.Tasks.Create("Write report", nil); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	deps.App.Tasks = unavailableTasks{deps.App.Tasks}
+
+	cmd := cli.NewRootCmd(deps)
+	cmd.SetArgs([]string{"do", "1"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); !errors.Is(err, errUnavailable) {
+		t.Errorf("Execute() error = %v, want %v", err, errUnavailable)
+	}
+
+	got, err := deps.App.GetTask.Execute(1)
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if got.Status != task.StatusTodo {
+		t.Errorf("Status = %q, want the task left open", got.Status)
+	}
+}
+
+func TestPauseEndsOnStartup(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	dataDir := t.TempDir()
+	deps := &cli.Dependencies{
+		App:    app.NewWithOptions(db, app.Options{Clock: clock.Fixed(time.Date(2025, 8, 4, 9, 0, 0, 0, time.Local))}),
+		Config: &config.Config{DataDir: dataDir},
+	}
+
+	recurType := task.RecurTypeFixed
+	recurRule := `{"interval":1,"unit":"week"}`
+	chore, _ := deps.App.Tasks.Create("Take out bins", &task.CreateOptions{RecurType: &recurType, RecurRule: &recurRule})
+	other, _ := deps.App.Tasks.Create("Water plants", &task.CreateOptions{RecurType: &recurType, RecurRule: &recurRule})
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := cli.NewRootCmd(deps)
+		cmd.SetArgs(args)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+	}
+
+	// Paused by hand before, so it stays paused afterwards
+	deps.App.Tasks.PauseRecurrence(other.ID)
+	run("pause", "--until", "2025-08-15")
+	run("list")
+	if got, _ := deps.App.Tasks.Get(chore.ID); !got.RecurPaused {
+		t.Fatal("recurrence should be paused during the pause")
+	}
+
+	deps.App = app.NewWithOptions(db, app.Options{Clock: clock.Fixed(time.Date(2025, 8, 16, 9, 0, 0, 0, time.Local))})
+	run("list")
+	if got, _ := deps.App.Tasks.Get(chore.ID); got.RecurPaused {
+		t.Error("recurrence should be resumed after the pause")
+	}
+	if got, _ := deps.App.Tasks.Get(other.ID); !got.RecurPaused {
+		t.Error("recurrence paused by hand should stay paused")
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, "vacation.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("vacation.json should be removed, stat error = %v", err)
+	}
+}
@@ -24,7 +24,7 @@ func NewSearchCmd(deps *Dependencies) *cobra.Command {
 				// No schedule filter = search across all tasks
 			}
 
-			tasks, err := deps.App.ListTasks.Execute(opts)
+			tasks, err := deps.App.Tasks.List(opts)
 			if err != nil {
 				return err
 			}
@@ -33,7 +33,8 @@ func NewSearchCmd(deps *Dependencies) *cobra.Command {
 				return output.WriteJSON(os.Stdout, tasks)
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
+			applyListLayout(formatter, deps.Config, "")
 			formatter.TaskList(tasks)
 			return nil
 		},
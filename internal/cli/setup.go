@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewSetupCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "setup",
+		Short: "Set up tt interactively: theme, areas and config file",
+		Long: `Walk through the first-run setup: pick a color theme, create your
+areas, and write a starter config file with the theme set.
+
+This runs by itself the first time tt is started with an empty database
+and no config file. The theme and config steps are skipped when a config
+file already exists.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSetup(deps, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+}
+
+// needsSetup reports whether starting tt should run the setup first: a new
+// user on a terminal, with neither a config file nor any data
+func needsSetup(deps *Dependencies) bool {
+	if deps.ReadOnly || !term.IsTerminal(os.Stdin.Fd()) || !term.IsTerminal(os.Stdout.Fd()) {
+		return false
+	}
+	path := config.Path()
+	if path == "" {
+		return false
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+
+	counts, err := deps.App.Tasks.Count()
+	if err != nil || counts.Open+counts.Someday+counts.Done+counts.Projects > 0 {
+		return false
+	}
+	areas, err := deps.App.Areas.List()
+	return err == nil && len(areas) == 0
+}
+
+// runSetup asks for a theme and areas on in and writes the starter config.
+// An empty answer takes the default; end of input accepts the defaults for
+// everything left.
+func runSetup(deps *Dependencies, in io.Reader, out io.Writer) error {
+	s := &setup{in: bufio.NewReader(in), formatter: deps.formatter(out)}
+	s.formatter.SetupWelcome()
+
+	configPath := config.Path()
+	_, statErr := os.Stat(configPath)
+	writeConfig := configPath != "" && errors.Is(statErr, os.ErrNotExist)
+
+	if writeConfig {
+		themes := output.AvailableThemes()
+		current := deps.Config.Theme.Name
+		if current == "" {
+			current = themes[0]
+		}
+		fallback := slices.Index(themes, current) + 1
+		if fallback == 0 {
+			fallback = 1
+		}
+
+		s.formatter.SetupThemes(themes, current)
+		choice := s.choose(fallback, len(themes), "Theme [%d]: ", fallback)
+		deps.Config.Theme.Name = themes[choice-1]
+		deps.Theme = output.NewTheme(&deps.Config.Theme)
+		if deps.Config.Accessible {
+			deps.Theme.SetAccessible()
+		}
+		s.formatter = deps.formatter(out)
+	}
+
+	s.formatter.SetupAreas()
+	existing, err := deps.App.Areas.List()
+	if err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, a := range existing {
+		seen[strings.ToLower(a.Name)] = true
+	}
+	for _, name := range strings.Split(s.ask("Areas to create, separated by commas (Enter for none): "), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		a, err := deps.App.Areas.Create(name)
+		if err != nil {
+			s.formatter.Warning(fmt.Sprintf("creating area %s: %v", name, err))
+			continue
+		}
+		s.formatter.AreaCreated(a)
+	}
+
+	if writeConfig {
+		path, err := config.InitWithTheme(deps.Config.Theme.Name)
+		if err != nil {
+			return fmt.Errorf("writing config: %w", err)
+		}
+		s.formatter.SetupDone(path, true)
+		return nil
+	}
+	s.formatter.SetupDone(configPath, false)
+	return nil
+}
+
+// pause waits for Enter, so the setup summary can be read before the TUI
+// takes over the screen
+func pause(deps *Dependencies, in io.Reader, out io.Writer) {
+	deps.formatter(out).Prompt("Press Enter to open tt...")
+	_, _ = bufio.NewReader(in).ReadString('\n')
+}
+
+// setup reads answers for runSetup
+type setup struct {
+	in        *bufio.Reader
+	formatter *output.Formatter
+	done      bool // input ended; take defaults from here on
+}
+
+// ask prints the prompt and returns the trimmed answer, "" at end of input
+func (s *setup) ask(prompt string, args ...any) string {
+	if s.done {
+		return ""
+	}
+	s.formatter.Prompt(prompt, args...)
+	line, err := s.in.ReadString('\n')
+	if err != nil {
+		s.done = true
+		s.formatter.PromptAbandoned()
+	}
+	return strings.TrimSpace(line)
+}
+
+// choose asks for a number from 1 to n until it gets one, returning
+// fallback for an empty answer
+func (s *setup) choose(fallback, n int, prompt string, args ...any) int {
+	for {
+		answer := s.ask(prompt, args...)
+		if answer == "" {
+			return fallback
+		}
+		if choice, err := strconv.Atoi(answer); err == nil && choice >= 1 && choice <= n {
+			return choice
+		}
+		s.formatter.ChoiceOutOfRange(n)
+	}
+}
@@ -0,0 +1,46 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/cli"
+	"github.com/devbydaniel/tt/internal/output"
+)
+
+func TestSetup(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	deps := setupCLI(t)
+	deps.Theme = output.DefaultTheme()
+
+	cmd := cli.NewRootCmd(deps)
+	cmd.SetArgs([]string{"setup"})
+	cmd.SetIn(strings.NewReader("9\n3\nWork, Health,work\n"))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Please enter a number from 1 to 6") {
+		t.Errorf("out of range choice was accepted:\n%s", out.String())
+	}
+	areas, err := deps.App.ListAreas.Execute()
+	if err != nil {
+		t.Fatalf("list areas failed: %v", err)
+	}
+	if len(areas) != 2 {
+		t.Errorf("got %d areas, want 2: %v", len(areas), areas)
+	}
+
+	data, err := os.ReadFile(config.Path())
+	if err != nil {
+		t.Fatalf("config not written: %v", err)
+	}
+	if !strings.Contains(string(data), "[theme]\nname = \"gruvbox\"") {
+		t.Error("config does not set the chosen theme")
+	}
+}
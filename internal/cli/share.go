@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewShareCmd(deps *Dependencies) *cobra.Command {
+	var filter string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "share",
+		Short: "Print tasks as a checklist to paste into a chat",
+		Long: `Print the open tasks matching a filter as a checklist with their
+deadlines, ready to paste into Slack, a markdown document or a plain text
+message.
+
+The filter joins terms with spaces or "and"; a task has to match all:
+
+  project:Launch, area:Work, tag:waiting, context:deep, assignee:anna,
+  location:office    tasks with that project, area, tag, ...
+  is:flagged, is:today, is:upcoming, is:anytime, is:inbox, is:someday
+  due<=friday, planned>today, due=2025-08-15    date comparisons (<, <=,
+                     =, >=, >), with any date tt understands
+  invoice            tasks with the word in their title
+
+Quote values with spaces: project:"Q1 Goals" or due<="end of month".
+
+Examples:
+  t share --filter "project:Launch and due<=friday"
+  t share --filter "is:today" --format slack`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			today := deps.today()
+			f, err := parseFilter(filter, today)
+			if err != nil {
+				return err
+			}
+			// Deadlines first, then the rest in the order they were added
+			if f.opts.Sort, err = task.ParseSort("due,created"); err != nil {
+				return err
+			}
+			tasks, err := f.list(deps)
+			if err != nil {
+				return err
+			}
+			return output.WriteShare(os.Stdout, tasks, format, today)
+		},
+	}
+
+	cmd.Flags().StringVar(&filter, "filter", "", "Which tasks to share (default: all open tasks)")
+	cmd.Flags().StringVar(&format, "format", output.ShareMarkdown, "Output format: "+strings.Join(output.ShareFormats(), ", "))
+	_ = cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(output.ShareFormats(), cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
@@ -0,0 +1,37 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/devbydaniel/tt/internal/cli"
+)
+
+func TestShareMalformedFilter(t *testing.T) {
+	tests := map[string]string{
+		`project:"Q1 Goals`:            "unclosed quote",
+		"project:a and project:b":      "project given twice",
+		"owner:anna":                   "unknown key",
+		"is:urgent":                    "unknown is:urgent",
+		"due<=someday-ish":             "due<=someday-ish",
+		`project:Launch due<="friday"`: "", // project lookup fails instead
+	}
+	for filter, want := range tests {
+		deps := setupCLI(t)
+		cmd := cli.NewRootCmd(deps)
+		cmd.SetArgs([]string{"share", "--filter", filter})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		err := cmd.Execute()
+		if want == "" {
+			if err == nil || strings.Contains(err.Error(), "filter") {
+				t.Errorf("share %s: error = %v, want only the missing project", filter, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("share %s: error = %v, want it to mention %q", filter, err, want)
+		}
+	}
+}
@@ -4,106 +4,177 @@ import (
 	"errors"
 	"os"
 	"strconv"
+	"strings"
 
-	"github.com/devbydaniel/tt/internal/output"
+	"github.com/devbydaniel/tt/internal/domain/task"
 	"github.com/spf13/cobra"
 )
 
 func NewInboxCmd(deps *Dependencies) *cobra.Command {
-	var group string
-	var sortStr string
-	var jsonOutput bool
+	var opts ListViewOptions
 
 	cmd := &cobra.Command{
 		Use:   "inbox",
 		Short: "List tasks with no project, area, or dates",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunListView(deps, "inbox", sortStr, group, jsonOutput)
+			return RunListView(deps, "inbox", opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&group, "group", "g", "", "Group tasks by: scope, date, none")
-	cmd.Flags().StringVarP(&sortStr, "sort", "s", "", "Sort by field(s): id, title, planned, due, created, project, area")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	addListViewFlags(cmd, &opts)
 	return cmd
 }
 
 func NewTodayCmd(deps *Dependencies) *cobra.Command {
-	var group string
-	var sortStr string
-	var jsonOutput bool
+	var opts ListViewOptions
 
 	cmd := &cobra.Command{
 		Use:   "today",
 		Short: "List tasks planned for today or overdue",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunListView(deps, "today", sortStr, group, jsonOutput)
+			return RunListView(deps, "today", opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&group, "group", "g", "", "Group tasks by: scope, date, none")
-	cmd.Flags().StringVarP(&sortStr, "sort", "s", "", "Sort by field(s): id, title, planned, due, created, project, area")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	addListViewFlags(cmd, &opts)
+	cmd.AddCommand(mutating(newTodayOrderCmd(deps)))
+	return cmd
+}
+
+func newTodayOrderCmd(deps *Dependencies) *cobra.Command {
+	var clearOrder bool
+
+	cmd := &cobra.Command{
+		Use:   "order <id> [id...]",
+		Short: "Pin the order of the first tasks in today's list",
+		Long: `Pin the order of the first tasks in today's list: "do these, in this order".
+The tasks given come first, in that order; the rest of the list follows
+in the configured sort. The order only holds for today, and giving a new
+one replaces it.
+
+Examples:
+  tt today order 12 7 3
+  tt today order --clear`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if clearOrder == (len(args) > 0) {
+				return errors.New("give the task IDs in order, or --clear")
+			}
+			ids, err := parseIDs(args)
+			if err != nil {
+				return err
+			}
+
+			if err := deps.App.Tasks.OrderToday(ids); err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.TodayOrdered(len(ids))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&clearOrder, "clear", false, "Drop today's order and go back to the configured sort")
 	return cmd
 }
 
 func NewUpcomingCmd(deps *Dependencies) *cobra.Command {
-	var group string
-	var sortStr string
-	var jsonOutput bool
+	var opts ListViewOptions
 
 	cmd := &cobra.Command{
 		Use:   "upcoming",
 		Short: "List tasks with future dates",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunListView(deps, "upcoming", sortStr, group, jsonOutput)
+			return RunListView(deps, "upcoming", opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&group, "group", "g", "", "Group tasks by: scope, date, none")
-	cmd.Flags().StringVarP(&sortStr, "sort", "s", "", "Sort by field(s): id, title, planned, due, created, project, area")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	addListViewFlags(cmd, &opts)
 	return cmd
 }
 
 func NewAnytimeCmd(deps *Dependencies) *cobra.Command {
-	var group string
-	var sortStr string
-	var jsonOutput bool
+	var opts ListViewOptions
 
 	cmd := &cobra.Command{
 		Use:   "anytime",
 		Short: "List active tasks with no specific dates",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunListView(deps, "anytime", sortStr, group, jsonOutput)
+			return RunListView(deps, "anytime", opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&group, "group", "g", "", "Group tasks by: scope, date, none")
-	cmd.Flags().StringVarP(&sortStr, "sort", "s", "", "Sort by field(s): id, title, planned, due, created, project, area")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	addListViewFlags(cmd, &opts)
 	return cmd
 }
 
 func NewSomedayCmd(deps *Dependencies) *cobra.Command {
-	var group string
-	var sortStr string
-	var jsonOutput bool
+	var opts ListViewOptions
 
 	cmd := &cobra.Command{
 		Use:   "someday",
 		Short: "List tasks deferred to someday",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return RunListView(deps, "someday", sortStr, group, jsonOutput)
+			return RunListView(deps, "someday", opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&group, "group", "g", "", "Group tasks by: scope, date, none")
-	cmd.Flags().StringVarP(&sortStr, "sort", "s", "", "Sort by field(s): id, title, planned, due, created, project, area")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	addListViewFlags(cmd, &opts)
 	return cmd
 }
 
+func NewMineCmd(deps *Dependencies) *cobra.Command {
+	var opts ListViewOptions
+
+	cmd := &cobra.Command{
+		Use:   "mine",
+		Short: "List open tasks assigned to you",
+		Long: `List the open tasks assigned to you in a shared database, i.e. to the
+user setting (default: your login name). Assign tasks with
+tt add --assignee or tt edit --assignee.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.Config.User == "" {
+				return errors.New("no user name: set user in the config file or TT_USER")
+			}
+			opts.Assignee = deps.Config.User
+			return RunListView(deps, "mine", opts)
+		},
+	}
+
+	addListViewFlags(cmd, &opts)
+	return cmd
+}
+
+func NewFlaggedCmd(deps *Dependencies) *cobra.Command {
+	var opts ListViewOptions
+
+	cmd := &cobra.Command{
+		Use:   "flagged",
+		Short: "List flagged tasks",
+		Long: `List the open tasks flagged with tt flag, whatever their dates. Flagged
+tasks also come first in every other list.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunListView(deps, "flagged", opts)
+		},
+	}
+
+	addListViewFlags(cmd, &opts)
+	return cmd
+}
+
+// addListViewFlags registers the flags shared by all list view shortcuts
+func addListViewFlags(cmd *cobra.Command, opts *ListViewOptions) {
+	cmd.Flags().StringVarP(&opts.Group, "group", "g", "", "Group tasks by: scope, date, tag, state, none")
+	cmd.Flags().StringVarP(&opts.Sort, "sort", "s", "", "Sort by field(s): id, title, planned, due, created, project, area")
+	cmd.Flags().StringVarP(&opts.Context, "context", "c", "", "Filter by context: "+strings.Join(task.ValidContexts(), ", "))
+	cmd.Flags().StringVar(&opts.Location, "location", "", "Filter by location (e.g. home, @office)")
+	cmd.Flags().BoolVar(&opts.Details, "details", false, "Show description excerpts and checklist progress")
+	cmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Omit the summary footer")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output as JSON")
+	_ = cmd.RegisterFlagCompletionFunc("context", contextCompletion)
+}
+
 func NewRenameCmd(deps *Dependencies) *cobra.Command {
 	return &cobra.Command{
 		Use:   "rename <task-id> <new-title>",
@@ -115,11 +186,11 @@ func NewRenameCmd(deps *Dependencies) *cobra.Command {
 				return errors.New("invalid task ID: " + args[0])
 			}
 
-			if _, err := deps.App.SetTaskTitle.Execute(id, args[1]); err != nil {
+			if _, err := deps.App.Tasks.SetTitle(id, args[1]); err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.TaskEdited(id, []string{"title"})
 			return nil
 		},
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewSplitCmd(deps *Dependencies) *cobra.Command {
+	var keep bool
+
+	cmd := &cobra.Command{
+		Use:   "split <id> [title...]",
+		Short: "Break a task into separate tasks",
+		Long: `Break a task that grew too big into separate tasks. The new tasks are
+the titles given, or else the task's open checklist items; when it has
+none, tt asks for them one per line (or reads them from a pipe).
+
+The new tasks go to the same project or area and share the task's dates,
+tags, context, location and assignee. The original task is completed, or
+kept open with --keep.
+
+Examples:
+  tt split 12                       # one task per open checklist item
+  tt split 12 "Draft" "Review"
+  tt split 12 --keep`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			original, err := deps.App.Tasks.Get(id)
+			if err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			titles := args[1:]
+			if len(titles) == 0 && (original.Description == nil || len(task.OpenChecklistItems(*original.Description)) == 0) {
+				titles = readSplitTitles(cmd.InOrStdin(), formatter, term.IsTerminal(os.Stdin.Fd()))
+			}
+
+			created, err := deps.App.Tasks.Split(id, titles, keep)
+			if err != nil {
+				return err
+			}
+			formatter.TaskSplit(original, created, keep)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&keep, "keep", false, "Keep the original task open")
+
+	return cmd
+}
+
+// readSplitTitles reads one title per line until an empty line or the end
+// of input, prompting for each when in is a terminal
+func readSplitTitles(in io.Reader, formatter *output.Formatter, prompt bool) []string {
+	r := bufio.NewReader(in)
+	var titles []string
+	for {
+		if prompt {
+			formatter.Prompt("Task %d (Enter when done): ", len(titles)+1)
+		}
+		line, err := r.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if prompt && err != nil {
+				formatter.PromptAbandoned()
+			}
+			if prompt || err != nil {
+				return titles
+			}
+			continue
+		}
+		titles = append(titles, line)
+		if err != nil {
+			return titles
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewStandupCmd(deps *Dependencies) *cobra.Command {
+	var sinceStr string
+	var blockerTag string
+
+	cmd := &cobra.Command{
+		Use:   "standup",
+		Short: "Print a standup report of yesterday, today and blockers",
+		Long: `Print a standup report: the tasks completed since the last working day,
+the tasks planned for today, and the open tasks tagged as blockers.
+
+The report is markdown, ready to paste into a chat. Set standup_template
+in the config to change it. On Mondays "yesterday" starts on Friday; use
+--since to start on another day.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			today := deps.today()
+			yesterday := today.AddDate(0, 0, -1)
+			since, err := parseLogDate("since", sinceStr)
+			if err != nil {
+				return err
+			}
+			if since == nil {
+				since = &yesterday
+				for since.Weekday() == time.Saturday || since.Weekday() == time.Sunday {
+					*since = since.AddDate(0, 0, -1)
+				}
+			}
+
+			finished, err := deps.App.Tasks.ListCompleted(&task.CompletedOptions{Since: since, Until: &yesterday})
+			if err != nil {
+				return err
+			}
+			report := output.Standup{Date: today}
+			for _, t := range finished {
+				// Canceled tasks weren't worked on
+				if t.Status == task.StatusDone {
+					report.Yesterday = append(report.Yesterday, t)
+				}
+			}
+			if report.Today, err = deps.App.Tasks.List(&task.ListOptions{Schedule: "today"}); err != nil {
+				return err
+			}
+			if report.Blockers, err = deps.App.Tasks.List(&task.ListOptions{TagName: blockerTag}); err != nil {
+				return err
+			}
+
+			return output.WriteStandup(os.Stdout, deps.Config.StandupTemplate, report)
+		},
+	}
+
+	cmd.Flags().StringVar(&sinceStr, "since", "", "Include tasks completed since this date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&blockerTag, "blocker-tag", "blocked", "Tag that marks blocked tasks")
+
+	return cmd
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/devbydaniel/tt/internal/output"
 	"github.com/spf13/cobra"
@@ -17,8 +18,9 @@ func NewTagCmd(deps *Dependencies) *cobra.Command {
 	}
 
 	cmd.AddCommand(newTagListCmd(deps))
-	cmd.AddCommand(newTagAddCmd(deps))
-	cmd.AddCommand(newTagRemoveCmd(deps))
+	cmd.AddCommand(mutating(newTagAddCmd(deps)))
+	cmd.AddCommand(mutating(newTagRemoveCmd(deps)))
+	cmd.AddCommand(mutating(newTagColorCmd(deps)))
 
 	return cmd
 }
@@ -31,7 +33,7 @@ func newTagListCmd(deps *Dependencies) *cobra.Command {
 		Short: "List all tags in use",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			tags, err := deps.App.ListTags.Execute()
+			tags, err := deps.App.Tasks.Tags()
 			if err != nil {
 				return err
 			}
@@ -40,8 +42,13 @@ func newTagListCmd(deps *Dependencies) *cobra.Command {
 				return output.WriteJSON(os.Stdout, tags)
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
-			formatter.TagList(tags)
+			colors, err := deps.App.Tasks.TagColors()
+			if err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.TagList(tags, colors)
 			return nil
 		},
 	}
@@ -66,12 +73,12 @@ func newTagAddCmd(deps *Dependencies) *cobra.Command {
 				return errors.New("tag name cannot be empty")
 			}
 
-			t, err := deps.App.AddTag.Execute(id, tagName)
+			t, err := deps.App.Tasks.AddTag(id, tagName)
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.TaskTagAdded(t, tagName)
 			return nil
 		},
@@ -95,14 +102,65 @@ func newTagRemoveCmd(deps *Dependencies) *cobra.Command {
 				return errors.New("tag name cannot be empty")
 			}
 
-			t, err := deps.App.RemoveTag.Execute(id, tagName)
+			t, err := deps.App.Tasks.RemoveTag(id, tagName)
 			if err != nil {
 				return err
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
+			formatter := deps.formatter(os.Stdout)
 			formatter.TaskTagRemoved(t, tagName)
 			return nil
 		},
 	}
 }
+
+func newTagColorCmd(deps *Dependencies) *cobra.Command {
+	var clearColor bool
+
+	cmd := &cobra.Command{
+		Use:   "color <tag-name> [color]",
+		Short: "Set the color a tag is shown in",
+		Long: `Set the color a tag's chips are shown in, in lists and the TUI. Colors
+take the same forms as theme colors: an ANSI code (0-255), a hex color
+(#RRGGBB), a name like "red" or "bright-blue", or "light|dark".
+
+Examples:
+  tt tag color urgent "#ff5555"
+  tt tag color home green
+  tt tag color urgent --clear`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tagName := args[0]
+			color := ""
+			switch {
+			case clearColor && len(args) == 2:
+				return errors.New("cannot specify both a color and --clear")
+			case !clearColor && len(args) == 1:
+				return errors.New("specify a color, or --clear to remove it")
+			case !clearColor:
+				color = args[1]
+				if err := output.ValidateColor(color); err != nil {
+					return err
+				}
+			}
+
+			if err := deps.App.Tasks.SetTagColor(tagName, color); err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.TagColorSet(strings.TrimPrefix(tagName, "#"), color)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&clearColor, "clear", false, "Remove the tag's color")
+	registry := NewCompletionRegistry(deps)
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return registry.TagCompletion()(cmd, args, toComplete)
+	}
+	return cmd
+}
@@ -15,7 +15,7 @@ func NewTagsCmd(deps *Dependencies) *cobra.Command {
 		Short: "List all tags alphabetically",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			tags, err := deps.App.ListTags.Execute()
+			tags, err := deps.App.Tasks.Tags()
 			if err != nil {
 				return err
 			}
@@ -24,8 +24,13 @@ func NewTagsCmd(deps *Dependencies) *cobra.Command {
 				return output.WriteJSON(os.Stdout, tags)
 			}
 
-			formatter := output.NewFormatter(os.Stdout, deps.Theme)
-			formatter.TagList(tags)
+			colors, err := deps.App.Tasks.TagColors()
+			if err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.TagList(tags, colors)
 			return nil
 		},
 	}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewThemeCmd(deps *Dependencies) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "theme",
+		Short: "List and preview color themes",
+	}
+
+	cmd.AddCommand(newThemeListCmd(deps))
+	cmd.AddCommand(newThemePreviewCmd(deps))
+
+	return cmd
+}
+
+func newThemeListCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List built-in and custom themes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := deps.formatter(os.Stdout)
+			formatter.ThemeList(output.Themes(&deps.Config.Theme), deps.Config.Theme.Name)
+			return nil
+		},
+	}
+}
+
+func newThemePreviewCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "preview [name...]",
+		Short: "Render a sample task list in each theme",
+		Long: `Render a sample task list in each theme, or only in the named ones.
+Your icon settings apply; color overrides under [theme] are left out so
+each preset is shown as defined.`,
+		ValidArgsFunction: NewCompletionRegistry(deps).ThemeCompletion(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var names []string
+			for _, info := range output.Themes(&deps.Config.Theme) {
+				names = append(names, info.Name)
+			}
+			for _, name := range args {
+				if !slices.Contains(names, name) {
+					return fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(names, ", "))
+				}
+			}
+			if len(args) > 0 {
+				names = args
+			}
+
+			for i, name := range names {
+				if i > 0 {
+					fmt.Fprintln(os.Stdout)
+				}
+				theme := output.NewTheme(&config.ThemeConfig{
+					Name:    name,
+					Presets: deps.Config.Theme.Presets,
+					Icons:   deps.Config.Theme.Icons,
+					NoColor: deps.Config.Theme.NoColor,
+				})
+				output.NewFormatter(os.Stdout, theme).ThemePreview(name)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/domain/token"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewTokenCmd(deps *Dependencies) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage API tokens",
+		Long: `Manage the tokens API clients authenticate with, each with its own
+scopes: read to list and show tasks, write to change them.
+
+Only a hash of each token is stored, so its secret is shown once, when
+it's created. Tokens need a database; file storage has none.
+
+Examples:
+  t token                                      List tokens
+  t token create --name phone --scopes read,write
+  t token revoke phone`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.App.ListTokens == nil {
+				return token.ErrNoDatabase
+			}
+			tokens, err := deps.App.ListTokens.Execute()
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.WriteJSON(os.Stdout, tokens)
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.TokenList(tokens)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	cmd.AddCommand(mutating(newTokenCreateCmd(deps)))
+	cmd.AddCommand(mutating(newTokenRevokeCmd(deps)))
+
+	return cmd
+}
+
+func newTokenCreateCmd(deps *Dependencies) *cobra.Command {
+	var name string
+	var scopes []string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an API token and show its secret",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.App.CreateToken == nil {
+				return token.ErrNoDatabase
+			}
+			if name == "" {
+				return errors.New("--name required, e.g. the device the token is for")
+			}
+
+			t, secret, err := deps.App.CreateToken.Execute(name, scopes)
+			if err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.TokenCreated(t, secret)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Name of the token, e.g. the device it's for")
+	cmd.Flags().StringSliceVar(&scopes, "scopes", []string{token.ScopeRead}, "Scopes to grant: "+strings.Join(token.ValidScopes(), ", "))
+	_ = cmd.RegisterFlagCompletionFunc("scopes", cobra.FixedCompletions(token.ValidScopes(), cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+func newTokenRevokeCmd(deps *Dependencies) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <name>",
+		Short: "Revoke an API token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deps.App.RevokeToken == nil {
+				return token.ErrNoDatabase
+			}
+			if err := deps.App.RevokeToken.Execute(args[0]); err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.TokenRevoked(args[0])
+			return nil
+		},
+	}
+}
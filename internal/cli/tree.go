@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func NewTreeCmd(deps *Dependencies) *cobra.Command {
+	var depth int
+
+	cmd := &cobra.Command{
+		Use:   "tree",
+		Short: "Show all open tasks as an area > project > task tree",
+		Long: `Show every area, project and open task as a tree, with the number of
+open tasks at each area and project. Tasks without an area are listed
+under "No area"; someday items are marked.
+
+Use --depth to collapse the tree: 1 shows only areas, 2 adds projects and
+tasks directly in an area.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if depth < 0 {
+				return errors.New("--depth must be 0 or more")
+			}
+
+			areas, err := deps.App.Areas.List()
+			if err != nil {
+				return err
+			}
+			tasks, err := deps.App.Tasks.List(nil)
+			if err != nil {
+				return err
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			formatter.Tree(areas, tasks, depth)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&depth, "depth", 0, "Levels to show (0 = all)")
+	return cmd
+}
@@ -1,16 +1,89 @@
 package cli
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/devbydaniel/tt/internal/tui"
 	"github.com/spf13/cobra"
 )
 
 func NewTUICmd(deps *Dependencies) *cobra.Command {
-	return &cobra.Command{
-		Use:   "ui",
+	var view string
+
+	cmd := &cobra.Command{
+		Use:   "ui [view]",
 		Short: "Open interactive TUI",
+		Long: `Open the interactive TUI. It returns to where it was left, unless a
+view is given here or with start_view in the config:
+
+  today, inbox, upcoming, anytime, someday
+  project:<name>, area:<name>, tag:<name>
+
+Examples:
+  tt ui today
+  tt ui --view project:Work`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return tui.Run(deps.App, deps.Theme, deps.Config)
+			if len(args) == 1 {
+				if view != "" {
+					return errors.New("cannot give a view both as an argument and with --view")
+				}
+				view = args[0]
+			}
+			return runTUI(deps, view)
 		},
 	}
+
+	cmd.Flags().StringVar(&view, "view", "", "View to open at, e.g. today or project:Work")
+
+	return cmd
+}
+
+// runTUI opens the TUI at view, else at start_view from the config, else
+// where it was left. A view named on the command line must exist.
+func runTUI(deps *Dependencies, view string) error {
+	explicit := view != ""
+	if !explicit {
+		view = deps.Config.StartView
+	}
+	if view == "" {
+		return tui.Run(deps.App, deps.Theme, deps.Config, nil)
+	}
+
+	session, err := tui.ParseView(view)
+	if err != nil {
+		return err
+	}
+	if explicit {
+		if err := checkView(deps, view); err != nil {
+			return err
+		}
+	}
+	return tui.Run(deps.App, deps.Theme, deps.Config, session)
+}
+
+// checkView reports a project, area or tag view whose target doesn't exist
+func checkView(deps *Dependencies, view string) error {
+	kind, name, _ := strings.Cut(view, ":")
+	name = strings.TrimSpace(name)
+	switch kind {
+	case "project":
+		_, err := deps.App.Projects.Get(name)
+		return err
+	case "area":
+		_, err := deps.App.Areas.Get(name)
+		return err
+	case "tag":
+		tags, err := deps.App.Tasks.Tags()
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(tags, name) {
+			return fmt.Errorf("no task is tagged %q", name)
+		}
+	}
+	return nil
 }
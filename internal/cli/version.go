@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/buildinfo"
+	"github.com/devbydaniel/tt/internal/database"
+	"github.com/spf13/cobra"
+)
+
+// updateCheckTimeout bounds the request to GitHub, so a slow network
+// doesn't hang tt version
+const updateCheckTimeout = 5 * time.Second
+
+func NewVersionCmd(deps *Dependencies) *cobra.Command {
+	var checkUpdate bool
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show version and build information",
+		Long: `Show the version of tt, the commit and date it was built from, the Go
+version and the database schema version it expects.
+
+With --check-update, also ask GitHub whether a newer release exists. This is
+the only time tt contacts the network; set offline = true in the config to
+turn it off entirely.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			info := buildinfo.Get()
+			formatter := deps.formatter(os.Stdout)
+			formatter.VersionInfo(info, database.SchemaVersion())
+			if !checkUpdate {
+				return nil
+			}
+			if deps.Config.Offline {
+				return errors.New("not checking for updates: offline is set in the config")
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), updateCheckTimeout)
+			defer cancel()
+			latest, err := buildinfo.LatestRelease(ctx, http.DefaultClient, buildinfo.ReleasesURL)
+			if err != nil {
+				return err
+			}
+			formatter.UpdateStatus(info.Version, latest)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&checkUpdate, "check-update", false, "Check GitHub for a newer release")
+	return cmd
+}
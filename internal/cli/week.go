@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewWeekCmd(deps *Dependencies) *cobra.Command {
+	var next, prev bool
+	var offset int
+	var sortStr string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "week",
+		Short: "Show tasks planned or due this week, day by day",
+		Long: `Show tasks planned or due this week, day by day.
+
+Weeks run Monday to Sunday. Use --next/--prev to step one week,
+or --offset to jump several weeks (e.g. --offset -2).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if next {
+				offset++
+			}
+			if prev {
+				offset--
+			}
+
+			sortToUse := sortStr
+			if sortToUse == "" {
+				sortToUse = deps.Config.GetSort("week")
+			}
+			sortOpts, err := task.ParseSort(sortToUse)
+			if err != nil {
+				return err
+			}
+
+			week, err := deps.App.Tasks.Week(offset, sortOpts)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.WriteJSON(os.Stdout, week)
+			}
+
+			formatter := deps.formatter(os.Stdout)
+			applyListLayout(formatter, deps.Config, "")
+			formatter.SetCapacity(dailyCapacity(deps.Config))
+			formatter.WeekView(week)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&next, "next", false, "Show next week")
+	cmd.Flags().BoolVar(&prev, "prev", false, "Show previous week")
+	cmd.Flags().IntVar(&offset, "offset", 0, "Show the week N weeks from now (negative for past weeks)")
+	cmd.Flags().StringVarP(&sortStr, "sort", "s", "", "Sort by field(s): id, title, planned, due, created, project, area")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.MarkFlagsMutuallyExclusive("next", "prev")
+
+	return cmd
+}
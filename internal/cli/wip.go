@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// addStrictWIPFlag adds the --strict flag for commands that add active tasks
+// to an area or project
+func addStrictWIPFlag(cmd *cobra.Command, strict *bool) {
+	cmd.Flags().BoolVar(strict, "strict", false, "Refuse to go over an area's or project's WIP limit instead of warning")
+}
+
+// checkWIP returns an error if activating the tasks would take an area or
+// project over its WIP limit (see task.WIPLimits), so nothing gets changed.
+// Tasks that are active already don't count twice.
+func checkWIP(deps *Dependencies, ids []int64) error {
+	added := map[string]int{}
+	for _, id := range ids {
+		t, err := deps.App.Tasks.Get(id)
+		if err != nil {
+			return err
+		}
+		if t.State == task.StateActive && t.Status == task.StatusTodo {
+			continue
+		}
+		counts, err := deps.App.Tasks.WIPFor(id)
+		if err != nil {
+			return err
+		}
+		for _, wip := range counts {
+			key := wip.Scope + ":" + wip.Name
+			if n := wip.Count + added[key]; n > wip.Limit {
+				return fmt.Errorf("task %d: %s %s has no room under its WIP limit (%d/%d)", id, wip.Scope, wip.Name, n-1, wip.Limit)
+			}
+			added[key]++
+		}
+	}
+	return nil
+}
+
+// warnWIP warns about each area and project the tasks are in that now holds
+// more open active tasks than its WIP limit
+func warnWIP(deps *Dependencies, formatter *output.Formatter, ids []int64) error {
+	warned := map[string]bool{}
+	for _, id := range ids {
+		counts, err := deps.App.Tasks.WIPFor(id)
+		if err != nil {
+			return err
+		}
+		for _, wip := range counts {
+			key := wip.Scope + ":" + wip.Name
+			if wip.Over() && !warned[key] {
+				formatter.Warning(fmt.Sprintf("%s %s is over its WIP limit (%d/%d)", wip.Scope, wip.Name, wip.Count, wip.Limit))
+				warned[key] = true
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Package clock provides the current time to code that depends on "now",
+// so dates like today and overdue can be tested at a fixed moment.
+package clock
+
+import "time"
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the real clock
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Fixed is a clock stopped at a given time
+type Fixed time.Time
+
+func (f Fixed) Now() time.Time { return time.Time(f) }
+
+// Now returns the current time from c, or from the system clock if c is nil
+func Now(c Clock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// Today returns midnight at the start of c's current day, in local time
+func Today(c Clock) time.Time {
+	now := Now(c)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
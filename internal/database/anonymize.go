@@ -0,0 +1,144 @@
+package database
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// anonymizedColumns are the columns holding private text, with the prefix of
+// their placeholders. Context labels come from a fixed list and stay.
+var anonymizedColumns = []struct{ table, column, prefix string }{
+	{"tasks", "title", "Task"},
+	{"tasks", "description", "Description"},
+	{"tasks", "assignee", "person"},
+	{"tasks", "creator", "person"},
+	{"tasks", "location", "place"},
+	{"tasks", "external_id", "external"},
+	{"tasks", "completion_note", "Note"},
+	{"areas", "name", "area"},
+	{"task_tags", "tag_name", "tag"},
+	{"tags", "name", "tag"},
+	{"task_comments", "body", "Comment"},
+	{"task_comments", "author", "person"},
+	{"holidays", "name", "Holiday"},
+}
+
+// Anonymize replaces the titles, descriptions, names and other text in the
+// database with placeholders, leaving dates, states, counts and how tasks
+// relate untouched, so the file can be shared to reproduce a bug. Equal
+// values get equal placeholders, hashed with a random key so they can't be
+// guessed back. API tokens are deleted. Run it on a copy, see Snapshot.
+func (db *DB) Anonymize() error {
+	if db.Driver == Postgres {
+		return fmt.Errorf("anonymize: %w with Postgres", errors.ErrUnsupported)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	placeholder := func(prefix, value string) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(value))
+		return prefix + "-" + hex.EncodeToString(mac.Sum(nil)[:6])
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range anonymizedColumns {
+		values, err := distinctValues(tx, c.table, c.column)
+		if err != nil {
+			return fmt.Errorf("anonymizing %s.%s: %w", c.table, c.column, err)
+		}
+		for _, v := range values {
+			query := fmt.Sprintf(`UPDATE %s SET %s = ? WHERE %s = ?`, c.table, c.column, c.column)
+			if _, err := tx.Exec(query, placeholder(c.prefix, v), v); err != nil {
+				return fmt.Errorf("anonymizing %s.%s: %w", c.table, c.column, err)
+			}
+		}
+	}
+
+	// Templates of recurring tasks repeat the title, description and tags
+	templates := map[int64]string{}
+	rows, err := tx.Query(`SELECT id, recur_template FROM tasks WHERE recur_template IS NOT NULL`)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id int64
+		var template string
+		if err := rows.Scan(&id, &template); err != nil {
+			rows.Close()
+			return err
+		}
+		templates[id] = template
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for id, template := range templates {
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(template), &fields); err != nil {
+			return fmt.Errorf("anonymizing template of task %d: %w", id, err)
+		}
+		for name, prefix := range map[string]string{"title": "Task", "description": "Description"} {
+			if s, ok := fields[name].(string); ok && s != "" {
+				fields[name] = placeholder(prefix, s)
+			}
+		}
+		if tags, ok := fields["tags"].([]any); ok {
+			for i, tag := range tags {
+				if s, ok := tag.(string); ok {
+					tags[i] = placeholder("tag", s)
+				}
+			}
+		}
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`UPDATE tasks SET recur_template = ? WHERE id = ?`, string(data), id); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM api_tokens`); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	// Don't leave the old text in free pages of the file
+	_, err = db.Conn.Exec(`VACUUM`)
+	return err
+}
+
+// distinctValues returns the non-empty values of a column
+func distinctValues(tx *sql.Tx, table, column string) ([]string, error) {
+	rows, err := tx.Query(fmt.Sprintf(`SELECT DISTINCT %s FROM %s WHERE %s IS NOT NULL AND %s != ''`, column, table, column, column))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
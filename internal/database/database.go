@@ -3,36 +3,276 @@ package database
 import (
 	"database/sql"
 	"embed"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
-	_ "modernc.org/sqlite"
+	"github.com/devbydaniel/tt/internal/logging"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 //go:embed migrations/*.sql
 var migrations embed.FS
 
+var (
+	// ErrCorrupt means the file is damaged or isn't a SQLite database at all
+	ErrCorrupt = errors.New("database file is corrupted")
+	// ErrLocked means another process kept the database locked past busyTimeout
+	ErrLocked = errors.New("database is locked by another process")
+)
+
+// busyTimeout is how long to wait for another process to release its lock,
+// e.g. a tt maintain run or a second TUI
+const busyTimeout = 2 * time.Second
+
+// Drivers a DB can be backed by
+const (
+	SQLite   = "sqlite"   // a local database file (default)
+	Postgres = "postgres" // a shared server, e.g. for a team
+)
+
 type DB struct {
-	Conn *sql.DB
+	Conn     *sql.DB
+	ReadOnly bool
+	Driver   string // SQLite or Postgres; empty means SQLite
 }
 
+// IsPostgres reports whether source is a Postgres connection URL rather
+// than the path of a SQLite file
+func IsPostgres(source string) bool {
+	return strings.HasPrefix(source, "postgres://") || strings.HasPrefix(source, "postgresql://")
+}
+
+// Open opens the SQLite database at path, creating it if needed, or
+// connects to Postgres when path is a postgres:// URL
 func Open(path string) (*DB, error) {
-	conn, err := sql.Open("sqlite", path)
+	if IsPostgres(path) {
+		return openPostgres(path, false)
+	}
+
+	// Enable foreign keys for CASCADE support
+	conn, err := sql.Open("sqlite", path+"?"+pragmas("foreign_keys(1)"))
 	if err != nil {
 		return nil, err
 	}
 
-	if err := conn.Ping(); err != nil {
+	if err := setup(conn); err != nil {
+		conn.Close()
 		return nil, err
 	}
 
-	// Enable foreign keys for CASCADE support
-	if _, err := conn.Exec("PRAGMA foreign_keys = ON"); err != nil {
+	return &DB{Conn: conn, Driver: SQLite}, nil
+}
+
+// OpenReadOnly opens an existing database without write access.
+// SQLite rejects every write on the connection, and the file is never created.
+func OpenReadOnly(path string) (*DB, error) {
+	if IsPostgres(path) {
+		return openPostgres(path, true)
+	}
+
+	dsn := (&url.URL{Scheme: "file", Path: path, RawQuery: "mode=ro&" + pragmas("query_only(1)")}).String()
+	conn, err := sql.Open("sqlite", dsn)
+	if err != nil {
 		return nil, err
 	}
 
-	return &DB{Conn: conn}, nil
+	if err := setup(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &DB{Conn: conn, ReadOnly: true, Driver: SQLite}, nil
+}
+
+// pragmas encodes the given pragmas and the busy timeout as DSN
+// parameters. The driver runs them on every connection it opens, so a
+// second pooled connection, e.g. one used by a TUI command while a list is
+// being streamed, waits for locks just like the first.
+func pragmas(list ...string) string {
+	v := url.Values{}
+	for _, p := range append(list, fmt.Sprintf("busy_timeout(%d)", busyTimeout.Milliseconds())) {
+		v.Add("_pragma", p)
+	}
+	return v.Encode()
+}
+
+// setup connects and reads the schema once, so a file that isn't a
+// database or is locked fails here with ErrCorrupt or ErrLocked rather
+// than on the first query
+func setup(conn *sql.DB) error {
+	if err := conn.Ping(); err != nil {
+		return classify(err)
+	}
+
+	var tables int
+	return classify(conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master`).Scan(&tables))
+}
+
+// classify wraps SQLite errors that mean the file can't be used at all in
+// ErrCorrupt or ErrLocked, keeping SQLite's message for details
+func classify(err error) error {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+	// Extended result codes keep the primary code in the low byte
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_CORRUPT, sqlite3.SQLITE_NOTADB:
+		return fmt.Errorf("%w: %v", ErrCorrupt, err)
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return fmt.Errorf("%w: %v", ErrLocked, err)
+	}
+	return err
+}
+
+// Exec, Query and QueryRow run a query written with ? placeholders, as
+// the repositories are, on either driver. With the debug log on, each
+// statement is recorded with its timing.
+func (db *DB) Exec(query string, args ...any) (result sql.Result, err error) {
+	defer func(start time.Time) { logging.Query(query, args, start, err) }(time.Now())
+	return db.Conn.Exec(db.rebind(query), args...)
+}
+
+func (db *DB) Query(query string, args ...any) (rows *sql.Rows, err error) {
+	defer func(start time.Time) { logging.Query(query, args, start, err) }(time.Now())
+	return db.Conn.Query(db.rebind(query), args...)
+}
+
+func (db *DB) QueryRow(query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := db.Conn.QueryRow(db.rebind(query), args...)
+	logging.Query(query, args, start, row.Err())
+	return row
+}
+
+// Insert runs an INSERT into a table with an id column and returns the id
+// of the new row. Postgres has no LastInsertId, so it's asked to return it.
+func (db *DB) Insert(query string, args ...any) (id int64, err error) {
+	defer func(start time.Time) { logging.Query(query, args, start, err) }(time.Now())
+	if db.Driver == Postgres {
+		err = db.Conn.QueryRow(rebind(query)+" RETURNING id", args...).Scan(&id)
+		return id, err
+	}
+
+	result, err := db.Conn.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (db *DB) rebind(query string) string {
+	if db.Driver == Postgres {
+		return rebind(query)
+	}
+	return query
+}
+
+// rebind numbers the ? placeholders of query as Postgres expects them,
+// $1, $2 and so on, leaving quoted strings alone
+func rebind(query string) string {
+	var b strings.Builder
+	n := 0
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == '?' && !quoted:
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Check runs SQLite's integrity check and returns the problems it reports,
+// or nil for a healthy database. Postgres has no equivalent.
+func (db *DB) Check() ([]string, error) {
+	if db.Driver == Postgres {
+		return nil, fmt.Errorf("integrity check: %w with Postgres", errors.ErrUnsupported)
+	}
+
+	rows, err := db.Conn.Query(`PRAGMA integrity_check`)
+	if err != nil {
+		return nil, classify(err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var problem string
+		if err := rows.Scan(&problem); err != nil {
+			return nil, classify(err)
+		}
+		problems = append(problems, problem)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, classify(err)
+	}
+	if len(problems) == 1 && problems[0] == "ok" {
+		return nil, nil
+	}
+	return problems, nil
 }
 
+// SchemaVersion returns the schema version this build migrates databases
+// to: the number of migrations it ships with
+func SchemaVersion() int {
+	entries, _ := migrations.ReadDir("migrations")
+	return len(entries)
+}
+
+// Version returns the schema version of the database, which is below
+// SchemaVersion while migrations are pending
+func (db *DB) Version() (int, error) {
+	if db.Driver == Postgres {
+		return db.postgresVersion()
+	}
+
+	var version int
+	err := db.Conn.QueryRow(`PRAGMA user_version`).Scan(&version)
+	return version, classify(err)
+}
+
+// Migrate applies pending migrations. The number of migrations applied is
+// kept in the user_version pragma, so an up-to-date database costs a single
+// query instead of one per migration.
 func (db *DB) Migrate() error {
+	if db.Driver == Postgres {
+		return db.migratePostgres()
+	}
+	return classify(db.migrate())
+}
+
+func (db *DB) migrate() error {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+
+	var version int
+	if err := db.Conn.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version == len(entries) {
+		return nil
+	}
+
+	if db.ReadOnly {
+		return db.checkMigrated()
+	}
+
 	// Create migrations tracking table
 	if _, err := db.Conn.Exec(`
 		CREATE TABLE IF NOT EXISTS schema_migrations (
@@ -43,11 +283,6 @@ func (db *DB) Migrate() error {
 		return err
 	}
 
-	entries, err := migrations.ReadDir("migrations")
-	if err != nil {
-		return err
-	}
-
 	for _, entry := range entries {
 		name := entry.Name()
 
@@ -81,9 +316,132 @@ func (db *DB) Migrate() error {
 		}
 	}
 
+	// PRAGMA doesn't take parameters
+	_, err = db.Conn.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, len(entries)))
+	return err
+}
+
+// checkMigrated fails if migrations are pending, since a read-only
+// database can't be brought up to date
+func (db *DB) checkMigrated() error {
+	applied := map[string]bool{}
+	rows, err := db.Conn.Query(`SELECT version FROM schema_migrations`)
+	if err == nil {
+		defer rows.Close()
+		for rows.Next() {
+			var version string
+			if err := rows.Scan(&version); err != nil {
+				return err
+			}
+			applied[version] = true
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+	pending := 0
+	for _, entry := range entries {
+		if !applied[entry.Name()] {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("database has %d pending migration(s); open it once without read-only mode to upgrade it", pending)
+	}
 	return nil
 }
 
+// Size returns the size of the database in bytes
+func (db *DB) Size() (int64, error) {
+	if db.Driver == Postgres {
+		var size int64
+		err := db.Conn.QueryRow(`SELECT pg_database_size(current_database())`).Scan(&size)
+		return size, err
+	}
+
+	var pages, pageSize int64
+	if err := db.Conn.QueryRow(`PRAGMA page_count`).Scan(&pages); err != nil {
+		return 0, err
+	}
+	if err := db.Conn.QueryRow(`PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return 0, err
+	}
+	return pages * pageSize, nil
+}
+
+// Optimize compacts the database file and refreshes the query planner's
+// statistics. Postgres compacts on its own (autovacuum), so there only the
+// statistics are refreshed.
+func (db *DB) Optimize() error {
+	if db.Driver == Postgres {
+		_, err := db.Conn.Exec(`ANALYZE`)
+		return err
+	}
+
+	if _, err := db.Conn.Exec(`VACUUM`); err != nil {
+		return err
+	}
+	_, err := db.Conn.Exec(`ANALYZE`)
+	return err
+}
+
+// backupPattern matches the files written by Backup
+const backupPattern = "tasks-*.db"
+
+// Backup writes a copy of the database to dir as tasks-YYYY-MM-DD.db,
+// replacing one from the same day, then removes all but the newest keep
+// backups. It returns the path written and the paths removed.
+// Postgres databases are backed up on the server, e.g. with pg_dump.
+func (db *DB) Backup(dir string, keep int, now time.Time) (string, []string, error) {
+	if db.Driver == Postgres {
+		return "", nil, fmt.Errorf("backup: %w with Postgres; use pg_dump", errors.ErrUnsupported)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", nil, err
+	}
+
+	path := filepath.Join(dir, "tasks-"+now.Format("2006-01-02")+".db")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return "", nil, err
+	}
+	if err := db.Snapshot(path); err != nil {
+		return "", nil, err
+	}
+
+	backups, err := filepath.Glob(filepath.Join(dir, backupPattern))
+	if err != nil {
+		return path, nil, err
+	}
+	// Dated names sort oldest first
+	slices.Sort(backups)
+
+	var removed []string
+	for len(backups) > max(keep, 1) {
+		if err := os.Remove(backups[0]); err != nil {
+			return path, removed, err
+		}
+		removed = append(removed, backups[0])
+		backups = backups[1:]
+	}
+	return path, removed, nil
+}
+
+// Snapshot writes a consistent copy of the database to path, which must not
+// exist yet
+func (db *DB) Snapshot(path string) error {
+	if db.Driver == Postgres {
+		return fmt.Errorf("snapshot: %w with Postgres; use pg_dump", errors.ErrUnsupported)
+	}
+	_, err := db.Conn.Exec(`VACUUM INTO ?`, path)
+	return err
+}
+
 func (db *DB) Close() error {
 	return db.Conn.Close()
 }
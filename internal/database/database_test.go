@@ -0,0 +1,255 @@
+package database_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/database"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// startupBudget is how long opening and migrating an up-to-date database may
+// take, since it happens on every invocation (e.g. tt today in a prompt)
+const startupBudget = 30 * time.Millisecond
+
+// migratedDB creates a database file with all migrations applied and returns its path
+func migratedDB(t testing.TB) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	db, err := database.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	return path
+}
+
+func openAndMigrate(t testing.TB, path string) {
+	t.Helper()
+	db, err := database.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+}
+
+func TestMigrateSetsUserVersion(t *testing.T) {
+	path := migratedDB(t)
+
+	db, err := database.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+
+	var version, applied int
+	if err := db.Conn.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Conn.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&applied); err != nil {
+		t.Fatal(err)
+	}
+	if version == 0 || version != applied {
+		t.Errorf("user_version = %d, want the %d applied migrations", version, applied)
+	}
+
+	// A database migrated before user_version was kept catches up
+	if _, err := db.Conn.Exec(`PRAGMA user_version = 0`); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if err := db.Conn.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	if version != applied {
+		t.Errorf("user_version = %d after re-migrating, want %d", version, applied)
+	}
+}
+
+func TestMigrateReadOnlyWhenCurrent(t *testing.T) {
+	path := migratedDB(t)
+
+	db, err := database.OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly() error = %v", err)
+	}
+	defer db.Close()
+	if err := db.Migrate(); err != nil {
+		t.Errorf("Migrate() on an up-to-date read-only database error = %v", err)
+	}
+}
+
+// TestStartupBudget only runs with TT_TEST_STARTUP_BUDGET set, since wall
+// clock timings fail on busy machines; BenchmarkStartup measures the same
+func TestStartupBudget(t *testing.T) {
+	if os.Getenv("TT_TEST_STARTUP_BUDGET") == "" {
+		t.Skip("TT_TEST_STARTUP_BUDGET not set")
+	}
+	path := migratedDB(t)
+
+	// Best of a few runs, to keep a busy machine from failing the test
+	best := time.Hour
+	for range 5 {
+		start := time.Now()
+		openAndMigrate(t, path)
+		best = min(best, time.Since(start))
+	}
+	if best > startupBudget {
+		t.Errorf("opening an up-to-date database took %v, budget is %v", best, startupBudget)
+	}
+}
+
+func BenchmarkStartup(b *testing.B) {
+	path := migratedDB(b)
+	for b.Loop() {
+		openAndMigrate(b, path)
+	}
+}
+
+func TestOpenCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	if err := os.WriteFile(path, bytes.Repeat([]byte("not a database "), 512), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.Open(path); !errors.Is(err, database.ErrCorrupt) {
+		t.Errorf("Open() error = %v, want ErrCorrupt", err)
+	}
+	if _, err := database.OpenReadOnly(path); !errors.Is(err, database.ErrCorrupt) {
+		t.Errorf("OpenReadOnly() error = %v, want ErrCorrupt", err)
+	}
+}
+
+func TestOpenLockedFile(t *testing.T) {
+	path := migratedDB(t)
+	holder, err := database.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer holder.Close()
+	conn, err := holder.Conn.Conn(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(t.Context(), `BEGIN EXCLUSIVE`); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.ExecContext(t.Context(), `ROLLBACK`)
+
+	if _, err := database.Open(path); !errors.Is(err, database.ErrLocked) {
+		t.Errorf("Open() error = %v, want ErrLocked", err)
+	}
+}
+
+func TestPragmasApplyToEveryConnection(t *testing.T) {
+	db, err := database.Open(migratedDB(t))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+
+	// Hold two connections at once so the pool has to open a second one
+	for i := range 2 {
+		conn, err := db.Conn.Conn(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		var timeout, foreignKeys int
+		if err := conn.QueryRowContext(t.Context(), `PRAGMA busy_timeout`).Scan(&timeout); err != nil {
+			t.Fatal(err)
+		}
+		if err := conn.QueryRowContext(t.Context(), `PRAGMA foreign_keys`).Scan(&foreignKeys); err != nil {
+			t.Fatal(err)
+		}
+		if timeout != 2000 || foreignKeys != 1 {
+			t.Errorf("connection %d: busy_timeout = %d, foreign_keys = %d; want 2000 and 1", i, timeout, foreignKeys)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	db, err := database.OpenReadOnly(migratedDB(t))
+	if err != nil {
+		t.Fatalf("OpenReadOnly() error = %v", err)
+	}
+	defer db.Close()
+
+	problems, err := db.Check()
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("Check() = %v, want no problems", problems)
+	}
+}
+
+func TestAnonymize(t *testing.T) {
+	db, err := database.Open(migratedDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	a := app.New(db)
+
+	a.CreateArea.Execute("Health")
+	project, _ := a.CreateProject.Execute("Divorce paperwork", &task.CreateProjectOptions{AreaName: "Health"})
+	first, _ := a.Tasks.Create("Call lawyer Smith", &task.CreateOptions{ProjectName: project.Title, Tags: []string{"legal"}, Description: "re: custody"})
+	a.Tasks.Create("Email lawyer Smith", &task.CreateOptions{ProjectName: project.Title, Tags: []string{"legal"}})
+	a.AddComment.Execute(first.ID, "Their number is 555-0100")
+	a.CreateToken.Execute("phone", []string{"read"})
+
+	if err := db.Anonymize(); err != nil {
+		t.Fatalf("Anonymize() error = %v", err)
+	}
+
+	tasks, err := a.Tasks.List(&task.ListOptions{TaskType: task.TaskTypeTask})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(tasks))
+	}
+	for _, tk := range tasks {
+		if strings.Contains(tk.Title, "lawyer") || (tk.Description != nil && strings.Contains(*tk.Description, "custody")) {
+			t.Errorf("task %d still has private text: %q", tk.ID, tk.Title)
+		}
+		if tk.ParentID == nil || *tk.ParentID != project.ID {
+			t.Errorf("task %d lost its project", tk.ID)
+		}
+		if len(tk.Tags) != 1 || tk.Tags[0] != tasks[0].Tags[0] || tk.Tags[0] == "legal" {
+			t.Errorf("task %d tags = %v, want one shared placeholder", tk.ID, tk.Tags)
+		}
+	}
+	if tasks[0].Title == tasks[1].Title {
+		t.Error("different titles got the same placeholder")
+	}
+
+	areas, _ := a.ListAreas.Execute()
+	if len(areas) != 1 || areas[0].Name == "Health" {
+		t.Errorf("areas = %v, want one placeholder", areas)
+	}
+	comments, _ := a.ListComments.Execute(first.ID)
+	if len(comments) != 1 || strings.Contains(comments[0].Body, "555") {
+		t.Errorf("comments = %v, want one placeholder", comments)
+	}
+	if tokens, _ := a.ListTokens.Execute(); len(tokens) != 0 {
+		t.Errorf("tokens = %v, want none", tokens)
+	}
+}
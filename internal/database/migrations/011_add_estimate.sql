@@ -0,0 +1,2 @@
+-- Effort estimate in minutes
+ALTER TABLE tasks ADD COLUMN estimate INTEGER;
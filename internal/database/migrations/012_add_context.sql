@@ -0,0 +1,2 @@
+-- Energy/context label (deep, shallow, errand, call)
+ALTER TABLE tasks ADD COLUMN context TEXT;
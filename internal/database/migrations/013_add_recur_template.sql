@@ -0,0 +1,3 @@
+-- Fields the next occurrence of a recurring task is created from, stored as
+-- JSON once an occurrence has been edited on its own (NULL = the task itself)
+ALTER TABLE tasks ADD COLUMN recur_template TEXT;
@@ -0,0 +1,2 @@
+-- Number of occurrences after which a recurring series ends (NULL = no limit)
+ALTER TABLE tasks ADD COLUMN recur_count INTEGER;
@@ -0,0 +1,5 @@
+-- Dates recurring tasks are kept off, such as public holidays
+CREATE TABLE holidays (
+    date TEXT PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,2 @@
+-- Date after which an open task is expired by maintenance (NULL = never)
+ALTER TABLE tasks ADD COLUMN expires TEXT;
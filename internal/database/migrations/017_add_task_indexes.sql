@@ -0,0 +1,15 @@
+-- Indexes for the common list filters. Rebuilding the tasks table in 010
+-- dropped idx_tasks_status, so every list scanned the whole table.
+CREATE INDEX IF NOT EXISTS idx_tasks_status_state ON tasks(status, state);
+CREATE INDEX IF NOT EXISTS idx_tasks_status_completed_at ON tasks(status, completed_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_planned_date ON tasks(planned_date);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks(due_date);
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks(parent_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_area_id ON tasks(area_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_recur_parent_id ON tasks(recur_parent_id);
+CREATE INDEX IF NOT EXISTS idx_task_tags_tag_name ON task_tags(tag_name);
+
+-- Date filters compare the columns directly so they can use the indexes,
+-- which needs every date in YYYY-MM-DD form
+UPDATE tasks SET planned_date = date(planned_date) WHERE planned_date != date(planned_date);
+UPDATE tasks SET due_date = date(due_date) WHERE due_date != date(due_date);
@@ -0,0 +1,5 @@
+-- Settings per tag name; tags themselves stay in task_tags
+CREATE TABLE IF NOT EXISTS tags (
+    name TEXT PRIMARY KEY,
+    color TEXT NOT NULL
+);
@@ -0,0 +1,2 @@
+-- Flagged tasks are sorted to the top of every list
+ALTER TABLE tasks ADD COLUMN flagged INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- The ID a task has in the system it was imported from (e.g. a GitHub
+-- issue or Jira key), so repeated syncs update it instead of adding a copy
+ALTER TABLE tasks ADD COLUMN external_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_external_id ON tasks(external_id);
@@ -0,0 +1,74 @@
+-- Postgres schema as of SQLite migration 017. Later migrations are numbered
+-- like their SQLite counterparts, so both report the same schema version.
+-- Dates stay YYYY-MM-DD text and timestamps RFC 3339 text, as in SQLite.
+CREATE TABLE areas (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL
+);
+
+CREATE TABLE tasks (
+    id BIGSERIAL PRIMARY KEY,
+    uuid TEXT UNIQUE NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT,
+    task_type TEXT NOT NULL DEFAULT 'task',
+    parent_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE,
+    area_id BIGINT REFERENCES areas(id) ON DELETE CASCADE,
+    planned_date TEXT,
+    due_date TEXT,
+    state TEXT NOT NULL DEFAULT 'active',
+    status TEXT NOT NULL DEFAULT 'todo',
+    created_at TEXT NOT NULL,
+    completed_at TEXT,
+    recur_type TEXT,
+    recur_rule TEXT,
+    recur_end TEXT,
+    recur_paused BOOLEAN NOT NULL DEFAULT FALSE,
+    recur_parent_id BIGINT REFERENCES tasks(id),
+    estimate INTEGER,
+    context TEXT,
+    recur_template TEXT,
+    recur_count INTEGER,
+    expires TEXT,
+    CONSTRAINT task_parent_area_exclusive CHECK (parent_id IS NULL OR area_id IS NULL),
+    CONSTRAINT task_project_cannot_have_parent CHECK (task_type <> 'project' OR parent_id IS NULL)
+);
+
+-- A CHECK constraint can't look at other rows, so the parent's type is
+-- checked by a trigger like in SQLite
+CREATE FUNCTION task_parent_must_be_project() RETURNS trigger AS $$
+BEGIN
+    IF NEW.parent_id IS NOT NULL AND NOT EXISTS (
+        SELECT 1 FROM tasks WHERE id = NEW.parent_id AND task_type = 'project'
+    ) THEN
+        RAISE EXCEPTION 'parent_id must reference a project';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_parent_must_be_project
+BEFORE INSERT OR UPDATE ON tasks
+FOR EACH ROW EXECUTE FUNCTION task_parent_must_be_project();
+
+CREATE UNIQUE INDEX idx_project_title_unique ON tasks(title) WHERE task_type = 'project';
+
+CREATE TABLE task_tags (
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    tag_name TEXT NOT NULL,
+    PRIMARY KEY (task_id, tag_name)
+);
+
+CREATE TABLE holidays (
+    date TEXT PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_tasks_status_state ON tasks(status, state);
+CREATE INDEX idx_tasks_status_completed_at ON tasks(status, completed_at);
+CREATE INDEX idx_tasks_planned_date ON tasks(planned_date);
+CREATE INDEX idx_tasks_due_date ON tasks(due_date);
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
+CREATE INDEX idx_tasks_area_id ON tasks(area_id);
+CREATE INDEX idx_tasks_recur_parent_id ON tasks(recur_parent_id);
+CREATE INDEX idx_task_tags_tag_name ON task_tags(tag_name);
@@ -0,0 +1,5 @@
+-- Who a task is assigned to and who added it, for databases shared by
+-- several people (NULL = nobody in particular)
+ALTER TABLE tasks ADD COLUMN assignee TEXT;
+ALTER TABLE tasks ADD COLUMN creator TEXT;
+CREATE INDEX IF NOT EXISTS idx_tasks_assignee ON tasks(assignee);
@@ -0,0 +1,10 @@
+-- Dated notes on a task, kept apart from its single description
+CREATE TABLE task_comments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    author TEXT,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_task_comments_task_id ON task_comments(task_id);
@@ -0,0 +1,4 @@
+-- Where a task can be done (home, office, a shop or a geo string), for
+-- errand-style lists (NULL = anywhere)
+ALTER TABLE tasks ADD COLUMN location TEXT;
+CREATE INDEX IF NOT EXISTS idx_tasks_location ON tasks(location);
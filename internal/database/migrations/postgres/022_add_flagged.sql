@@ -0,0 +1,2 @@
+-- Flagged tasks are sorted to the top of every list
+ALTER TABLE tasks ADD COLUMN flagged BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,8 @@
+-- The order pinned for the first tasks of a day's Today list; the rest
+-- follow in the configured sort
+CREATE TABLE day_order (
+    day TEXT NOT NULL,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (day, task_id)
+);
@@ -0,0 +1,2 @@
+-- A short note on how a task turned out, set when completing it
+ALTER TABLE tasks ADD COLUMN completion_note TEXT;
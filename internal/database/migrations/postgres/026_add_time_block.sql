@@ -0,0 +1,3 @@
+-- A slot of time set aside to work on a task, exported to calendars
+ALTER TABLE tasks ADD COLUMN block_start TEXT;
+ALTER TABLE tasks ADD COLUMN block_minutes INTEGER;
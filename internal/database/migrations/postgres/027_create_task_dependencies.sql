@@ -0,0 +1,6 @@
+-- Tasks that have to be done before a task can start
+CREATE TABLE task_dependencies (
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    depends_on_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, depends_on_id)
+);
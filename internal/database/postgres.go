@@ -0,0 +1,185 @@
+package database
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// postgresMigrations start at the schema of SQLite migration 017 and are
+// numbered like their SQLite counterparts from there
+//
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// migrationLock is the advisory lock clients hold while migrating, so two
+// of them starting at once don't both apply the same migration
+const migrationLock = 0x7474
+
+// openPostgres connects to the Postgres database at dsn. Read-only
+// connections run every transaction read-only, so the server rejects writes.
+func openPostgres(dsn string, readOnly bool) (*DB, error) {
+	if readOnly {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return nil, err
+		}
+		q := u.Query()
+		// Unknown parameters are sent to the server as settings
+		q.Set("default_transaction_read_only", "on")
+		u.RawQuery = q.Encode()
+		dsn = u.String()
+	}
+
+	conn, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &DB{Conn: conn, ReadOnly: readOnly, Driver: Postgres}, nil
+}
+
+// Redact hides the password of a Postgres URL, for messages and reports.
+// SQLite paths are returned unchanged.
+func Redact(source string) string {
+	if !IsPostgres(source) {
+		return source
+	}
+	u, err := url.Parse(source)
+	if err != nil {
+		return "postgres://"
+	}
+	return u.Redacted()
+}
+
+// migratePostgres applies pending migrations, each in a transaction of its
+// own. Clients sharing the database may start at the same time, so the
+// applied migrations are read again once the lock is held.
+func (db *DB) migratePostgres() error {
+	entries, err := postgresMigrations.ReadDir("migrations/postgres")
+	if err != nil {
+		return err
+	}
+
+	applied, err := db.postgresApplied()
+	if err != nil {
+		return err
+	}
+	pending := 0
+	for _, entry := range entries {
+		if !applied[entry.Name()] {
+			pending++
+		}
+	}
+	if pending == 0 {
+		return nil
+	}
+	if db.ReadOnly {
+		return fmt.Errorf("database has %d pending migration(s); open it once without read-only mode to upgrade it", pending)
+	}
+
+	if _, err := db.Conn.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version TEXT PRIMARY KEY,
+			applied_at TEXT NOT NULL
+		)
+	`); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := db.applyPostgres(entry.Name()); err != nil {
+			return fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// applyPostgres applies one migration unless another client already has
+func (db *DB) applyPostgres(name string) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, migrationLock); err != nil {
+		return err
+	}
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM schema_migrations WHERE version = $1`, name).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	content, err := postgresMigrations.ReadFile("migrations/postgres/" + name)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(string(content)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO schema_migrations (version, applied_at) VALUES ($1, $2)`,
+		name, time.Now().UTC().Format(time.RFC3339),
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// postgresApplied returns the names of the applied migrations, none if
+// the database is new
+func (db *DB) postgresApplied() (map[string]bool, error) {
+	var exists bool
+	if err := db.Conn.QueryRow(`SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return nil, err
+	}
+	applied := map[string]bool{}
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err := db.Conn.Query(`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// postgresVersion returns the number of the newest applied migration,
+// which matches the SQLite schema version with the same tables
+func (db *DB) postgresVersion() (int, error) {
+	applied, err := db.postgresApplied()
+	if err != nil {
+		return 0, err
+	}
+	version := 0
+	for name := range applied {
+		prefix, _, _ := strings.Cut(name, "_")
+		if n, err := strconv.Atoi(prefix); err == nil {
+			version = max(version, n)
+		}
+	}
+	return version, nil
+}
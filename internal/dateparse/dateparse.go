@@ -8,24 +8,65 @@ import (
 	"time"
 )
 
+// ParseError is returned when a string doesn't match any supported format.
+type ParseError struct {
+	Input string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("cannot parse date %q (supported: %s)", e.Input, strings.Join(Examples(), ", "))
+}
+
+// Examples returns one example of each supported format, for help texts and
+// error messages.
+func Examples() []string {
+	return []string{
+		"today", "tomorrow", "fri", "next monday", "next week", "end of month",
+		"in 2 weeks", "+3d", "jul 15", "15.7.", "2025-01-15", "2025-W03",
+	}
+}
+
 // Parse parses a date string and returns a time.Time.
 // Supported formats:
 //   - ISO date: 2025-01-15
+//   - ISO week: 2025-W03 (its Monday), 2025-W03-5 (its Friday)
 //   - Keywords: today, tomorrow
-//   - Weekday: monday, tuesday, ..., sunday
+//   - Weekday: monday, tuesday, ..., sunday, or mon, tue, ..., sun
 //   - Next weekday: next monday, next tuesday, ...
-//   - Relative: +3d, +1w, +2m (days, weeks, months)
+//   - Periods: next week, next month, next year (their first day),
+//     end of week, end of month, end of year (their last day)
+//   - Relative: +3d, +1w, +2m, +1y, in 3 days, in 2 weeks, in a month
+//   - Month and day: jul 15, 15 july, jul 15 2026 (next year if already past)
+//   - Day and month: 15.7., 15.7.2026
 func Parse(s string) (time.Time, error) {
 	return ParseFrom(s, time.Now())
 }
 
-// ParseFrom parses a date string relative to a given reference time.
+// ParseFrom parses a date string relative to a given reference time. Dates
+// are midnight in now's location, in the years 1 to 9999 so they survive
+// being stored as YYYY-MM-DD.
 func ParseFrom(s string, now time.Time) (time.Time, error) {
-	s = strings.TrimSpace(strings.ToLower(s))
+	t, err := parseFrom(s, now)
+	if err != nil {
+		return t, err
+	}
+	if t.Year() < 1 || t.Year() > 9999 {
+		return time.Time{}, &ParseError{Input: strings.TrimSpace(s)}
+	}
+	return t, nil
+}
+
+func parseFrom(s string, now time.Time) (time.Time, error) {
+	input := s
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
 	// ISO date: 2025-01-15
-	if t, err := time.Parse("2006-01-02", s); err == nil {
+	if t, err := time.ParseInLocation("2006-01-02", s, today.Location()); err == nil {
+		return t, nil
+	}
+
+	if t, ok := parseISOWeek(s, today); ok {
 		return t, nil
 	}
 
@@ -37,7 +78,11 @@ func ParseFrom(s string, now time.Time) (time.Time, error) {
 		return today.AddDate(0, 0, 1), nil
 	}
 
-	// Relative: +3d, +1w, +2m
+	if t, ok := parsePeriod(s, today); ok {
+		return t, nil
+	}
+
+	// Relative: +3d, +1w, +2m, in 2 weeks
 	if relTime, ok := parseRelative(s, today); ok {
 		return relTime, nil
 	}
@@ -47,18 +92,45 @@ func ParseFrom(s string, now time.Time) (time.Time, error) {
 		return nextWeekday(today, weekday), nil
 	}
 
-	return time.Time{}, fmt.Errorf("cannot parse date: %s", s)
+	if t, ok := parseMonthDay(s, today); ok {
+		return t, nil
+	}
+
+	if t, ok := parseDotted(s, today); ok {
+		return t, nil
+	}
+
+	return time.Time{}, &ParseError{Input: strings.TrimSpace(input)}
 }
 
+var (
+	plusRe = regexp.MustCompile(`^\+(\d+)([dwmy])$`)
+	inRe   = regexp.MustCompile(`^in (\d+|a|an|one) (day|days|week|weeks|month|months|year|years)$`)
+)
+
+// maxRelative caps the number in relative dates, so adding it can't
+// overflow: +9999y is as far as dates go
+const maxRelative = 9999 * 366
+
 func parseRelative(s string, base time.Time) (time.Time, bool) {
-	re := regexp.MustCompile(`^\+(\d+)([dwm])$`)
-	matches := re.FindStringSubmatch(s)
-	if matches == nil {
+	var n int
+	var unit string
+	if matches := plusRe.FindStringSubmatch(s); matches != nil {
+		n, _ = strconv.Atoi(matches[1])
+		unit = matches[2]
+	} else if matches := inRe.FindStringSubmatch(s); matches != nil {
+		n, _ = strconv.Atoi(matches[1])
+		if matches[1] == "a" || matches[1] == "an" || matches[1] == "one" {
+			n = 1
+		}
+		unit = matches[2][:1]
+	} else {
+		return time.Time{}, false
+	}
+	// Atoi saturates numbers too big for an int
+	if n > maxRelative {
 		return time.Time{}, false
 	}
-
-	n, _ := strconv.Atoi(matches[1])
-	unit := matches[2]
 
 	switch unit {
 	case "d":
@@ -67,23 +139,75 @@ func parseRelative(s string, base time.Time) (time.Time, bool) {
 		return base.AddDate(0, 0, n*7), true
 	case "m":
 		return base.AddDate(0, n, 0), true
+	case "y":
+		return base.AddDate(n, 0, 0), true
 	}
 
 	return time.Time{}, false
 }
 
-func parseWeekday(s string) (time.Weekday, bool) {
-	s = strings.TrimPrefix(s, "next ")
+// parsePeriod handles "next week/month/year" (the first day of the period)
+// and "end of week/month/year" (the last day of the current one). Weeks run
+// Monday to Sunday.
+func parsePeriod(s string, today time.Time) (time.Time, bool) {
+	sinceMonday := (int(today.Weekday()) + 6) % 7
+	switch s {
+	case "next week":
+		return today.AddDate(0, 0, 7-sinceMonday), true
+	case "next month":
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), true
+	case "next year":
+		return time.Date(today.Year()+1, 1, 1, 0, 0, 0, 0, today.Location()), true
+	case "end of week", "end of the week":
+		return today.AddDate(0, 0, 6-sinceMonday), true
+	case "end of month", "end of the month":
+		return time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()), true
+	case "end of year", "end of the year":
+		return time.Date(today.Year(), 12, 31, 0, 0, 0, 0, today.Location()), true
+	}
+	return time.Time{}, false
+}
+
+var isoWeekRe = regexp.MustCompile(`^(\d{4})-?w(\d{2})(?:-?([1-7]))?$`)
 
-	weekdays := map[string]time.Weekday{
-		"sunday":    time.Sunday,
-		"monday":    time.Monday,
-		"tuesday":   time.Tuesday,
-		"wednesday": time.Wednesday,
-		"thursday":  time.Thursday,
-		"friday":    time.Friday,
-		"saturday":  time.Saturday,
+// parseISOWeek handles ISO 8601 week dates: 2025-W03, 2025W03, 2025-W03-5.
+// Without a day, the Monday of the week is returned.
+func parseISOWeek(s string, today time.Time) (time.Time, bool) {
+	matches := isoWeekRe.FindStringSubmatch(s)
+	if matches == nil {
+		return time.Time{}, false
 	}
+	year, _ := strconv.Atoi(matches[1])
+	week, _ := strconv.Atoi(matches[2])
+	day := 1
+	if matches[3] != "" {
+		day, _ = strconv.Atoi(matches[3])
+	}
+
+	// Week 1 is the week with January 4th in it
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, today.Location())
+	week1 := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	date := week1.AddDate(0, 0, (week-1)*7+day-1)
+
+	// Reject weeks past the end of the year, e.g. W53 in a 52-week year
+	if y, w := date.ISOWeek(); week < 1 || y != year || w != week {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	s = strings.TrimPrefix(s, "next ")
 
 	if wd, ok := weekdays[s]; ok {
 		return wd, true
@@ -98,3 +222,86 @@ func nextWeekday(from time.Time, target time.Weekday) time.Time {
 	}
 	return from.AddDate(0, 0, daysUntil)
 }
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+var (
+	monthDayRe = regexp.MustCompile(`^([a-z]+)\.? (\d{1,2})(?:st|nd|rd|th)?(?:,? (\d{4}))?$`)
+	dayMonthRe = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?\.? ([a-z]+)\.?(?:,? (\d{4}))?$`)
+	dottedRe   = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.(\d{4}|\d{2})?$`)
+)
+
+// parseMonthDay handles "jul 15", "july 15th", "15 jul", "jul 15 2026"
+func parseMonthDay(s string, today time.Time) (time.Time, bool) {
+	var monthStr, dayStr, yearStr string
+	if matches := monthDayRe.FindStringSubmatch(s); matches != nil {
+		monthStr, dayStr, yearStr = matches[1], matches[2], matches[3]
+	} else if matches := dayMonthRe.FindStringSubmatch(s); matches != nil {
+		dayStr, monthStr, yearStr = matches[1], matches[2], matches[3]
+	} else {
+		return time.Time{}, false
+	}
+
+	month, ok := months[monthStr]
+	if !ok {
+		return time.Time{}, false
+	}
+	day, _ := strconv.Atoi(dayStr)
+	year := 0
+	if yearStr != "" {
+		year, _ = strconv.Atoi(yearStr)
+	}
+	return dateOf(year, month, day, today)
+}
+
+// parseDotted handles day-first dotted dates: "15.7.", "15.07.2026", "15.7.26"
+func parseDotted(s string, today time.Time) (time.Time, bool) {
+	matches := dottedRe.FindStringSubmatch(s)
+	if matches == nil {
+		return time.Time{}, false
+	}
+	day, _ := strconv.Atoi(matches[1])
+	month, _ := strconv.Atoi(matches[2])
+	year := 0
+	if matches[3] != "" {
+		year, _ = strconv.Atoi(matches[3])
+		if len(matches[3]) == 2 {
+			year += 2000
+		}
+	}
+	if month < 1 || month > 12 {
+		return time.Time{}, false
+	}
+	return dateOf(year, time.Month(month), day, today)
+}
+
+// dateOf builds a date, rejecting days the month doesn't have. Without a
+// year (0), the next such date from today on is used.
+func dateOf(year int, month time.Month, day int, today time.Time) (time.Time, bool) {
+	explicit := year != 0
+	if !explicit {
+		year = today.Year()
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, today.Location())
+	if !explicit && date.Before(today) {
+		date = time.Date(year+1, month, day, 0, 0, 0, 0, today.Location())
+	}
+	if date.Day() != day || date.Month() != month {
+		return time.Time{}, false
+	}
+	return date, true
+}
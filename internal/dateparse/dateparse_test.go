@@ -1,6 +1,8 @@
 package dateparse
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -39,6 +41,43 @@ func TestParse(t *testing.T) {
 		// Next weekday
 		{"next monday", time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)},
 		{"next friday", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+
+		// Abbreviated weekdays
+		{"fri", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"Thurs", time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"next mon", time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)},
+
+		// Periods
+		{"next week", time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)},
+		{"next month", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"next year", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"end of week", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"end of month", time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"end  of the  year", time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)},
+
+		// Relative phrases
+		{"in 3 days", today.AddDate(0, 0, 3)},
+		{"in 2 weeks", today.AddDate(0, 0, 14)},
+		{"in a month", today.AddDate(0, 1, 0)},
+		{"+1y", today.AddDate(1, 0, 0)},
+
+		// Month and day
+		{"jul 15", time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)},
+		{"July 15th", time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)},
+		{"15 jul", time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)},
+		{"jan 10", time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)}, // already past this year
+		{"jan 15", today},
+		{"jan 10, 2027", time.Date(2027, 1, 10, 0, 0, 0, 0, time.UTC)},
+
+		// Day and month
+		{"15.7.", time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)},
+		{"15.07.2026", time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC)},
+		{"1.2.26", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+
+		// ISO weeks
+		{"2025-W03", time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)},
+		{"2025w03-5", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"2026-W01", time.Date(2025, 12, 29, 0, 0, 0, 0, time.UTC)},
 	}
 
 	for _, tt := range tests {
@@ -61,6 +100,14 @@ func TestParseError(t *testing.T) {
 		"yesterday",
 		"++1d",
 		"1d",
+		"feb 30",
+		"31.4.",
+		"15.13.",
+		"2025-W53", // 2025 has 52 weeks
+		"in 2 fortnights",
+		"+99999999999999999999d", // past what an int holds
+		"+9000y",                 // past year 9999
+		"0000-W01",
 	}
 
 	for _, input := range tests {
@@ -72,3 +119,65 @@ func TestParseError(t *testing.T) {
 		})
 	}
 }
+
+func TestParseErrorListsFormats(t *testing.T) {
+	_, err := Parse("someday soon")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Parse() error = %v, want a *ParseError", err)
+	}
+	if parseErr.Input != "someday soon" {
+		t.Errorf("Input = %q, want %q", parseErr.Input, "someday soon")
+	}
+	for _, example := range Examples() {
+		if !strings.Contains(err.Error(), example) {
+			t.Errorf("error %q does not mention %q", err.Error(), example)
+		}
+	}
+}
+
+// FuzzParseFrom checks properties of every date ParseFrom accepts: it's
+// midnight in the reference time's location, in a year that can be stored,
+// and the date written as YYYY-MM-DD parses back to itself
+func FuzzParseFrom(f *testing.F) {
+	for _, seed := range append(Examples(),
+		"in a month", "in 0 days", "end of the year", "next year", "2024-02-29",
+		"29.2.", "feb 29", "dec 31 2025", "2026-W53", "2027w01-7", "+120m", "+9999999999d",
+	) {
+		f.Add(seed)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		berlin = time.FixedZone("CET", 3600)
+	}
+	refs := []time.Time{
+		time.Date(2025, 1, 31, 23, 30, 0, 0, berlin),
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC),
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, now := range refs {
+			got, err := ParseFrom(s, now)
+			if err != nil {
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) {
+					t.Fatalf("ParseFrom(%q) error = %v, want a *ParseError", s, err)
+				}
+				continue
+			}
+			if got.Location() != now.Location() || got.Hour() != 0 || got.Minute() != 0 {
+				t.Fatalf("ParseFrom(%q, %v) = %v, want midnight in %v", s, now, got, now.Location())
+			}
+			if got.Year() < 1 || got.Year() > 9999 {
+				t.Fatalf("ParseFrom(%q) = %v, out of range", s, got)
+			}
+			day := got.Format("2006-01-02")
+			again, err := ParseFrom(day, now)
+			if err != nil || !again.Equal(got) {
+				t.Fatalf("ParseFrom(%q) = %s, which parses to %v, %v", s, day, again, err)
+			}
+		}
+	})
+}
@@ -18,7 +18,7 @@ func NewRepository(db *database.DB) *Repository {
 }
 
 func (r *Repository) Create(area *Area) error {
-	result, err := r.db.Conn.Exec(
+	id, err := r.db.Insert(
 		`INSERT INTO areas (name) VALUES (?)`,
 		area.Name,
 	)
@@ -26,17 +26,12 @@ func (r *Repository) Create(area *Area) error {
 		return err
 	}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		return err
-	}
-
 	area.ID = id
 	return nil
 }
 
 func (r *Repository) List() ([]Area, error) {
-	rows, err := r.db.Conn.Query(`SELECT id, name FROM areas ORDER BY name`)
+	rows, err := r.db.Query(`SELECT id, name FROM areas ORDER BY name`)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +41,7 @@ func (r *Repository) List() ([]Area, error) {
 }
 
 func (r *Repository) GetByID(id int64) (*Area, error) {
-	row := r.db.Conn.QueryRow(`SELECT id, name FROM areas WHERE id = ?`, id)
+	row := r.db.QueryRow(`SELECT id, name FROM areas WHERE id = ?`, id)
 
 	var a Area
 	if err := row.Scan(&a.ID, &a.Name); err != nil {
@@ -60,7 +55,7 @@ func (r *Repository) GetByID(id int64) (*Area, error) {
 }
 
 func (r *Repository) GetByName(name string) (*Area, error) {
-	row := r.db.Conn.QueryRow(`SELECT id, name FROM areas WHERE name = ?`, name)
+	row := r.db.QueryRow(`SELECT id, name FROM areas WHERE name = ?`, name)
 
 	var a Area
 	if err := row.Scan(&a.ID, &a.Name); err != nil {
@@ -74,7 +69,7 @@ func (r *Repository) GetByName(name string) (*Area, error) {
 }
 
 func (r *Repository) Delete(id int64) error {
-	result, err := r.db.Conn.Exec(`DELETE FROM areas WHERE id = ?`, id)
+	result, err := r.db.Exec(`DELETE FROM areas WHERE id = ?`, id)
 	if err != nil {
 		return err
 	}
@@ -91,7 +86,7 @@ func (r *Repository) Delete(id int64) error {
 }
 
 func (r *Repository) Update(area *Area) error {
-	result, err := r.db.Conn.Exec(
+	result, err := r.db.Exec(
 		`UPDATE areas SET name = ? WHERE id = ?`,
 		area.Name, area.ID,
 	)
@@ -0,0 +1,11 @@
+package area
+
+// Service is what the CLI and TUI use to work with areas. The area use
+// cases implement it (see app.AreaService); tests can swap in a fake.
+type Service interface {
+	Create(name string) (*Area, error)
+	Get(name string) (*Area, error)
+	List() ([]Area, error)
+	Delete(name string) (*Area, error)
+	Rename(oldName, newName string) (*Area, error)
+}
@@ -0,0 +1,13 @@
+package area
+
+// Store keeps areas. Repository stores them in SQLite.
+type Store interface {
+	Create(area *Area) error
+	Update(area *Area) error
+	Delete(id int64) error
+	GetByID(id int64) (*Area, error)
+	GetByName(name string) (*Area, error)
+	List() ([]Area, error)
+}
+
+var _ Store = (*Repository)(nil)
@@ -3,7 +3,7 @@ package usecases
 import "github.com/devbydaniel/tt/internal/domain/area"
 
 type CreateArea struct {
-	Repo *area.Repository
+	Repo area.Store
 }
 
 func (c *CreateArea) Execute(name string) (*area.Area, error) {
@@ -3,7 +3,7 @@ package usecases
 import "github.com/devbydaniel/tt/internal/domain/area"
 
 type DeleteArea struct {
-	Repo *area.Repository
+	Repo area.Store
 }
 
 func (d *DeleteArea) Execute(name string) (*area.Area, error) {
@@ -3,7 +3,7 @@ package usecases
 import "github.com/devbydaniel/tt/internal/domain/area"
 
 type GetAreaByName struct {
-	Repo *area.Repository
+	Repo area.Store
 }
 
 func (g *GetAreaByName) Execute(name string) (*area.Area, error) {
@@ -3,7 +3,7 @@ package usecases
 import "github.com/devbydaniel/tt/internal/domain/area"
 
 type ListAreas struct {
-	Repo *area.Repository
+	Repo area.Store
 }
 
 func (l *ListAreas) Execute() ([]area.Area, error) {
@@ -3,7 +3,7 @@ package usecases
 import "github.com/devbydaniel/tt/internal/domain/area"
 
 type RenameArea struct {
-	Repo *area.Repository
+	Repo area.Store
 }
 
 func (r *RenameArea) Execute(oldName, newName string) (*area.Area, error) {
@@ -0,0 +1,13 @@
+package comment
+
+import "time"
+
+// Comment is a dated note on a task, e.g. "waiting on legal". Unlike the
+// description, comments are only ever added, and read in order.
+type Comment struct {
+	ID        int64     `json:"id"`
+	TaskID    int64     `json:"taskId"`
+	Body      string    `json:"body"`
+	Author    *string   `json:"author,omitempty"` // who wrote it (nil = unknown)
+	CreatedAt time.Time `json:"createdAt"`
+}
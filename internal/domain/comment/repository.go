@@ -0,0 +1,51 @@
+package comment
+
+import (
+	"time"
+
+	"github.com/devbydaniel/tt/internal/database"
+)
+
+type Repository struct {
+	db *database.DB
+}
+
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Create(c *Comment) error {
+	id, err := r.db.Insert(
+		`INSERT INTO task_comments (task_id, body, author, created_at) VALUES (?, ?, ?, ?)`,
+		c.TaskID, c.Body, c.Author, c.CreatedAt.Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.ID = id
+	return nil
+}
+
+func (r *Repository) ListByTask(taskID int64) ([]Comment, error) {
+	rows, err := r.db.Query(
+		`SELECT id, task_id, body, author, created_at FROM task_comments WHERE task_id = ? ORDER BY created_at, id`,
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []Comment
+	for rows.Next() {
+		var c Comment
+		var createdAt string
+		if err := rows.Scan(&c.ID, &c.TaskID, &c.Body, &c.Author, &createdAt); err != nil {
+			return nil, err
+		}
+		c.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
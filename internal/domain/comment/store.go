@@ -0,0 +1,10 @@
+package comment
+
+// Store keeps comments. Repository stores them in SQLite.
+type Store interface {
+	Create(c *Comment) error
+	// ListByTask returns the comments on a task, oldest first
+	ListByTask(taskID int64) ([]Comment, error)
+}
+
+var _ Store = (*Repository)(nil)
@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/comment"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// TaskLookup is what this use case needs from the task domain
+type TaskLookup interface {
+	Execute(id int64) (*task.Task, error)
+}
+
+type AddComment struct {
+	Repo  comment.Store
+	Tasks TaskLookup
+	Clock clock.Clock
+	User  string // recorded as the author (empty = unknown)
+}
+
+// Execute adds a comment to the task, which must exist
+func (a *AddComment) Execute(taskID int64, body string) (*comment.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, errors.New("comment cannot be empty")
+	}
+	if _, err := a.Tasks.Execute(taskID); err != nil {
+		return nil, err
+	}
+
+	c := &comment.Comment{TaskID: taskID, Body: body, CreatedAt: clock.Now(a.Clock)}
+	if a.User != "" {
+		c.Author = &a.User
+	}
+	if err := a.Repo.Create(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,12 @@
+package usecases
+
+import "github.com/devbydaniel/tt/internal/domain/comment"
+
+type ListComments struct {
+	Repo comment.Store
+}
+
+// Execute returns the comments on a task, oldest first
+func (l *ListComments) Execute(taskID int64) ([]comment.Comment, error) {
+	return l.Repo.ListByTask(taskID)
+}
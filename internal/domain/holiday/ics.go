@@ -0,0 +1,110 @@
+package holiday
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxEventDays caps how many days a single calendar event may cover, so a
+// malformed end date can't flood the table.
+const maxEventDays = 31
+
+// ParseICS reads the events of an iCalendar file as holidays, one per day an
+// event covers. Only the dates of DTSTART/DTEND are used; DTEND is exclusive
+// as in the iCalendar spec, and events without one last a single day.
+func ParseICS(r io.Reader) ([]Holiday, error) {
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []Holiday
+	var inEvent bool
+	var start, end *time.Time
+	var name string
+
+	for i, line := range lines {
+		prop, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		prop, _, _ = strings.Cut(strings.ToUpper(prop), ";")
+
+		switch prop {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end, name = true, nil, nil, ""
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			date, err := parseICSDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			if prop == "DTSTART" {
+				start = &date
+			} else {
+				end = &date
+			}
+		case "SUMMARY":
+			if inEvent {
+				name = unescapeICS(value)
+			}
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if start == nil {
+				continue
+			}
+			last := *start
+			if end != nil && end.After(*start) {
+				last = end.AddDate(0, 0, -1)
+			}
+			for d, n := *start, 0; !d.After(last) && n < maxEventDays; d, n = d.AddDate(0, 0, 1), n+1 {
+				holidays = append(holidays, Holiday{Date: d, Name: name})
+			}
+		}
+	}
+
+	return holidays, nil
+}
+
+// unfoldICS splits an iCalendar file into logical lines, joining continuation
+// lines (starting with a space or tab) onto the line before.
+func unfoldICS(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseICSDate parses the date part of a DATE or DATE-TIME value
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	date, err := time.ParseInLocation("20060102", value[:8], time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+// unescapeICS resolves the backslash escapes of iCalendar text values
+func unescapeICS(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
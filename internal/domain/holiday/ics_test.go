@@ -0,0 +1,52 @@
+package holiday_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/devbydaniel/tt/internal/domain/holiday"
+)
+
+func TestParseICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20251225",
+		"DTEND;VALUE=DATE:20251227",
+		"SUMMARY:Christmas\\, Boxing Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20260101T000000Z",
+		"SUMMARY:New Year's",
+		"  Day",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	holidays, err := holiday.ParseICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("ParseICS() error = %v", err)
+	}
+
+	want := []struct{ date, name string }{
+		{"2025-12-25", "Christmas, Boxing Day"},
+		{"2025-12-26", "Christmas, Boxing Day"},
+		{"2026-01-01", "New Year's Day"},
+	}
+	if len(holidays) != len(want) {
+		t.Fatalf("got %d holidays, want %d: %v", len(holidays), len(want), holidays)
+	}
+	for i, w := range want {
+		if holidays[i].Key() != w.date || holidays[i].Name != w.name {
+			t.Errorf("holidays[%d] = %s %q, want %s %q", i, holidays[i].Key(), holidays[i].Name, w.date, w.name)
+		}
+	}
+}
+
+func TestParseICSInvalidDate(t *testing.T) {
+	ics := "BEGIN:VEVENT\nDTSTART:2025\nEND:VEVENT\n"
+	if _, err := holiday.ParseICS(strings.NewReader(ics)); err == nil {
+		t.Error("ParseICS() should fail on an invalid date")
+	}
+}
@@ -0,0 +1,15 @@
+package holiday
+
+import "time"
+
+const dateFormat = "2006-01-02"
+
+type Holiday struct {
+	Date time.Time `json:"date"`
+	Name string    `json:"name,omitempty"`
+}
+
+// Key returns the date as YYYY-MM-DD, the form holidays are stored and looked up by
+func (h Holiday) Key() string {
+	return h.Date.Format(dateFormat)
+}
@@ -0,0 +1,70 @@
+package holiday
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/database"
+)
+
+var ErrHolidayNotFound = errors.New("holiday not found")
+
+type Repository struct {
+	db *database.DB
+}
+
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Save adds a holiday, replacing the name of an existing one on the same date
+func (r *Repository) Save(h *Holiday) error {
+	_, err := r.db.Exec(
+		`INSERT INTO holidays (date, name) VALUES (?, ?) ON CONFLICT(date) DO UPDATE SET name = excluded.name`,
+		h.Key(), h.Name,
+	)
+	return err
+}
+
+func (r *Repository) List() ([]Holiday, error) {
+	rows, err := r.db.Query(`SELECT date, name FROM holidays ORDER BY date`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanHolidays(rows)
+}
+
+func (r *Repository) Delete(date time.Time) error {
+	result, err := r.db.Exec(`DELETE FROM holidays WHERE date = ?`, date.Format(dateFormat))
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrHolidayNotFound
+	}
+
+	return nil
+}
+
+func scanHolidays(rows *sql.Rows) ([]Holiday, error) {
+	var holidays []Holiday
+	for rows.Next() {
+		var h Holiday
+		var date string
+		if err := rows.Scan(&date, &h.Name); err != nil {
+			return nil, err
+		}
+		h.Date, _ = time.ParseInLocation(dateFormat, date, time.Local)
+		holidays = append(holidays, h)
+	}
+
+	return holidays, rows.Err()
+}
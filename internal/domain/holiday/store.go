@@ -0,0 +1,12 @@
+package holiday
+
+import "time"
+
+// Store keeps holidays. Repository stores them in SQLite.
+type Store interface {
+	Save(h *Holiday) error
+	Delete(date time.Time) error
+	List() ([]Holiday, error)
+}
+
+var _ Store = (*Repository)(nil)
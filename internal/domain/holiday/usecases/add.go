@@ -0,0 +1,21 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/holiday"
+)
+
+type AddHoliday struct {
+	Repo holiday.Store
+}
+
+func (a *AddHoliday) Execute(date time.Time, name string) (*holiday.Holiday, error) {
+	h := &holiday.Holiday{Date: date, Name: name}
+
+	if err := a.Repo.Save(h); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"io"
+
+	"github.com/devbydaniel/tt/internal/domain/holiday"
+)
+
+type ImportHolidays struct {
+	Repo holiday.Store
+}
+
+// Execute adds the holidays of an iCalendar file and returns how many dates
+// were imported. Dates already present keep their date but take the new name.
+func (i *ImportHolidays) Execute(r io.Reader) (int, error) {
+	holidays, err := holiday.ParseICS(r)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, h := range holidays {
+		if err := i.Repo.Save(&h); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(holidays), nil
+}
@@ -0,0 +1,11 @@
+package usecases
+
+import "github.com/devbydaniel/tt/internal/domain/holiday"
+
+type ListHolidays struct {
+	Repo holiday.Store
+}
+
+func (l *ListHolidays) Execute() ([]holiday.Holiday, error) {
+	return l.Repo.List()
+}
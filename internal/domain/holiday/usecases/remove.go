@@ -0,0 +1,15 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/holiday"
+)
+
+type RemoveHoliday struct {
+	Repo holiday.Store
+}
+
+func (r *RemoveHoliday) Execute(date time.Time) error {
+	return r.Repo.Delete(date)
+}
@@ -1,7 +1,9 @@
 package task
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -147,13 +149,29 @@ type Task struct {
 	Status      Status     `json:"status"`
 	CreatedAt   time.Time  `json:"createdAt"`
 	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	Estimate    *int       `json:"estimate,omitempty"`   // effort estimate in minutes
+	Context     *string    `json:"context,omitempty"`    // energy/context label, see ValidContexts
+	Expires     *time.Time `json:"expires,omitempty"`    // expired by maintenance once this date has passed
+	Assignee    *string    `json:"assignee,omitempty"`   // who the task is assigned to, in a shared database
+	Creator     *string    `json:"creator,omitempty"`    // who added the task (nil = unknown)
+	Location    *string    `json:"location,omitempty"`   // where the task can be done (nil = anywhere)
+	Flagged     bool       `json:"flagged,omitempty"`    // pinned to the top of every list
+	ExternalID  *string    `json:"externalId,omitempty"` // ID in the system the task was imported from, unique
+
+	CompletionNote *string `json:"completionNote,omitempty"` // how a completed task turned out, e.g. what was shipped
+
+	// Time block: a slot of time set aside to work on the task
+	BlockStart   *time.Time `json:"blockStart,omitempty"`
+	BlockMinutes *int       `json:"blockMinutes,omitempty"`
 
 	// Recurrence fields
 	RecurType     *string    `json:"recurType,omitempty"`     // "fixed" or "relative"
 	RecurRule     *string    `json:"recurRule,omitempty"`     // JSON rule: {"interval":1,"unit":"week",...}
 	RecurEnd      *time.Time `json:"recurEnd,omitempty"`      // optional end date
+	RecurCount    *int       `json:"recurCount,omitempty"`    // optional number of occurrences
 	RecurPaused   bool       `json:"recurPaused,omitempty"`   // true = paused
 	RecurParentID *int64     `json:"recurParentId,omitempty"` // links to original recurring task
+	RecurTemplate *string    `json:"recurTemplate,omitempty"` // JSON RecurTemplate, set after a this-only edit
 
 	// Tags
 	Tags []string `json:"tags,omitempty"`
@@ -173,6 +191,69 @@ func (t *Task) IsTask() bool {
 	return t.TaskType == TaskTypeTask
 }
 
+// RecurTemplate holds the fields the next occurrence of a recurring task is
+// created from. Normally the current occurrence is its own template; one is
+// only stored once the occurrence has been edited without affecting later ones.
+type RecurTemplate struct {
+	Title       string   `json:"title"`
+	Description *string  `json:"description,omitempty"`
+	ParentID    *int64   `json:"parentId,omitempty"`
+	AreaID      *int64   `json:"areaId,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Estimate    *int     `json:"estimate,omitempty"`
+	Context     *string  `json:"context,omitempty"`
+}
+
+// Template returns the fields the next occurrence of t is created from
+func (t *Task) Template() RecurTemplate {
+	if t.RecurTemplate != nil {
+		var tmpl RecurTemplate
+		if err := json.Unmarshal([]byte(*t.RecurTemplate), &tmpl); err == nil {
+			return tmpl
+		}
+	}
+	return RecurTemplate{
+		Title:       t.Title,
+		Description: t.Description,
+		ParentID:    t.ParentID,
+		AreaID:      t.AreaID,
+		Tags:        t.Tags,
+		Estimate:    t.Estimate,
+		Context:     t.Context,
+	}
+}
+
+// ToJSON serializes the template for storage in RecurTemplate
+func (r RecurTemplate) ToJSON() (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Fields a recurring edit can carry over to the template and later occurrences
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldProject     = "project" // parent project, with its area
+	FieldArea        = "area"
+	FieldTags        = "tags"
+	FieldEstimate    = "estimate"
+	FieldContext     = "context"
+)
+
+// Expire actions: what maintenance does with a task past its expires date
+const (
+	ExpireSomeday = "someday" // move it to someday (default)
+	ExpireDelete  = "delete"  // delete it
+)
+
+// ExpireActions returns the accepted values of the `expire_action` config setting
+func ExpireActions() []string {
+	return []string{ExpireSomeday, ExpireDelete}
+}
+
 // Recurrence type constants
 const (
 	RecurTypeFixed    = "fixed"
@@ -185,6 +266,9 @@ type Status string
 const (
 	StatusTodo Status = "todo"
 	StatusDone Status = "done"
+	// StatusCanceled is a task that won't be done. It's listed in the
+	// logbook like a completed one but not counted as completed.
+	StatusCanceled Status = "canceled"
 )
 
 // State represents the planning state of a task
@@ -213,28 +297,393 @@ type CreateOptions struct {
 	DueDate     *time.Time
 	Someday     bool     // if true, create in someday state
 	Tags        []string // tags to assign
+	Estimate    *int     // effort estimate in minutes
+	Context     string   // energy/context label
+	Expires     *time.Time
+	Assignee    string // who the task is assigned to
+	Location    string // where the task can be done, see ParseLocation
+	ExternalID  string // ID in the system the task comes from; see UpsertTask
+	StrictWIP   bool   // refuse to go over a WIP limit instead of just going over it
 
 	// Recurrence options
 	RecurType     *string    // "fixed" or "relative"
 	RecurRule     *string    // JSON rule
 	RecurEnd      *time.Time // optional end date
+	RecurCount    *int       // optional number of occurrences
 	RecurParentID *int64     // for linking regenerated tasks
 }
 
+// CreateProjectOptions contains options for creating a project
+type CreateProjectOptions struct {
+	AreaName    string
+	Description string
+	PlannedDate *time.Time
+	DueDate     *time.Time
+	Someday     bool
+}
+
+// SuggestOptions controls how the next task is picked
+type SuggestOptions struct {
+	Random   bool   // pick uniformly at random instead of scoring
+	Context  string // only consider tasks with this context label
+	Capacity int    // daily capacity in minutes (0 = ignore estimates vs. capacity)
+}
+
+// CompletedOptions contains options for listing completed tasks (the logbook)
+type CompletedOptions struct {
+	ProjectName string     // user-facing: filter by project name
+	AreaName    string     // filter by area, including tasks in the area's projects
+	TagName     string     // filter by tag
+	Search      string     // case-insensitive title search
+	Since       *time.Time // first day included
+	Until       *time.Time // last day included
+}
+
 // ListOptions contains options for listing tasks
 type ListOptions struct {
-	TaskType    TaskType     // filter by task type ("task", "project", or empty for all)
-	ProjectName string       // user-facing: filter by project name (internally uses ParentID)
+	TaskType    TaskType // filter by task type ("task", "project", or empty for all)
+	ProjectName string   // user-facing: filter by project name (internally uses ParentID)
 	AreaName    string
 	TagName     string       // filter by tag
+	Context     string       // filter by context label
+	Assignee    string       // filter by assignee
+	Location    string       // filter by location
+	Flagged     bool         // only flagged tasks
 	Schedule    string       // "today", "upcoming", "anytime", "inbox", "someday"
+	AllUpcoming bool         // ignore upcoming_days, so grouping by schedule doesn't drop far-off tasks
 	State       State        // explicit state filter ("active", "someday", or empty for schedule-based)
 	Search      string       // case-insensitive title search
 	Sort        []SortOption // sort options (default: created desc)
 }
 
+// ValidContexts returns the supported context labels, in cycling order
+func ValidContexts() []string {
+	return []string{"deep", "shallow", "errand", "call"}
+}
+
+// ParseContext validates a context label (case-insensitive)
+func ParseContext(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, c := range ValidContexts() {
+		if s == c {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("invalid context: %s (valid: %s)", s, strings.Join(ValidContexts(), ", "))
+}
+
+// NextContext returns the context after current in ValidContexts, cycling back to none (nil)
+func NextContext(current *string) *string {
+	contexts := ValidContexts()
+	if current == nil {
+		return &contexts[0]
+	}
+	for i, c := range contexts {
+		if c == *current && i+1 < len(contexts) {
+			return &contexts[i+1]
+		}
+	}
+	return nil
+}
+
+// ParseLocation normalizes a location: "@home" and "home" are the same
+// place. Any other text, such as a geo string, is kept as written; an empty
+// result means no location.
+func ParseLocation(s string) string {
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "@"))
+}
+
+// ParseEstimate parses an effort estimate such as "30m", "2h" or "1h30m" into minutes.
+// A bare number is taken as minutes.
+func ParseEstimate(s string) (int, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return 0, fmt.Errorf("invalid estimate: empty")
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if n <= 0 {
+			return 0, fmt.Errorf("invalid estimate: %s (must be positive)", s)
+		}
+		return n, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < time.Minute {
+		return 0, fmt.Errorf("invalid estimate: %s (use e.g. 30m, 2h, 1h30m)", s)
+	}
+	return int(d.Minutes()), nil
+}
+
+// FormatEstimate formats minutes as a compact duration like "45m", "2h" or "1h30m"
+func FormatEstimate(minutes int) string {
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
+
+// TotalEstimate sums the estimates of the given tasks, ignoring tasks without one
+func TotalEstimate(tasks []Task) int {
+	total := 0
+	for _, t := range tasks {
+		if t.Estimate != nil {
+			total += *t.Estimate
+		}
+	}
+	return total
+}
+
+// ChecklistItem reports whether a description line is a markdown checkbox
+// ("- [ ] item", "* [x] item") and whether it is ticked.
+func ChecklistItem(line string) (isItem, checked bool) {
+	line = strings.TrimSpace(line)
+	if len(line) < 5 || (line[0] != '-' && line[0] != '*') || line[1] != ' ' || line[2] != '[' || line[4] != ']' {
+		return false, false
+	}
+	switch line[3] {
+	case ' ':
+		return true, false
+	case 'x', 'X':
+		return true, true
+	}
+	return false, false
+}
+
+// Checklist counts the checkboxes in a description: how many are ticked and how many there are
+func Checklist(description string) (done, total int) {
+	for _, line := range strings.Split(description, "\n") {
+		if isItem, checked := ChecklistItem(line); isItem {
+			total++
+			if checked {
+				done++
+			}
+		}
+	}
+	return done, total
+}
+
+// OpenChecklistItems returns the text of the unticked checkboxes in a
+// description, in order
+func OpenChecklistItems(description string) []string {
+	var items []string
+	for _, line := range strings.Split(description, "\n") {
+		if isItem, checked := ChecklistItem(line); isItem && !checked {
+			if text := strings.TrimSpace(strings.TrimSpace(line)[5:]); text != "" {
+				items = append(items, text)
+			}
+		}
+	}
+	return items
+}
+
+// ScheduleSettings controls how the Today and Upcoming filters interpret the calendar
+type ScheduleSettings struct {
+	UpcomingDays    int // how many days ahead Upcoming reaches (0 = unbounded)
+	DayRolloverHour int // hour (0-23) at which a new day starts; earlier times count as the previous day
+}
+
+// Today returns the date considered "today" at the given time.
+// Before the rollover hour, the previous calendar day is still today.
+func (s ScheduleSettings) Today(now time.Time) time.Time {
+	if s.DayRolloverHour > 0 && s.DayRolloverHour < 24 && now.Hour() < s.DayRolloverHour {
+		now = now.AddDate(0, 0, -1)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// DateWarnings returns what looks off about a task's dates: a due date that
+// has already passed, or a planned date after the due date. Dates are
+// compared by calendar day; today is the date from ScheduleSettings.Today.
+func DateWarnings(planned, due *time.Time, today time.Time) []string {
+	if due == nil {
+		return nil
+	}
+	day := func(t time.Time) string { return t.Format("2006-01-02") }
+
+	var warnings []string
+	if day(*due) < day(today) {
+		warnings = append(warnings, fmt.Sprintf("due date %s is in the past", due.Format("Jan 2 2006")))
+	}
+	if planned != nil && day(*planned) > day(*due) {
+		warnings = append(warnings, fmt.Sprintf("planned date %s is after the due date %s",
+			planned.Format("Jan 2"), due.Format("Jan 2")))
+	}
+	return warnings
+}
+
+// DaysBetween counts the calendar days from one date to another, negative
+// when to is earlier. Unlike dividing the difference by 24 hours, it isn't
+// thrown off by days that are shorter or longer because of DST.
+func DaysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// UpcomingUntil returns the last date included in Upcoming, or nil if unbounded.
+func (s ScheduleSettings) UpcomingUntil(today time.Time) *time.Time {
+	if s.UpcomingDays <= 0 {
+		return nil
+	}
+	until := today.AddDate(0, 0, s.UpcomingDays)
+	return &until
+}
+
+// AreaDefaults are what new tasks in an area, directly or through their
+// project, get unless they're given their own
+type AreaDefaults struct {
+	Tags      []string // added to the task's tags
+	PlannedIn *int     // days after today the task is planned for (nil = unplanned)
+}
+
+// WIPLimits are how many open active tasks areas and projects should hold,
+// keyed by name: a soft, kanban-style limit on work in progress. An area's
+// count includes the tasks of its projects.
+type WIPLimits struct {
+	Areas    map[string]int
+	Projects map[string]int
+}
+
+// WIP scopes
+const (
+	WIPArea    = "area"
+	WIPProject = "project"
+)
+
+// WIPCount is how many open active tasks an area or project with a WIP
+// limit holds
+type WIPCount struct {
+	Scope string // WIPArea or WIPProject
+	Name  string
+	Count int
+	Limit int
+}
+
+// Over reports whether the area or project holds more than its limit
+func (c WIPCount) Over() bool {
+	return c.Count > c.Limit
+}
+
+// LintReport is what tt lint found: open tasks and projects that are
+// probably filed by mistake, and tags that are probably typos
+type LintReport struct {
+	AreaConflicts  []AreaConflict `json:"areaConflicts"`  // tasks with an area other than their project's
+	ProjectsNoArea []Task         `json:"projectsNoArea"` // open projects without an area
+	EmptyProjects  []Task         `json:"emptyProjects"`  // open projects without open tasks, created before the cutoff
+	SingleUseTags  []string       `json:"singleUseTags"`  // tags on a single task, open or done
+}
+
+// AreaConflict is a task in a project of one area that has another area
+// of its own
+type AreaConflict struct {
+	Task        Task   `json:"task"`
+	Area        string `json:"area"` // the task's area
+	Project     string `json:"project"`
+	ProjectArea string `json:"projectArea"`
+}
+
+// Len returns how many findings the report holds
+func (r *LintReport) Len() int {
+	return len(r.AreaConflicts) + len(r.ProjectsNoArea) + len(r.EmptyProjects) + len(r.SingleUseTags)
+}
+
+// RecurrenceSettings controls how recurring tasks generate their occurrences
+type RecurrenceSettings struct {
+	Ahead       bool   // keep the next occurrence of fixed recurrences created ahead of time
+	HolidayMode string // what happens to occurrences on holidays: HolidaySkip (default) or HolidayShift
+}
+
+// What happens to a recurring task's occurrence that falls on a holiday
+const (
+	HolidaySkip  = "skip"  // move to the next occurrence of the rule
+	HolidayShift = "shift" // move to the next day that isn't a holiday
+)
+
+// HolidayModes returns the accepted values of RecurrenceSettings.HolidayMode
+func HolidayModes() []string {
+	return []string{HolidaySkip, HolidayShift}
+}
+
+// Week holds active tasks scheduled within a seven-day span (Monday to Sunday for week views).
+// Each task appears once: on its planned day, or on its due day if not planned that span.
+type Week struct {
+	Start time.Time `json:"start"`
+	Days  [7][]Task `json:"days"`
+}
+
+// Suggestion is a task recommended by the next-task scorer, with the reasons it ranked highly
+type Suggestion struct {
+	Task    Task               `json:"task"`
+	Score   int                `json:"score"`
+	Reasons []SuggestionReason `json:"reasons,omitempty"`
+}
+
+// SuggestionReason is one reason a task was suggested: a Reason* kind, and
+// for some kinds a number of days
+type SuggestionReason struct {
+	Kind string `json:"kind"`
+	Days int    `json:"days,omitempty"`
+}
+
+// Kinds of SuggestionReason
+const (
+	ReasonOverdue      = "overdue" // Days past the due date
+	ReasonDueToday     = "due_today"
+	ReasonDueSoon      = "due_soon" // Days until the due date
+	ReasonPlanned      = "planned"
+	ReasonWaiting      = "waiting" // Days since the task was created
+	ReasonFitsCapacity = "fits_capacity"
+	ReasonQuickWin     = "quick_win"
+)
+
 // CompleteResult represents the result of completing a task
 type CompleteResult struct {
 	Completed Task
 	NextTask  *Task // non-nil if a recurring task was regenerated
 }
+
+// VacationPlan is what pausing for a vacation changes: the fixed
+// recurrences it pauses, and the tasks planned during it, which move to
+// the day after
+type VacationPlan struct {
+	Until   time.Time // last day of the vacation
+	Paused  []Task    // fixed recurrences paused until it's over
+	Shifted []Task    // tasks planned during it, with their new planned date
+}
+
+// ProjectPlan is a proposed schedule for the open tasks of a project, in
+// dependency order and within a daily capacity
+type ProjectPlan struct {
+	Project  Task
+	Capacity int        // minutes of work a day
+	Steps    []PlanStep // in the order to work on them
+}
+
+// PlanStep is a task of a ProjectPlan and the days proposed for it
+type PlanStep struct {
+	Task      Task
+	Start     time.Time // proposed planned date
+	Finish    time.Time // day its estimate is used up, after Start for long tasks
+	DependsOn []int64   // open tasks of the project it waits for
+}
+
+// Finish returns the day the last step of the plan finishes
+func (p *ProjectPlan) Finish() time.Time {
+	var last time.Time
+	for _, s := range p.Steps {
+		if s.Finish.After(last) {
+			last = s.Finish
+		}
+	}
+	return last
+}
+
+// UncompleteResult represents the result of reopening a task
+type UncompleteResult struct {
+	Reopened Task
+	Removed  *Task // the next occurrence its completion created, deleted again
+}
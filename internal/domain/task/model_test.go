@@ -0,0 +1,107 @@
+package task
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseContext(t *testing.T) {
+	for _, input := range []string{"deep", "Shallow", " errand ", "CALL"} {
+		if _, err := ParseContext(input); err != nil {
+			t.Errorf("ParseContext(%q) error = %v", input, err)
+		}
+	}
+	if _, err := ParseContext("focus"); err == nil {
+		t.Error("ParseContext(\"focus\") expected error")
+	}
+}
+
+func TestNextContext(t *testing.T) {
+	var current *string
+	var seen []string
+	for i := 0; i < len(ValidContexts()); i++ {
+		current = NextContext(current)
+		if current == nil {
+			t.Fatalf("NextContext() = nil after %v", seen)
+		}
+		seen = append(seen, *current)
+	}
+	if NextContext(current) != nil {
+		t.Errorf("NextContext(%q) should cycle back to none", *current)
+	}
+	for i, c := range ValidContexts() {
+		if seen[i] != c {
+			t.Errorf("cycle[%d] = %q, want %q", i, seen[i], c)
+		}
+	}
+}
+
+func TestChecklist(t *testing.T) {
+	description := "Packing list\n- [x] passport\n- [ ] charger\n  * [X] tickets\n- [] not a box\n-[ ] nor this"
+	done, total := Checklist(description)
+	if done != 2 || total != 3 {
+		t.Errorf("Checklist() = %d/%d, want 2/3", done, total)
+	}
+	if done, total := Checklist("no boxes here"); done != 0 || total != 0 {
+		t.Errorf("Checklist() = %d/%d, want 0/0", done, total)
+	}
+}
+
+func TestDateWarnings(t *testing.T) {
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	date := func(day int) *time.Time {
+		d := time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+
+	tests := []struct {
+		name    string
+		planned *time.Time
+		due     *time.Time
+		want    []string
+	}{
+		{"no dates", nil, nil, nil},
+		{"planned only", date(1), nil, nil},
+		{"due today", nil, date(10), nil},
+		{"due in the past", nil, date(9), []string{"in the past"}},
+		{"planned on due date", date(12), date(12), nil},
+		{"planned after due", date(14), date(12), []string{"after the due date"}},
+		{"both", date(11), date(9), []string{"in the past", "after the due date"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DateWarnings(tt.planned, tt.due, today)
+			if len(got) != len(tt.want) {
+				t.Fatalf("DateWarnings() = %q, want %d warnings", got, len(tt.want))
+			}
+			for i, w := range tt.want {
+				if !strings.Contains(got[i], w) {
+					t.Errorf("warning %d = %q, want it to mention %q", i, got[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestDaysBetweenAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	// Clocks went forward on 2026-03-29 and back on 2026-10-25
+	tests := []struct {
+		from, to time.Time
+		want     int
+	}{
+		{time.Date(2026, 3, 28, 0, 0, 0, 0, berlin), time.Date(2026, 3, 30, 0, 0, 0, 0, berlin), 2},
+		{time.Date(2026, 10, 24, 0, 0, 0, 0, berlin), time.Date(2026, 10, 26, 0, 0, 0, 0, berlin), 2},
+		{time.Date(2026, 3, 30, 0, 0, 0, 0, berlin), time.Date(2026, 3, 28, 23, 0, 0, 0, berlin), -2},
+		{time.Date(2026, 10, 25, 23, 30, 0, 0, berlin), time.Date(2026, 10, 25, 0, 0, 0, 0, berlin), 0},
+	}
+	for _, tt := range tests {
+		if got := DaysBetween(tt.from, tt.to); got != tt.want {
+			t.Errorf("DaysBetween(%v, %v) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
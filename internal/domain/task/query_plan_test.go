@@ -0,0 +1,72 @@
+package task
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/testutil"
+)
+
+// TestListQueriesUseIndexes guards against list filters falling back to a
+// full scan of the tasks table, which gets slow on large databases
+func TestListQueriesUseIndexes(t *testing.T) {
+	db := testutil.NewTestDB(t)
+
+	id := int64(1)
+	today := time.Now()
+	until := today.AddDate(0, 0, 14)
+	list := func(filter *ListFilter) func() (string, []any) {
+		return func() (string, []any) { return listQuery(joinedTaskColumns, filter, today) }
+	}
+
+	tests := []struct {
+		name  string
+		query func() (string, []any)
+		want  string // index (or index name prefix) the plan must use
+	}{
+		{"list", list(nil), "idx_tasks_status_"},
+		{"someday", list(&ListFilter{State: StateSomeday}), "idx_tasks_status_state"},
+		{"project", list(&ListFilter{ParentID: &id}), "idx_tasks_parent_id"},
+		{"area", list(&ListFilter{AreaID: &id}), "idx_tasks_area_id"},
+		{"tag", list(&ListFilter{TagName: "work"}), "idx_task_tags_tag_name"},
+		{"today", list(&ListFilter{Today: true}), "idx_tasks_"},
+		{"upcoming", list(&ListFilter{Upcoming: true, Until: &until}), "idx_tasks_"},
+		{"week", list(&ListFilter{From: &today, To: &until}), "idx_tasks_"},
+		{"logbook", func() (string, []any) { return completedQuery(joinedTaskColumns, &CompletedFilter{Since: &today}) }, "idx_tasks_status_completed_at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := tt.query()
+			rows, err := db.Conn.Query("EXPLAIN QUERY PLAN "+query, args...)
+			if err != nil {
+				t.Fatalf("EXPLAIN QUERY PLAN error = %v", err)
+			}
+			defer rows.Close()
+
+			var plan []string
+			for rows.Next() {
+				var id, parent, notUsed int
+				var detail string
+				if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+					t.Fatal(err)
+				}
+				plan = append(plan, detail)
+			}
+			if err := rows.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			text := strings.Join(plan, "\n")
+			for _, step := range plan {
+				if step == "SCAN t" || strings.HasPrefix(step, "SCAN t ") {
+					t.Errorf("query scans the whole tasks table:\n%s", text)
+				}
+			}
+			if !strings.Contains(text, tt.want) {
+				t.Errorf("query plan doesn't use %s:\n%s", tt.want, text)
+			}
+		})
+	}
+}
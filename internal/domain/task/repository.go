@@ -3,25 +3,60 @@ package task
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/database"
 )
 
 var ErrTaskNotFound = errors.New("task not found")
 
+// NotFoundError is ErrTaskNotFound naming the task that wasn't found, for
+// commands that take several IDs
+type NotFoundError struct {
+	ID int64
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("task %d not found", e.ID)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrTaskNotFound
+}
+
 type Repository struct {
-	db *database.DB
+	db    *database.DB
+	clock clock.Clock
 }
 
 func NewRepository(db *database.DB) *Repository {
-	return &Repository{db: db}
+	return &Repository{db: db, clock: clock.System}
+}
+
+// SetClock sets the clock the schedule filters fall back to when a filter
+// has no reference date
+func (r *Repository) SetClock(c clock.Clock) {
+	r.clock = c
 }
 
 const dateFormat = "2006-01-02"
 
+// taskColumns lists the tasks columns read into a Task, in scan order
+const taskColumns = `id, uuid, title, description, task_type, parent_id, area_id, planned_date, due_date, state, status, created_at, completed_at, recur_type, recur_rule, recur_end, recur_paused, recur_parent_id, estimate, context, recur_template, recur_count, expires, assignee, creator, location, flagged, external_id, completion_note, block_start, block_minutes`
+
+// joinedTaskColumns is taskColumns for queries aliasing tasks as t and joining
+// parent/area names (see taskJoins), followed by the display name columns
+const joinedTaskColumns = `t.id, t.uuid, t.title, t.description, t.task_type, t.parent_id, t.area_id, t.planned_date, t.due_date, t.state, t.status, t.created_at, t.completed_at, t.recur_type, t.recur_rule, t.recur_end, t.recur_paused, t.recur_parent_id, t.estimate, t.context, t.recur_template, t.recur_count, t.expires, t.assignee, t.creator, t.location, t.flagged, t.external_id, t.completion_note, t.block_start, t.block_minutes, parent.title, COALESCE(a.name, parent_area.name)`
+
+// taskJoins resolves parent project and area names for joinedTaskColumns
+const taskJoins = ` LEFT JOIN tasks parent ON t.parent_id = parent.id LEFT JOIN areas a ON t.area_id = a.id LEFT JOIN areas parent_area ON parent.area_id = parent_area.id`
+
 func (r *Repository) Create(task *Task) error {
-	var plannedDate, dueDate, recurEnd *string
+	var plannedDate, dueDate, recurEnd, expires *string
 	if task.PlannedDate != nil {
 		s := task.PlannedDate.Format(dateFormat)
 		plannedDate = &s
@@ -34,6 +69,10 @@ func (r *Repository) Create(task *Task) error {
 		s := task.RecurEnd.Format(dateFormat)
 		recurEnd = &s
 	}
+	if task.Expires != nil {
+		s := task.Expires.Format(dateFormat)
+		expires = &s
+	}
 
 	// Default task_type to "task" if not set
 	taskType := task.TaskType
@@ -41,51 +80,74 @@ func (r *Repository) Create(task *Task) error {
 		taskType = TaskTypeTask
 	}
 
-	result, err := r.db.Conn.Exec(
-		`INSERT INTO tasks (uuid, title, description, task_type, parent_id, area_id, planned_date, due_date, state, status, created_at, recur_type, recur_rule, recur_end, recur_paused, recur_parent_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+	id, err := r.db.Insert(
+		`INSERT INTO tasks (uuid, title, description, task_type, parent_id, area_id, planned_date, due_date, state, status, created_at, recur_type, recur_rule, recur_end, recur_paused, recur_parent_id, estimate, context, recur_template, recur_count, expires, assignee, creator, location, flagged, external_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		task.UUID, task.Title, task.Description, taskType, task.ParentID, task.AreaID, plannedDate, dueDate, task.State, task.Status, task.CreatedAt.Format(time.RFC3339),
-		task.RecurType, task.RecurRule, recurEnd, task.RecurPaused, task.RecurParentID,
+		task.RecurType, task.RecurRule, recurEnd, task.RecurPaused, task.RecurParentID, task.Estimate, task.Context, task.RecurTemplate, task.RecurCount, expires, task.Assignee, task.Creator, task.Location, task.Flagged, task.ExternalID,
 	)
 	if err != nil {
 		return err
 	}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		return err
-	}
-
 	task.ID = id
 	task.TaskType = taskType
 	return nil
 }
 
 type ListFilter struct {
-	TaskType TaskType     // filter by task type ("task", "project", or empty for all)
-	ParentID *int64       // filter by parent project ID
+	TaskType TaskType // filter by task type ("task", "project", or empty for all)
+	ParentID *int64   // filter by parent project ID
 	AreaID   *int64
 	State    State        // filter by state (active, someday)
 	Today    bool         // planned_date = today OR overdue
 	Upcoming bool         // future planned/due dates
+	Date     *time.Time   // reference date for Today/Upcoming (nil = current date)
+	Until    *time.Time   // last date included in Upcoming and Due (nil = unbounded)
+	From     *time.Time   // planned or due on/after this date (used with To)
+	To       *time.Time   // planned or due on/before this date (used with From)
+	Due      bool         // has a due_date, overdue included
 	Anytime  bool         // no planned_date and no due_date (active only)
 	Inbox    bool         // no project, no area, no dates
 	TagName  string       // filter by tag
+	Context  string       // filter by context label
+	Assignee string       // filter by assignee
+	Location string       // filter by location
+	Flagged  bool         // only flagged tasks
 	Search   string       // case-insensitive title search
+	Series   *int64       // occurrences of the recurring series started by this task
 	Sort     []SortOption // sort options (default: created desc)
 }
 
-// buildOrderByClause builds the ORDER BY clause from sort options
+// CompletedFilter narrows the completed tasks returned by ListCompleted
+type CompletedFilter struct {
+	ParentID *int64     // filter by parent project ID
+	AreaID   *int64     // tasks in the area, directly or through their project
+	TagName  string     // filter by tag
+	Search   string     // case-insensitive title search
+	Since    *time.Time // completed at or after this time
+	Until    *time.Time // completed before this time
+}
+
+// referenceDate returns the date string the schedule filters compare
+// against: the filter's date, or now's
+func (f *ListFilter) referenceDate(now time.Time) string {
+	if f.Date != nil {
+		return f.Date.Format(dateFormat)
+	}
+	return now.Format(dateFormat)
+}
+
+// buildOrderByClause builds the ORDER BY clause from sort options, after
+// putting flagged tasks first
 func buildOrderByClause(filter *ListFilter) string {
 	sortOpts := DefaultSort()
 	if filter != nil && len(filter.Sort) > 0 {
 		sortOpts = filter.Sort
 	}
 
-	clause := " ORDER BY "
-	for i, opt := range sortOpts {
-		if i > 0 {
-			clause += ", "
-		}
+	clause := " ORDER BY t.flagged DESC"
+	for _, opt := range sortOpts {
+		clause += ", "
 		col := sortFieldToColumn(opt.Field)
 		dir := "ASC"
 		if opt.Direction == SortDesc {
@@ -134,10 +196,31 @@ func isNullableField(f SortField) bool {
 }
 
 func (r *Repository) List(filter *ListFilter) ([]Task, error) {
-	query := `SELECT t.id, t.uuid, t.title, t.description, t.task_type, t.parent_id, t.area_id, t.planned_date, t.due_date, t.state, t.status, t.created_at, t.completed_at, t.recur_type, t.recur_rule, t.recur_end, t.recur_paused, t.recur_parent_id, parent.title, COALESCE(a.name, parent_area.name) FROM tasks t`
-	query += ` LEFT JOIN tasks parent ON t.parent_id = parent.id`
-	query += ` LEFT JOIN areas a ON t.area_id = a.id`
-	query += ` LEFT JOIN areas parent_area ON parent.area_id = parent_area.id`
+	query, args := listQuery(joinedTaskColumns, filter, clock.Now(r.clock))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load tags for all tasks
+	if err := r.loadTagsForTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// listQuery builds the query selecting columns of the tasks matching filter,
+// with now as the reference date when the filter has none
+func listQuery(columns string, filter *ListFilter, now time.Time) (string, []any) {
+	query := `SELECT ` + columns + ` FROM tasks t` + taskJoins
 	args := []any{}
 
 	// Join with task_tags if filtering by tag
@@ -165,21 +248,44 @@ func (r *Repository) List(filter *ListFilter) ([]Task, error) {
 			query += ` AND t.area_id = ?`
 			args = append(args, *filter.AreaID)
 		}
+		if filter.Series != nil {
+			query += ` AND (t.id = ? OR t.recur_parent_id = ?)`
+			args = append(args, *filter.Series, *filter.Series)
+		}
 		if filter.State != "" {
 			query += ` AND t.state = ?`
 			args = append(args, filter.State)
 		}
 		if filter.Today {
 			// planned_date = today OR planned_date < today (overdue)
-			today := time.Now().Format("2006-01-02")
-			query += ` AND (date(t.planned_date) <= ? OR date(t.due_date) <= ?)`
+			today := filter.referenceDate(now)
+			query += ` AND (t.planned_date <= ? OR t.due_date <= ?)`
 			args = append(args, today, today)
 		}
 		if filter.Upcoming {
-			// future planned_date or due_date
-			today := time.Now().Format("2006-01-02")
-			query += ` AND (date(t.planned_date) > ? OR date(t.due_date) > ?)`
-			args = append(args, today, today)
+			// future planned_date or due_date, optionally bounded by Until
+			today := filter.referenceDate(now)
+			if filter.Until != nil {
+				until := filter.Until.Format(dateFormat)
+				query += ` AND ((t.planned_date > ? AND t.planned_date <= ?) OR (t.due_date > ? AND t.due_date <= ?))`
+				args = append(args, today, until, today, until)
+			} else {
+				query += ` AND (t.planned_date > ? OR t.due_date > ?)`
+				args = append(args, today, today)
+			}
+		}
+		if filter.From != nil && filter.To != nil {
+			from := filter.From.Format(dateFormat)
+			to := filter.To.Format(dateFormat)
+			query += ` AND ((t.planned_date BETWEEN ? AND ?) OR (t.due_date BETWEEN ? AND ?))`
+			args = append(args, from, to, from, to)
+		}
+		if filter.Due {
+			query += ` AND t.due_date IS NOT NULL`
+			if filter.Until != nil {
+				query += ` AND t.due_date <= ?`
+				args = append(args, filter.Until.Format(dateFormat))
+			}
 		}
 		if filter.Anytime {
 			// no planned_date and no due_date, must have parent or area (excludes inbox)
@@ -194,79 +300,83 @@ func (r *Repository) List(filter *ListFilter) ([]Task, error) {
 			query += ` AND t.parent_id IS NULL AND t.area_id IS NULL AND t.planned_date IS NULL AND t.due_date IS NULL AND t.state = ?`
 			args = append(args, StateActive)
 		}
+		if filter.Context != "" {
+			query += ` AND t.context = ?`
+			args = append(args, filter.Context)
+		}
+		if filter.Assignee != "" {
+			query += ` AND t.assignee = ?`
+			args = append(args, filter.Assignee)
+		}
+		if filter.Flagged {
+			query += ` AND t.flagged = ?`
+			args = append(args, true)
+		}
+		if filter.Location != "" {
+			query += ` AND LOWER(t.location) = LOWER(?)`
+			args = append(args, filter.Location)
+		}
 		if filter.Search != "" {
-			query += ` AND t.title LIKE ? COLLATE NOCASE`
+			query += ` AND LOWER(t.title) LIKE LOWER(?)`
 			args = append(args, "%"+filter.Search+"%")
 		}
 	}
 
 	query += buildOrderByClause(filter)
+	return query, args
+}
 
-	rows, err := r.db.Conn.Query(query, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+func (r *Repository) GetByID(id int64) (*Task, error) {
+	row := r.db.QueryRow(`SELECT `+taskColumns+` FROM tasks WHERE id = ?`, id)
 
-	tasks, err := scanTasks(rows)
+	t, err := scanTask(row, false)
 	if err != nil {
 		return nil, err
 	}
 
-	// Load tags for all tasks
-	if err := r.loadTagsForTasks(tasks); err != nil {
+	// Load tags
+	tags, err := r.getTagsForTask(id)
+	if err != nil {
 		return nil, err
 	}
+	t.Tags = tags
 
-	return tasks, nil
+	return t, nil
 }
 
-func (r *Repository) GetByID(id int64) (*Task, error) {
-	row := r.db.Conn.QueryRow(
-		`SELECT id, uuid, title, description, task_type, parent_id, area_id, planned_date, due_date, state, status, created_at, completed_at, recur_type, recur_rule, recur_end, recur_paused, recur_parent_id FROM tasks WHERE id = ?`,
-		id,
+func (r *Repository) Complete(id int64, completedAt time.Time) error {
+	result, err := r.db.Exec(
+		`UPDATE tasks SET status = ?, completed_at = ? WHERE id = ? AND status = ?`,
+		StatusDone, completedAt.Format(time.RFC3339), id, StatusTodo,
 	)
-
-	var t Task
-	var plannedDate, dueDate *string
-	var createdAt string
-	var completedAt *string
-	var recurEnd *string
-	if err := row.Scan(&t.ID, &t.UUID, &t.Title, &t.Description, &t.TaskType, &t.ParentID, &t.AreaID, &plannedDate, &dueDate, &t.State, &t.Status, &createdAt, &completedAt, &t.RecurType, &t.RecurRule, &recurEnd, &t.RecurPaused, &t.RecurParentID); err != nil {
-		return nil, err
-	}
-	if plannedDate != nil {
-		parsed, _ := time.Parse(dateFormat, *plannedDate)
-		t.PlannedDate = &parsed
-	}
-	if dueDate != nil {
-		parsed, _ := time.Parse(dateFormat, *dueDate)
-		t.DueDate = &parsed
-	}
-	t.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-	if completedAt != nil {
-		parsed, _ := time.Parse(time.RFC3339, *completedAt)
-		t.CompletedAt = &parsed
-	}
-	if recurEnd != nil {
-		parsed, _ := time.Parse(dateFormat, *recurEnd)
-		t.RecurEnd = &parsed
+	if err != nil {
+		return err
 	}
 
-	// Load tags
-	tags, err := r.getTagsForTask(id)
+	rows, err := result.RowsAffected()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if rows == 0 {
+		return ErrTaskNotFound
 	}
-	t.Tags = tags
 
-	return &t, nil
+	return nil
 }
 
-func (r *Repository) Complete(id int64, completedAt time.Time) error {
-	result, err := r.db.Conn.Exec(
+// Cancel marks an open task canceled, along with the open tasks of a project
+func (r *Repository) Cancel(id int64, canceledAt time.Time) error {
+	_, err := r.db.Exec(
+		`UPDATE tasks SET status = ?, completed_at = ? WHERE parent_id = ? AND status = ?`,
+		StatusCanceled, canceledAt.Format(time.RFC3339), id, StatusTodo,
+	)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
 		`UPDATE tasks SET status = ?, completed_at = ? WHERE id = ? AND status = ?`,
-		StatusDone, completedAt.Format(time.RFC3339), id, StatusTodo,
+		StatusCanceled, canceledAt.Format(time.RFC3339), id, StatusTodo,
 	)
 	if err != nil {
 		return err
@@ -283,10 +393,11 @@ func (r *Repository) Complete(id int64, completedAt time.Time) error {
 	return nil
 }
 
+// Uncomplete reopens a done or canceled task
 func (r *Repository) Uncomplete(id int64) error {
-	result, err := r.db.Conn.Exec(
-		`UPDATE tasks SET status = ?, completed_at = NULL WHERE id = ? AND status = ?`,
-		StatusTodo, id, StatusDone,
+	result, err := r.db.Exec(
+		`UPDATE tasks SET status = ?, completed_at = NULL, completion_note = NULL WHERE id = ? AND status IN (?, ?)`,
+		StatusTodo, id, StatusDone, StatusCanceled,
 	)
 	if err != nil {
 		return err
@@ -304,7 +415,7 @@ func (r *Repository) Uncomplete(id int64) error {
 }
 
 func (r *Repository) Delete(id int64) error {
-	result, err := r.db.Conn.Exec(`DELETE FROM tasks WHERE id = ?`, id)
+	result, err := r.db.Exec(`DELETE FROM tasks WHERE id = ?`, id)
 	if err != nil {
 		return err
 	}
@@ -320,33 +431,10 @@ func (r *Repository) Delete(id int64) error {
 	return nil
 }
 
-func (r *Repository) ListCompleted(since *time.Time) ([]Task, error) {
-	var rows *sql.Rows
-	var err error
-
-	if since != nil {
-		rows, err = r.db.Conn.Query(
-			`SELECT t.id, t.uuid, t.title, t.description, t.task_type, t.parent_id, t.area_id, t.planned_date, t.due_date, t.state, t.status, t.created_at, t.completed_at, t.recur_type, t.recur_rule, t.recur_end, t.recur_paused, t.recur_parent_id, parent.title, COALESCE(a.name, parent_area.name)
-			 FROM tasks t
-			 LEFT JOIN tasks parent ON t.parent_id = parent.id
-			 LEFT JOIN areas a ON t.area_id = a.id
-			 LEFT JOIN areas parent_area ON parent.area_id = parent_area.id
-			 WHERE t.status = ? AND t.completed_at >= ?
-			 ORDER BY t.completed_at DESC`,
-			StatusDone, since.Format(time.RFC3339),
-		)
-	} else {
-		rows, err = r.db.Conn.Query(
-			`SELECT t.id, t.uuid, t.title, t.description, t.task_type, t.parent_id, t.area_id, t.planned_date, t.due_date, t.state, t.status, t.created_at, t.completed_at, t.recur_type, t.recur_rule, t.recur_end, t.recur_paused, t.recur_parent_id, parent.title, COALESCE(a.name, parent_area.name)
-			 FROM tasks t
-			 LEFT JOIN tasks parent ON t.parent_id = parent.id
-			 LEFT JOIN areas a ON t.area_id = a.id
-			 LEFT JOIN areas parent_area ON parent.area_id = parent_area.id
-			 WHERE t.status = ?
-			 ORDER BY t.completed_at DESC`,
-			StatusDone,
-		)
-	}
+func (r *Repository) ListCompleted(filter *CompletedFilter) ([]Task, error) {
+	query, args := completedQuery(joinedTaskColumns, filter)
+
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -365,8 +453,52 @@ func (r *Repository) ListCompleted(since *time.Time) ([]Task, error) {
 	return tasks, nil
 }
 
+// completedQuery builds the query selecting columns of the completed tasks
+// matching filter
+func completedQuery(columns string, filter *CompletedFilter) (string, []any) {
+	query := `SELECT ` + columns + ` FROM tasks t` + taskJoins
+	args := []any{}
+
+	if filter != nil && filter.TagName != "" {
+		query += ` INNER JOIN task_tags tt ON t.id = tt.task_id`
+	}
+
+	query += ` WHERE t.status IN (?, ?)`
+	args = append(args, StatusDone, StatusCanceled)
+
+	if filter != nil {
+		if filter.ParentID != nil {
+			query += ` AND t.parent_id = ?`
+			args = append(args, *filter.ParentID)
+		}
+		if filter.AreaID != nil {
+			query += ` AND (t.area_id = ? OR parent.area_id = ?)`
+			args = append(args, *filter.AreaID, *filter.AreaID)
+		}
+		if filter.TagName != "" {
+			query += ` AND tt.tag_name = ?`
+			args = append(args, filter.TagName)
+		}
+		if filter.Search != "" {
+			query += ` AND LOWER(t.title) LIKE LOWER(?)`
+			args = append(args, "%"+filter.Search+"%")
+		}
+		if filter.Since != nil {
+			query += ` AND t.completed_at >= ?`
+			args = append(args, filter.Since.Format(time.RFC3339))
+		}
+		if filter.Until != nil {
+			query += ` AND t.completed_at < ?`
+			args = append(args, filter.Until.Format(time.RFC3339))
+		}
+	}
+
+	query += ` ORDER BY t.completed_at DESC`
+	return query, args
+}
+
 func (r *Repository) Update(task *Task) error {
-	var plannedDate, dueDate, recurEnd *string
+	var plannedDate, dueDate, recurEnd, expires, blockStart *string
 	if task.PlannedDate != nil {
 		s := task.PlannedDate.Format(dateFormat)
 		plannedDate = &s
@@ -379,10 +511,18 @@ func (r *Repository) Update(task *Task) error {
 		s := task.RecurEnd.Format(dateFormat)
 		recurEnd = &s
 	}
+	if task.Expires != nil {
+		s := task.Expires.Format(dateFormat)
+		expires = &s
+	}
+	if task.BlockStart != nil {
+		s := task.BlockStart.Format(time.RFC3339)
+		blockStart = &s
+	}
 
-	result, err := r.db.Conn.Exec(
-		`UPDATE tasks SET title = ?, description = ?, parent_id = ?, area_id = ?, planned_date = ?, due_date = ?, state = ?, recur_type = ?, recur_rule = ?, recur_end = ?, recur_paused = ? WHERE id = ?`,
-		task.Title, task.Description, task.ParentID, task.AreaID, plannedDate, dueDate, task.State, task.RecurType, task.RecurRule, recurEnd, task.RecurPaused, task.ID,
+	result, err := r.db.Exec(
+		`UPDATE tasks SET title = ?, description = ?, parent_id = ?, area_id = ?, planned_date = ?, due_date = ?, state = ?, recur_type = ?, recur_rule = ?, recur_end = ?, recur_paused = ?, estimate = ?, context = ?, recur_template = ?, recur_count = ?, expires = ?, assignee = ?, location = ?, flagged = ?, completion_note = ?, block_start = ?, block_minutes = ? WHERE id = ?`,
+		task.Title, task.Description, task.ParentID, task.AreaID, plannedDate, dueDate, task.State, task.RecurType, task.RecurRule, recurEnd, task.RecurPaused, task.Estimate, task.Context, task.RecurTemplate, task.RecurCount, expires, task.Assignee, task.Location, task.Flagged, task.CompletionNote, blockStart, task.BlockMinutes, task.ID,
 	)
 	if err != nil {
 		return err
@@ -402,40 +542,119 @@ func (r *Repository) Update(task *Task) error {
 func scanTasks(rows *sql.Rows) ([]Task, error) {
 	var tasks []Task
 	for rows.Next() {
-		var t Task
-		var plannedDate, dueDate *string
-		var createdAt string
-		var completedAt *string
-		var recurEnd *string
-		if err := rows.Scan(&t.ID, &t.UUID, &t.Title, &t.Description, &t.TaskType, &t.ParentID, &t.AreaID, &plannedDate, &dueDate, &t.State, &t.Status, &createdAt, &completedAt, &t.RecurType, &t.RecurRule, &recurEnd, &t.RecurPaused, &t.RecurParentID, &t.ParentName, &t.AreaName); err != nil {
+		t, err := scanTask(rows, true)
+		if err != nil {
 			return nil, err
 		}
-		if plannedDate != nil {
-			parsed, _ := time.Parse(dateFormat, *plannedDate)
-			t.PlannedDate = &parsed
-		}
-		if dueDate != nil {
-			parsed, _ := time.Parse(dateFormat, *dueDate)
-			t.DueDate = &parsed
+		tasks = append(tasks, *t)
+	}
+
+	return tasks, rows.Err()
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask scans taskColumns into a Task, or joinedTaskColumns when withNames
+// is set. Any columns selected after those are scanned into extra.
+func scanTask(row rowScanner, withNames bool, extra ...any) (*Task, error) {
+	var t Task
+	var plannedDate, dueDate *string
+	var createdAt string
+	var completedAt *string
+	var recurEnd, expires, blockStart *string
+	dest := []any{&t.ID, &t.UUID, &t.Title, &t.Description, &t.TaskType, &t.ParentID, &t.AreaID, &plannedDate, &dueDate, &t.State, &t.Status, &createdAt, &completedAt, &t.RecurType, &t.RecurRule, &recurEnd, &t.RecurPaused, &t.RecurParentID, &t.Estimate, &t.Context, &t.RecurTemplate, &t.RecurCount, &expires, &t.Assignee, &t.Creator, &t.Location, &t.Flagged, &t.ExternalID, &t.CompletionNote, &blockStart, &t.BlockMinutes}
+	if withNames {
+		dest = append(dest, &t.ParentName, &t.AreaName)
+	}
+	dest = append(dest, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if plannedDate != nil {
+		parsed, _ := time.Parse(dateFormat, *plannedDate)
+		t.PlannedDate = &parsed
+	}
+	if dueDate != nil {
+		parsed, _ := time.Parse(dateFormat, *dueDate)
+		t.DueDate = &parsed
+	}
+	t.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	if completedAt != nil {
+		parsed, _ := time.Parse(time.RFC3339, *completedAt)
+		t.CompletedAt = &parsed
+	}
+	if recurEnd != nil {
+		parsed, _ := time.Parse(dateFormat, *recurEnd)
+		t.RecurEnd = &parsed
+	}
+	if expires != nil {
+		parsed, _ := time.Parse(dateFormat, *expires)
+		t.Expires = &parsed
+	}
+	if blockStart != nil {
+		parsed, _ := time.Parse(time.RFC3339, *blockStart)
+		t.BlockStart = &parsed
+	}
+	return &t, nil
+}
+
+// tagSeparator separates the tags selected by tagsColumn
+const tagSeparator = "\x1f"
+
+// tagsColumn selects a task's tags as one string, separated by tagSeparator,
+// so streamed rows don't need a query of their own for them
+func (r *Repository) tagsColumn() string {
+	if r.db.Driver == database.Postgres {
+		return `(SELECT string_agg(tag_name, chr(31)) FROM task_tags WHERE task_id = t.id)`
+	}
+	return `(SELECT group_concat(tag_name, char(31)) FROM task_tags WHERE task_id = t.id)`
+}
+
+// ListIter calls fn for each task matching filter as the rows are read,
+// instead of loading them all into memory, for exports of large databases.
+// It stops at the first error fn returns.
+func (r *Repository) ListIter(filter *ListFilter, fn func(Task) error) error {
+	query, args := listQuery(joinedTaskColumns+", "+r.tagsColumn(), filter, clock.Now(r.clock))
+	return r.iterate(query, args, fn)
+}
+
+// ListCompletedIter is ListIter for completed tasks
+func (r *Repository) ListCompletedIter(filter *CompletedFilter, fn func(Task) error) error {
+	query, args := completedQuery(joinedTaskColumns+", "+r.tagsColumn(), filter)
+	return r.iterate(query, args, fn)
+}
+
+// iterate runs a query selecting joinedTaskColumns and tagsColumn, and calls fn per row
+func (r *Repository) iterate(query string, args []any, fn func(Task) error) error {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tags *string
+		t, err := scanTask(rows, true, &tags)
+		if err != nil {
+			return err
 		}
-		t.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-		if completedAt != nil {
-			parsed, _ := time.Parse(time.RFC3339, *completedAt)
-			t.CompletedAt = &parsed
+		if tags != nil {
+			t.Tags = strings.Split(*tags, tagSeparator)
+			slices.Sort(t.Tags)
 		}
-		if recurEnd != nil {
-			parsed, _ := time.Parse(dateFormat, *recurEnd)
-			t.RecurEnd = &parsed
+		if err := fn(*t); err != nil {
+			return err
 		}
-		tasks = append(tasks, t)
 	}
-
-	return tasks, rows.Err()
+	return rows.Err()
 }
 
 // getTagsForTask returns all tag names for a single task
 func (r *Repository) getTagsForTask(taskID int64) ([]string, error) {
-	rows, err := r.db.Conn.Query(`SELECT tag_name FROM task_tags WHERE task_id = ? ORDER BY tag_name`, taskID)
+	rows, err := r.db.Query(`SELECT tag_name FROM task_tags WHERE task_id = ? ORDER BY tag_name`, taskID)
 	if err != nil {
 		return nil, err
 	}
@@ -472,7 +691,7 @@ func (r *Repository) loadTagsForTasks(tasks []Task) error {
 		placeholders += ",?"
 	}
 
-	rows, err := r.db.Conn.Query(
+	rows, err := r.db.Query(
 		`SELECT task_id, tag_name FROM task_tags WHERE task_id IN (`+placeholders+`) ORDER BY tag_name`,
 		ids...,
 	)
@@ -496,8 +715,8 @@ func (r *Repository) loadTagsForTasks(tasks []Task) error {
 
 // AddTag adds a tag to a task
 func (r *Repository) AddTag(taskID int64, tagName string) error {
-	_, err := r.db.Conn.Exec(
-		`INSERT OR IGNORE INTO task_tags (task_id, tag_name) VALUES (?, ?)`,
+	_, err := r.db.Exec(
+		`INSERT INTO task_tags (task_id, tag_name) VALUES (?, ?) ON CONFLICT DO NOTHING`,
 		taskID, tagName,
 	)
 	return err
@@ -505,7 +724,7 @@ func (r *Repository) AddTag(taskID int64, tagName string) error {
 
 // RemoveTag removes a tag from a task
 func (r *Repository) RemoveTag(taskID int64, tagName string) error {
-	_, err := r.db.Conn.Exec(
+	_, err := r.db.Exec(
 		`DELETE FROM task_tags WHERE task_id = ? AND tag_name = ?`,
 		taskID, tagName,
 	)
@@ -514,7 +733,7 @@ func (r *Repository) RemoveTag(taskID int64, tagName string) error {
 
 // ListTags returns all unique tags in use
 func (r *Repository) ListTags() ([]string, error) {
-	rows, err := r.db.Conn.Query(`SELECT DISTINCT tag_name FROM task_tags ORDER BY tag_name`)
+	rows, err := r.db.Query(`SELECT DISTINCT tag_name FROM task_tags ORDER BY tag_name`)
 	if err != nil {
 		return nil, err
 	}
@@ -531,16 +750,140 @@ func (r *Repository) ListTags() ([]string, error) {
 	return tags, rows.Err()
 }
 
+// ListLocations returns the locations of open tasks
+func (r *Repository) ListLocations() ([]string, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT location FROM tasks WHERE location IS NOT NULL AND status = ? ORDER BY location`, StatusTodo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var locations []string
+	for rows.Next() {
+		var location string
+		if err := rows.Scan(&location); err != nil {
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+	return locations, rows.Err()
+}
+
+// SetTagColor sets the color of a tag; an empty color removes it
+func (r *Repository) SetTagColor(name, color string) error {
+	if color == "" {
+		_, err := r.db.Exec(`DELETE FROM tags WHERE name = ?`, name)
+		return err
+	}
+	_, err := r.db.Exec(
+		`INSERT INTO tags (name, color) VALUES (?, ?) ON CONFLICT (name) DO UPDATE SET color = excluded.color`,
+		name, color,
+	)
+	return err
+}
+
+// TagColors returns the colors of all tags that have one
+func (r *Repository) TagColors() (map[string]string, error) {
+	rows, err := r.db.Query(`SELECT name, color FROM tags`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	colors := map[string]string{}
+	for rows.Next() {
+		var name, color string
+		if err := rows.Scan(&name, &color); err != nil {
+			return nil, err
+		}
+		colors[name] = color
+	}
+	return colors, rows.Err()
+}
+
+// SetDayOrder pins the order of the given tasks at the top of the day's
+// Today list, replacing any earlier order. Orders of past days are dropped.
+func (r *Repository) SetDayOrder(day time.Time, ids []int64) error {
+	key := day.Format(dateFormat)
+	if _, err := r.db.Exec(`DELETE FROM day_order WHERE day <= ?`, key); err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if _, err := r.db.Exec(
+			`INSERT INTO day_order (day, task_id, position) VALUES (?, ?, ?)`,
+			key, id, i,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DayOrder returns the IDs pinned for the day, in order
+func (r *Repository) DayOrder(day time.Time) ([]int64, error) {
+	rows, err := r.db.Query(`SELECT task_id FROM day_order WHERE day = ? ORDER BY position`, day.Format(dateFormat))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// SetDependencies replaces the tasks that have to be done before a task
+// can start
+func (r *Repository) SetDependencies(taskID int64, dependsOn []int64) error {
+	if _, err := r.db.Exec(`DELETE FROM task_dependencies WHERE task_id = ?`, taskID); err != nil {
+		return err
+	}
+	for _, id := range dependsOn {
+		if _, err := r.db.Exec(
+			`INSERT INTO task_dependencies (task_id, depends_on_id) VALUES (?, ?)`,
+			taskID, id,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dependencies returns the IDs of the tasks a task depends on, in order
+func (r *Repository) Dependencies(taskID int64) ([]int64, error) {
+	rows, err := r.db.Query(`SELECT depends_on_id FROM task_dependencies WHERE task_id = ? ORDER BY depends_on_id`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 // SetTags replaces all tags on a task
 func (r *Repository) SetTags(taskID int64, tags []string) error {
 	// Delete existing tags
-	if _, err := r.db.Conn.Exec(`DELETE FROM task_tags WHERE task_id = ?`, taskID); err != nil {
+	if _, err := r.db.Exec(`DELETE FROM task_tags WHERE task_id = ?`, taskID); err != nil {
 		return err
 	}
 
 	// Insert new tags
 	for _, tag := range tags {
-		if _, err := r.db.Conn.Exec(
+		if _, err := r.db.Exec(
 			`INSERT INTO task_tags (task_id, tag_name) VALUES (?, ?)`,
 			taskID, tag,
 		); err != nil {
@@ -552,54 +895,51 @@ func (r *Repository) SetTags(taskID int64, tags []string) error {
 
 // GetByName finds a task by title and type (for project lookup)
 func (r *Repository) GetByName(name string, taskType TaskType) (*Task, error) {
-	row := r.db.Conn.QueryRow(
-		`SELECT id, uuid, title, description, task_type, parent_id, area_id, planned_date, due_date, state, status, created_at, completed_at, recur_type, recur_rule, recur_end, recur_paused, recur_parent_id FROM tasks WHERE title = ? AND task_type = ?`,
-		name, taskType,
-	)
+	row := r.db.QueryRow(`SELECT `+taskColumns+` FROM tasks WHERE title = ? AND task_type = ?`, name, taskType)
 
-	var t Task
-	var plannedDate, dueDate *string
-	var createdAt string
-	var completedAt *string
-	var recurEnd *string
-	if err := row.Scan(&t.ID, &t.UUID, &t.Title, &t.Description, &t.TaskType, &t.ParentID, &t.AreaID, &plannedDate, &dueDate, &t.State, &t.Status, &createdAt, &completedAt, &t.RecurType, &t.RecurRule, &recurEnd, &t.RecurPaused, &t.RecurParentID); err != nil {
+	t, err := scanTask(row, false)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrTaskNotFound
 		}
 		return nil, err
 	}
-	if plannedDate != nil {
-		parsed, _ := time.Parse(dateFormat, *plannedDate)
-		t.PlannedDate = &parsed
-	}
-	if dueDate != nil {
-		parsed, _ := time.Parse(dateFormat, *dueDate)
-		t.DueDate = &parsed
-	}
-	t.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-	if completedAt != nil {
-		parsed, _ := time.Parse(time.RFC3339, *completedAt)
-		t.CompletedAt = &parsed
+
+	// Load tags
+	tags, err := r.getTagsForTask(t.ID)
+	if err != nil {
+		return nil, err
 	}
-	if recurEnd != nil {
-		parsed, _ := time.Parse(dateFormat, *recurEnd)
-		t.RecurEnd = &parsed
+	t.Tags = tags
+
+	return t, nil
+}
+
+// GetByExternalID returns the task imported with the given external ID
+func (r *Repository) GetByExternalID(externalID string) (*Task, error) {
+	row := r.db.QueryRow(`SELECT `+taskColumns+` FROM tasks WHERE external_id = ?`, externalID)
+
+	t, err := scanTask(row, false)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
 	}
 
-	// Load tags
 	tags, err := r.getTagsForTask(t.ID)
 	if err != nil {
 		return nil, err
 	}
 	t.Tags = tags
 
-	return &t, nil
+	return t, nil
 }
 
 // CompleteWithChildren completes a task and all its child tasks (for projects)
 func (r *Repository) CompleteWithChildren(id int64, completedAt time.Time) error {
 	// Complete all child tasks first
-	_, err := r.db.Conn.Exec(
+	_, err := r.db.Exec(
 		`UPDATE tasks SET status = ?, completed_at = ? WHERE parent_id = ? AND status = ?`,
 		StatusDone, completedAt.Format(time.RFC3339), id, StatusTodo,
 	)
@@ -608,7 +948,7 @@ func (r *Repository) CompleteWithChildren(id int64, completedAt time.Time) error
 	}
 
 	// Complete the parent task
-	result, err := r.db.Conn.Exec(
+	result, err := r.db.Exec(
 		`UPDATE tasks SET status = ?, completed_at = ? WHERE id = ? AND status = ?`,
 		StatusDone, completedAt.Format(time.RFC3339), id, StatusTodo,
 	)
@@ -627,6 +967,99 @@ func (r *Repository) CompleteWithChildren(id int64, completedAt time.Time) error
 	return nil
 }
 
+// CountOccurrences returns how many occurrences the recurring series started
+// by root has had, done or not
+func (r *Repository) CountOccurrences(root int64) (int, error) {
+	var n int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM tasks WHERE id = ? OR recur_parent_id = ?`, root, root).Scan(&n)
+	return n, err
+}
+
+// Counts is how many tasks and projects are stored, by status
+type Counts struct {
+	Open     int // to do, including someday
+	Someday  int
+	Done     int
+	Projects int // open and done
+}
+
+// Counts tallies tasks by status and projects in a single pass
+func (r *Repository) Counts() (Counts, error) {
+	var c Counts
+	err := r.db.QueryRow(`
+		SELECT
+			COUNT(CASE WHEN task_type = ? AND status = ? THEN 1 END),
+			COUNT(CASE WHEN task_type = ? AND status = ? AND state = ? THEN 1 END),
+			COUNT(CASE WHEN task_type = ? AND status = ? THEN 1 END),
+			COUNT(CASE WHEN task_type = ? THEN 1 END)
+		FROM tasks`,
+		TaskTypeTask, StatusTodo,
+		TaskTypeTask, StatusTodo, StateSomeday,
+		TaskTypeTask, StatusDone,
+		TaskTypeProject,
+	).Scan(&c.Open, &c.Someday, &c.Done, &c.Projects)
+	return c, err
+}
+
+// ListExpired returns open tasks whose expires date is before today
+func (r *Repository) ListExpired(today time.Time) ([]Task, error) {
+	rows, err := r.db.Query(
+		`SELECT `+joinedTaskColumns+` FROM tasks t`+taskJoins+` WHERE t.status = ? AND t.expires IS NOT NULL AND t.expires < ? ORDER BY t.expires, t.id`,
+		StatusTodo, today.Format(dateFormat),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadTagsForTasks(tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListArchivable returns tasks that can be archived: tasks completed before
+// completedBefore, and someday tasks created before somedayBefore. A nil
+// cutoff leaves that kind out. Projects and tasks that started a recurring
+// series with other occurrences are never included.
+func (r *Repository) ListArchivable(completedBefore, somedayBefore *time.Time) ([]Task, error) {
+	var conds []string
+	var args []any
+	if completedBefore != nil {
+		conds = append(conds, `(t.status IN (?, ?) AND t.completed_at < ?)`)
+		args = append(args, StatusDone, StatusCanceled, completedBefore.Format(time.RFC3339))
+	}
+	if somedayBefore != nil {
+		conds = append(conds, `(t.status = ? AND t.state = ? AND t.created_at < ?)`)
+		args = append(args, StatusTodo, StateSomeday, somedayBefore.Format(time.RFC3339))
+	}
+	if len(conds) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT ` + joinedTaskColumns + ` FROM tasks t` + taskJoins +
+		` WHERE t.task_type = ? AND NOT EXISTS (SELECT 1 FROM tasks o WHERE o.recur_parent_id = t.id)` +
+		` AND (` + strings.Join(conds, ` OR `) + `) ORDER BY t.id`
+	rows, err := r.db.Query(query, append([]any{TaskTypeTask}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadTagsForTasks(tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
 // ListChildren returns all child tasks of a given parent (project)
 func (r *Repository) ListChildren(parentID int64) ([]Task, error) {
 	return r.List(&ListFilter{ParentID: &parentID, TaskType: TaskTypeTask})
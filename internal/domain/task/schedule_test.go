@@ -0,0 +1,110 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleSettings_Today(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings ScheduleSettings
+		now      time.Time
+		want     string
+	}{
+		{
+			name:     "no rollover uses calendar date",
+			settings: ScheduleSettings{},
+			now:      time.Date(2025, 3, 10, 1, 30, 0, 0, time.Local),
+			want:     "2025-03-10",
+		},
+		{
+			name:     "before rollover counts as previous day",
+			settings: ScheduleSettings{DayRolloverHour: 4},
+			now:      time.Date(2025, 3, 10, 3, 59, 0, 0, time.Local),
+			want:     "2025-03-09",
+		},
+		{
+			name:     "at rollover starts the new day",
+			settings: ScheduleSettings{DayRolloverHour: 4},
+			now:      time.Date(2025, 3, 10, 4, 0, 0, 0, time.Local),
+			want:     "2025-03-10",
+		},
+		{
+			name:     "rollover crosses month boundary",
+			settings: ScheduleSettings{DayRolloverHour: 4},
+			now:      time.Date(2025, 3, 1, 2, 0, 0, 0, time.Local),
+			want:     "2025-02-28",
+		},
+		{
+			name:     "out of range hour is ignored",
+			settings: ScheduleSettings{DayRolloverHour: 30},
+			now:      time.Date(2025, 3, 10, 2, 0, 0, 0, time.Local),
+			want:     "2025-03-10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.settings.Today(tt.now).Format("2006-01-02")
+			if got != tt.want {
+				t.Errorf("Today() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleSettings_UpcomingUntil(t *testing.T) {
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+
+	if got := (ScheduleSettings{}).UpcomingUntil(today); got != nil {
+		t.Errorf("UpcomingUntil() = %v, want nil for unbounded", got)
+	}
+
+	got := ScheduleSettings{UpcomingDays: 14}.UpcomingUntil(today)
+	if got == nil {
+		t.Fatal("UpcomingUntil() = nil, want date")
+	}
+	if got.Format("2006-01-02") != "2025-03-24" {
+		t.Errorf("UpcomingUntil() = %s, want 2025-03-24", got.Format("2006-01-02"))
+	}
+}
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{input: "30m", want: 30},
+		{input: "2h", want: 120},
+		{input: "1h30m", want: 90},
+		{input: "45", want: 45},
+		{input: " 1H ", want: 60},
+		{input: "", wantErr: true},
+		{input: "0", wantErr: true},
+		{input: "30s", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseEstimate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEstimate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEstimate(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatEstimate(t *testing.T) {
+	tests := map[int]string{45: "45m", 60: "1h", 90: "1h30m", 150: "2h30m"}
+	for minutes, want := range tests {
+		if got := FormatEstimate(minutes); got != want {
+			t.Errorf("FormatEstimate(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
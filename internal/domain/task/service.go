@@ -0,0 +1,113 @@
+package task
+
+import (
+	"io"
+	"time"
+)
+
+// Service is what the CLI and TUI use to work with tasks. The task use
+// cases implement it (see app.TaskService); tests can swap in a fake, and
+// other backends can provide their own.
+type Service interface {
+	Create(title string, opts *CreateOptions) (*Task, error)
+	// Upsert creates the task, or updates the one with the same
+	// opts.ExternalID; created reports which
+	Upsert(title string, opts *CreateOptions) (t *Task, created bool, err error)
+	Get(id int64) (*Task, error)
+	List(opts *ListOptions) ([]Task, error)
+	// Each calls fn for each task List would return, stopping at the first error
+	Each(opts *ListOptions, fn func(Task) error) error
+	// Week returns the week containing today, shifted by offset weeks
+	Week(offset int, sort []SortOption) (*Week, error)
+	// WeekFrom returns the seven days beginning at start
+	WeekFrom(start time.Time, sort []SortOption) (*Week, error)
+	// Due returns open tasks with a due date, up to until if given
+	Due(until *time.Time) ([]Task, error)
+	SuggestNext(opts SuggestOptions) (*Suggestion, error)
+	Count() (Counts, error)
+	// WIP returns the open active task counts of the areas and projects
+	// with a WIP limit
+	WIP() ([]WIPCount, error)
+	// WIPFor returns the counts of the limited area and project a task is
+	// in, counting it as active even if it's not
+	WIPFor(id int64) ([]WIPCount, error)
+	// Lint looks for tasks and projects that are probably filed by mistake
+	// and tags used only once; projects without open tasks are reported
+	// once they're older than emptyDays
+	Lint(emptyDays int) (*LintReport, error)
+
+	Complete(ids []int64) ([]CompleteResult, error)
+	// Uncomplete reopens tasks, deleting the next occurrence completing a
+	// recurring one created while it's still open
+	Uncomplete(ids []int64) ([]UncompleteResult, error)
+	// Cancel marks tasks as won't do; Uncomplete reopens them
+	Cancel(ids []int64) ([]CompleteResult, error)
+	// SetCompletionNote sets the note on a completed or canceled task; nil clears it
+	SetCompletionNote(id int64, note *string) (*Task, error)
+	// Split breaks a task into new tasks with the given titles, or its open
+	// checklist items, completing it unless keep is set
+	Split(id int64, titles []string, keep bool) ([]Task, error)
+	Delete(ids []int64) ([]Task, error)
+	ListCompleted(opts *CompletedOptions) ([]Task, error)
+	EachCompleted(opts *CompletedOptions, fn func(Task) error) error
+	Defer(id int64) (*Task, error)
+	Activate(id int64) (*Task, error)
+	// Expire handles open tasks past their expires date
+	Expire() ([]Task, error)
+	// Archive writes old completed and stale someday tasks to w and removes them
+	Archive(w io.Writer, completedBefore, somedayBefore *time.Time) ([]Task, error)
+
+	SetTitle(id int64, title string) (*Task, error)
+	SetDescription(id int64, description *string) (*Task, error)
+	SetProject(id int64, projectName string) (*Task, error)
+	SetArea(id int64, areaName string) (*Task, error)
+	SetPlannedDate(id int64, date *time.Time) (*Task, error)
+	SetDueDate(id int64, date *time.Time) (*Task, error)
+	SetEstimate(id int64, minutes *int) (*Task, error)
+	SetContext(id int64, context string) (*Task, error)
+	SetAssignee(id int64, assignee string) (*Task, error)
+	SetLocation(id int64, location string) (*Task, error)
+	SetFlagged(ids []int64, flagged bool) ([]Task, error)
+	OrderToday(ids []int64) error
+	SetExpires(id int64, date *time.Time) (*Task, error)
+	// SetTimeBlock sets aside minutes from start to work on a task, planning
+	// it for that day; a nil start clears the block
+	SetTimeBlock(id int64, start *time.Time, minutes int) (*Task, error)
+	// PauseForVacation pauses fixed recurrences and moves the tasks planned
+	// from today through until to the day after; dryRun only reports it
+	PauseForVacation(until time.Time, dryRun bool) (*VacationPlan, error)
+	// SetDependencies sets the tasks that have to be done before a task can
+	// start; no IDs clear them
+	SetDependencies(id int64, dependsOn []int64) (*Task, error)
+	// PlanProject proposes planned dates for a project's open tasks in
+	// dependency order, capacity minutes a day; apply plans them
+	PlanProject(name string, capacity int, apply bool) (*ProjectPlan, error)
+
+	SetRecurrence(id int64, recurType, recurRule *string, recurEnd *time.Time, recurCount *int) (*Task, error)
+	PauseRecurrence(id int64) (*Task, error)
+	ResumeRecurrence(id int64) (*Task, error)
+	SetRecurrenceEnd(id int64, endDate *time.Time) (*Task, error)
+	SetRecurrenceCount(id int64, count *int) (*Task, error)
+	KeepRecurTemplate(id int64) (*Task, error)
+	PropagateRecurEdit(id int64, fields []string) (int, error)
+
+	AddTag(id int64, tagName string) (*Task, error)
+	RemoveTag(id int64, tagName string) (*Task, error)
+	SetTags(id int64, tags []string) (*Task, error)
+	Tags() ([]string, error)
+	Locations() ([]string, error)
+	SetTagColor(name, color string) error
+	TagColors() (map[string]string, error)
+}
+
+// ProjectService is what the CLI and TUI use to work with projects
+type ProjectService interface {
+	Create(name string, opts *CreateProjectOptions) (*Task, error)
+	Get(name string) (*Task, error)
+	// List returns the active projects
+	List() ([]Task, error)
+	// ListAll returns projects in any state
+	ListAll() ([]Task, error)
+	// ListWithArea returns the active projects with their area names
+	ListWithArea() ([]Task, error)
+}
@@ -1,12 +1,16 @@
 package task_test
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/domain/task"
-	"github.com/devbydaniel/tt/internal/domain/task/usecases"
 	"github.com/devbydaniel/tt/internal/testutil"
 )
 
@@ -82,6 +86,298 @@ func TestTaskCompleteNonexistent(t *testing.T) {
 	}
 }
 
+func TestMissingIDChangesNothing(t *testing.T) {
+	application := setupApp(t)
+
+	first, _ := application.CreateTask.Execute("First", nil)
+	gone, _ := application.CreateTask.Execute("Gone", nil)
+	last, _ := application.CreateTask.Execute("Last", nil)
+	application.DeleteTasks.Execute([]int64{gone.ID})
+	ids := []int64{first.ID, gone.ID, last.ID}
+	want := fmt.Sprintf("task %d not found", gone.ID)
+
+	for name, run := range map[string]func() error{
+		"complete": func() error { _, err := application.CompleteTasks.Execute(ids); return err },
+		"cancel":   func() error { _, err := application.CancelTasks.Execute(ids); return err },
+		"delete":   func() error { _, err := application.DeleteTasks.Execute(ids); return err },
+	} {
+		err := run()
+		if !errors.Is(err, task.ErrTaskNotFound) || err.Error() != want {
+			t.Errorf("%s: error = %v, want %q", name, err, want)
+		}
+	}
+	if open, _ := application.ListTasks.Execute(nil); len(open) != 2 {
+		t.Errorf("%d open tasks after failed commands, want 2", len(open))
+	}
+
+	application.CompleteTasks.Execute([]int64{first.ID, last.ID})
+	if _, err := application.UncompleteTasks.Execute(ids); err == nil || err.Error() != want {
+		t.Errorf("uncomplete: error = %v, want %q", err, want)
+	}
+	if done, _ := application.ListCompletedTasks.Execute(nil); len(done) != 2 {
+		t.Errorf("%d done tasks after failed uncomplete, want 2", len(done))
+	}
+}
+
+func TestTaskCompletionNote(t *testing.T) {
+	application := setupApp(t)
+
+	created, _ := application.CreateTask.Execute("Release", nil)
+	note := "deployed as v1.4"
+	if _, err := application.SetCompletionNote.Execute(created.ID, &note); err == nil {
+		t.Error("SetCompletionNote() should error for an open task")
+	}
+
+	application.CompleteTasks.Execute([]int64{created.ID})
+	if _, err := application.SetCompletionNote.Execute(created.ID, &note); err != nil {
+		t.Fatalf("SetCompletionNote() error = %v", err)
+	}
+	got, _ := application.GetTask.Execute(created.ID)
+	if got.CompletionNote == nil || *got.CompletionNote != note {
+		t.Errorf("CompletionNote = %v, want %q", got.CompletionNote, note)
+	}
+
+	// Reopening the task drops the note along with the completion
+	application.UncompleteTasks.Execute([]int64{created.ID})
+	got, _ = application.GetTask.Execute(created.ID)
+	if got.CompletionNote != nil {
+		t.Errorf("CompletionNote = %q after uncomplete, want nil", *got.CompletionNote)
+	}
+}
+
+func TestTaskTimeBlock(t *testing.T) {
+	application := setupApp(t)
+
+	created, _ := application.CreateTask.Execute("Write report", &task.CreateOptions{Someday: true})
+	start := time.Date(2026, 3, 12, 14, 0, 0, 0, time.Local)
+	if _, err := application.SetTimeBlock.Execute(created.ID, &start, 90); err != nil {
+		t.Fatalf("SetTimeBlock() error = %v", err)
+	}
+
+	// The block plans the task for its day
+	got, _ := application.GetTask.Execute(created.ID)
+	if got.BlockStart == nil || !got.BlockStart.Equal(start) || got.BlockMinutes == nil || *got.BlockMinutes != 90 {
+		t.Errorf("block = %v for %v, want %v for 90", got.BlockStart, got.BlockMinutes, start)
+	}
+	if got.PlannedDate == nil || got.PlannedDate.Format("2006-01-02") != "2026-03-12" || got.State != task.StateActive {
+		t.Errorf("planned = %v, state %q, want 2026-03-12, active", got.PlannedDate, got.State)
+	}
+
+	if _, err := application.SetTimeBlock.Execute(created.ID, nil, 0); err != nil {
+		t.Fatalf("SetTimeBlock(nil) error = %v", err)
+	}
+	got, _ = application.GetTask.Execute(created.ID)
+	if got.BlockStart != nil || got.BlockMinutes != nil || got.PlannedDate == nil {
+		t.Errorf("after clearing: block = %v for %v, planned %v", got.BlockStart, got.BlockMinutes, got.PlannedDate)
+	}
+
+	application.CompleteTasks.Execute([]int64{created.ID})
+	if _, err := application.SetTimeBlock.Execute(created.ID, &start, 30); err == nil {
+		t.Error("SetTimeBlock() should error for a completed task")
+	}
+}
+
+func TestPauseForVacation(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	now := time.Date(2025, 8, 4, 9, 0, 0, 0, time.Local)
+	application := app.NewWithOptions(db, app.Options{Clock: clock.Fixed(now)})
+
+	recurType := task.RecurTypeFixed
+	recurRule := `{"interval":1,"unit":"week"}`
+	chore, _ := application.CreateTask.Execute("Take out bins", &task.CreateOptions{
+		RecurType: &recurType,
+		RecurRule: &recurRule,
+	})
+	inWindow := time.Date(2025, 8, 12, 0, 0, 0, 0, time.Local)
+	during, _ := application.CreateTask.Execute("Call plumber", &task.CreateOptions{PlannedDate: &inWindow})
+	later := time.Date(2025, 8, 20, 0, 0, 0, 0, time.Local)
+	after, _ := application.CreateTask.Execute("Book flights", &task.CreateOptions{PlannedDate: &later})
+
+	until := time.Date(2025, 8, 15, 0, 0, 0, 0, time.Local)
+	plan, err := application.PauseForVacation.Execute(until, true)
+	if err != nil {
+		t.Fatalf("PauseForVacation(dry run) error = %v", err)
+	}
+	if len(plan.Paused) != 1 || plan.Paused[0].ID != chore.ID || len(plan.Shifted) != 1 || plan.Shifted[0].ID != during.ID {
+		t.Errorf("plan = paused %v, shifted %v, want the chore and the call", plan.Paused, plan.Shifted)
+	}
+	// A dry run changes nothing
+	if got, _ := application.GetTask.Execute(chore.ID); got.RecurPaused {
+		t.Error("dry run paused the recurrence")
+	}
+
+	if _, err := application.PauseForVacation.Execute(until, false); err != nil {
+		t.Fatalf("PauseForVacation() error = %v", err)
+	}
+	if got, _ := application.GetTask.Execute(chore.ID); !got.RecurPaused {
+		t.Error("recurrence should be paused")
+	}
+	if got, _ := application.GetTask.Execute(during.ID); got.PlannedDate == nil || got.PlannedDate.Format("2006-01-02") != "2025-08-16" {
+		t.Errorf("planned = %v, want 2025-08-16", got.PlannedDate)
+	}
+	if got, _ := application.GetTask.Execute(after.ID); got.PlannedDate == nil || !got.PlannedDate.Equal(later) {
+		t.Errorf("planned = %v, want it left at %v", got.PlannedDate, later)
+	}
+
+	if _, err := application.PauseForVacation.Execute(now.AddDate(0, 0, -1), false); err == nil {
+		t.Error("PauseForVacation() should error for a day that has passed")
+	}
+}
+
+func TestPlanProject(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	monday := time.Date(2025, 8, 4, 9, 0, 0, 0, time.Local)
+	application := app.NewWithOptions(db, app.Options{Clock: clock.Fixed(monday)})
+
+	application.CreateProject.Execute("Launch", nil)
+	create := func(title string, minutes int) int64 {
+		created, _ := application.CreateTask.Execute(title, &task.CreateOptions{ProjectName: "Launch", Estimate: &minutes})
+		return created.ID
+	}
+	text := create("Write text", 120)
+	signup := create("Order domain", 60)
+	page := create("Design page", 180)
+	build := create("Build page", 360)
+	if _, err := application.SetDependencies.Execute(page, []int64{text}); err != nil {
+		t.Fatalf("SetDependencies() error = %v", err)
+	}
+	application.SetDependencies.Execute(build, []int64{page})
+
+	// A task can't end up depending on itself
+	if _, err := application.SetDependencies.Execute(text, []int64{build}); err == nil {
+		t.Error("SetDependencies() should error for a cycle")
+	}
+
+	plan, err := application.PlanProject.Execute("Launch", 240, false)
+	if err != nil {
+		t.Fatalf("PlanProject() error = %v", err)
+	}
+	want := map[int64][2]string{
+		text:   {"2025-08-04", "2025-08-04"},
+		signup: {"2025-08-04", "2025-08-04"},
+		page:   {"2025-08-05", "2025-08-05"}, // no room left on Monday
+		build:  {"2025-08-05", "2025-08-07"}, // runs over three days
+	}
+	var order []int64
+	for _, s := range plan.Steps {
+		order = append(order, s.Task.ID)
+		if got := [2]string{s.Start.Format("2006-01-02"), s.Finish.Format("2006-01-02")}; got != want[s.Task.ID] {
+			t.Errorf("#%d planned %v, want %v", s.Task.ID, got, want[s.Task.ID])
+		}
+	}
+	if !slices.Equal(order, []int64{text, signup, page, build}) {
+		t.Errorf("order = %v, want %v", order, []int64{text, signup, page, build})
+	}
+	if got, _ := application.GetTask.Execute(build); got.PlannedDate != nil {
+		t.Error("plan without apply should change nothing")
+	}
+
+	if _, err := application.PlanProject.Execute("Launch", 240, true); err != nil {
+		t.Fatalf("PlanProject(apply) error = %v", err)
+	}
+	if got, _ := application.GetTask.Execute(build); got.PlannedDate == nil || got.PlannedDate.Format("2006-01-02") != "2025-08-05" {
+		t.Errorf("planned = %v, want 2025-08-05", got.PlannedDate)
+	}
+}
+
+func TestTaskCancel(t *testing.T) {
+	application := setupApp(t)
+
+	created, _ := application.CreateTask.Execute("Old idea", nil)
+	results, err := application.CancelTasks.Execute([]int64{created.ID})
+	if err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if got := results[0].Completed; got.Status != task.StatusCanceled || got.CompletedAt == nil {
+		t.Errorf("Status = %q, CompletedAt = %v, want canceled with a time", got.Status, got.CompletedAt)
+	}
+
+	// Listed in the logbook, but not counted as done
+	logbook, _ := application.ListCompletedTasks.Execute(nil)
+	if len(logbook) != 1 || logbook[0].ID != created.ID {
+		t.Errorf("logbook = %v, want the canceled task", logbook)
+	}
+	counts, _ := application.CountTasks.Execute()
+	if counts.Done != 0 || counts.Open != 0 {
+		t.Errorf("counts = %+v, want none open or done", counts)
+	}
+	if _, err := application.CompleteTasks.Execute([]int64{created.ID}); err == nil {
+		t.Error("Complete() should error for a canceled task")
+	}
+
+	reopened, err := application.UncompleteTasks.Execute([]int64{created.ID})
+	if err != nil {
+		t.Fatalf("Uncomplete() error = %v", err)
+	}
+	if got := reopened[0].Reopened; got.Status != task.StatusTodo || got.CompletedAt != nil {
+		t.Errorf("Status = %q, CompletedAt = %v, want reopened", got.Status, got.CompletedAt)
+	}
+}
+
+func TestTaskCancelRecurring(t *testing.T) {
+	application := setupApp(t)
+
+	recurType := task.RecurTypeFixed
+	recurRule := `{"interval":1,"unit":"day"}`
+	created, _ := application.CreateTask.Execute("Water plants", &task.CreateOptions{
+		RecurType: &recurType,
+		RecurRule: &recurRule,
+	})
+
+	// Canceling skips this occurrence only
+	results, err := application.CancelTasks.Execute([]int64{created.ID})
+	if err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if results[0].NextTask == nil {
+		t.Fatal("NextTask should be set for a canceled recurring task")
+	}
+}
+
+func TestTaskRecurRotation(t *testing.T) {
+	application := setupApp(t)
+
+	recurType := task.RecurTypeFixed
+	start := func(rule string) *task.Task {
+		created, _ := application.CreateTask.Execute("Take out trash", &task.CreateOptions{
+			RecurType: &recurType,
+			RecurRule: &rule,
+			Tags:      []string{"home", "ben"},
+			Assignee:  "ben",
+		})
+		return created
+	}
+	next := func(current *task.Task) *task.Task {
+		results, err := application.CompleteTasks.Execute([]int64{current.ID})
+		if err != nil {
+			t.Fatalf("Complete() error = %v", err)
+		}
+		return results[0].NextTask
+	}
+
+	// Each occurrence is tagged with the next one in turn, starting over
+	// after the last; other tags are kept
+	current := start(`{"interval":1,"unit":"week","rotate":{"field":"tag","values":["anna","ben","cara"]}}`)
+	for _, want := range []string{"cara", "anna", "ben"} {
+		current = next(current)
+		if !slices.Equal(current.Tags, []string{"home", want}) {
+			t.Errorf("next occurrence tagged %v, want [home %s]", current.Tags, want)
+		}
+	}
+
+	// Or assigned to them
+	current = start(`{"interval":1,"unit":"week","rotate":{"field":"assignee","values":["anna","ben","cara"]}}`)
+	for _, want := range []string{"cara", "anna", "ben"} {
+		current = next(current)
+		if current.Assignee == nil || *current.Assignee != want {
+			t.Errorf("next occurrence assigned to %v, want %s", current.Assignee, want)
+		}
+		if !slices.Equal(current.Tags, []string{"ben", "home"}) {
+			t.Errorf("tags = %v, want them kept", current.Tags)
+		}
+	}
+}
+
 func TestTaskCompleteAlreadyDone(t *testing.T) {
 	application := setupApp(t)
 
@@ -125,7 +421,7 @@ func TestTaskDeleteNonexistent(t *testing.T) {
 	application := setupApp(t)
 
 	_, err := application.DeleteTasks.Execute([]int64{999})
-	if err != task.ErrTaskNotFound {
+	if !errors.Is(err, task.ErrTaskNotFound) {
 		t.Errorf("Delete() error = %v, want ErrTaskNotFound", err)
 	}
 }
@@ -157,9 +453,9 @@ func TestTaskListCompletedSince(t *testing.T) {
 	t1, _ := application.CreateTask.Execute("Old task", nil)
 	application.CompleteTasks.Execute([]int64{t1.ID})
 
-	// Use a time in the future to filter
-	future := time.Now().Add(time.Hour)
-	completed, err := application.ListCompletedTasks.Execute(&future)
+	// Use a date in the future to filter
+	future := time.Now().AddDate(0, 0, 1)
+	completed, err := application.ListCompletedTasks.Execute(&task.CompletedOptions{Since: &future})
 	if err != nil {
 		t.Fatalf("ListCompleted() error = %v", err)
 	}
@@ -167,6 +463,51 @@ func TestTaskListCompletedSince(t *testing.T) {
 	if len(completed) != 0 {
 		t.Errorf("got %d completed tasks, want 0 (filtered by since)", len(completed))
 	}
+
+	// Since and until are whole days, so today's completions match both
+	today := time.Now()
+	completed, err = application.ListCompletedTasks.Execute(&task.CompletedOptions{Since: &today, Until: &today})
+	if err != nil {
+		t.Fatalf("ListCompleted() error = %v", err)
+	}
+	if len(completed) != 1 {
+		t.Errorf("got %d completed tasks, want 1 (completed today)", len(completed))
+	}
+}
+
+func TestTaskListCompletedFilters(t *testing.T) {
+	application := setupApp(t)
+
+	application.CreateArea.Execute("Clients")
+	application.CreateProject.Execute("ClientX", &task.CreateProjectOptions{AreaName: "Clients"})
+	inProject, _ := application.CreateTask.Execute("Write invoice", &task.CreateOptions{ProjectName: "ClientX", Tags: []string{"billing"}})
+	inArea, _ := application.CreateTask.Execute("Call accountant", &task.CreateOptions{AreaName: "Clients"})
+	other, _ := application.CreateTask.Execute("Water plants", nil)
+	application.CompleteTasks.Execute([]int64{inProject.ID, inArea.ID, other.ID})
+
+	tests := []struct {
+		name string
+		opts *task.CompletedOptions
+		want int
+	}{
+		{"project", &task.CompletedOptions{ProjectName: "ClientX"}, 1},
+		{"area includes its projects", &task.CompletedOptions{AreaName: "Clients"}, 2},
+		{"tag", &task.CompletedOptions{TagName: "billing"}, 1},
+		{"search", &task.CompletedOptions{Search: "PLANTS"}, 1},
+	}
+	for _, tt := range tests {
+		completed, err := application.ListCompletedTasks.Execute(tt.opts)
+		if err != nil {
+			t.Fatalf("%s: ListCompleted() error = %v", tt.name, err)
+		}
+		if len(completed) != tt.want {
+			t.Errorf("%s: got %d completed tasks, want %d", tt.name, len(completed), tt.want)
+		}
+	}
+
+	if _, err := application.ListCompletedTasks.Execute(&task.CompletedOptions{ProjectName: "Nope"}); err == nil {
+		t.Error("expected error for unknown project")
+	}
 }
 
 func TestTaskCompleteMultiple(t *testing.T) {
@@ -353,7 +694,7 @@ func TestCascadeDeleteArea(t *testing.T) {
 	application := setupApp(t)
 
 	application.CreateArea.Execute("Work")
-	application.CreateProject.Execute("Project in Work", &usecases.CreateProjectOptions{AreaName: "Work"})
+	application.CreateProject.Execute("Project in Work", &task.CreateProjectOptions{AreaName: "Work"})
 	application.CreateTask.Execute("Task in project", &task.CreateOptions{ProjectName: "Project in Work"})
 	application.CreateTask.Execute("Task in area directly", &task.CreateOptions{AreaName: "Work"})
 	application.CreateTask.Execute("Standalone", nil)
@@ -392,7 +733,7 @@ func TestProjectWithArea(t *testing.T) {
 
 	application.CreateArea.Execute("Work")
 
-	proj, err := application.CreateProject.Execute("Important Project", &usecases.CreateProjectOptions{AreaName: "Work"})
+	proj, err := application.CreateProject.Execute("Important Project", &task.CreateProjectOptions{AreaName: "Work"})
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -405,7 +746,7 @@ func TestProjectWithArea(t *testing.T) {
 func TestProjectWithNonexistentArea(t *testing.T) {
 	application := setupApp(t)
 
-	_, err := application.CreateProject.Execute("Project", &usecases.CreateProjectOptions{AreaName: "Nonexistent"})
+	_, err := application.CreateProject.Execute("Project", &task.CreateProjectOptions{AreaName: "Nonexistent"})
 	if err == nil {
 		t.Error("Create() should error for nonexistent area")
 	}
@@ -456,6 +797,46 @@ func TestRecurringTaskRegeneration(t *testing.T) {
 	}
 }
 
+func TestUncompleteRemovesRegeneratedTask(t *testing.T) {
+	application := setupApp(t)
+
+	recurType := task.RecurTypeFixed
+	recurRule := `{"interval":1,"unit":"day"}`
+	created, _ := application.CreateTask.Execute("Daily standup", &task.CreateOptions{
+		RecurType: &recurType,
+		RecurRule: &recurRule,
+	})
+
+	completed, _ := application.CompleteTasks.Execute([]int64{created.ID})
+	next := completed[0].NextTask
+
+	results, err := application.UncompleteTasks.Execute([]int64{created.ID})
+	if err != nil {
+		t.Fatalf("Uncomplete() error = %v", err)
+	}
+	if results[0].Removed == nil || results[0].Removed.ID != next.ID {
+		t.Fatalf("Removed = %v, want #%d", results[0].Removed, next.ID)
+	}
+	if _, err := application.GetTask.Execute(next.ID); err == nil {
+		t.Error("the regenerated task should be deleted")
+	}
+
+	// Once the next occurrence is done too, it's kept
+	completed, _ = application.CompleteTasks.Execute([]int64{created.ID})
+	next = completed[0].NextTask
+	application.CompleteTasks.Execute([]int64{next.ID})
+	results, err = application.UncompleteTasks.Execute([]int64{created.ID})
+	if err != nil {
+		t.Fatalf("Uncomplete() error = %v", err)
+	}
+	if results[0].Removed != nil {
+		t.Errorf("Removed = #%d, want none", results[0].Removed.ID)
+	}
+	if _, err := application.GetTask.Execute(next.ID); err != nil {
+		t.Errorf("the done occurrence should be kept: %v", err)
+	}
+}
+
 func TestNonRecurringTaskNoRegeneration(t *testing.T) {
 	application := setupApp(t)
 
@@ -503,7 +884,7 @@ func TestSetRecurrence(t *testing.T) {
 	recurType := task.RecurTypeRelative
 	recurRule := `{"interval":3,"unit":"day"}`
 
-	updated, err := application.SetRecurrence.Execute(created.ID, &recurType, &recurRule, nil)
+	updated, err := application.SetRecurrence.Execute(created.ID, &recurType, &recurRule, nil, nil)
 	if err != nil {
 		t.Fatalf("SetRecurrence() error = %v", err)
 	}
@@ -527,7 +908,7 @@ func TestClearRecurrence(t *testing.T) {
 	})
 
 	// Clear recurrence
-	updated, err := application.SetRecurrence.Execute(created.ID, nil, nil, nil)
+	updated, err := application.SetRecurrence.Execute(created.ID, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("SetRecurrence() error = %v", err)
 	}
@@ -683,6 +1064,240 @@ func TestRecurringTaskCopyTags(t *testing.T) {
 	}
 }
 
+func TestRecurringEditThisOnly(t *testing.T) {
+	application := setupApp(t)
+
+	recurType := task.RecurTypeFixed
+	recurRule := `{"interval":1,"unit":"week"}`
+	created, _ := application.CreateTask.Execute("Water plants", &task.CreateOptions{
+		RecurType: &recurType,
+		RecurRule: &recurRule,
+		Tags:      []string{"home"},
+	})
+
+	if _, err := application.KeepRecurTemplate.Execute(created.ID); err != nil {
+		t.Fatalf("KeepRecurTemplate() error = %v", err)
+	}
+	application.SetTaskTitle.Execute(created.ID, "Water plants (balcony)")
+	application.AddTag.Execute(created.ID, "outside")
+
+	results, err := application.CompleteTasks.Execute([]int64{created.ID})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	next := results[0].NextTask
+	if next == nil {
+		t.Fatal("NextTask should be set for recurring task")
+	}
+	if next.Title != "Water plants" {
+		t.Errorf("NextTask.Title = %q, want %q", next.Title, "Water plants")
+	}
+	if len(next.Tags) != 1 || next.Tags[0] != "home" {
+		t.Errorf("NextTask.Tags = %v, want [home]", next.Tags)
+	}
+	if next.RecurTemplate != nil {
+		t.Error("NextTask should not carry over the stored template")
+	}
+}
+
+func TestRecurringEditAllFuture(t *testing.T) {
+	application := setupApp(t)
+
+	recurType := task.RecurTypeFixed
+	recurRule := `{"interval":1,"unit":"week"}`
+	estimate := 15
+	created, _ := application.CreateTask.Execute("Water plnats", &task.CreateOptions{
+		RecurType: &recurType,
+		RecurRule: &recurRule,
+		Estimate:  &estimate,
+	})
+
+	// A this-only edit followed by a correction for all future occurrences
+	application.KeepRecurTemplate.Execute(created.ID)
+	application.SetEstimate.Execute(created.ID, nil)
+	application.SetTaskTitle.Execute(created.ID, "Water plants")
+	application.AddTag.Execute(created.ID, "home")
+	if _, err := application.PropagateRecurEdit.Execute(created.ID, []string{task.FieldTitle, task.FieldTags}); err != nil {
+		t.Fatalf("PropagateRecurEdit() error = %v", err)
+	}
+
+	results, err := application.CompleteTasks.Execute([]int64{created.ID})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	next := results[0].NextTask
+	if next == nil {
+		t.Fatal("NextTask should be set for recurring task")
+	}
+	if next.Title != "Water plants" {
+		t.Errorf("NextTask.Title = %q, want %q", next.Title, "Water plants")
+	}
+	if len(next.Tags) != 1 || next.Tags[0] != "home" {
+		t.Errorf("NextTask.Tags = %v, want [home]", next.Tags)
+	}
+	if next.Estimate == nil || *next.Estimate != 15 {
+		t.Errorf("NextTask.Estimate = %v, want 15 (this-only edit)", next.Estimate)
+	}
+
+	// Editing the new occurrence updates the open one only
+	application.SetTaskTitle.Execute(next.ID, "Water all plants")
+	updated, err := application.PropagateRecurEdit.Execute(next.ID, []string{task.FieldTitle})
+	if err != nil {
+		t.Fatalf("PropagateRecurEdit() error = %v", err)
+	}
+	if updated != 0 {
+		t.Errorf("PropagateRecurEdit() updated %d occurrences, want 0", updated)
+	}
+	done, _ := application.GetTask.Execute(created.ID)
+	if done.Title != "Water plants" {
+		t.Errorf("completed occurrence Title = %q, want %q", done.Title, "Water plants")
+	}
+}
+
+func TestRecurringTaskAhead(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	application := app.NewWithOptions(db, app.Options{
+		Recurrence: task.RecurrenceSettings{Ahead: true},
+	})
+
+	recurType := task.RecurTypeFixed
+	recurRule := `{"interval":1,"unit":"week"}`
+	due := time.Date(2030, 1, 7, 0, 0, 0, 0, time.Local)
+	created, _ := application.CreateTask.Execute("Team meeting", &task.CreateOptions{
+		RecurType: &recurType,
+		RecurRule: &recurRule,
+		DueDate:   &due,
+		Tags:      []string{"work"},
+	})
+
+	dueDates := func() []string {
+		t.Helper()
+		tasks, err := application.ListTasks.Execute(&task.ListOptions{Sort: []task.SortOption{{Field: task.SortByDue}}})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		var dates []string
+		for _, tk := range tasks {
+			if tk.DueDate != nil {
+				dates = append(dates, tk.DueDate.Format("2006-01-02"))
+			}
+		}
+		return dates
+	}
+
+	// The next occurrence exists right after creation
+	if got := dueDates(); len(got) != 2 || got[0] != "2030-01-07" || got[1] != "2030-01-14" {
+		t.Fatalf("due dates after create = %v, want [2030-01-07 2030-01-14]", got)
+	}
+
+	// Completing one occurrence schedules the one after the next
+	results, err := application.CompleteTasks.Execute([]int64{created.ID})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	next := results[0].NextTask
+	if next == nil {
+		t.Fatal("NextTask should be set for recurring task")
+	}
+	if next.DueDate == nil || next.DueDate.Format("2006-01-02") != "2030-01-21" {
+		t.Errorf("NextTask.DueDate = %v, want 2030-01-21", next.DueDate)
+	}
+	if len(next.Tags) != 1 || next.Tags[0] != "work" {
+		t.Errorf("NextTask.Tags = %v, want [work]", next.Tags)
+	}
+	if got := dueDates(); len(got) != 2 || got[0] != "2030-01-14" || got[1] != "2030-01-21" {
+		t.Errorf("due dates after complete = %v, want [2030-01-14 2030-01-21]", got)
+	}
+
+	// Setting the recurrence again doesn't add more occurrences
+	if _, err := application.SetRecurrence.Execute(next.ID, &recurType, &recurRule, nil, nil); err != nil {
+		t.Fatalf("SetRecurrence() error = %v", err)
+	}
+	if got := dueDates(); len(got) != 2 {
+		t.Errorf("due dates after SetRecurrence = %v, want 2 occurrences", got)
+	}
+}
+
+func TestRecurringTaskHolidays(t *testing.T) {
+	for _, tt := range []struct {
+		mode string
+		want string
+	}{
+		{task.HolidaySkip, "2030-01-21"},
+		{task.HolidayShift, "2030-01-15"},
+	} {
+		t.Run(tt.mode, func(t *testing.T) {
+			db := testutil.NewTestDB(t)
+			application := app.NewWithOptions(db, app.Options{
+				Recurrence: task.RecurrenceSettings{Ahead: true, HolidayMode: tt.mode},
+			})
+
+			holiday := time.Date(2030, 1, 14, 0, 0, 0, 0, time.Local)
+			if _, err := application.AddHoliday.Execute(holiday, "Team offsite"); err != nil {
+				t.Fatalf("AddHoliday() error = %v", err)
+			}
+
+			recurType := task.RecurTypeFixed
+			recurRule := `{"interval":1,"unit":"week","weekdays":["mon"]}`
+			due := time.Date(2030, 1, 7, 0, 0, 0, 0, time.Local)
+			created, _ := application.CreateTask.Execute("Standup", &task.CreateOptions{
+				RecurType: &recurType,
+				RecurRule: &recurRule,
+				DueDate:   &due,
+			})
+
+			tasks, err := application.ListTasks.Execute(nil)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			for _, tk := range tasks {
+				if tk.ID == created.ID {
+					continue
+				}
+				if got := tk.DueDate.Format("2006-01-02"); got != tt.want {
+					t.Errorf("next occurrence due %s, want %s", got, tt.want)
+				}
+			}
+			if len(tasks) != 2 {
+				t.Errorf("got %d tasks, want 2", len(tasks))
+			}
+		})
+	}
+}
+
+func TestRecurringTaskCount(t *testing.T) {
+	application := setupApp(t)
+
+	recurType := task.RecurTypeFixed
+	recurRule := `{"interval":1,"unit":"day"}`
+	count := 2
+	created, _ := application.CreateTask.Execute("Physio exercises", &task.CreateOptions{
+		RecurType:  &recurType,
+		RecurRule:  &recurRule,
+		RecurCount: &count,
+	})
+
+	results, err := application.CompleteTasks.Execute([]int64{created.ID})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	next := results[0].NextTask
+	if next == nil {
+		t.Fatal("NextTask should be set for the first of 2 occurrences")
+	}
+	if next.RecurCount == nil || *next.RecurCount != 2 {
+		t.Errorf("NextTask.RecurCount = %v, want 2", next.RecurCount)
+	}
+
+	results, err = application.CompleteTasks.Execute([]int64{next.ID})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if results[0].NextTask != nil {
+		t.Error("NextTask should be nil after the last occurrence")
+	}
+}
+
 func TestSetTitle(t *testing.T) {
 	application := setupApp(t)
 
@@ -981,3 +1596,848 @@ func TestListDefaultSort(t *testing.T) {
 	// With same created_at, order depends on ID (which is also desc in the CASE expression)
 	// The important thing is it doesn't error
 }
+
+func TestUpcomingRespectsHorizon(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	application := app.NewWithOptions(db, app.Options{
+		Schedule: task.ScheduleSettings{UpcomingDays: 7},
+	})
+
+	soon := time.Now().AddDate(0, 0, 3)
+	later := time.Now().AddDate(0, 0, 30)
+	application.CreateTask.Execute("Soon", &task.CreateOptions{PlannedDate: &soon})
+	application.CreateTask.Execute("Later", &task.CreateOptions{PlannedDate: &later})
+
+	tasks, err := application.ListTasks.Execute(&task.ListOptions{Schedule: "upcoming"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(tasks) != 1 {
+		t.Fatalf("got %d tasks, want 1", len(tasks))
+	}
+	if tasks[0].Title != "Soon" {
+		t.Errorf("tasks[0].Title = %q, want %q", tasks[0].Title, "Soon")
+	}
+
+	// Grouping by schedule must still place the far-off task somewhere
+	all, err := application.ListTasks.Execute(&task.ListOptions{Schedule: "upcoming", AllUpcoming: true})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("got %d tasks with AllUpcoming, want 2", len(all))
+	}
+}
+
+func TestListWeek(t *testing.T) {
+	application := setupApp(t)
+
+	today := time.Now()
+	nextWeek := today.AddDate(0, 0, 7)
+	application.CreateTask.Execute("This week", &task.CreateOptions{PlannedDate: &today})
+	application.CreateTask.Execute("Due this week", &task.CreateOptions{DueDate: &today})
+	application.CreateTask.Execute("Next week", &task.CreateOptions{PlannedDate: &nextWeek})
+	application.CreateTask.Execute("Unscheduled", nil)
+
+	week, err := application.ListWeek.Execute(0, nil)
+	if err != nil {
+		t.Fatalf("ListWeek() error = %v", err)
+	}
+
+	if week.Start.Weekday() != time.Monday {
+		t.Errorf("Start weekday = %v, want Monday", week.Start.Weekday())
+	}
+
+	dayIndex := (int(today.Weekday()) + 6) % 7
+	if len(week.Days[dayIndex]) != 2 {
+		t.Fatalf("got %d tasks today, want 2", len(week.Days[dayIndex]))
+	}
+
+	total := 0
+	for _, day := range week.Days {
+		total += len(day)
+	}
+	if total != 2 {
+		t.Errorf("got %d tasks in week, want 2", total)
+	}
+
+	next, err := application.ListWeek.Execute(1, nil)
+	if err != nil {
+		t.Fatalf("ListWeek(1) error = %v", err)
+	}
+	if len(next.Days[dayIndex]) != 1 || next.Days[dayIndex][0].Title != "Next week" {
+		t.Errorf("next week day %d = %v, want [Next week]", dayIndex, next.Days[dayIndex])
+	}
+}
+
+func TestListDue(t *testing.T) {
+	application := setupApp(t)
+
+	today := time.Now()
+	yesterday := today.AddDate(0, 0, -1)
+	nextWeek := today.AddDate(0, 0, 7)
+	application.CreateTask.Execute("Next week", &task.CreateOptions{DueDate: &nextWeek})
+	application.CreateTask.Execute("Overdue", &task.CreateOptions{DueDate: &yesterday})
+	application.CreateTask.Execute("Due today", &task.CreateOptions{DueDate: &today})
+	application.CreateTask.Execute("Planned only", &task.CreateOptions{PlannedDate: &today})
+
+	tasks, err := application.ListDue.Execute(nil)
+	if err != nil {
+		t.Fatalf("ListDue() error = %v", err)
+	}
+	var titles []string
+	for _, tk := range tasks {
+		titles = append(titles, tk.Title)
+	}
+	want := []string{"Overdue", "Due today", "Next week"}
+	if !slices.Equal(titles, want) {
+		t.Errorf("ListDue() = %v, want %v", titles, want)
+	}
+
+	until := today.AddDate(0, 0, 3)
+	tasks, err = application.ListDue.Execute(&until)
+	if err != nil {
+		t.Fatalf("ListDue(until) error = %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("ListDue(until) returned %d tasks, want 2", len(tasks))
+	}
+}
+
+func TestTaskEstimate(t *testing.T) {
+	application := setupApp(t)
+
+	estimate := 45
+	created, err := application.CreateTask.Execute("Write report", &task.CreateOptions{Estimate: &estimate})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := application.GetTask.Execute(created.ID)
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if got.Estimate == nil || *got.Estimate != 45 {
+		t.Fatalf("Estimate = %v, want 45", got.Estimate)
+	}
+
+	updated, err := application.SetEstimate.Execute(created.ID, nil)
+	if err != nil {
+		t.Fatalf("SetEstimate() error = %v", err)
+	}
+	if updated.Estimate != nil {
+		t.Errorf("Estimate = %v, want nil after clearing", *updated.Estimate)
+	}
+}
+
+func TestFilterByContext(t *testing.T) {
+	application := setupApp(t)
+
+	application.CreateTask.Execute("Write design doc", &task.CreateOptions{Context: "deep"})
+	application.CreateTask.Execute("Call plumber", &task.CreateOptions{Context: "call"})
+	application.CreateTask.Execute("No context", nil)
+
+	tasks, err := application.ListTasks.Execute(&task.ListOptions{Context: "deep"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Write design doc" {
+		t.Fatalf("got %v, want [Write design doc]", tasks)
+	}
+
+	if _, err := application.CreateTask.Execute("Bad", &task.CreateOptions{Context: "focus"}); err == nil {
+		t.Error("expected error for invalid context")
+	}
+}
+
+func TestAssigneeAndCreator(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	application := app.NewWithOptions(db, app.Options{User: "alice"})
+
+	created, err := application.CreateTask.Execute("Fix the sink", &task.CreateOptions{Assignee: "bob"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	application.CreateTask.Execute("Buy milk", nil)
+
+	got, err := application.GetTask.Execute(created.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Assignee == nil || *got.Assignee != "bob" || got.Creator == nil || *got.Creator != "alice" {
+		t.Errorf("Assignee, Creator = %v, %v; want bob, alice", got.Assignee, got.Creator)
+	}
+
+	tasks, err := application.ListTasks.Execute(&task.ListOptions{Assignee: "bob"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Fix the sink" {
+		t.Fatalf("got %v, want [Fix the sink]", tasks)
+	}
+
+	cleared, err := application.SetAssignee.Execute(created.ID, "")
+	if err != nil {
+		t.Fatalf("SetAssignee() error = %v", err)
+	}
+	if cleared.Assignee != nil {
+		t.Errorf("Assignee = %q after clearing, want nil", *cleared.Assignee)
+	}
+}
+
+func TestLocation(t *testing.T) {
+	application := setupApp(t)
+
+	created, err := application.CreateTask.Execute("Water the plants", &task.CreateOptions{Location: "@home"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.Location == nil || *created.Location != "home" {
+		t.Fatalf("Location = %v, want home", created.Location)
+	}
+	office, _ := application.CreateTask.Execute("Print slides", nil)
+	application.SetLocation.Execute(office.ID, "Office")
+
+	tasks, err := application.ListTasks.Execute(&task.ListOptions{Location: "@HOME"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Water the plants" {
+		t.Errorf("got %v, want [Water the plants]", tasks)
+	}
+
+	locations, err := application.ListLocations.Execute()
+	if err != nil {
+		t.Fatalf("ListLocations() error = %v", err)
+	}
+	if !slices.Equal(locations, []string{"Office", "home"}) {
+		t.Errorf("locations = %v, want [Office home]", locations)
+	}
+
+	cleared, _ := application.SetLocation.Execute(office.ID, "")
+	if cleared.Location != nil {
+		t.Errorf("Location = %q after clearing, want nil", *cleared.Location)
+	}
+}
+
+func TestTagColors(t *testing.T) {
+	application := setupApp(t)
+
+	if err := application.SetTagColor.Execute("#urgent", "#ff5555"); err != nil {
+		t.Fatalf("SetTagColor() error = %v", err)
+	}
+	application.SetTagColor.Execute("home", "green")
+	application.SetTagColor.Execute("urgent", "red")
+
+	colors, err := application.ListTagColors.Execute()
+	if err != nil {
+		t.Fatalf("ListTagColors() error = %v", err)
+	}
+	if len(colors) != 2 || colors["urgent"] != "red" || colors["home"] != "green" {
+		t.Errorf("colors = %v, want urgent=red home=green", colors)
+	}
+
+	application.SetTagColor.Execute("home", "")
+	if colors, _ := application.ListTagColors.Execute(); len(colors) != 1 {
+		t.Errorf("colors = %v after clearing home, want only urgent", colors)
+	}
+}
+
+func TestFlagged(t *testing.T) {
+	application := setupApp(t)
+
+	application.CreateTask.Execute("First", nil)
+	second, _ := application.CreateTask.Execute("Second", nil)
+	if _, err := application.SetFlagged.Execute([]int64{second.ID}, true); err != nil {
+		t.Fatalf("SetFlagged() error = %v", err)
+	}
+
+	tasks, err := application.ListTasks.Execute(&task.ListOptions{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Title != "Second" || !tasks[0].Flagged {
+		t.Errorf("got %v, want the flagged task first", tasks)
+	}
+
+	flagged, _ := application.ListTasks.Execute(&task.ListOptions{Flagged: true})
+	if len(flagged) != 1 || flagged[0].ID != second.ID {
+		t.Errorf("flagged = %v, want [Second]", flagged)
+	}
+
+	if _, err := application.SetFlagged.Execute([]int64{second.ID, 999}, false); !errors.Is(err, task.ErrTaskNotFound) {
+		t.Errorf("SetFlagged() on a missing task error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestUpsertByExternalID(t *testing.T) {
+	application := setupApp(t)
+
+	first, created, err := application.UpsertTask.Execute("Fix login", &task.CreateOptions{ExternalID: "gh-42", Tags: []string{"github"}})
+	if err != nil || !created {
+		t.Fatalf("Upsert() = %v, %v; want created", created, err)
+	}
+	application.CompleteTasks.Execute([]int64{first.ID})
+
+	due := time.Date(2026, 5, 1, 0, 0, 0, 0, time.Local)
+	second, created, err := application.UpsertTask.Execute("Fix login on Safari", &task.CreateOptions{ExternalID: "gh-42", DueDate: &due, Tags: []string{"github", "bug"}})
+	if err != nil || created {
+		t.Fatalf("Upsert() again = %v, %v; want updated", created, err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("Upsert() again updated #%d, want #%d", second.ID, first.ID)
+	}
+
+	got, _ := application.GetTask.Execute(first.ID)
+	if got.Title != "Fix login on Safari" || got.DueDate == nil || got.Status != task.StatusDone {
+		t.Errorf("updated task = %q due %v status %s, want new title, due date and still done", got.Title, got.DueDate, got.Status)
+	}
+	if !slices.Equal(got.Tags, []string{"bug", "github"}) {
+		t.Errorf("tags = %v, want [bug github]", got.Tags)
+	}
+
+	// An update can't put the task in a project and an area at once
+	application.CreateArea.Execute("Work")
+	application.CreateProject.Execute("Web", nil)
+	if _, _, err := application.UpsertTask.Execute("Fix login", &task.CreateOptions{ExternalID: "gh-42", ProjectName: "Web", AreaName: "Work"}); err == nil {
+		t.Error("Upsert() with both a project and an area succeeded")
+	}
+	if got, _ := application.GetTask.Execute(first.ID); got.Title != "Fix login on Safari" {
+		t.Errorf("failed Upsert() changed the title to %q", got.Title)
+	}
+
+	// Without an external ID, adding twice makes two tasks
+	a, _, _ := application.UpsertTask.Execute("Call mum", nil)
+	b, _, _ := application.UpsertTask.Execute("Call mum", nil)
+	if a.ID == b.ID {
+		t.Error("Upsert() without external ID reused a task")
+	}
+
+	if _, err := application.CreateTask.Execute("Copy", &task.CreateOptions{ExternalID: "gh-42"}); err == nil {
+		t.Error("CreateTask() with a used external ID succeeded")
+	}
+}
+
+func TestSplitTask(t *testing.T) {
+	application := setupApp(t)
+
+	project, _ := application.CreateProject.Execute("Launch", nil)
+	description := "Notes\n- [ ] Draft\n- [x] Outline\n- [ ] Review"
+	original, _ := application.CreateTask.Execute("Write post", &task.CreateOptions{
+		ProjectName: project.Title,
+		Description: description,
+		Tags:        []string{"blog"},
+	})
+
+	created, err := application.SplitTask.Execute(original.ID, nil, false)
+	if err != nil {
+		t.Fatalf("SplitTask() error = %v", err)
+	}
+	if len(created) != 2 || created[0].Title != "Draft" || created[1].Title != "Review" {
+		t.Fatalf("SplitTask() created %v, want Draft and Review", created)
+	}
+	for _, c := range created {
+		got, _ := application.GetTask.Execute(c.ID)
+		if got.ParentID == nil || *got.ParentID != project.ID || !slices.Equal(got.Tags, []string{"blog"}) {
+			t.Errorf("#%d: project %v tags %v, want Launch and [blog]", c.ID, got.ParentID, got.Tags)
+		}
+	}
+	if got, _ := application.GetTask.Execute(original.ID); got.Status != task.StatusDone {
+		t.Errorf("original status = %s, want done", got.Status)
+	}
+
+	kept, _ := application.CreateTask.Execute("Plan trip", nil)
+	created, err = application.SplitTask.Execute(kept.ID, []string{"Book train", " "}, true)
+	if err != nil {
+		t.Fatalf("SplitTask(keep) error = %v", err)
+	}
+	if len(created) != 1 {
+		t.Errorf("SplitTask(keep) created %d tasks, want 1", len(created))
+	}
+	if got, _ := application.GetTask.Execute(kept.ID); got.Status != task.StatusTodo {
+		t.Errorf("kept status = %s, want todo", got.Status)
+	}
+
+	if _, err := application.SplitTask.Execute(kept.ID, nil, false); err == nil {
+		t.Error("SplitTask() without titles or checklist succeeded")
+	}
+	if _, err := application.SplitTask.Execute(project.ID, []string{"x"}, false); err == nil {
+		t.Error("SplitTask() on a project succeeded")
+	}
+}
+
+func TestAreaDefaults(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	now := time.Date(2026, 3, 11, 9, 0, 0, 0, time.Local)
+	plannedIn := 1
+	application := app.NewWithOptions(db, app.Options{
+		Clock: clock.Fixed(now),
+		AreaDefaults: map[string]task.AreaDefaults{
+			"Work":    {Tags: []string{"work"}, PlannedIn: &plannedIn},
+			"Missing": {Tags: []string{"never"}},
+		},
+	})
+	application.CreateArea.Execute("Work")
+	application.CreateArea.Execute("Home")
+	application.CreateProject.Execute("Launch", &task.CreateProjectOptions{AreaName: "Work"})
+
+	tomorrow := time.Date(2026, 3, 12, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name        string
+		opts        *task.CreateOptions
+		wantTags    []string
+		wantPlanned *time.Time
+	}{
+		{"in the area", &task.CreateOptions{AreaName: "Work", Tags: []string{"urgent"}}, []string{"urgent", "work"}, &tomorrow},
+		{"in a project of the area", &task.CreateOptions{ProjectName: "Launch"}, []string{"work"}, &tomorrow},
+		{"someday", &task.CreateOptions{AreaName: "Work", Someday: true}, []string{"work"}, nil},
+		{"other area", &task.CreateOptions{AreaName: "Home"}, nil, nil},
+		{"no area", nil, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, err := application.CreateTask.Execute("Task", tt.opts)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if !slices.Equal(created.Tags, tt.wantTags) {
+				t.Errorf("Tags = %v, want %v", created.Tags, tt.wantTags)
+			}
+			if (created.PlannedDate == nil) != (tt.wantPlanned == nil) ||
+				(created.PlannedDate != nil && !created.PlannedDate.Equal(*tt.wantPlanned)) {
+				t.Errorf("PlannedDate = %v, want %v", created.PlannedDate, tt.wantPlanned)
+			}
+		})
+	}
+}
+
+func TestWIPLimits(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	application := app.NewWithOptions(db, app.Options{
+		WIPLimits: task.WIPLimits{
+			Areas:    map[string]int{"Work": 3, "Missing": 1},
+			Projects: map[string]int{"Launch": 1},
+		},
+	})
+	application.CreateArea.Execute("Work")
+	application.CreateProject.Execute("Launch", &task.CreateProjectOptions{AreaName: "Work"})
+
+	inArea, _ := application.CreateTask.Execute("Direct", &task.CreateOptions{AreaName: "Work"})
+	application.CreateTask.Execute("Later", &task.CreateOptions{AreaName: "Work", Someday: true})
+	application.CreateTask.Execute("Elsewhere", nil)
+	inProject, err := application.CreateTask.Execute("Launch it", &task.CreateOptions{ProjectName: "Launch", StrictWIP: true})
+	if err != nil {
+		t.Fatalf("Create() under the limit error = %v", err)
+	}
+
+	counts, err := application.Tasks.WIP()
+	if err != nil {
+		t.Fatalf("WIP() error = %v", err)
+	}
+	want := []task.WIPCount{
+		{Scope: task.WIPArea, Name: "Work", Count: 2, Limit: 3},
+		{Scope: task.WIPProject, Name: "Launch", Count: 1, Limit: 1},
+	}
+	if !slices.Equal(counts, want) {
+		t.Errorf("WIP() = %+v, want %+v", counts, want)
+	}
+
+	// Tasks that are counted already count once
+	counts, err = application.Tasks.WIPFor(inProject.ID)
+	if err != nil {
+		t.Fatalf("WIPFor() error = %v", err)
+	}
+	if len(counts) != 2 || counts[0].Count != 1 || counts[1].Count != 2 {
+		t.Errorf("WIPFor(task in project) = %+v, want Launch 1/1 and Work 2/3", counts)
+	}
+
+	if _, err := application.CreateTask.Execute("One more", &task.CreateOptions{ProjectName: "Launch", StrictWIP: true}); err == nil {
+		t.Error("Create() over the project's limit with StrictWIP succeeded")
+	}
+	if _, err := application.CreateTask.Execute("One more", &task.CreateOptions{ProjectName: "Launch", StrictWIP: true, Someday: true}); err != nil {
+		t.Errorf("Create() of a someday task with StrictWIP error = %v", err)
+	}
+	over, err := application.CreateTask.Execute("One more", &task.CreateOptions{ProjectName: "Launch"})
+	if err != nil {
+		t.Fatalf("Create() over the limit error = %v", err)
+	}
+	counts, _ = application.Tasks.WIPFor(over.ID)
+	if len(counts) != 2 || !counts[0].Over() || counts[1].Over() {
+		t.Errorf("WIPFor() after going over = %+v, want only Launch over", counts)
+	}
+
+	if counts, _ := application.Tasks.WIPFor(inArea.ID); len(counts) != 1 || counts[0].Name != "Work" {
+		t.Errorf("WIPFor(task in area) = %+v, want only Work", counts)
+	}
+}
+
+func TestLint(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	created := time.Date(2026, 1, 5, 9, 0, 0, 0, time.Local)
+	application := app.NewWithOptions(db, app.Options{Clock: clock.Fixed(created)})
+	application.CreateArea.Execute("Work")
+	application.CreateArea.Execute("Home")
+	application.CreateProject.Execute("Launch", &task.CreateProjectOptions{AreaName: "Work"})
+	application.CreateProject.Execute("Loose", nil)
+	application.CreateProject.Execute("Idle", &task.CreateProjectOptions{AreaName: "Home"})
+
+	if _, err := application.CreateTask.Execute("Book venue", &task.CreateOptions{ProjectName: "Launch", Tags: []string{"work", "venue"}}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	application.CreateTask.Execute("Write post", &task.CreateOptions{ProjectName: "Loose", Tags: []string{"work"}})
+	done, _ := application.CreateTask.Execute("Old", &task.CreateOptions{Tags: []string{"wrok"}})
+	application.CompleteTasks.Execute([]int64{done.ID})
+
+	report, err := application.Tasks.Lint(30)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(report.AreaConflicts) != 0 {
+		t.Errorf("AreaConflicts = %+v, want none", report.AreaConflicts)
+	}
+	if len(report.ProjectsNoArea) != 1 || report.ProjectsNoArea[0].Title != "Loose" {
+		t.Errorf("ProjectsNoArea = %+v, want Loose", report.ProjectsNoArea)
+	}
+	if len(report.EmptyProjects) != 0 {
+		t.Errorf("EmptyProjects = %+v, want none before the cutoff", report.EmptyProjects)
+	}
+	if want := []string{"venue", "wrok"}; !slices.Equal(report.SingleUseTags, want) {
+		t.Errorf("SingleUseTags = %v, want %v", report.SingleUseTags, want)
+	}
+
+	application.Reconfigure(app.Options{Clock: clock.Fixed(created.AddDate(0, 0, 31))})
+	report, err = application.Tasks.Lint(30)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(report.EmptyProjects) != 1 || report.EmptyProjects[0].Title != "Idle" {
+		t.Errorf("EmptyProjects = %+v, want Idle", report.EmptyProjects)
+	}
+}
+
+func TestOrderToday(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	now := time.Date(2026, 3, 11, 9, 0, 0, 0, time.Local)
+	application := app.NewWithOptions(db, app.Options{Clock: clock.Fixed(now)})
+
+	titles := func(tasks []task.Task) []string {
+		var titles []string
+		for _, tk := range tasks {
+			titles = append(titles, tk.Title)
+		}
+		return titles
+	}
+
+	today := time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local)
+	var ids []int64
+	for _, title := range []string{"A", "B", "C", "D"} {
+		created, _ := application.CreateTask.Execute(title, &task.CreateOptions{PlannedDate: &today})
+		ids = append(ids, created.ID)
+	}
+
+	if err := application.OrderToday.Execute([]int64{ids[2], ids[0]}); err != nil {
+		t.Fatalf("OrderToday() error = %v", err)
+	}
+	tasks, err := application.ListTasks.Execute(&task.ListOptions{Schedule: "today"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if got := titles(tasks); !slices.Equal(got, []string{"C", "A", "B", "D"}) {
+		t.Errorf("today = %v, want [C A B D]", got)
+	}
+
+	// Other lists keep to the sort, and tomorrow the order has lapsed
+	all, _ := application.ListTasks.Execute(&task.ListOptions{})
+	if got := titles(all); !slices.Equal(got, []string{"A", "B", "C", "D"}) {
+		t.Errorf("all = %v, want [A B C D]", got)
+	}
+	later := app.NewWithOptions(db, app.Options{Clock: clock.Fixed(now.AddDate(0, 0, 1))})
+	tasks, _ = later.ListTasks.Execute(&task.ListOptions{Schedule: "today"})
+	if got := titles(tasks); !slices.Equal(got, []string{"A", "B", "C", "D"}) {
+		t.Errorf("tomorrow = %v, want [A B C D]", got)
+	}
+
+	if err := application.OrderToday.Execute(nil); err != nil {
+		t.Fatalf("OrderToday(nil) error = %v", err)
+	}
+	tasks, _ = application.ListTasks.Execute(&task.ListOptions{Schedule: "today"})
+	if got := titles(tasks); !slices.Equal(got, []string{"A", "B", "C", "D"}) {
+		t.Errorf("cleared = %v, want [A B C D]", got)
+	}
+}
+
+func TestComments(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	application := app.NewWithOptions(db, app.Options{User: "alice", Clock: clock.Fixed(time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC))})
+
+	created, _ := application.CreateTask.Execute("Sign contract", nil)
+	if _, err := application.AddComment.Execute(created.ID, "waiting on legal"); err != nil {
+		t.Fatalf("AddComment() error = %v", err)
+	}
+	application.AddComment.Execute(created.ID, "legal approved")
+	if _, err := application.AddComment.Execute(created.ID, "  "); err == nil {
+		t.Error("AddComment() with an empty body should fail")
+	}
+	if _, err := application.AddComment.Execute(999, "lost"); err == nil {
+		t.Error("AddComment() on a missing task should fail")
+	}
+
+	comments, err := application.ListComments.Execute(created.ID)
+	if err != nil {
+		t.Fatalf("ListComments() error = %v", err)
+	}
+	if len(comments) != 2 || comments[0].Body != "waiting on legal" || comments[1].Body != "legal approved" {
+		t.Fatalf("got %v, want both comments oldest first", comments)
+	}
+	if comments[0].Author == nil || *comments[0].Author != "alice" {
+		t.Errorf("Author = %v, want alice", comments[0].Author)
+	}
+
+	if _, err := application.DeleteTasks.Execute([]int64{created.ID}); err != nil {
+		t.Fatalf("DeleteTasks() error = %v", err)
+	}
+	if comments, _ := application.ListComments.Execute(created.ID); len(comments) != 0 {
+		t.Errorf("got %d comments after deleting the task, want 0", len(comments))
+	}
+}
+
+func TestSuggestNext(t *testing.T) {
+	application := setupApp(t)
+
+	suggestion, err := application.SuggestNext.Execute(task.SuggestOptions{})
+	if err != nil {
+		t.Fatalf("SuggestNext() error = %v", err)
+	}
+	if suggestion != nil {
+		t.Errorf("expected no suggestion without tasks, got %q", suggestion.Task.Title)
+	}
+
+	today := time.Now()
+	yesterday := today.AddDate(0, 0, -1)
+	tomorrow := today.AddDate(0, 0, 1)
+	application.CreateTask.Execute("Someday", &task.CreateOptions{Someday: true})
+	application.CreateTask.Execute("Later", &task.CreateOptions{PlannedDate: &tomorrow, DueDate: &yesterday})
+	application.CreateTask.Execute("Whenever", nil)
+	application.CreateTask.Execute("Overdue", &task.CreateOptions{DueDate: &yesterday})
+
+	suggestion, err = application.SuggestNext.Execute(task.SuggestOptions{})
+	if err != nil {
+		t.Fatalf("SuggestNext() error = %v", err)
+	}
+	if suggestion == nil || suggestion.Task.Title != "Overdue" {
+		t.Fatalf("got %v, want Overdue", suggestion)
+	}
+	if len(suggestion.Reasons) == 0 || suggestion.Reasons[0] != (task.SuggestionReason{Kind: task.ReasonOverdue, Days: 1}) {
+		t.Errorf("Reasons = %v, want overdue by 1 day first", suggestion.Reasons)
+	}
+
+	for range 10 {
+		random, err := application.SuggestNext.Execute(task.SuggestOptions{Random: true})
+		if err != nil {
+			t.Fatalf("SuggestNext(random) error = %v", err)
+		}
+		if title := random.Task.Title; title != "Overdue" && title != "Whenever" {
+			t.Errorf("random pick %q is not actionable", title)
+		}
+	}
+}
+
+func TestExpireTasks(t *testing.T) {
+	application := setupApp(t)
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	today := time.Now()
+	expired, _ := application.CreateTask.Execute("Conference early-bird", &task.CreateOptions{Expires: &yesterday, PlannedDate: &today})
+	current, _ := application.CreateTask.Execute("Sale ends today", &task.CreateOptions{Expires: &today})
+	done, _ := application.CreateTask.Execute("Already bought", &task.CreateOptions{Expires: &yesterday})
+	if _, err := application.CompleteTasks.Execute([]int64{done.ID}); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	got, err := application.ExpireTasks.Execute()
+	if err != nil {
+		t.Fatalf("ExpireTasks() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != expired.ID {
+		t.Fatalf("ExpireTasks() = %v, want only #%d", got, expired.ID)
+	}
+
+	moved, _ := application.GetTask.Execute(expired.ID)
+	if moved.State != task.StateSomeday || moved.PlannedDate != nil || moved.Expires != nil {
+		t.Errorf("expired task = state %q, planned %v, expires %v; want someday with both cleared", moved.State, moved.PlannedDate, moved.Expires)
+	}
+	if kept, _ := application.GetTask.Execute(current.ID); kept.State != task.StateActive || kept.Expires == nil {
+		t.Error("a task expiring today should be left alone")
+	}
+
+	// Moved tasks don't expire again
+	if got, _ := application.ExpireTasks.Execute(); len(got) != 0 {
+		t.Errorf("second run expired %d tasks, want 0", len(got))
+	}
+}
+
+func TestExpireTasksDelete(t *testing.T) {
+	application := app.NewWithOptions(testutil.NewTestDB(t), app.Options{ExpireAction: task.ExpireDelete})
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	created, _ := application.CreateTask.Execute("Expired offer", &task.CreateOptions{Expires: &yesterday})
+
+	if got, err := application.ExpireTasks.Execute(); err != nil || len(got) != 1 {
+		t.Fatalf("ExpireTasks() = %d tasks, %v; want 1", len(got), err)
+	}
+	if _, err := application.GetTask.Execute(created.ID); err == nil {
+		t.Error("expired task should be deleted")
+	}
+}
+
+func TestArchiveTasks(t *testing.T) {
+	application := setupApp(t)
+
+	done, _ := application.CreateTask.Execute("Done long ago", nil)
+	open, _ := application.CreateTask.Execute("Still open", nil)
+	idea, _ := application.CreateTask.Execute("Old idea", &task.CreateOptions{Someday: true, Tags: []string{"maybe"}})
+	if _, err := application.CompleteTasks.Execute([]int64{done.ID}); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	var archive strings.Builder
+	future := time.Now().Add(time.Hour)
+
+	// Someday tasks are only archived with their own cutoff
+	got, err := application.ArchiveTasks.Execute(&archive, &future, nil)
+	if err != nil {
+		t.Fatalf("ArchiveTasks() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != done.ID {
+		t.Fatalf("ArchiveTasks() = %v, want only #%d", got, done.ID)
+	}
+
+	got, err = application.ArchiveTasks.Execute(&archive, nil, &future)
+	if err != nil {
+		t.Fatalf("ArchiveTasks() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != idea.ID {
+		t.Fatalf("ArchiveTasks() = %v, want only #%d", got, idea.ID)
+	}
+
+	lines := strings.Split(strings.TrimSpace(archive.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"tags":["maybe"]`) {
+		t.Errorf("archive = %q, want one JSON line per task with tags", archive.String())
+	}
+	if _, err := application.GetTask.Execute(done.ID); err == nil {
+		t.Error("archived task should be removed")
+	}
+	if _, err := application.GetTask.Execute(open.ID); err != nil {
+		t.Errorf("open task should be kept: %v", err)
+	}
+}
+
+func TestListEachMatchesExecute(t *testing.T) {
+	application := setupApp(t)
+
+	_, _ = application.CreateTask.Execute("Tagged", &task.CreateOptions{Tags: []string{"work", "errand"}})
+	_, _ = application.CreateTask.Execute("Plain", nil)
+	done, _ := application.CreateTask.Execute("Done", &task.CreateOptions{Tags: []string{"home"}})
+	if _, err := application.CompleteTasks.Execute([]int64{done.ID}); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	want, err := application.ListTasks.Execute(nil)
+	if err != nil {
+		t.Fatalf("ListTasks.Execute() error = %v", err)
+	}
+	var got []task.Task
+	if err := application.ListTasks.Each(nil, func(t task.Task) error {
+		got = append(got, t)
+		return nil
+	}); err != nil {
+		t.Fatalf("ListTasks.Each() error = %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Each() returned %d tasks, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID || strings.Join(got[i].Tags, ",") != strings.Join(want[i].Tags, ",") {
+			t.Errorf("task %d = #%d %v, want #%d %v", i, got[i].ID, got[i].Tags, want[i].ID, want[i].Tags)
+		}
+	}
+
+	var completed []task.Task
+	if err := application.ListCompletedTasks.Each(nil, func(t task.Task) error {
+		completed = append(completed, t)
+		return nil
+	}); err != nil {
+		t.Fatalf("ListCompletedTasks.Each() error = %v", err)
+	}
+	if len(completed) != 1 || completed[0].ID != done.ID || len(completed[0].Tags) != 1 {
+		t.Errorf("ListCompletedTasks.Each() = %v, want #%d with its tag", completed, done.ID)
+	}
+
+	// An error from fn stops the iteration
+	stop := errors.New("stop")
+	calls := 0
+	err = application.ListTasks.Each(nil, func(task.Task) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Each() = %v after %d calls, want stop after 1", err, calls)
+	}
+}
+
+func TestCountTasks(t *testing.T) {
+	application := setupApp(t)
+
+	application.CreateTask.Execute("Open", nil)
+	application.CreateTask.Execute("Later", &task.CreateOptions{Someday: true})
+	done, _ := application.CreateTask.Execute("Done", nil)
+	application.CompleteTasks.Execute([]int64{done.ID})
+	application.CreateProject.Execute("Work", nil)
+
+	counts, err := application.CountTasks.Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := task.Counts{Open: 2, Someday: 1, Done: 1, Projects: 1}
+	if counts != want {
+		t.Errorf("Execute() = %+v, want %+v", counts, want)
+	}
+}
+
+func TestFixedClock(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	now := time.Date(2026, 3, 11, 23, 30, 0, 0, time.Local)
+	application := app.NewWithOptions(db, app.Options{Clock: clock.Fixed(now)})
+
+	today := time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local)
+	tomorrow := today.AddDate(0, 0, 1)
+	application.CreateTask.Execute("Today", &task.CreateOptions{PlannedDate: &today})
+	application.CreateTask.Execute("Tomorrow", &task.CreateOptions{PlannedDate: &tomorrow})
+
+	tasks, err := application.ListTasks.Execute(&task.ListOptions{Schedule: "today"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Today" {
+		t.Fatalf("today = %+v, want only Today", tasks)
+	}
+	if !tasks[0].CreatedAt.Equal(now) {
+		t.Errorf("CreatedAt = %v, want %v", tasks[0].CreatedAt, now)
+	}
+
+	// The next occurrence of a fixed daily task is the day after the clock's today
+	recurType := task.RecurTypeFixed
+	recurRule := `{"interval":1,"unit":"day"}`
+	daily, _ := application.CreateTask.Execute("Daily", &task.CreateOptions{RecurType: &recurType, RecurRule: &recurRule})
+	results, err := application.CompleteTasks.Execute([]int64{daily.ID})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if got := results[0].Completed.CompletedAt; got == nil || !got.Equal(now) {
+		t.Errorf("CompletedAt = %v, want %v", got, now)
+	}
+	if next := results[0].NextTask; next == nil || next.PlannedDate == nil || !next.PlannedDate.Equal(tomorrow) {
+		t.Errorf("NextTask = %+v, want planned %s", next, tomorrow.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,84 @@
+package task
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SortTasks orders tasks in memory the way List does: flagged tasks first,
+// then by opts (by default by ID). Tasks without a value for a field come
+// last, whatever the direction.
+func SortTasks(list []Task, opts []SortOption) {
+	if len(opts) == 0 {
+		opts = DefaultSort()
+	}
+
+	slices.SortStableFunc(list, func(a, b Task) int {
+		if a.Flagged != b.Flagged {
+			if a.Flagged {
+				return -1
+			}
+			return 1
+		}
+		for _, opt := range opts {
+			va, vb := sortKey(&a, opt.Field), sortKey(&b, opt.Field)
+			if c := compareKeys(va, vb, opt.Direction == SortDesc); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+}
+
+// key is a task's value for a sort field; nil stands for NULL
+type key struct {
+	id  int64
+	str *string
+}
+
+func sortKey(t *Task, field SortField) key {
+	date := func(d *time.Time) key {
+		if d == nil {
+			return key{}
+		}
+		s := d.Format(dateFormat)
+		return key{str: &s}
+	}
+	switch field {
+	case SortByTitle:
+		return key{str: &t.Title}
+	case SortByPlanned:
+		return date(t.PlannedDate)
+	case SortByDue:
+		return date(t.DueDate)
+	case SortByCreated:
+		created := t.CreatedAt.Format(time.RFC3339)
+		return key{str: &created}
+	case SortByProject:
+		return key{str: t.ParentName}
+	case SortByArea:
+		return key{str: t.AreaName}
+	default:
+		return key{id: t.ID}
+	}
+}
+
+func compareKeys(a, b key, desc bool) int {
+	var c int
+	switch {
+	case a.str == nil && b.str == nil:
+		c = cmp.Compare(a.id, b.id)
+	case a.str == nil:
+		return 1
+	case b.str == nil:
+		return -1
+	default:
+		c = strings.Compare(*a.str, *b.str)
+	}
+	if desc {
+		return -c
+	}
+	return c
+}
@@ -1,7 +1,9 @@
 package task
 
 import (
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestParseSort(t *testing.T) {
@@ -148,3 +150,25 @@ func TestDefaultSort(t *testing.T) {
 		t.Errorf("DefaultSort()[0].Direction = %v, want %v", got[0].Direction, SortAsc)
 	}
 }
+
+func TestSortTasks(t *testing.T) {
+	day := func(d int) *time.Time {
+		date := time.Date(2026, 3, d, 0, 0, 0, 0, time.Local)
+		return &date
+	}
+	tasks := []Task{
+		{ID: 1, Title: "No due date"},
+		{ID: 2, Title: "Due later", DueDate: day(20)},
+		{ID: 3, Title: "Flagged", Flagged: true},
+		{ID: 4, Title: "Due soon", DueDate: day(10)},
+	}
+	SortTasks(tasks, []SortOption{{Field: SortByDue, Direction: SortAsc}})
+
+	var got []int64
+	for _, tk := range tasks {
+		got = append(got, tk.ID)
+	}
+	if want := []int64{3, 4, 2, 1}; !slices.Equal(got, want) {
+		t.Errorf("SortTasks() order = %v, want %v (flagged first, no due date last)", got, want)
+	}
+}
@@ -0,0 +1,51 @@
+package task
+
+import "time"
+
+// Store keeps tasks and projects. Repository stores them in SQLite; other
+// backends implement the same behavior, including returning an error
+// matching sql.ErrNoRows from GetByID for unknown IDs.
+type Store interface {
+	Create(task *Task) error
+	Update(task *Task) error
+	Delete(id int64) error
+	GetByID(id int64) (*Task, error)
+	GetByName(name string, taskType TaskType) (*Task, error)
+	// GetByExternalID returns ErrTaskNotFound if no task has the ID
+	GetByExternalID(externalID string) (*Task, error)
+
+	List(filter *ListFilter) ([]Task, error)
+	ListIter(filter *ListFilter, fn func(Task) error) error
+	ListCompleted(filter *CompletedFilter) ([]Task, error)
+	ListCompletedIter(filter *CompletedFilter, fn func(Task) error) error
+	ListExpired(today time.Time) ([]Task, error)
+	ListArchivable(completedBefore, somedayBefore *time.Time) ([]Task, error)
+
+	Complete(id int64, completedAt time.Time) error
+	CompleteWithChildren(id int64, completedAt time.Time) error
+	// Cancel marks an open task canceled, along with the open tasks of a project
+	Cancel(id int64, canceledAt time.Time) error
+	// Uncomplete reopens a done or canceled task
+	Uncomplete(id int64) error
+
+	AddTag(taskID int64, tagName string) error
+	RemoveTag(taskID int64, tagName string) error
+	SetTags(taskID int64, tags []string) error
+	ListTags() ([]string, error)
+	ListLocations() ([]string, error)
+	SetTagColor(name, color string) error
+	TagColors() (map[string]string, error)
+
+	SetDayOrder(day time.Time, ids []int64) error
+	DayOrder(day time.Time) ([]int64, error)
+
+	// SetDependencies replaces the tasks that have to be done before a task
+	// can start
+	SetDependencies(taskID int64, dependsOn []int64) error
+	Dependencies(taskID int64) ([]int64, error)
+
+	CountOccurrences(root int64) (int, error)
+	Counts() (Counts, error)
+}
+
+var _ Store = (*Repository)(nil)
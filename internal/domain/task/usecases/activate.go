@@ -8,7 +8,7 @@ import (
 )
 
 type ActivateTask struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (a *ActivateTask) Execute(id int64) (*task.Task, error) {
@@ -8,7 +8,7 @@ import (
 )
 
 type AddTag struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (a *AddTag) Execute(id int64, tagName string) (*task.Task, error) {
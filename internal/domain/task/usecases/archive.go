@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// ArchiveTasks moves old tasks out of the database: each is written to an
+// archive as a line of JSON, then deleted.
+type ArchiveTasks struct {
+	Repo task.Store
+}
+
+// Execute archives tasks completed before completedBefore and someday tasks
+// created before somedayBefore to w, and returns them. A nil cutoff skips
+// that kind of task. Nothing is deleted unless all of them were written.
+func (a *ArchiveTasks) Execute(w io.Writer, completedBefore, somedayBefore *time.Time) ([]task.Task, error) {
+	tasks, err := a.Repo.ListArchivable(completedBefore, somedayBefore)
+	if err != nil || len(tasks) == 0 {
+		return nil, err
+	}
+
+	enc := json.NewEncoder(w)
+	for _, t := range tasks {
+		if err := enc.Encode(t); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, t := range tasks {
+		if err := a.Repo.Delete(t.ID); err != nil {
+			return tasks[:i], err
+		}
+	}
+	return tasks, nil
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type CancelTasks struct {
+	Repo     task.Store
+	Complete *CompleteTasks // schedules the next occurrence of recurring tasks
+	Clock    clock.Clock
+}
+
+// Execute marks tasks as won't do. Canceling a project cancels its open
+// tasks, and a canceled recurring task still gets its next occurrence, as
+// only this one is skipped.
+func (c *CancelTasks) Execute(ids []int64) ([]task.CompleteResult, error) {
+	if _, err := getAll(c.Repo, ids); err != nil {
+		return nil, err
+	}
+
+	canceledAt := clock.Now(c.Clock)
+	var results []task.CompleteResult
+
+	for _, id := range ids {
+		if err := c.Repo.Cancel(id, canceledAt); err != nil {
+			return results, err
+		}
+
+		t, err := c.Repo.GetByID(id)
+		if err != nil {
+			return results, err
+		}
+
+		result := task.CompleteResult{Completed: *t}
+		if !t.IsProject() && t.RecurType != nil && t.RecurRule != nil && !t.RecurPaused {
+			result.NextTask = c.Complete.regenerateTask(t, canceledAt)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -3,17 +3,24 @@ package usecases
 import (
 	"time"
 
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/domain/task"
 	"github.com/devbydaniel/tt/internal/recurparse"
-	"github.com/google/uuid"
 )
 
 type CompleteTasks struct {
-	Repo *task.Repository
+	Repo       task.Store
+	Recurrence task.RecurrenceSettings
+	Holidays   HolidayLookup
+	Clock      clock.Clock
 }
 
 func (c *CompleteTasks) Execute(ids []int64) ([]task.CompleteResult, error) {
-	completedAt := time.Now()
+	if _, err := getAll(c.Repo, ids); err != nil {
+		return nil, err
+	}
+
+	completedAt := clock.Now(c.Clock)
 	var results []task.CompleteResult
 
 	for _, id := range ids {
@@ -57,8 +64,28 @@ func (c *CompleteTasks) Execute(ids []int64) ([]task.CompleteResult, error) {
 }
 
 func (c *CompleteTasks) regenerateTask(t *task.Task, completedAt time.Time) *task.Task {
+	b, err := blackout(c.Holidays, c.Recurrence)
+	if err != nil {
+		return nil
+	}
+
+	// Fixed recurrences created ahead of time already have their next
+	// occurrence; schedule the one after it instead
+	if c.Recurrence.Ahead && *t.RecurType == task.RecurTypeFixed {
+		next, err := materializeAhead(c.Repo, t, b, completedAt)
+		if err != nil {
+			return nil
+		}
+		return next
+	}
+
 	// Check if past end date
-	if t.RecurEnd != nil && time.Now().After(*t.RecurEnd) {
+	if t.RecurEnd != nil && completedAt.After(*t.RecurEnd) {
+		return nil
+	}
+
+	// Check if all occurrences have been created
+	if done, err := seriesDone(c.Repo, t); err != nil || done {
 		return nil
 	}
 
@@ -74,60 +101,13 @@ func (c *CompleteTasks) regenerateTask(t *task.Task, completedAt time.Time) *tas
 		recurrenceType = recurparse.TypeRelative
 	}
 
-	var fromDate time.Time
-	if recurrenceType == recurparse.TypeRelative {
-		fromDate = completedAt
-	} else {
-		fromDate = time.Now()
-	}
-	nextDate := recurparse.NextOccurrence(rule, recurrenceType, fromDate)
-
-	// Determine which date field to set based on original task
-	var plannedDate, dueDate *time.Time
-	if t.DueDate != nil {
-		dueDate = &nextDate
-	} else {
-		plannedDate = &nextDate
-	}
-
-	// Determine the parent ID for linking
-	parentID := t.RecurParentID
-	if parentID == nil {
-		parentID = &t.ID
-	}
-
-	// Create the next task
-	nextTask := &task.Task{
-		UUID:          uuid.New().String(),
-		Title:         t.Title,
-		Description:   t.Description,
-		TaskType:      task.TaskTypeTask,
-		ParentID:      t.ParentID,
-		AreaID:        t.AreaID,
-		PlannedDate:   plannedDate,
-		DueDate:       dueDate,
-		State:         task.StateActive,
-		Status:        task.StatusTodo,
-		CreatedAt:     time.Now(),
-		RecurType:     t.RecurType,
-		RecurRule:     t.RecurRule,
-		RecurEnd:      t.RecurEnd,
-		RecurParentID: parentID,
-	}
+	// Relative recurrences count from the completion, fixed ones from
+	// today, which is the same moment
+	nextDate := b.Apply(rule, recurrenceType, recurparse.NextOccurrence(rule, recurrenceType, completedAt))
 
-	if err := c.Repo.Create(nextTask); err != nil {
+	nextTask, err := createOccurrence(c.Repo, t, nextDate, completedAt)
+	if err != nil {
 		return nil
 	}
-
-	// Copy tags from original task
-	if len(t.Tags) > 0 {
-		for _, tag := range t.Tags {
-			if err := c.Repo.AddTag(nextTask.ID, tag); err != nil {
-				return nil
-			}
-		}
-		nextTask.Tags = t.Tags
-	}
-
 	return nextTask
 }
@@ -0,0 +1,11 @@
+package usecases
+
+import "github.com/devbydaniel/tt/internal/domain/task"
+
+type CountTasks struct {
+	Repo task.Store
+}
+
+func (c *CountTasks) Execute() (task.Counts, error) {
+	return c.Repo.Counts()
+}
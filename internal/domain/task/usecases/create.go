@@ -1,11 +1,13 @@
 package usecases
 
 import (
-	"time"
+	"errors"
+	"slices"
+	"strings"
 
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/domain/area"
 	"github.com/devbydaniel/tt/internal/domain/task"
-	"github.com/google/uuid"
 )
 
 // ProjectLookup is what this use case needs to look up projects (which are now tasks)
@@ -19,46 +21,79 @@ type AreaLookup interface {
 }
 
 type CreateTask struct {
-	Repo          *task.Repository
+	Repo          task.Store
 	ProjectLookup ProjectLookup
 	AreaLookup    AreaLookup
+	Recurrence    task.RecurrenceSettings
+	Holidays      HolidayLookup
+	Clock         clock.Clock
+	User          string // recorded as the creator of new tasks (empty = unknown)
+
+	// AreaDefaults are applied to new tasks in an area, keyed by area name
+	AreaDefaults map[string]task.AreaDefaults
+	Schedule     task.ScheduleSettings // tells today for AreaDefaults.PlannedIn
+
+	// WIP checks WIP limits for CreateOptions.StrictWIP
+	WIP *CountWIP
 }
 
 func (c *CreateTask) Execute(title string, opts *task.CreateOptions) (*task.Task, error) {
+	now := clock.Now(c.Clock)
 	t := &task.Task{
-		UUID:      uuid.New().String(),
+		UUID:      task.NewUUID(now),
 		Title:     title,
 		TaskType:  task.TaskTypeTask,
 		State:     task.StateActive,
 		Status:    task.StatusTodo,
-		CreatedAt: time.Now(),
+		CreatedAt: now,
+	}
+	if c.User != "" {
+		t.Creator = &c.User
 	}
 
+	var areaID *int64
 	if opts != nil {
-		if opts.ProjectName != "" {
-			p, err := c.ProjectLookup.Execute(opts.ProjectName)
-			if err != nil {
-				return nil, err
-			}
+		p, a, err := placement(c.ProjectLookup, c.AreaLookup, opts)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
 			t.ParentID = &p.ID
+			areaID = p.AreaID
 		}
-		if opts.AreaName != "" {
-			a, err := c.AreaLookup.Execute(opts.AreaName)
-			if err != nil {
-				return nil, err
-			}
+		if a != nil {
 			t.AreaID = &a.ID
+			areaID = &a.ID
 		}
 		if opts.Description != "" {
 			t.Description = &opts.Description
 		}
 		t.PlannedDate = opts.PlannedDate
 		t.DueDate = opts.DueDate
+		t.Estimate = opts.Estimate
+		t.Expires = opts.Expires
+		if opts.Context != "" {
+			context, err := task.ParseContext(opts.Context)
+			if err != nil {
+				return nil, err
+			}
+			t.Context = &context
+		}
+		if assignee := strings.TrimSpace(opts.Assignee); assignee != "" {
+			t.Assignee = &assignee
+		}
+		if location := task.ParseLocation(opts.Location); location != "" {
+			t.Location = &location
+		}
+		if externalID := strings.TrimSpace(opts.ExternalID); externalID != "" {
+			t.ExternalID = &externalID
+		}
 
 		// Recurrence fields
 		t.RecurType = opts.RecurType
 		t.RecurRule = opts.RecurRule
 		t.RecurEnd = opts.RecurEnd
+		t.RecurCount = opts.RecurCount
 		t.RecurParentID = opts.RecurParentID
 
 		if opts.Someday {
@@ -68,19 +103,84 @@ func (c *CreateTask) Execute(title string, opts *task.CreateOptions) (*task.Task
 		}
 	}
 
+	var tags []string
+	if opts != nil {
+		tags = opts.Tags
+	}
+	if defaults, ok := c.areaDefaults(areaID); ok {
+		for _, tag := range defaults.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(slices.Clip(tags), tag)
+			}
+		}
+		// Only plan tasks that weren't given a date or put off to someday
+		if defaults.PlannedIn != nil && t.PlannedDate == nil && t.DueDate == nil && t.State == task.StateActive {
+			planned := c.Schedule.Today(now).AddDate(0, 0, *defaults.PlannedIn)
+			t.PlannedDate = &planned
+		}
+	}
+
+	if opts != nil && opts.StrictWIP && c.WIP != nil && t.State == task.StateActive {
+		if err := c.WIP.Check(t); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := c.Repo.Create(t); err != nil {
 		return nil, err
 	}
 
 	// Save tags if provided
-	if opts != nil && len(opts.Tags) > 0 {
-		for _, tag := range opts.Tags {
+	if len(tags) > 0 {
+		for _, tag := range tags {
 			if err := c.Repo.AddTag(t.ID, tag); err != nil {
 				return nil, err
 			}
 		}
-		t.Tags = opts.Tags
+		t.Tags = tags
+	}
+
+	if c.Recurrence.Ahead {
+		b, err := blackout(c.Holidays, c.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := materializeAhead(c.Repo, t, b, now); err != nil {
+			return nil, err
+		}
 	}
 
 	return t, nil
 }
+
+// placement looks up the project or area opts puts a task in, if any. A
+// task can't be in both.
+func placement(projects ProjectLookup, areas AreaLookup, opts *task.CreateOptions) (*task.Task, *area.Area, error) {
+	if opts.ProjectName != "" && opts.AreaName != "" {
+		return nil, nil, errors.New("a task can't be in both a project and an area")
+	}
+	if opts.ProjectName != "" {
+		p, err := projects.Execute(opts.ProjectName)
+		return p, nil, err
+	}
+	if opts.AreaName != "" {
+		a, err := areas.Execute(opts.AreaName)
+		return nil, a, err
+	}
+	return nil, nil, nil
+}
+
+// areaDefaults returns the defaults configured for the area with the given
+// ID, if any. Areas are configured by name; ones that don't exist are
+// skipped.
+func (c *CreateTask) areaDefaults(areaID *int64) (task.AreaDefaults, bool) {
+	if areaID == nil {
+		return task.AreaDefaults{}, false
+	}
+	for name, defaults := range c.AreaDefaults {
+		if a, err := c.AreaLookup.Execute(name); err == nil && a.ID == *areaID {
+			return defaults, true
+		}
+	}
+	return task.AreaDefaults{}, false
+}
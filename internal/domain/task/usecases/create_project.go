@@ -1,11 +1,9 @@
 package usecases
 
 import (
-	"time"
-
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/domain/area"
 	"github.com/devbydaniel/tt/internal/domain/task"
-	"github.com/google/uuid"
 )
 
 // AreaLookupForCreateProject is what this use case needs from the area domain
@@ -13,33 +11,26 @@ type AreaLookupForCreateProject interface {
 	Execute(name string) (*area.Area, error)
 }
 
-// CreateProjectOptions contains options for creating a project
-type CreateProjectOptions struct {
-	AreaName    string
-	Description string
-	PlannedDate *time.Time
-	DueDate     *time.Time
-	Someday     bool
-}
-
 type CreateProject struct {
-	Repo       *task.Repository
+	Repo       task.Store
 	AreaLookup AreaLookupForCreateProject
+	Clock      clock.Clock
 }
 
-func (c *CreateProject) Execute(name string, opts *CreateProjectOptions) (*task.Task, error) {
+func (c *CreateProject) Execute(name string, opts *task.CreateProjectOptions) (*task.Task, error) {
 	state := task.StateActive
 	if opts != nil && opts.Someday {
 		state = task.StateSomeday
 	}
 
+	now := clock.Now(c.Clock)
 	p := &task.Task{
-		UUID:      uuid.New().String(),
+		UUID:      task.NewUUID(now),
 		Title:     name,
 		TaskType:  task.TaskTypeProject,
 		State:     state,
 		Status:    task.StatusTodo,
-		CreatedAt: time.Now(),
+		CreatedAt: now,
 	}
 
 	if opts != nil {
@@ -8,7 +8,7 @@ import (
 )
 
 type DeferTask struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (d *DeferTask) Execute(id int64) (*task.Task, error) {
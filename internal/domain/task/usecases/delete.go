@@ -1,28 +1,20 @@
 package usecases
 
-import (
-	"database/sql"
-	"errors"
-
-	"github.com/devbydaniel/tt/internal/domain/task"
-)
+import "github.com/devbydaniel/tt/internal/domain/task"
 
 type DeleteTasks struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (d *DeleteTasks) Execute(ids []int64) ([]task.Task, error) {
-	var deleted []task.Task
+	tasks, err := getAll(d.Repo, ids)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, id := range ids {
-		t, err := d.Repo.GetByID(id)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return deleted, task.ErrTaskNotFound
-			}
-			return deleted, err
-		}
-		if err := d.Repo.Delete(id); err != nil {
+	var deleted []task.Task
+	for _, t := range tasks {
+		if err := d.Repo.Delete(t.ID); err != nil {
 			return deleted, err
 		}
 		deleted = append(deleted, *t)
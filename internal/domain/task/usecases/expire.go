@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// ExpireTasks handles open tasks whose expires date has passed, for
+// time-boxed opportunities that aren't worth keeping around afterwards.
+type ExpireTasks struct {
+	Repo     task.Store
+	Schedule task.ScheduleSettings
+	Action   string // task.ExpireSomeday (default) or task.ExpireDelete
+	Clock    clock.Clock
+}
+
+// Execute moves expired tasks to someday, or deletes them, and returns them.
+// Tasks moved to someday lose their expires date so they stay there.
+func (e *ExpireTasks) Execute() ([]task.Task, error) {
+	expired, err := e.Repo.ListExpired(e.Schedule.Today(clock.Now(e.Clock)))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range expired {
+		t := &expired[i]
+		if e.Action == task.ExpireDelete {
+			if err := e.Repo.Delete(t.ID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		t.State = task.StateSomeday
+		t.PlannedDate = nil
+		t.Expires = nil
+		if err := e.Repo.Update(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return expired, nil
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type GetTask struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (g *GetTask) Execute(id int64) (*task.Task, error) {
@@ -21,3 +21,21 @@ func (g *GetTask) Execute(id int64) (*task.Task, error) {
 	}
 	return t, nil
 }
+
+// getAll returns the tasks with the given IDs, or a task.NotFoundError for
+// the first one that doesn't exist. Use cases that take several IDs look
+// them all up before changing any, so a gap in a range changes nothing.
+func getAll(repo task.Store, ids []int64) ([]*task.Task, error) {
+	tasks := make([]*task.Task, 0, len(ids))
+	for _, id := range ids {
+		t, err := repo.GetByID(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) || errors.Is(err, task.ErrTaskNotFound) {
+				return nil, &task.NotFoundError{ID: id}
+			}
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
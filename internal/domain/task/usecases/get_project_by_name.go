@@ -3,7 +3,7 @@ package usecases
 import "github.com/devbydaniel/tt/internal/domain/task"
 
 type GetProjectByName struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (g *GetProjectByName) Execute(name string) (*task.Task, error) {
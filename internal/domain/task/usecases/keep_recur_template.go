@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// KeepRecurTemplate pins the fields later occurrences of a recurring task are
+// created from, so that edits made afterwards only affect this occurrence.
+type KeepRecurTemplate struct {
+	Repo task.Store
+}
+
+func (k *KeepRecurTemplate) Execute(id int64) (*task.Task, error) {
+	t, err := k.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	// Non-recurring tasks have no later occurrences; an existing template
+	// already holds the right fields.
+	if t.RecurType == nil || t.RecurTemplate != nil {
+		return t, nil
+	}
+
+	tmpl, err := t.Template().ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	t.RecurTemplate = &tmpl
+
+	if err := k.Repo.Update(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
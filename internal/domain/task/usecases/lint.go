@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/area"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// AreaListForLint is what this use case needs from the area domain
+type AreaListForLint interface {
+	Execute() ([]area.Area, error)
+}
+
+// LintTasks looks for tasks, projects and tags that are probably mistakes
+type LintTasks struct {
+	Repo  task.Store
+	Areas AreaListForLint
+	Clock clock.Clock
+}
+
+// Execute returns the findings. Projects without open tasks are reported
+// once they're older than emptyDays.
+func (l *LintTasks) Execute(emptyDays int) (*task.LintReport, error) {
+	areas, err := l.Areas.Execute()
+	if err != nil {
+		return nil, err
+	}
+	areaNames := make(map[int64]string, len(areas))
+	for _, a := range areas {
+		areaNames[a.ID] = a.Name
+	}
+
+	projects, err := l.Repo.List(&task.ListFilter{TaskType: task.TaskTypeProject})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]task.Task, len(projects))
+	for _, p := range projects {
+		byID[p.ID] = p
+	}
+
+	report := &task.LintReport{}
+	openTasks := make(map[int64]int)
+	uses := make(map[string]int)
+	err = l.Repo.ListIter(&task.ListFilter{TaskType: task.TaskTypeTask}, func(t task.Task) error {
+		for _, tag := range t.Tags {
+			uses[tag]++
+		}
+		if t.ParentID == nil {
+			return nil
+		}
+		openTasks[*t.ParentID]++
+		p, ok := byID[*t.ParentID]
+		if ok && t.AreaID != nil && p.AreaID != nil && *t.AreaID != *p.AreaID {
+			report.AreaConflicts = append(report.AreaConflicts, task.AreaConflict{
+				Task:        t,
+				Area:        areaNames[*t.AreaID],
+				Project:     p.Title,
+				ProjectArea: areaNames[*p.AreaID],
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = l.Repo.ListCompletedIter(&task.CompletedFilter{}, func(t task.Task) error {
+		for _, tag := range t.Tags {
+			uses[tag]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := clock.Now(l.Clock).AddDate(0, 0, -emptyDays)
+	for _, p := range projects {
+		for _, tag := range p.Tags {
+			uses[tag]++
+		}
+		if p.AreaID == nil {
+			report.ProjectsNoArea = append(report.ProjectsNoArea, p)
+		}
+		if openTasks[p.ID] == 0 && p.CreatedAt.Before(cutoff) {
+			report.EmptyProjects = append(report.EmptyProjects, p)
+		}
+	}
+	byTitle := func(a, b task.Task) int {
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	}
+	slices.SortFunc(report.ProjectsNoArea, byTitle)
+	slices.SortFunc(report.EmptyProjects, byTitle)
+
+	for tag, n := range uses {
+		if n == 1 {
+			report.SingleUseTags = append(report.SingleUseTags, tag)
+		}
+	}
+	slices.Sort(report.SingleUseTags)
+	return report, nil
+}
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/domain/area"
 	"github.com/devbydaniel/tt/internal/domain/task"
 )
@@ -16,12 +17,44 @@ type AreaLookupForList interface {
 }
 
 type ListTasks struct {
-	Repo          *task.Repository
+	Repo          task.Store
 	ProjectLookup ProjectLookupForList
 	AreaLookup    AreaLookupForList
+	Schedule      task.ScheduleSettings
+	Clock         clock.Clock
 }
 
+// Execute lists the matching tasks. The Today list starts with the tasks
+// pinned with OrderToday.
 func (l *ListTasks) Execute(opts *task.ListOptions) ([]task.Task, error) {
+	filter, err := l.filter(opts)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := l.Repo.List(filter)
+	if err != nil || !filter.Today {
+		return tasks, err
+	}
+	pinned, err := l.Repo.DayOrder(*filter.Date)
+	if err != nil {
+		return nil, err
+	}
+	return pinToday(tasks, pinned), nil
+}
+
+// Each calls fn for every matching task as it is read from the database,
+// so exports of large lists don't hold them all in memory. It keeps to the
+// sort, without the order pinned for Today.
+func (l *ListTasks) Each(opts *task.ListOptions, fn func(task.Task) error) error {
+	filter, err := l.filter(opts)
+	if err != nil {
+		return err
+	}
+	return l.Repo.ListIter(filter, fn)
+}
+
+// filter turns user-facing options into a repository filter
+func (l *ListTasks) filter(opts *task.ListOptions) (*task.ListFilter, error) {
 	filter := &task.ListFilter{}
 
 	if opts != nil {
@@ -50,6 +83,20 @@ func (l *ListTasks) Execute(opts *task.ListOptions) ([]task.Task, error) {
 		if opts.Search != "" {
 			filter.Search = opts.Search
 		}
+		if opts.Context != "" {
+			context, err := task.ParseContext(opts.Context)
+			if err != nil {
+				return nil, err
+			}
+			filter.Context = context
+		}
+		if opts.Assignee != "" {
+			filter.Assignee = opts.Assignee
+		}
+		filter.Flagged = opts.Flagged
+		if opts.Location != "" {
+			filter.Location = task.ParseLocation(opts.Location)
+		}
 		if len(opts.Sort) > 0 {
 			filter.Sort = opts.Sort
 		}
@@ -59,11 +106,17 @@ func (l *ListTasks) Execute(opts *task.ListOptions) ([]task.Task, error) {
 			filter.State = opts.State
 		}
 
+		today := l.Schedule.Today(clock.Now(l.Clock))
 		switch opts.Schedule {
 		case "today":
 			filter.Today = true
+			filter.Date = &today
 		case "upcoming":
 			filter.Upcoming = true
+			filter.Date = &today
+			if !opts.AllUpcoming {
+				filter.Until = l.Schedule.UpcomingUntil(today)
+			}
 		case "anytime":
 			filter.Anytime = true
 			filter.TaskType = task.TaskTypeTask // Only show tasks, not projects
@@ -77,5 +130,5 @@ func (l *ListTasks) Execute(opts *task.ListOptions) ([]task.Task, error) {
 		}
 	}
 
-	return l.Repo.List(filter)
+	return filter, nil
 }
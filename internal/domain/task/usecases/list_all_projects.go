@@ -3,7 +3,7 @@ package usecases
 import "github.com/devbydaniel/tt/internal/domain/task"
 
 type ListAllProjects struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (l *ListAllProjects) Execute() ([]task.Task, error) {
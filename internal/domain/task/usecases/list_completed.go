@@ -1,15 +1,62 @@
 package usecases
 
-import (
-	"time"
-
-	"github.com/devbydaniel/tt/internal/domain/task"
-)
+import "github.com/devbydaniel/tt/internal/domain/task"
 
 type ListCompletedTasks struct {
-	Repo *task.Repository
+	Repo          task.Store
+	ProjectLookup ProjectLookupForList
+	AreaLookup    AreaLookupForList
+}
+
+func (l *ListCompletedTasks) Execute(opts *task.CompletedOptions) ([]task.Task, error) {
+	filter, err := l.filter(opts)
+	if err != nil {
+		return nil, err
+	}
+	return l.Repo.ListCompleted(filter)
 }
 
-func (l *ListCompletedTasks) Execute(since *time.Time) ([]task.Task, error) {
-	return l.Repo.ListCompleted(since)
+// Each calls fn for every matching task as it is read from the database,
+// so exports of a large logbook don't hold it all in memory
+func (l *ListCompletedTasks) Each(opts *task.CompletedOptions, fn func(task.Task) error) error {
+	filter, err := l.filter(opts)
+	if err != nil {
+		return err
+	}
+	return l.Repo.ListCompletedIter(filter, fn)
+}
+
+// filter turns user-facing options into a repository filter
+func (l *ListCompletedTasks) filter(opts *task.CompletedOptions) (*task.CompletedFilter, error) {
+	filter := &task.CompletedFilter{}
+
+	if opts != nil {
+		if opts.ProjectName != "" {
+			p, err := l.ProjectLookup.Execute(opts.ProjectName)
+			if err != nil {
+				return nil, err
+			}
+			filter.ParentID = &p.ID
+		}
+		if opts.AreaName != "" {
+			a, err := l.AreaLookup.Execute(opts.AreaName)
+			if err != nil {
+				return nil, err
+			}
+			filter.AreaID = &a.ID
+		}
+		filter.TagName = opts.TagName
+		filter.Search = opts.Search
+		if opts.Since != nil {
+			since := dateOnly(*opts.Since)
+			filter.Since = &since
+		}
+		if opts.Until != nil {
+			// Until is inclusive: everything before the following midnight
+			until := dateOnly(*opts.Until).AddDate(0, 0, 1)
+			filter.Until = &until
+		}
+	}
+
+	return filter, nil
 }
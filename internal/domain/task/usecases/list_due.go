@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type ListDue struct {
+	Repo task.Store
+}
+
+// Execute returns active tasks and projects with a due date, overdue ones
+// included, soonest first. until limits them to those due on or before it
+// (nil = no limit). Planned dates play no part.
+func (l *ListDue) Execute(until *time.Time) ([]task.Task, error) {
+	return l.Repo.List(&task.ListFilter{
+		State: task.StateActive,
+		Due:   true,
+		Until: until,
+		Sort: []task.SortOption{
+			{Field: task.SortByDue, Direction: task.SortAsc},
+			{Field: task.SortByID, Direction: task.SortAsc},
+		},
+	})
+}
@@ -3,7 +3,7 @@ package usecases
 import "github.com/devbydaniel/tt/internal/domain/task"
 
 type ListProjects struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (l *ListProjects) Execute() ([]task.Task, error) {
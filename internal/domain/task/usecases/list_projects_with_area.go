@@ -3,7 +3,7 @@ package usecases
 import "github.com/devbydaniel/tt/internal/domain/task"
 
 type ListProjectsWithArea struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 // Execute returns all active projects with their area names populated
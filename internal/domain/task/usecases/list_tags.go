@@ -1,11 +1,47 @@
 package usecases
 
-import "github.com/devbydaniel/tt/internal/domain/task"
+import (
+	"errors"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
 
 type ListTags struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (l *ListTags) Execute() ([]string, error) {
 	return l.Repo.ListTags()
 }
+
+type ListLocations struct {
+	Repo task.Store
+}
+
+func (l *ListLocations) Execute() ([]string, error) {
+	return l.Repo.ListLocations()
+}
+
+type SetTagColor struct {
+	Repo task.Store
+}
+
+// Execute sets the color tag chips are shown in; an empty color removes it.
+// The color isn't checked here: callers validate it against the theme's
+// color syntax.
+func (s *SetTagColor) Execute(name, color string) error {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "#")
+	if name == "" {
+		return errors.New("tag name cannot be empty")
+	}
+	return s.Repo.SetTagColor(name, strings.TrimSpace(color))
+}
+
+type ListTagColors struct {
+	Repo task.Store
+}
+
+func (l *ListTagColors) Execute() (map[string]string, error) {
+	return l.Repo.TagColors()
+}
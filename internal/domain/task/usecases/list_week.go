@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type ListWeek struct {
+	Repo     task.Store
+	Schedule task.ScheduleSettings
+	Clock    clock.Clock
+}
+
+// Execute returns the week containing today, shifted by offset weeks
+// (negative for past weeks, positive for future weeks).
+func (l *ListWeek) Execute(offset int, sort []task.SortOption) (*task.Week, error) {
+	today := l.Schedule.Today(clock.Now(l.Clock))
+	// Weeks start on Monday
+	daysSinceMonday := (int(today.Weekday()) + 6) % 7
+	start := today.AddDate(0, 0, -daysSinceMonday+offset*7)
+	return l.ExecuteFrom(start, sort)
+}
+
+// ExecuteFrom returns the seven days beginning at start, regardless of weekday
+func (l *ListWeek) ExecuteFrom(start time.Time, sort []task.SortOption) (*task.Week, error) {
+	end := start.AddDate(0, 0, 6)
+
+	tasks, err := l.Repo.List(&task.ListFilter{
+		State: task.StateActive,
+		From:  &start,
+		To:    &end,
+		Sort:  sort,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	week := &task.Week{Start: start}
+	for _, t := range tasks {
+		day := weekDayIndex(start, t.PlannedDate)
+		if day < 0 {
+			day = weekDayIndex(start, t.DueDate)
+		}
+		if day < 0 {
+			continue
+		}
+		week.Days[day] = append(week.Days[day], t)
+	}
+	return week, nil
+}
+
+// weekDayIndex returns the 0-based day of d within the week, or -1 if outside
+func weekDayIndex(start time.Time, d *time.Time) int {
+	if d == nil {
+		return -1
+	}
+	date := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, start.Location())
+	for i := 0; i < 7; i++ {
+		if start.AddDate(0, 0, i).Equal(date) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"slices"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/holiday"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/recurparse"
+)
+
+// HolidayLookup is what recurring use cases need from the holiday domain
+type HolidayLookup interface {
+	Execute() ([]holiday.Holiday, error)
+}
+
+// blackout returns the holidays occurrences are kept off, handled as
+// settings say. A nil lookup blocks nothing.
+func blackout(lookup HolidayLookup, settings task.RecurrenceSettings) (recurparse.Blackout, error) {
+	b := recurparse.Blackout{Shift: settings.HolidayMode == task.HolidayShift}
+	if lookup == nil {
+		return b, nil
+	}
+	holidays, err := lookup.Execute()
+	if err != nil {
+		return b, err
+	}
+	b.Dates = make(map[string]bool, len(holidays))
+	for _, h := range holidays {
+		b.Dates[h.Key()] = true
+	}
+	return b, nil
+}
+
+// seriesRoot returns the ID of the task that started t's recurring series
+func seriesRoot(t *task.Task) int64 {
+	if t.RecurParentID != nil {
+		return *t.RecurParentID
+	}
+	return t.ID
+}
+
+// occurrenceDate returns the date an occurrence is scheduled for: its due
+// date if it has one, else its planned date.
+func occurrenceDate(t *task.Task) *time.Time {
+	if t.DueDate != nil {
+		return t.DueDate
+	}
+	return t.PlannedDate
+}
+
+// seriesDone reports whether t's recurring series has had all the
+// occurrences its count allows
+func seriesDone(repo task.Store, t *task.Task) (bool, error) {
+	if t.RecurCount == nil {
+		return false, nil
+	}
+	n, err := repo.CountOccurrences(seriesRoot(t))
+	if err != nil {
+		return false, err
+	}
+	return n >= *t.RecurCount, nil
+}
+
+// createOccurrence creates the occurrence of source's series on date, from
+// source's template, as created at now. The date goes in the same field
+// source uses.
+func createOccurrence(repo task.Store, source *task.Task, date, now time.Time) (*task.Task, error) {
+	var plannedDate, dueDate *time.Time
+	if source.DueDate != nil {
+		dueDate = &date
+	} else {
+		plannedDate = &date
+	}
+	root := seriesRoot(source)
+
+	// Create the next task from the series template, which differs from the
+	// source only if it was edited with --this-only
+	tmpl := source.Template()
+	next := &task.Task{
+		UUID:          task.NewUUID(now),
+		Title:         tmpl.Title,
+		Description:   tmpl.Description,
+		TaskType:      task.TaskTypeTask,
+		ParentID:      tmpl.ParentID,
+		AreaID:        tmpl.AreaID,
+		PlannedDate:   plannedDate,
+		DueDate:       dueDate,
+		State:         task.StateActive,
+		Status:        task.StatusTodo,
+		CreatedAt:     now,
+		RecurType:     source.RecurType,
+		RecurRule:     source.RecurRule,
+		RecurEnd:      source.RecurEnd,
+		RecurCount:    source.RecurCount,
+		RecurParentID: &root,
+		Estimate:      tmpl.Estimate,
+		Context:       tmpl.Context,
+		Assignee:      source.Assignee,
+		Creator:       source.Creator,
+		Location:      source.Location,
+	}
+
+	tags := tmpl.Tags
+	if source.RecurRule != nil {
+		if rule, err := recurparse.FromJSON(*source.RecurRule); err == nil && rule.Rotate != nil {
+			tags = rotate(rule.Rotate, source, next, tags)
+		}
+	}
+
+	if err := repo.Create(next); err != nil {
+		return nil, err
+	}
+
+	// Copy tags from the template
+	for _, tag := range tags {
+		if err := repo.AddTag(next.ID, tag); err != nil {
+			return nil, err
+		}
+	}
+	next.Tags = tags
+
+	return next, nil
+}
+
+// rotate hands next to whoever follows source's tag or assignee in the
+// rotation, and returns next's tags.
+func rotate(r *recurparse.Rotation, source, next *task.Task, tags []string) []string {
+	if r.Field == recurparse.RotateAssignee {
+		current := ""
+		if source.Assignee != nil {
+			current = *source.Assignee
+		}
+		assignee := r.Next(current)
+		next.Assignee = &assignee
+		return tags
+	}
+
+	current := ""
+	for _, tag := range source.Tags {
+		if slices.Contains(r.Values, tag) {
+			current = tag
+			break
+		}
+	}
+	var rotated []string
+	for _, tag := range tags {
+		if !slices.Contains(r.Values, tag) {
+			rotated = append(rotated, tag)
+		}
+	}
+	return append(rotated, r.Next(current))
+}
+
+// materializeAhead creates occurrences of t's fixed recurring series until
+// one is scheduled beyond the current one, so upcoming occurrences show up
+// before the current one is done. Dates blocked by b are avoided. Returns the
+// first occurrence created, or nil if the series is already ahead, has ended
+// or isn't a fixed recurrence. A series with no open occurrence continues
+// from now.
+func materializeAhead(repo task.Store, t *task.Task, b recurparse.Blackout, now time.Time) (*task.Task, error) {
+	if t.RecurType == nil || *t.RecurType != task.RecurTypeFixed || t.RecurRule == nil || t.RecurPaused {
+		return nil, nil
+	}
+	rule, err := recurparse.FromJSON(*t.RecurRule)
+	if err != nil {
+		return nil, err
+	}
+
+	root := seriesRoot(t)
+	open, err := repo.List(&task.ListFilter{Series: &root})
+	if err != nil {
+		return nil, err
+	}
+
+	var first *task.Task
+	for len(open) < 2 {
+		// With no open occurrence left, continue from today like a regular
+		// regeneration; otherwise follow the latest one
+		source := t
+		var next time.Time
+		if len(open) == 0 {
+			next = recurparse.NextOccurrence(rule, recurparse.TypeFixed, now)
+		} else {
+			source = latestOccurrence(open)
+			from := now
+			if date := occurrenceDate(source); date != nil {
+				from = *date
+			}
+			next = recurparse.NextAfter(rule, from)
+		}
+		next = b.Apply(rule, recurparse.TypeFixed, next)
+		if t.RecurEnd != nil && next.After(*t.RecurEnd) {
+			break
+		}
+		if done, err := seriesDone(repo, t); err != nil || done {
+			return first, err
+		}
+
+		created, err := createOccurrence(repo, source, next, now)
+		if err != nil {
+			return first, err
+		}
+		if first == nil {
+			first = created
+		}
+		open = append(open, *created)
+	}
+
+	return first, nil
+}
+
+// latestOccurrence returns the occurrence scheduled furthest ahead; undated
+// occurrences count as earliest.
+func latestOccurrence(occurrences []task.Task) *task.Task {
+	latest := &occurrences[0]
+	for i := range occurrences[1:] {
+		o := &occurrences[i+1]
+		date := occurrenceDate(o)
+		if date == nil {
+			continue
+		}
+		if current := occurrenceDate(latest); current == nil || date.After(*current) {
+			latest = o
+		}
+	}
+	return latest
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// OrderToday pins the order of the first tasks of today's Today list, a
+// plan for the day on top of the configured sort. The order lapses when
+// the day ends.
+type OrderToday struct {
+	Repo     task.Store
+	Schedule task.ScheduleSettings
+	Clock    clock.Clock
+}
+
+// Execute pins the tasks in the given order; no IDs clear the order
+func (o *OrderToday) Execute(ids []int64) error {
+	for _, id := range ids {
+		t, err := o.Repo.GetByID(id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return task.ErrTaskNotFound
+			}
+			return err
+		}
+		if t.Status == task.StatusDone {
+			return fmt.Errorf("task %d is already done", id)
+		}
+	}
+	return o.Repo.SetDayOrder(o.Schedule.Today(clock.Now(o.Clock)), ids)
+}
+
+// pinToday moves the tasks pinned for today to the front of tasks, in their
+// pinned order. The others keep their order after them.
+func pinToday(tasks []task.Task, pinned []int64) []task.Task {
+	if len(pinned) == 0 {
+		return tasks
+	}
+	rank := make(map[int64]int, len(pinned))
+	for i, id := range pinned {
+		rank[id] = i
+	}
+
+	first := make([]task.Task, len(pinned))
+	found := make([]bool, len(pinned))
+	rest := make([]task.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if i, ok := rank[t.ID]; ok {
+			first[i], found[i] = t, true
+		} else {
+			rest = append(rest, t)
+		}
+	}
+
+	ordered := make([]task.Task, 0, len(tasks))
+	for i, t := range first {
+		if found[i] {
+			ordered = append(ordered, t)
+		}
+	}
+	return append(ordered, rest...)
+}
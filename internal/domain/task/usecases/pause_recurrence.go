@@ -8,7 +8,7 @@ import (
 )
 
 type PauseRecurrence struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (p *PauseRecurrence) Execute(id int64) (*task.Task, error) {
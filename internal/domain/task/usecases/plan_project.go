@@ -0,0 +1,213 @@
+package usecases
+
+import (
+	"cmp"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// PlanProject proposes planned dates for the open tasks of a project: each
+// starts once the tasks it depends on are done, and no day gets more
+// estimated work than the daily capacity. Weekends and holidays are skipped.
+type PlanProject struct {
+	Repo          task.Store
+	ProjectLookup ProjectLookup
+	Holidays      HolidayLookup
+	Schedule      task.ScheduleSettings
+	Clock         clock.Clock
+}
+
+// Execute plans the project with capacity minutes of work a day, starting
+// today. With apply set it plans the tasks for their start days.
+func (p *PlanProject) Execute(name string, capacity int, apply bool) (*task.ProjectPlan, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("invalid daily capacity: %d minutes (must be positive)", capacity)
+	}
+	project, err := p.ProjectLookup.Execute(name)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := p.Repo.List(&task.ListFilter{ParentID: &project.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	day := func(t time.Time) string { return t.Format("2006-01-02") }
+	off := map[string]bool{}
+	if p.Holidays != nil {
+		holidays, err := p.Holidays.Execute()
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range holidays {
+			off[h.Key()] = true
+		}
+	}
+	// workday returns d, or the first day after it that isn't off
+	workday := func(d time.Time) time.Time {
+		for d.Weekday() == time.Saturday || d.Weekday() == time.Sunday || off[day(d)] {
+			d = d.AddDate(0, 0, 1)
+		}
+		return d
+	}
+
+	// Tasks wait for open tasks of the project; ones elsewhere only hold
+	// them back until their own planned date
+	open := make(map[int64]bool, len(tasks))
+	for _, t := range tasks {
+		open[t.ID] = true
+	}
+	today := p.Schedule.Today(clock.Now(p.Clock))
+	dependsOn := map[int64][]int64{}
+	notBefore := map[int64]time.Time{}
+	for _, t := range tasks {
+		notBefore[t.ID] = today
+		ids, err := p.Repo.Dependencies(t.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			if open[id] {
+				dependsOn[t.ID] = append(dependsOn[t.ID], id)
+				continue
+			}
+			dep, err := p.Repo.GetByID(id)
+			if errors.Is(err, sql.ErrNoRows) || errors.Is(err, task.ErrTaskNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if dep.Status == task.StatusTodo && dep.PlannedDate != nil && dep.PlannedDate.After(notBefore[t.ID]) {
+				notBefore[t.ID] = *dep.PlannedDate
+			}
+		}
+	}
+
+	order, err := dependencyOrder(tasks, dependsOn)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &task.ProjectPlan{Project: *project, Capacity: capacity}
+	used := map[string]int{} // minutes planned per day
+	finish := map[int64]time.Time{}
+	for _, t := range order {
+		start := notBefore[t.ID]
+		for _, id := range dependsOn[t.ID] {
+			if finish[id].After(start) {
+				start = finish[id]
+			}
+		}
+		start = workday(start)
+
+		minutes := 0
+		if t.Estimate != nil {
+			minutes = *t.Estimate
+		}
+		// A task that fits in a day starts on a day with room for all of
+		// it; a longer one starts on the first day with any room and runs on
+		for used[day(start)] >= capacity || (minutes <= capacity && used[day(start)]+minutes > capacity) {
+			start = workday(start.AddDate(0, 0, 1))
+		}
+		end := start
+		for left := minutes; left > 0; {
+			if used[day(end)] >= capacity {
+				end = workday(end.AddDate(0, 0, 1))
+				continue
+			}
+			take := min(capacity-used[day(end)], left)
+			used[day(end)] += take
+			left -= take
+		}
+		finish[t.ID] = end
+
+		plan.Steps = append(plan.Steps, task.PlanStep{Task: t, Start: start, Finish: end, DependsOn: dependsOn[t.ID]})
+	}
+
+	if apply {
+		for i := range plan.Steps {
+			step := &plan.Steps[i]
+			if step.Task.PlannedDate != nil && day(*step.Task.PlannedDate) == day(step.Start) && step.Task.State == task.StateActive {
+				continue
+			}
+			step.Task.PlannedDate = &step.Start
+			step.Task.State = task.StateActive
+			if err := p.Repo.Update(&step.Task); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return plan, nil
+}
+
+// dependencyOrder sorts tasks so each comes after the tasks it depends on.
+// Of the tasks that can go next, the one due first goes first, then the
+// oldest.
+func dependencyOrder(tasks []task.Task, dependsOn map[int64][]int64) ([]task.Task, error) {
+	waiting := make(map[int64]int, len(tasks))
+	dependents := map[int64][]int64{}
+	byID := make(map[int64]task.Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+		waiting[t.ID] = len(dependsOn[t.ID])
+		for _, id := range dependsOn[t.ID] {
+			dependents[id] = append(dependents[id], t.ID)
+		}
+	}
+
+	var ready []task.Task
+	for _, t := range tasks {
+		if waiting[t.ID] == 0 {
+			ready = append(ready, t)
+		}
+	}
+	order := make([]task.Task, 0, len(tasks))
+	for len(ready) > 0 {
+		slices.SortFunc(ready, func(a, b task.Task) int {
+			if c := compareDue(a.DueDate, b.DueDate); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.ID, b.ID)
+		})
+		next := ready[0]
+		ready = ready[1:]
+		order = append(order, next)
+		for _, id := range dependents[next.ID] {
+			if waiting[id]--; waiting[id] == 0 {
+				ready = append(ready, byID[id])
+			}
+		}
+	}
+
+	if len(order) < len(tasks) {
+		var stuck []string
+		for _, t := range tasks {
+			if waiting[t.ID] > 0 {
+				stuck = append(stuck, fmt.Sprintf("#%d", t.ID))
+			}
+		}
+		return nil, fmt.Errorf("tasks depend on each other in a circle: %s", strings.Join(stuck, ", "))
+	}
+	return order, nil
+}
+
+// compareDue orders due dates earliest first, tasks without one last
+func compareDue(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(*b)
+}
@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// PropagateRecurEdit carries edited fields of a recurring task over to its
+// stored template and to the other open occurrences of its series, so a
+// correction doesn't reappear with the next cycle.
+type PropagateRecurEdit struct {
+	Repo task.Store
+}
+
+// Execute copies the given fields (task.FieldTitle, ...) from task id and
+// returns the number of other occurrences that were updated.
+func (p *PropagateRecurEdit) Execute(id int64, fields []string) (int, error) {
+	t, err := p.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, task.ErrTaskNotFound
+		}
+		return 0, err
+	}
+	if t.RecurType == nil || len(fields) == 0 {
+		return 0, nil
+	}
+
+	if t.RecurTemplate != nil {
+		if err := updateRecurTemplate(t, t, fields); err != nil {
+			return 0, err
+		}
+		if err := p.Repo.Update(t); err != nil {
+			return 0, err
+		}
+	}
+
+	root := t.ID
+	if t.RecurParentID != nil {
+		root = *t.RecurParentID
+	}
+	occurrences, err := p.Repo.List(&task.ListFilter{Series: &root})
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for i := range occurrences {
+		o := &occurrences[i]
+		if o.ID == t.ID {
+			continue
+		}
+		copyRecurFields(o, t, fields)
+		if o.RecurTemplate != nil {
+			if err := updateRecurTemplate(o, t, fields); err != nil {
+				return updated, err
+			}
+		}
+		if err := p.Repo.Update(o); err != nil {
+			return updated, err
+		}
+		if slices.Contains(fields, task.FieldTags) {
+			if err := p.Repo.SetTags(o.ID, t.Tags); err != nil {
+				return updated, err
+			}
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+// updateRecurTemplate copies fields from src into dst's stored template
+func updateRecurTemplate(dst, src *task.Task, fields []string) error {
+	tmpl := dst.Template()
+	for _, field := range fields {
+		switch field {
+		case task.FieldTitle:
+			tmpl.Title = src.Title
+		case task.FieldDescription:
+			tmpl.Description = src.Description
+		case task.FieldProject, task.FieldArea:
+			tmpl.ParentID, tmpl.AreaID = src.ParentID, src.AreaID
+		case task.FieldTags:
+			tmpl.Tags = src.Tags
+		case task.FieldEstimate:
+			tmpl.Estimate = src.Estimate
+		case task.FieldContext:
+			tmpl.Context = src.Context
+		}
+	}
+	data, err := tmpl.ToJSON()
+	if err != nil {
+		return err
+	}
+	dst.RecurTemplate = &data
+	return nil
+}
+
+// copyRecurFields copies fields from src to dst. Project and area move
+// together since a task belongs to one or the other.
+func copyRecurFields(dst, src *task.Task, fields []string) {
+	for _, field := range fields {
+		switch field {
+		case task.FieldTitle:
+			dst.Title = src.Title
+		case task.FieldDescription:
+			dst.Description = src.Description
+		case task.FieldProject, task.FieldArea:
+			dst.ParentID, dst.AreaID = src.ParentID, src.AreaID
+		case task.FieldTags:
+			dst.Tags = src.Tags
+		case task.FieldEstimate:
+			dst.Estimate = src.Estimate
+		case task.FieldContext:
+			dst.Context = src.Context
+		}
+	}
+}
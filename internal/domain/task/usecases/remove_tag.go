@@ -8,7 +8,7 @@ import (
 )
 
 type RemoveTag struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (r *RemoveTag) Execute(id int64, tagName string) (*task.Task, error) {
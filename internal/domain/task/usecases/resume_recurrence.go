@@ -8,7 +8,7 @@ import (
 )
 
 type ResumeRecurrence struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (r *ResumeRecurrence) Execute(id int64) (*task.Task, error) {
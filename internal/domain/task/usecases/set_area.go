@@ -14,7 +14,7 @@ type AreaLookupForSetArea interface {
 }
 
 type SetTaskArea struct {
-	Repo       *task.Repository
+	Repo       task.Store
 	AreaLookup AreaLookupForSetArea
 }
 
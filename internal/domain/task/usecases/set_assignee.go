@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SetAssignee struct {
+	Repo task.Store
+}
+
+// Execute assigns the task to assignee; an empty assignee clears it
+func (s *SetAssignee) Execute(id int64, assignee string) (*task.Task, error) {
+	t, err := s.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	if assignee = strings.TrimSpace(assignee); assignee == "" {
+		t.Assignee = nil
+	} else {
+		t.Assignee = &assignee
+	}
+
+	if err := s.Repo.Update(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SetCompletionNote struct {
+	Repo task.Store
+}
+
+// Execute sets the note on a completed or canceled task; nil clears it
+func (s *SetCompletionNote) Execute(id int64, note *string) (*task.Task, error) {
+	t, err := s.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, err
+	}
+	if t.Status == task.StatusTodo {
+		return nil, fmt.Errorf("#%d is still open", id)
+	}
+
+	t.CompletionNote = note
+
+	if err := s.Repo.Update(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SetContext struct {
+	Repo task.Store
+}
+
+// Execute sets the task's context label; an empty context clears it
+func (s *SetContext) Execute(id int64, context string) (*task.Task, error) {
+	t, err := s.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	if context == "" {
+		t.Context = nil
+	} else {
+		parsed, err := task.ParseContext(context)
+		if err != nil {
+			return nil, err
+		}
+		t.Context = &parsed
+	}
+
+	if err := s.Repo.Update(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SetDependencies struct {
+	Repo task.Store
+}
+
+// Execute sets the tasks that have to be done before a task can start; no
+// IDs clear them. A task can't depend on itself, directly or through others.
+func (s *SetDependencies) Execute(id int64, dependsOn []int64) (*task.Task, error) {
+	t, err := s.getTask(id)
+	if err != nil {
+		return nil, err
+	}
+
+	dependsOn = slices.Compact(slices.Sorted(slices.Values(dependsOn)))
+	for _, dep := range dependsOn {
+		if dep == id {
+			return nil, fmt.Errorf("#%d can't depend on itself", id)
+		}
+		if _, err := s.getTask(dep); err != nil {
+			return nil, fmt.Errorf("#%d: %w", dep, err)
+		}
+		if err := s.checkCycle(id, dep); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.Repo.SetDependencies(id, dependsOn); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// checkCycle returns an error if dep depends on id, directly or through
+// other tasks
+func (s *SetDependencies) checkCycle(id, dep int64) error {
+	seen := map[int64]bool{}
+	queue := []int64{dep}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if seen[next] {
+			continue
+		}
+		seen[next] = true
+		ids, err := s.Repo.Dependencies(next)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(ids, id) {
+			return fmt.Errorf("#%d already depends on #%d", dep, id)
+		}
+		queue = append(queue, ids...)
+	}
+	return nil
+}
+
+func (s *SetDependencies) getTask(id int64) (*task.Task, error) {
+	t, err := s.Repo.GetByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, task.ErrTaskNotFound
+	}
+	return t, err
+}
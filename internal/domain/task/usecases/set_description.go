@@ -8,7 +8,7 @@ import (
 )
 
 type SetTaskDescription struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (s *SetTaskDescription) Execute(id int64, description *string) (*task.Task, error) {
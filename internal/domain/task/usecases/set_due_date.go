@@ -9,7 +9,7 @@ import (
 )
 
 type SetDueDate struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (s *SetDueDate) Execute(id int64, date *time.Time) (*task.Task, error) {
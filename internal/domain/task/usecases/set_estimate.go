@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SetEstimate struct {
+	Repo task.Store
+}
+
+// Execute sets the effort estimate in minutes; nil clears it
+func (s *SetEstimate) Execute(id int64, minutes *int) (*task.Task, error) {
+	t, err := s.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	t.Estimate = minutes
+
+	if err := s.Repo.Update(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SetExpires struct {
+	Repo task.Store
+}
+
+// Execute sets the date after which the task expires; nil clears it
+func (s *SetExpires) Execute(id int64, date *time.Time) (*task.Task, error) {
+	t, err := s.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	t.Expires = date
+
+	if err := s.Repo.Update(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,25 @@
+package usecases
+
+import "github.com/devbydaniel/tt/internal/domain/task"
+
+type SetFlagged struct {
+	Repo task.Store
+}
+
+// Execute flags or unflags the tasks, all of which must exist
+func (s *SetFlagged) Execute(ids []int64, flagged bool) ([]task.Task, error) {
+	tasks, err := getAll(s.Repo, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []task.Task
+	for _, t := range tasks {
+		t.Flagged = flagged
+		if err := s.Repo.Update(t); err != nil {
+			return nil, err
+		}
+		changed = append(changed, *t)
+	}
+	return changed, nil
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SetLocation struct {
+	Repo task.Store
+}
+
+// Execute sets where the task can be done; an empty location clears it
+func (s *SetLocation) Execute(id int64, location string) (*task.Task, error) {
+	t, err := s.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	if location = task.ParseLocation(location); location == "" {
+		t.Location = nil
+	} else {
+		t.Location = &location
+	}
+
+	if err := s.Repo.Update(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type SetPlannedDate struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (s *SetPlannedDate) Execute(id int64, date *time.Time) (*task.Task, error) {
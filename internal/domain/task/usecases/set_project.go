@@ -13,7 +13,7 @@ type ProjectLookupForSetProject interface {
 }
 
 type SetTaskProject struct {
-	Repo          *task.Repository
+	Repo          task.Store
 	ProjectLookup ProjectLookupForSetProject
 }
 
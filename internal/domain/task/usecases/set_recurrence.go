@@ -5,14 +5,18 @@ import (
 	"errors"
 	"time"
 
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/domain/task"
 )
 
 type SetRecurrence struct {
-	Repo *task.Repository
+	Repo       task.Store
+	Recurrence task.RecurrenceSettings
+	Holidays   HolidayLookup
+	Clock      clock.Clock
 }
 
-func (s *SetRecurrence) Execute(id int64, recurType, recurRule *string, recurEnd *time.Time) (*task.Task, error) {
+func (s *SetRecurrence) Execute(id int64, recurType, recurRule *string, recurEnd *time.Time, recurCount *int) (*task.Task, error) {
 	t, err := s.Repo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -24,6 +28,7 @@ func (s *SetRecurrence) Execute(id int64, recurType, recurRule *string, recurEnd
 	t.RecurType = recurType
 	t.RecurRule = recurRule
 	t.RecurEnd = recurEnd
+	t.RecurCount = recurCount
 
 	// If setting recurrence, unpause
 	if recurType != nil {
@@ -34,5 +39,15 @@ func (s *SetRecurrence) Execute(id int64, recurType, recurRule *string, recurEnd
 		return nil, err
 	}
 
+	if s.Recurrence.Ahead && t.Status == task.StatusTodo {
+		b, err := blackout(s.Holidays, s.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := materializeAhead(s.Repo, t, b, clock.Now(s.Clock)); err != nil {
+			return nil, err
+		}
+	}
+
 	return t, nil
 }
@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SetRecurrenceCount struct {
+	Repo task.Store
+}
+
+func (s *SetRecurrenceCount) Execute(id int64, count *int) (*task.Task, error) {
+	t, err := s.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	t.RecurCount = count
+
+	if err := s.Repo.Update(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
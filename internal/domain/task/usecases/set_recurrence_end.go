@@ -9,7 +9,7 @@ import (
 )
 
 type SetRecurrenceEnd struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (s *SetRecurrenceEnd) Execute(id int64, endDate *time.Time) (*task.Task, error) {
@@ -8,7 +8,7 @@ import (
 )
 
 type SetTags struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (s *SetTags) Execute(id int64, tags []string) (*task.Task, error) {
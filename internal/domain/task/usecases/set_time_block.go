@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SetTimeBlock struct {
+	Repo task.Store
+}
+
+// Execute sets aside minutes from start to work on an open task, planning it
+// for that day. A nil start clears the block and keeps the planned date.
+func (s *SetTimeBlock) Execute(id int64, start *time.Time, minutes int) (*task.Task, error) {
+	t, err := s.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	if start == nil {
+		t.BlockStart, t.BlockMinutes = nil, nil
+	} else {
+		if t.Status != task.StatusTodo {
+			return nil, fmt.Errorf("#%d is already %s", id, t.Status)
+		}
+		if minutes <= 0 {
+			return nil, fmt.Errorf("invalid block length: %d minutes (must be positive)", minutes)
+		}
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+		t.BlockStart, t.BlockMinutes = start, &minutes
+		t.PlannedDate = &day
+		t.State = task.StateActive
+	}
+
+	if err := s.Repo.Update(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
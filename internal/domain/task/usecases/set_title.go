@@ -8,7 +8,7 @@ import (
 )
 
 type SetTaskTitle struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
 func (s *SetTaskTitle) Execute(id int64, title string) (*task.Task, error) {
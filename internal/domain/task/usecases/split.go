@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SplitTask struct {
+	Repo     task.Store
+	Complete *CompleteTasks
+	Clock    clock.Clock
+	User     string // recorded as the creator of the new tasks (empty = unknown)
+}
+
+// Execute breaks a task into new tasks with the given titles, or its open
+// checklist items when there are none. The new tasks share its project or
+// area, dates, tags, context, location and assignee. The original is
+// completed unless keep is set.
+func (s *SplitTask) Execute(id int64, titles []string, keep bool) ([]task.Task, error) {
+	original, err := s.Repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, err
+	}
+	if original.IsProject() {
+		return nil, fmt.Errorf("#%d is a project; add tasks to it instead", id)
+	}
+	if original.Status == task.StatusDone {
+		return nil, fmt.Errorf("#%d is already done", id)
+	}
+
+	if len(titles) == 0 && original.Description != nil {
+		titles = task.OpenChecklistItems(*original.Description)
+	}
+	var pieces []string
+	for _, title := range titles {
+		if title = strings.TrimSpace(title); title != "" {
+			pieces = append(pieces, title)
+		}
+	}
+	if len(pieces) == 0 {
+		return nil, fmt.Errorf("nothing to split #%d into: it has no open checklist items", id)
+	}
+
+	now := clock.Now(s.Clock)
+	var created []task.Task
+	for _, title := range pieces {
+		t := &task.Task{
+			UUID:        task.NewUUID(now),
+			Title:       title,
+			TaskType:    task.TaskTypeTask,
+			State:       original.State,
+			Status:      task.StatusTodo,
+			CreatedAt:   now,
+			ParentID:    original.ParentID,
+			AreaID:      original.AreaID,
+			PlannedDate: original.PlannedDate,
+			DueDate:     original.DueDate,
+			Context:     original.Context,
+			Assignee:    original.Assignee,
+			Location:    original.Location,
+		}
+		if s.User != "" {
+			t.Creator = &s.User
+		}
+		if err := s.Repo.Create(t); err != nil {
+			return created, err
+		}
+		for _, tag := range original.Tags {
+			if err := s.Repo.AddTag(t.ID, tag); err != nil {
+				return created, err
+			}
+		}
+		t.Tags = original.Tags
+		created = append(created, *t)
+	}
+
+	if !keep {
+		if _, err := s.Complete.Execute([]int64{id}); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
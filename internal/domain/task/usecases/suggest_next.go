@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+type SuggestNext struct {
+	Repo     task.Store
+	Schedule task.ScheduleSettings
+	Clock    clock.Clock
+}
+
+// Execute returns the most actionable task, or nil if there is nothing to do.
+// Candidates are active tasks that are not planned for a future date.
+func (s *SuggestNext) Execute(opts task.SuggestOptions) (*task.Suggestion, error) {
+	filter := &task.ListFilter{TaskType: task.TaskTypeTask, State: task.StateActive}
+	if opts.Context != "" {
+		context, err := task.ParseContext(opts.Context)
+		if err != nil {
+			return nil, err
+		}
+		filter.Context = context
+	}
+
+	tasks, err := s.Repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	today := s.Schedule.Today(clock.Now(s.Clock))
+	var candidates []task.Task
+	for _, t := range tasks {
+		if t.PlannedDate != nil && dateOnly(*t.PlannedDate).After(today) {
+			continue
+		}
+		candidates = append(candidates, t)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	if opts.Random {
+		return &task.Suggestion{Task: candidates[rand.IntN(len(candidates))]}, nil
+	}
+
+	remaining := 0
+	if opts.Capacity > 0 {
+		remaining, err = s.remainingCapacity(today, opts.Capacity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var best *task.Suggestion
+	for _, t := range candidates {
+		score, reasons := scoreTask(&t, today, opts.Capacity > 0, remaining)
+		if best == nil || score > best.Score {
+			best = &task.Suggestion{Task: t, Score: score, Reasons: reasons}
+		}
+	}
+	return best, nil
+}
+
+// remainingCapacity subtracts today's completed estimates from the daily capacity
+func (s *SuggestNext) remainingCapacity(today time.Time, capacity int) (int, error) {
+	done, err := s.Repo.ListCompleted(&task.CompletedFilter{Since: &today})
+	if err != nil {
+		return 0, err
+	}
+	return capacity - task.TotalEstimate(done), nil
+}
+
+// scoreTask ranks a task by due proximity, whether it is planned, its age,
+// and whether its estimate fits in what is left of the day
+func scoreTask(t *task.Task, today time.Time, useCapacity bool, remaining int) (int, []task.SuggestionReason) {
+	score := 0
+	var reasons []task.SuggestionReason
+
+	if t.DueDate != nil {
+		days := task.DaysBetween(today, *t.DueDate)
+		switch {
+		case days < 0:
+			score += 100 + min(-days, 10)*5
+			reasons = append(reasons, task.SuggestionReason{Kind: task.ReasonOverdue, Days: -days})
+		case days == 0:
+			score += 80
+			reasons = append(reasons, task.SuggestionReason{Kind: task.ReasonDueToday})
+		case days <= 7:
+			score += 60 - days*6
+			reasons = append(reasons, task.SuggestionReason{Kind: task.ReasonDueSoon, Days: days})
+		}
+	}
+
+	if t.PlannedDate != nil {
+		score += 40
+		reasons = append(reasons, task.SuggestionReason{Kind: task.ReasonPlanned})
+	}
+
+	age := task.DaysBetween(t.CreatedAt, today)
+	if age > 0 {
+		score += min(age, 30)
+		if age >= 14 {
+			reasons = append(reasons, task.SuggestionReason{Kind: task.ReasonWaiting, Days: age})
+		}
+	}
+
+	if t.Estimate != nil {
+		switch {
+		case useCapacity && *t.Estimate > remaining:
+			score -= 30
+		case useCapacity:
+			score += 10
+			reasons = append(reasons, task.SuggestionReason{Kind: task.ReasonFitsCapacity})
+		case *t.Estimate <= 15:
+			score += 5
+			reasons = append(reasons, task.SuggestionReason{Kind: task.ReasonQuickWin})
+		}
+	}
+
+	return score, reasons
+}
+
+// dateOnly truncates a time to midnight local time of its calendar date
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -3,22 +3,76 @@ package usecases
 import "github.com/devbydaniel/tt/internal/domain/task"
 
 type UncompleteTasks struct {
-	Repo *task.Repository
+	Repo task.Store
 }
 
-func (u *UncompleteTasks) Execute(ids []int64) ([]task.Task, error) {
-	var tasks []task.Task
+// Execute reopens done or canceled tasks. Completing a recurring task
+// creates its next occurrence; if that one is still open, it's deleted
+// again, so completing the wrong task by mistake can be taken back.
+func (u *UncompleteTasks) Execute(ids []int64) ([]task.UncompleteResult, error) {
+	if _, err := getAll(u.Repo, ids); err != nil {
+		return nil, err
+	}
+
+	var results []task.UncompleteResult
 
 	for _, id := range ids {
+		t, err := u.Repo.GetByID(id)
+		if err != nil {
+			return results, err
+		}
+		next, err := u.regenerated(t)
+		if err != nil {
+			return results, err
+		}
+
 		if err := u.Repo.Uncomplete(id); err != nil {
-			return tasks, err
+			return results, err
 		}
-		t, err := u.Repo.GetByID(id)
+		if next != nil {
+			if err := u.Repo.Delete(next.ID); err != nil {
+				return results, err
+			}
+		}
+
+		t, err = u.Repo.GetByID(id)
 		if err != nil {
-			return tasks, err
+			return results, err
 		}
-		tasks = append(tasks, *t)
+		results = append(results, task.UncompleteResult{Reopened: *t, Removed: next})
 	}
 
-	return tasks, nil
+	return results, nil
+}
+
+// regenerated returns the open occurrence of t's recurring series that was
+// created when t was completed, if any. Occurrences are created as of the
+// completion, which is what links them. Once another occurrence has been
+// completed since, the series has moved on and nothing is returned.
+func (u *UncompleteTasks) regenerated(t *task.Task) (*task.Task, error) {
+	if t.RecurType == nil || t.CompletedAt == nil || t.IsProject() {
+		return nil, nil
+	}
+	root := seriesRoot(t)
+	since, err := u.Repo.ListCompleted(&task.CompletedFilter{Since: t.CompletedAt})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range since {
+		if c.ID != t.ID && seriesRoot(&c) == root {
+			return nil, nil
+		}
+	}
+
+	open, err := u.Repo.List(&task.ListFilter{Series: &root})
+	if err != nil {
+		return nil, err
+	}
+	for i := range open {
+		o := &open[i]
+		if o.ID != t.ID && o.RecurParentID != nil && o.CreatedAt.Equal(*t.CompletedAt) {
+			return o, nil
+		}
+	}
+	return nil, nil
 }
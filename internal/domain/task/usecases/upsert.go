@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// UpsertTask lets importers run again without adding duplicates: a task
+// whose external ID was seen before is updated instead of created.
+type UpsertTask struct {
+	Repo          task.Store
+	Create        *CreateTask
+	ProjectLookup ProjectLookup
+	AreaLookup    AreaLookup
+}
+
+// Execute creates the task, or updates the one with opts.ExternalID; created
+// reports which. An update sets the title and the options given, adds the
+// tags and leaves everything else, the status included, alone.
+func (u *UpsertTask) Execute(title string, opts *task.CreateOptions) (t *task.Task, created bool, err error) {
+	externalID := ""
+	if opts != nil {
+		externalID = strings.TrimSpace(opts.ExternalID)
+	}
+	if externalID == "" {
+		t, err := u.Create.Execute(title, opts)
+		return t, err == nil, err
+	}
+
+	t, err = u.Repo.GetByExternalID(externalID)
+	if errors.Is(err, task.ErrTaskNotFound) {
+		t, err := u.Create.Execute(title, opts)
+		return t, err == nil, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	p, a, err := placement(u.ProjectLookup, u.AreaLookup, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	t.Title = title
+	if p != nil {
+		t.ParentID = &p.ID
+		t.AreaID = nil
+	}
+	if a != nil {
+		t.AreaID = &a.ID
+		t.ParentID = nil
+	}
+	if opts.Description != "" {
+		t.Description = &opts.Description
+	}
+	if opts.PlannedDate != nil {
+		t.PlannedDate = opts.PlannedDate
+	}
+	if opts.DueDate != nil {
+		t.DueDate = opts.DueDate
+	}
+	if opts.Estimate != nil {
+		t.Estimate = opts.Estimate
+	}
+	if opts.Expires != nil {
+		t.Expires = opts.Expires
+	}
+	if opts.Context != "" {
+		context, err := task.ParseContext(opts.Context)
+		if err != nil {
+			return nil, false, err
+		}
+		t.Context = &context
+	}
+	if assignee := strings.TrimSpace(opts.Assignee); assignee != "" {
+		t.Assignee = &assignee
+	}
+	if location := task.ParseLocation(opts.Location); location != "" {
+		t.Location = &location
+	}
+	if err := u.Repo.Update(t); err != nil {
+		return nil, false, err
+	}
+
+	for _, tag := range opts.Tags {
+		if slices.Contains(t.Tags, tag) {
+			continue
+		}
+		if err := u.Repo.AddTag(t.ID, tag); err != nil {
+			return nil, false, err
+		}
+		t.Tags = append(t.Tags, tag)
+	}
+	return t, false, nil
+}
@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// PauseForVacation clears the way for time off: fixed recurrences stop
+// producing occurrences, and tasks planned during it move to the day after
+type PauseForVacation struct {
+	Repo     task.Store
+	Schedule task.ScheduleSettings
+	Clock    clock.Clock
+}
+
+// Execute pauses fixed recurrences and shifts the tasks planned from today
+// through until to the day after. With dryRun set it only reports what it
+// would change.
+func (p *PauseForVacation) Execute(until time.Time, dryRun bool) (*task.VacationPlan, error) {
+	day := func(t time.Time) string { return t.Format("2006-01-02") }
+	today, last := day(p.Schedule.Today(clock.Now(p.Clock))), day(until)
+	if last < today {
+		return nil, fmt.Errorf("%s has already passed", last)
+	}
+	after := time.Date(until.Year(), until.Month(), until.Day()+1, 0, 0, 0, 0, until.Location())
+
+	open, err := p.Repo.List(&task.ListFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &task.VacationPlan{Until: until}
+	for _, t := range open {
+		pause := t.RecurType != nil && *t.RecurType == task.RecurTypeFixed && t.RecurRule != nil && !t.RecurPaused
+		shift := false
+		if t.PlannedDate != nil {
+			planned := day(*t.PlannedDate)
+			shift = planned >= today && planned <= last
+		}
+		if !pause && !shift {
+			continue
+		}
+
+		if pause {
+			t.RecurPaused = true
+		}
+		if shift {
+			t.PlannedDate = &after
+		}
+		if !dryRun {
+			if err := p.Repo.Update(&t); err != nil {
+				return nil, err
+			}
+		}
+		if pause {
+			plan.Paused = append(plan.Paused, t)
+		}
+		if shift {
+			plan.Shifted = append(plan.Shifted, t)
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,144 @@
+package usecases
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// CountWIP counts the open active tasks of the areas and projects that have
+// a WIP limit
+type CountWIP struct {
+	Repo          task.Store
+	ProjectLookup ProjectLookup
+	AreaLookup    AreaLookup
+	Limits        task.WIPLimits
+}
+
+// Execute returns the counts of all areas and projects with a limit, areas
+// first, by name. Ones that don't exist are skipped.
+func (c *CountWIP) Execute() ([]task.WIPCount, error) {
+	var counts []task.WIPCount
+	for _, name := range slices.Sorted(maps.Keys(c.Limits.Areas)) {
+		a, err := c.AreaLookup.Execute(name)
+		if err != nil {
+			continue
+		}
+		n, err := c.countArea(a.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, task.WIPCount{Scope: task.WIPArea, Name: name, Count: n, Limit: c.Limits.Areas[name]})
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Limits.Projects)) {
+		p, err := c.ProjectLookup.Execute(name)
+		if err != nil {
+			continue
+		}
+		n, err := c.countProject(p.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, task.WIPCount{Scope: task.WIPProject, Name: name, Count: n, Limit: c.Limits.Projects[name]})
+	}
+	return counts, nil
+}
+
+// For returns the counts of the limited area and project t is in, counting
+// t as an open active task whether it is one yet or not, e.g. before it's
+// added or activated
+func (c *CountWIP) For(t *task.Task) ([]task.WIPCount, error) {
+	if t.TaskType == task.TaskTypeProject {
+		return nil, nil
+	}
+
+	var counts []task.WIPCount
+	areaID := t.AreaID
+	if t.ParentID != nil {
+		p, err := c.Repo.GetByID(*t.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if areaID == nil {
+			areaID = p.AreaID
+		}
+		if limit, ok := c.Limits.Projects[p.Title]; ok {
+			n, err := c.countProject(p.ID, t.ID)
+			if err != nil {
+				return nil, err
+			}
+			counts = append(counts, task.WIPCount{Scope: task.WIPProject, Name: p.Title, Count: n + 1, Limit: limit})
+		}
+	}
+	if areaID != nil {
+		for _, name := range slices.Sorted(maps.Keys(c.Limits.Areas)) {
+			if a, err := c.AreaLookup.Execute(name); err != nil || a.ID != *areaID {
+				continue
+			}
+			n, err := c.countArea(*areaID, t.ID)
+			if err != nil {
+				return nil, err
+			}
+			counts = append(counts, task.WIPCount{Scope: task.WIPArea, Name: name, Count: n + 1, Limit: c.Limits.Areas[name]})
+		}
+	}
+	return counts, nil
+}
+
+// Check returns an error if t would take an area or project over its
+// limit, see For
+func (c *CountWIP) Check(t *task.Task) error {
+	counts, err := c.For(t)
+	if err != nil {
+		return err
+	}
+	for _, wip := range counts {
+		if wip.Over() {
+			return fmt.Errorf("%s %s has no room under its WIP limit (%d/%d)", wip.Scope, wip.Name, wip.Count-1, wip.Limit)
+		}
+	}
+	return nil
+}
+
+// countArea counts the open active tasks in an area, directly or through
+// its projects, leaving out the task skip
+func (c *CountWIP) countArea(areaID, skip int64) (int, error) {
+	n, err := c.count(&task.ListFilter{TaskType: task.TaskTypeTask, AreaID: &areaID, State: task.StateActive}, skip)
+	if err != nil {
+		return 0, err
+	}
+	projects, err := c.Repo.List(&task.ListFilter{TaskType: task.TaskTypeProject, AreaID: &areaID})
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range projects {
+		m, err := c.countProject(p.ID, skip)
+		if err != nil {
+			return 0, err
+		}
+		n += m
+	}
+	return n, nil
+}
+
+// countProject counts the open active tasks in a project, leaving out the
+// task skip
+func (c *CountWIP) countProject(projectID, skip int64) (int, error) {
+	return c.count(&task.ListFilter{TaskType: task.TaskTypeTask, ParentID: &projectID, State: task.StateActive}, skip)
+}
+
+func (c *CountWIP) count(filter *task.ListFilter, skip int64) (int, error) {
+	tasks, err := c.Repo.List(filter)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, t := range tasks {
+		if t.ID != skip {
+			n++
+		}
+	}
+	return n, nil
+}
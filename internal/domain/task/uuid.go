@@ -0,0 +1,56 @@
+package task
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// uuidSeq keeps UUIDs made in the same millisecond in the order they were
+// made
+var uuidSeq struct {
+	sync.Mutex
+	ms  int64
+	seq uint16
+}
+
+// NewUUID returns a time-ordered UUID (version 7) for a task created at
+// now: the first 48 bits are the Unix time in milliseconds, so sorting
+// UUIDs sorts tasks by creation, even across machines, and new rows land at
+// the end of the uuid index. UUIDs made within the same millisecond count
+// up in the 12 bits after the version. Tasks created before tt switched
+// keep their random (version 4) UUIDs.
+func NewUUID(now time.Time) string {
+	ms := now.UnixMilli()
+
+	uuidSeq.Lock()
+	switch {
+	case ms > uuidSeq.ms:
+		uuidSeq.seq = 0
+	case uuidSeq.seq < 0xfff:
+		// Same millisecond, one borrowed earlier, or the clock went back:
+		// carry on from the last UUID so the order holds
+		ms = uuidSeq.ms
+		uuidSeq.seq++
+	default:
+		// 4096 in a millisecond: borrow the next one
+		ms = uuidSeq.ms + 1
+		uuidSeq.seq = 0
+	}
+	uuidSeq.ms = ms
+	seq := uuidSeq.seq
+	uuidSeq.Unlock()
+
+	var u uuid.UUID
+	var stamp [8]byte
+	binary.BigEndian.PutUint64(stamp[:], uint64(ms))
+	copy(u[0:6], stamp[2:])
+	u[6] = 0x70 | byte(seq>>8)
+	u[7] = byte(seq)
+	_, _ = rand.Read(u[8:])
+	u[8] = 0x80 | u[8]&0x3f // RFC 9562 variant
+	return u.String()
+}
@@ -0,0 +1,50 @@
+package task
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewUUID(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	// Later tasks sort after earlier ones, also within a millisecond
+	var ids []string
+	for i := range 50 {
+		ids = append(ids, NewUUID(start.Add(time.Duration(i/10)*time.Millisecond)))
+	}
+	if !slices.IsSorted(ids) {
+		t.Errorf("UUIDs aren't in creation order: %v", ids)
+	}
+	if len(slices.Compact(slices.Clone(ids))) != len(ids) {
+		t.Error("UUIDs repeat")
+	}
+
+	for _, id := range ids {
+		u, err := uuid.Parse(id)
+		if err != nil {
+			t.Fatalf("uuid.Parse(%q) error = %v", id, err)
+		}
+		if u.Version() != 7 || u.Variant() != uuid.RFC4122 {
+			t.Errorf("%s: version %d, variant %v", id, u.Version(), u.Variant())
+		}
+	}
+
+	// A burst of more than 4096 borrows the next millisecond, and neither
+	// that nor the clock going back breaks the order
+	next := start.Add(time.Second)
+	ids = nil
+	for range 4100 {
+		ids = append(ids, NewUUID(next))
+	}
+	ids = append(ids, NewUUID(next.Add(time.Millisecond)), NewUUID(next.Add(-time.Minute)))
+	if !slices.IsSorted(ids) {
+		t.Error("UUIDs lost their order after a burst or the clock going back")
+	}
+	if len(slices.Compact(slices.Clone(ids))) != len(ids) {
+		t.Error("UUIDs repeat after a burst or the clock going back")
+	}
+}
@@ -0,0 +1,53 @@
+package token
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"time"
+)
+
+// Scopes a token can be granted
+const (
+	ScopeRead  = "read"  // list and show tasks
+	ScopeWrite = "write" // add, change and complete tasks
+)
+
+// ValidScopes returns the scopes a token can be granted
+func ValidScopes() []string {
+	return []string{ScopeRead, ScopeWrite}
+}
+
+// SecretPrefix starts every token secret, so leaked ones are easy to spot
+const SecretPrefix = "tt_"
+
+var (
+	ErrTokenNotFound = errors.New("token not found")
+	// ErrInvalidToken is returned for an unknown secret, or one without
+	// the scope asked for
+	ErrInvalidToken = errors.New("invalid API token")
+	// ErrNoDatabase is returned when tokens are used with file storage
+	ErrNoDatabase = errors.New("API tokens are kept in the database and aren't available with file storage")
+)
+
+// Token lets an API client in with the given scopes. Only a hash of its
+// secret is stored; the secret itself is shown once, when it's created.
+type Token struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"` // e.g. the device it's for
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// HasScope reports whether the token was granted scope
+func (t *Token) HasScope(scope string) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+// Hash returns the hash a secret is stored and looked up by
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
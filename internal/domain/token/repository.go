@@ -0,0 +1,97 @@
+package token
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/database"
+)
+
+type Repository struct {
+	db *database.DB
+}
+
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+const tokenColumns = `id, name, scopes, created_at, last_used_at`
+
+func (r *Repository) Create(t *Token, hash string) error {
+	id, err := r.db.Insert(
+		`INSERT INTO api_tokens (name, hash, scopes, created_at) VALUES (?, ?, ?, ?)`,
+		t.Name, hash, strings.Join(t.Scopes, ","), t.CreatedAt.Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	t.ID = id
+	return nil
+}
+
+func (r *Repository) List() ([]Token, error) {
+	rows, err := r.db.Query(`SELECT ` + tokenColumns + ` FROM api_tokens ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []Token
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *t)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *Repository) GetByHash(hash string) (*Token, error) {
+	t, err := scanToken(r.db.QueryRow(`SELECT `+tokenColumns+` FROM api_tokens WHERE hash = ?`, hash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTokenNotFound
+	}
+	return t, err
+}
+
+func (r *Repository) Delete(name string) error {
+	result, err := r.db.Exec(`DELETE FROM api_tokens WHERE name = ?`, name)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrTokenNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) Touch(id int64, at time.Time) error {
+	_, err := r.db.Exec(`UPDATE api_tokens SET last_used_at = ? WHERE id = ?`, at.Format(time.RFC3339), id)
+	return err
+}
+
+func scanToken(row interface{ Scan(...any) error }) (*Token, error) {
+	var t Token
+	var scopes, createdAt string
+	var lastUsedAt sql.NullString
+	if err := row.Scan(&t.ID, &t.Name, &scopes, &createdAt, &lastUsedAt); err != nil {
+		return nil, err
+	}
+	t.Scopes = strings.Split(scopes, ",")
+	t.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	if lastUsedAt.Valid {
+		used, _ := time.Parse(time.RFC3339, lastUsedAt.String)
+		t.LastUsedAt = &used
+	}
+	return &t, nil
+}
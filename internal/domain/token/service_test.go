@@ -0,0 +1,64 @@
+package token_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/token"
+	"github.com/devbydaniel/tt/internal/testutil"
+)
+
+func TestAPITokens(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	application := app.NewWithOptions(db, app.Options{Clock: clock.Fixed(now)})
+
+	created, secret, err := application.CreateToken.Execute("phone", []string{"write", "read"})
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	if !strings.HasPrefix(secret, token.SecretPrefix) || !slices.Equal(created.Scopes, []string{"read", "write"}) {
+		t.Errorf("secret %q, scopes %v", secret, created.Scopes)
+	}
+	if _, _, err := application.CreateToken.Execute("phone", []string{"read"}); err == nil {
+		t.Error("CreateToken() should error for a name in use")
+	}
+	if _, _, err := application.CreateToken.Execute("laptop", []string{"admin"}); err == nil {
+		t.Error("CreateToken() should error for an unknown scope")
+	}
+	application.CreateToken.Execute("dashboard", []string{"read"})
+
+	got, err := application.VerifyToken.Execute(secret, token.ScopeWrite)
+	if err != nil {
+		t.Fatalf("VerifyToken() error = %v", err)
+	}
+	if got.Name != "phone" || got.LastUsedAt == nil || !got.LastUsedAt.Equal(now) {
+		t.Errorf("verified %q, last used %v", got.Name, got.LastUsedAt)
+	}
+	if _, err := application.VerifyToken.Execute(secret+"x", token.ScopeRead); !errors.Is(err, token.ErrInvalidToken) {
+		t.Errorf("VerifyToken(wrong secret) error = %v, want ErrInvalidToken", err)
+	}
+
+	// Only the hash is stored
+	var stored int
+	db.QueryRow(`SELECT COUNT(*) FROM api_tokens WHERE hash = ?`, secret).Scan(&stored)
+	if stored != 0 {
+		t.Error("secret stored in plain text")
+	}
+
+	if err := application.RevokeToken.Execute("phone"); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if _, err := application.VerifyToken.Execute(secret, token.ScopeRead); !errors.Is(err, token.ErrInvalidToken) {
+		t.Errorf("VerifyToken(revoked) error = %v, want ErrInvalidToken", err)
+	}
+	tokens, _ := application.ListTokens.Execute()
+	if len(tokens) != 1 || tokens[0].Name != "dashboard" {
+		t.Errorf("tokens = %v, want only dashboard", tokens)
+	}
+}
@@ -0,0 +1,17 @@
+package token
+
+import "time"
+
+// Store keeps API tokens. Repository stores them in SQLite.
+type Store interface {
+	// Create adds a token with the hash of its secret
+	Create(t *Token, hash string) error
+	List() ([]Token, error)
+	// GetByHash returns ErrTokenNotFound if no token has the hash
+	GetByHash(hash string) (*Token, error)
+	Delete(name string) error
+	// Touch records that the token was used at the given time
+	Touch(id int64, at time.Time) error
+}
+
+var _ Store = (*Repository)(nil)
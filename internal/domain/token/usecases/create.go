@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/token"
+)
+
+type CreateToken struct {
+	Repo  token.Store
+	Clock clock.Clock
+}
+
+// Execute creates a token with the given scopes and returns it with its
+// secret, which isn't stored and can't be shown again
+func (c *CreateToken) Execute(name string, scopes []string) (*token.Token, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", errors.New("token name cannot be empty")
+	}
+	if len(scopes) == 0 {
+		return nil, "", errors.New("a token needs at least one scope")
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(token.ValidScopes(), scope) {
+			return nil, "", fmt.Errorf("invalid scope %q (use %s)", scope, strings.Join(token.ValidScopes(), ", "))
+		}
+	}
+
+	existing, err := c.Repo.List()
+	if err != nil {
+		return nil, "", err
+	}
+	if slices.ContainsFunc(existing, func(t token.Token) bool { return t.Name == name }) {
+		return nil, "", fmt.Errorf("a token named %q already exists", name)
+	}
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", err
+	}
+	secret := token.SecretPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	t := &token.Token{
+		Name:      name,
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(scopes))),
+		CreatedAt: clock.Now(c.Clock),
+	}
+	if err := c.Repo.Create(t, token.Hash(secret)); err != nil {
+		return nil, "", err
+	}
+	return t, secret, nil
+}
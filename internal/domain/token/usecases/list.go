@@ -0,0 +1,11 @@
+package usecases
+
+import "github.com/devbydaniel/tt/internal/domain/token"
+
+type ListTokens struct {
+	Repo token.Store
+}
+
+func (l *ListTokens) Execute() ([]token.Token, error) {
+	return l.Repo.List()
+}
@@ -0,0 +1,12 @@
+package usecases
+
+import "github.com/devbydaniel/tt/internal/domain/token"
+
+type RevokeToken struct {
+	Repo token.Store
+}
+
+// Execute deletes the token, so its secret no longer lets anyone in
+func (r *RevokeToken) Execute(name string) error {
+	return r.Repo.Delete(name)
+}
@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"errors"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/token"
+)
+
+type VerifyToken struct {
+	Repo  token.Store
+	Clock clock.Clock
+}
+
+// Execute returns the token a client presented if it was granted scope,
+// and records that it was used. Anything else is ErrInvalidToken, without
+// telling an unknown secret from a missing scope.
+func (v *VerifyToken) Execute(secret, scope string) (*token.Token, error) {
+	t, err := v.Repo.GetByHash(token.Hash(secret))
+	if errors.Is(err, token.ErrTokenNotFound) {
+		return nil, token.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !t.HasScope(scope) {
+		return nil, token.ErrInvalidToken
+	}
+
+	now := clock.Now(v.Clock)
+	if err := v.Repo.Touch(t.ID, now); err != nil {
+		return nil, err
+	}
+	t.LastUsedAt = &now
+	return t, nil
+}
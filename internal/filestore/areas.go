@@ -0,0 +1,170 @@
+package filestore
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/domain/area"
+)
+
+var _ area.Store = (*AreaStore)(nil)
+
+// AreaStore keeps areas in a text file, one per line: the ID, a space and
+// the name
+type AreaStore struct {
+	path     string
+	tasks    *TaskStore // deleting an area deletes its tasks
+	readOnly bool
+}
+
+// load reads the areas in ID order
+func (s *AreaStore) load() ([]area.Area, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var areas []area.Area
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idStr, name, _ := strings.Cut(line, " ")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil || id <= 0 || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%s:%d: expected an ID and a name", areasFile, n)
+		}
+		areas = append(areas, area.Area{ID: id, Name: strings.TrimSpace(name)})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(areas, func(a, b area.Area) int { return cmp.Compare(a.ID, b.ID) })
+	return areas, nil
+}
+
+func (s *AreaStore) save(areas []area.Area) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	var b bytes.Buffer
+	for _, a := range areas {
+		fmt.Fprintf(&b, "%d %s\n", a.ID, a.Name)
+	}
+	return writeFile(s.path, b.Bytes())
+}
+
+// names returns the area names by ID
+func (s *AreaStore) names() (map[int64]string, error) {
+	areas, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(areas))
+	for _, a := range areas {
+		names[a.ID] = a.Name
+	}
+	return names, nil
+}
+
+// checkName returns an error if name can't be stored or another area has it
+func checkName(areas []area.Area, id int64, name string) error {
+	if strings.TrimSpace(name) != name || name == "" || strings.ContainsAny(name, "\r\n") {
+		return fmt.Errorf("invalid area name %q", name)
+	}
+	for _, a := range areas {
+		if a.ID != id && a.Name == name {
+			return fmt.Errorf("area %q already exists", name)
+		}
+	}
+	return nil
+}
+
+func (s *AreaStore) Create(a *area.Area) error {
+	areas, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := checkName(areas, 0, a.Name); err != nil {
+		return err
+	}
+	var id int64
+	for _, existing := range areas {
+		id = max(id, existing.ID)
+	}
+	a.ID = id + 1
+	return s.save(append(areas, *a))
+}
+
+func (s *AreaStore) List() ([]area.Area, error) {
+	areas, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(areas, func(a, b area.Area) int { return strings.Compare(a.Name, b.Name) })
+	return areas, nil
+}
+
+func (s *AreaStore) GetByID(id int64) (*area.Area, error) {
+	return s.find(func(a area.Area) bool { return a.ID == id })
+}
+
+func (s *AreaStore) GetByName(name string) (*area.Area, error) {
+	return s.find(func(a area.Area) bool { return a.Name == name })
+}
+
+func (s *AreaStore) find(match func(area.Area) bool) (*area.Area, error) {
+	areas, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(areas, match)
+	if i < 0 {
+		return nil, area.ErrAreaNotFound
+	}
+	return &areas[i], nil
+}
+
+// Delete deletes an area along with its tasks
+func (s *AreaStore) Delete(id int64) error {
+	areas, err := s.load()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(areas, func(a area.Area) bool { return a.ID == id })
+	if i < 0 {
+		return area.ErrAreaNotFound
+	}
+	if err := s.tasks.deleteInArea(id); err != nil {
+		return err
+	}
+	return s.save(slices.Delete(areas, i, i+1))
+}
+
+func (s *AreaStore) Update(a *area.Area) error {
+	areas, err := s.load()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(areas, func(existing area.Area) bool { return existing.ID == a.ID })
+	if i < 0 {
+		return area.ErrAreaNotFound
+	}
+	if err := checkName(areas, a.ID, a.Name); err != nil {
+		return err
+	}
+	areas[i].Name = a.Name
+	return s.save(areas)
+}
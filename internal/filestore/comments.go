@@ -0,0 +1,117 @@
+package filestore
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/comment"
+)
+
+var _ comment.Store = (*CommentStore)(nil)
+
+// CommentStore keeps the comments on all tasks in a text file, one per line
+// in the order they were added: the task ID, the time, the author ("-" if
+// unknown) and the body, separated by tabs. Line breaks in a body are
+// joined into one line.
+type CommentStore struct {
+	path     string
+	readOnly bool
+}
+
+// load reads all comments. IDs are line positions, so they stay stable
+// while comments are only added.
+func (s *CommentStore) load() ([]comment.Comment, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []comment.Comment
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("%s:%d: expected task ID, time, author and text separated by tabs", commentsFile, n)
+		}
+		taskID, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid task ID %q", commentsFile, n, fields[0])
+		}
+		createdAt, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", commentsFile, n, err)
+		}
+		c := comment.Comment{ID: int64(len(comments) + 1), TaskID: taskID, Body: fields[3], CreatedAt: createdAt}
+		if author := fields[2]; author != "-" {
+			c.Author = &author
+		}
+		comments = append(comments, c)
+	}
+	return comments, sc.Err()
+}
+
+func (s *CommentStore) save(comments []comment.Comment) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	var b bytes.Buffer
+	for _, c := range comments {
+		author := "-"
+		if c.Author != nil {
+			author = oneLine(*c.Author)
+		}
+		fmt.Fprintf(&b, "%d\t%s\t%s\t%s\n", c.TaskID, c.CreatedAt.Format(time.RFC3339), author, oneLine(c.Body))
+	}
+	return writeFile(s.path, b.Bytes())
+}
+
+// oneLine collapses runs of whitespace, line breaks and tabs included, into
+// single spaces
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func (s *CommentStore) Create(c *comment.Comment) error {
+	comments, err := s.load()
+	if err != nil {
+		return err
+	}
+	c.ID = int64(len(comments) + 1)
+	return s.save(append(comments, *c))
+}
+
+func (s *CommentStore) ListByTask(taskID int64) ([]comment.Comment, error) {
+	comments, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(comments, func(c comment.Comment) bool { return c.TaskID != taskID }), nil
+}
+
+// deleteTasks removes the comments on the given tasks, so a task created
+// later under a reused ID doesn't inherit them
+func (s *CommentStore) deleteTasks(ids []int64) error {
+	comments, err := s.load()
+	if err != nil {
+		return err
+	}
+	kept := slices.DeleteFunc(slices.Clone(comments), func(c comment.Comment) bool { return slices.Contains(ids, c.TaskID) })
+	if len(kept) == len(comments) {
+		return nil
+	}
+	return s.save(kept)
+}
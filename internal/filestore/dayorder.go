@@ -0,0 +1,59 @@
+package filestore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The order pinned for the Today list lives in a text file next to the
+// tasks: one line with the day followed by the task IDs, separated by
+// spaces. Only the latest day is kept.
+
+// SetDayOrder pins the order of the given tasks at the top of the day's
+// Today list, replacing any earlier order
+func (s *TaskStore) SetDayOrder(day time.Time, ids []int64) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if len(ids) == 0 {
+		err := os.Remove(s.dayOrderPath)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	fields := []string{day.Format(dateFormat)}
+	for _, id := range ids {
+		fields = append(fields, strconv.FormatInt(id, 10))
+	}
+	return writeFile(s.dayOrderPath, []byte(strings.Join(fields, " ")+"\n"))
+}
+
+// DayOrder returns the IDs pinned for the day, in order
+func (s *TaskStore) DayOrder(day time.Time) ([]int64, error) {
+	data, err := os.ReadFile(s.dayOrderPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 || fields[0] != day.Format(dateFormat) {
+		return nil, nil
+	}
+	var ids []int64
+	for _, field := range fields[1:] {
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid task ID %q", dayOrderFile, field)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,87 @@
+package filestore
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Dependencies live in a text file next to the tasks: one line per task
+// that depends on others, with its ID followed by theirs, separated by
+// spaces.
+
+// SetDependencies replaces the tasks that have to be done before a task
+// can start
+func (s *TaskStore) SetDependencies(taskID int64, dependsOn []int64) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	all, err := s.readDependencies()
+	if err != nil {
+		return err
+	}
+	if len(dependsOn) == 0 {
+		delete(all, taskID)
+	} else {
+		all[taskID] = dependsOn
+	}
+	if len(all) == 0 {
+		err := os.Remove(s.depsPath)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	var b strings.Builder
+	for _, id := range slices.Sorted(maps.Keys(all)) {
+		b.WriteString(strconv.FormatInt(id, 10))
+		for _, dep := range all[id] {
+			b.WriteString(" " + strconv.FormatInt(dep, 10))
+		}
+		b.WriteString("\n")
+	}
+	return writeFile(s.depsPath, []byte(b.String()))
+}
+
+// Dependencies returns the IDs of the tasks a task depends on, in order
+func (s *TaskStore) Dependencies(taskID int64) ([]int64, error) {
+	all, err := s.readDependencies()
+	if err != nil {
+		return nil, err
+	}
+	ids := slices.Clone(all[taskID])
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// readDependencies reads the dependencies of all tasks, by task ID
+func (s *TaskStore) readDependencies() (map[int64][]int64, error) {
+	data, err := os.ReadFile(s.depsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[int64][]int64{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	all := map[int64][]int64{}
+	for n, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		ids := make([]int64, len(fields))
+		for i, field := range fields {
+			if ids[i], err = strconv.ParseInt(field, 10, 64); err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid task ID %q", depsFile, n+1, field)
+			}
+		}
+		all[ids[0]] = ids[1:]
+	}
+	return all, nil
+}
@@ -0,0 +1,117 @@
+// Package filestore keeps tasks in a directory of plain-text files instead
+// of SQLite: one Markdown file per task, with its fields as YAML front
+// matter and its description as the body, so the data can be versioned with
+// git and searched with grep. Areas, holidays, comments, tag colors and the
+// order pinned for Today live in small text files next to the tasks.
+//
+// Every operation reads the files it needs and writes changes back right
+// away, so edits made by hand or pulled in with git show up immediately.
+package filestore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/devbydaniel/tt/internal/clock"
+)
+
+const (
+	tasksDir     = "tasks"
+	areasFile    = "areas.txt"
+	holidaysFile = "holidays.txt"
+	commentsFile = "comments.txt"
+	tagsFile     = "tags.txt"
+	dayOrderFile = "today.txt"
+	depsFile     = "dependencies.txt"
+)
+
+// ErrReadOnly is returned by every write when the store was opened with
+// OpenReadOnly
+var ErrReadOnly = errors.New("task files are opened read-only; rerun without --read-only (or unset read_only in the config) to make changes")
+
+// Store is a directory of task, area, holiday and comment files
+type Store struct {
+	Tasks    *TaskStore
+	Areas    *AreaStore
+	Holidays *HolidayStore
+	Comments *CommentStore
+}
+
+// Open prepares dir for storing tasks, creating it if needed
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Join(dir, tasksDir), 0755); err != nil {
+		return nil, err
+	}
+	return newStore(dir, false), nil
+}
+
+// OpenReadOnly opens dir without write access. Nothing is created, a
+// missing directory reads as empty and every write returns ErrReadOnly.
+func OpenReadOnly(dir string) *Store {
+	return newStore(dir, true)
+}
+
+func newStore(dir string, readOnly bool) *Store {
+	tasks := &TaskStore{dir: filepath.Join(dir, tasksDir), tagsPath: filepath.Join(dir, tagsFile), dayOrderPath: filepath.Join(dir, dayOrderFile), depsPath: filepath.Join(dir, depsFile), clock: clock.System, readOnly: readOnly}
+	areas := &AreaStore{path: filepath.Join(dir, areasFile), tasks: tasks, readOnly: readOnly}
+	comments := &CommentStore{path: filepath.Join(dir, commentsFile), readOnly: readOnly}
+	tasks.areas = areas
+	tasks.comments = comments
+	return &Store{
+		Tasks:    tasks,
+		Areas:    areas,
+		Holidays: &HolidayStore{path: filepath.Join(dir, holidaysFile), readOnly: readOnly},
+		Comments: comments,
+	}
+}
+
+// writeFile replaces the file at path through a temporary file, so readers
+// never see it half written
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// maxSlugLength limits the title part of task file names
+const maxSlugLength = 40
+
+// slug turns a title into a file name part: lowercase letters and digits
+// separated by dashes
+func slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+			if b.Len() >= maxSlugLength {
+				break
+			}
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
@@ -0,0 +1,438 @@
+package filestore_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/filestore"
+)
+
+var now = time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+
+func setupApp(t *testing.T) (*app.App, string) {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := filestore.Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	a := app.NewWithStores(app.Stores{
+		Tasks:    store.Tasks,
+		Areas:    store.Areas,
+		Holidays: store.Holidays,
+		Comments: store.Comments,
+	}, app.Options{Clock: clock.Fixed(now)})
+	return a, dir
+}
+
+func titles(tasks []task.Task) []string {
+	var list []string
+	for _, t := range tasks {
+		list = append(list, t.Title)
+	}
+	return list
+}
+
+func TestCreateWritesMarkdownFile(t *testing.T) {
+	a, dir := setupApp(t)
+
+	planned := time.Date(2026, 3, 12, 0, 0, 0, 0, time.Local)
+	created, err := a.CreateTask.Execute("Call the bank: about fees", &task.CreateOptions{
+		Description: "Ask about the\nyearly fee",
+		PlannedDate: &planned,
+		Tags:        []string{"money", "phone"},
+	})
+	if err != nil {
+		t.Fatalf("CreateTask error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "tasks", "1-call-the-bank-about-fees.md"))
+	if err != nil {
+		t.Fatalf("reading task file: %v", err)
+	}
+	for _, want := range []string{
+		"---\nid: 1\n",
+		`title: "Call the bank: about fees"`,
+		"planned: 2026-03-12\n",
+		"tags: [money, phone]\n",
+		"---\n\nAsk about the\nyearly fee\n",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("task file missing %q:\n%s", want, data)
+		}
+	}
+
+	got, err := a.GetTask.Execute(created.ID)
+	if err != nil {
+		t.Fatalf("GetTask error = %v", err)
+	}
+	if got.Title != "Call the bank: about fees" || got.Description == nil || *got.Description != "Ask about the\nyearly fee" {
+		t.Errorf("read back %q / %v", got.Title, got.Description)
+	}
+	if !slices.Equal(got.Tags, []string{"money", "phone"}) {
+		t.Errorf("Tags = %v, want [money phone]", got.Tags)
+	}
+}
+
+func TestRenameMovesFile(t *testing.T) {
+	a, dir := setupApp(t)
+
+	created, _ := a.CreateTask.Execute("Old title", nil)
+	if _, err := a.SetTaskTitle.Execute(created.ID, "New title"); err != nil {
+		t.Fatalf("SetTaskTitle error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "tasks", "*.md"))
+	if len(files) != 1 || filepath.Base(files[0]) != "1-new-title.md" {
+		t.Errorf("files = %v, want only 1-new-title.md", files)
+	}
+}
+
+func TestHandEditedFile(t *testing.T) {
+	a, dir := setupApp(t)
+
+	file := `---
+id: 7
+uuid: 5b1c0a4e-0000-4000-8000-000000000007
+type: task
+title: 'It''s written by hand'
+status: todo
+state: active
+due: 2026-03-09
+tags:
+  - errands
+  - "two words"
+created: 2026-03-01T08:00:00Z
+---
+`
+	if err := os.WriteFile(filepath.Join(dir, "tasks", "whatever.md"), []byte(file), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	today, err := a.ListTasks.Execute(&task.ListOptions{Schedule: "today"})
+	if err != nil {
+		t.Fatalf("ListTasks error = %v", err)
+	}
+	if got := titles(today); !slices.Equal(got, []string{"It's written by hand"}) {
+		t.Fatalf("today = %v", got)
+	}
+	if !slices.Equal(today[0].Tags, []string{"errands", "two words"}) {
+		t.Errorf("Tags = %v", today[0].Tags)
+	}
+
+	// New tasks continue after the highest ID
+	created, _ := a.CreateTask.Execute("Next", nil)
+	if created.ID != 8 {
+		t.Errorf("ID = %d, want 8", created.ID)
+	}
+}
+
+func TestMalformedFileIsReported(t *testing.T) {
+	a, dir := setupApp(t)
+
+	if err := os.WriteFile(filepath.Join(dir, "tasks", "broken.md"), []byte("no front matter\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := a.ListTasks.Execute(nil)
+	if err == nil || !strings.Contains(err.Error(), "broken.md") {
+		t.Errorf("ListTasks error = %v, want one naming broken.md", err)
+	}
+}
+
+func TestProjectsAndAreas(t *testing.T) {
+	a, dir := setupApp(t)
+
+	if _, err := a.CreateArea.Execute("Home"); err != nil {
+		t.Fatalf("CreateArea error = %v", err)
+	}
+	if _, err := a.CreateProject.Execute("Garden", &task.CreateProjectOptions{AreaName: "Home"}); err != nil {
+		t.Fatalf("CreateProject error = %v", err)
+	}
+	if _, err := a.CreateProject.Execute("Garden", nil); err == nil {
+		t.Error("creating a second project named Garden should fail")
+	}
+	if _, err := a.CreateTask.Execute("Mow the lawn", &task.CreateOptions{ProjectName: "Garden"}); err != nil {
+		t.Fatalf("CreateTask error = %v", err)
+	}
+
+	// Tasks inherit the area of their project
+	inArea, err := a.ListTasks.Execute(&task.ListOptions{ProjectName: "Garden"})
+	if err != nil {
+		t.Fatalf("ListTasks error = %v", err)
+	}
+	if len(inArea) != 1 || inArea[0].ParentName == nil || *inArea[0].ParentName != "Garden" ||
+		inArea[0].AreaName == nil || *inArea[0].AreaName != "Home" {
+		t.Fatalf("project tasks = %+v", inArea)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "areas.txt"))
+	if err != nil || string(data) != "1 Home\n" {
+		t.Errorf("areas.txt = %q, %v", data, err)
+	}
+
+	// Deleting the area deletes its project along with the project's tasks
+	if _, err := a.DeleteArea.Execute("Home"); err != nil {
+		t.Fatalf("DeleteArea error = %v", err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "tasks", "*.md"))
+	if len(files) != 0 {
+		t.Errorf("files left after deleting the area: %v", files)
+	}
+}
+
+func TestLintAreaConflict(t *testing.T) {
+	// Task files can name both a project and an area, unlike the database
+	a, dir := setupApp(t)
+	home, _ := a.CreateArea.Execute("Home")
+	a.CreateArea.Execute("Work")
+	if _, err := a.CreateProject.Execute("Launch", &task.CreateProjectOptions{AreaName: "Work"}); err != nil {
+		t.Fatalf("CreateProject error = %v", err)
+	}
+	conflict, err := a.CreateTask.Execute("Book venue", &task.CreateOptions{ProjectName: "Launch"})
+	if err != nil {
+		t.Fatalf("CreateTask error = %v", err)
+	}
+	path := filepath.Join(dir, "tasks", fmt.Sprintf("%d-book-venue.md", conflict.ID))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	parent := fmt.Sprintf("parent: %d\n", *conflict.ParentID)
+	data = []byte(strings.Replace(string(data), parent, parent+fmt.Sprintf("area: %d\n", home.ID), 1))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	report, err := a.Tasks.Lint(30)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	want := task.AreaConflict{Area: "Home", Project: "Launch", ProjectArea: "Work"}
+	if len(report.AreaConflicts) != 1 || report.AreaConflicts[0].Task.ID != conflict.ID {
+		t.Fatalf("AreaConflicts = %+v, want Book venue", report.AreaConflicts)
+	}
+	if got := report.AreaConflicts[0]; got.Area != want.Area || got.Project != want.Project || got.ProjectArea != want.ProjectArea {
+		t.Errorf("AreaConflicts[0] = %+v, want %+v", got, want)
+	}
+}
+
+func TestCompleteAndTags(t *testing.T) {
+	a, _ := setupApp(t)
+
+	first, _ := a.CreateTask.Execute("First", &task.CreateOptions{Tags: []string{"work"}})
+	a.CreateTask.Execute("Second", &task.CreateOptions{Tags: []string{"home"}})
+
+	if _, err := a.CompleteTasks.Execute([]int64{first.ID}); err != nil {
+		t.Fatalf("CompleteTasks error = %v", err)
+	}
+	open, _ := a.ListTasks.Execute(nil)
+	if got := titles(open); !slices.Equal(got, []string{"Second"}) {
+		t.Errorf("open = %v, want [Second]", got)
+	}
+	done, err := a.ListCompletedTasks.Execute(nil)
+	if err != nil {
+		t.Fatalf("ListCompletedTasks error = %v", err)
+	}
+	if got := titles(done); !slices.Equal(got, []string{"First"}) {
+		t.Errorf("done = %v, want [First]", got)
+	}
+
+	tags, _ := a.ListTags.Execute()
+	if !slices.Equal(tags, []string{"home", "work"}) {
+		t.Errorf("tags = %v", tags)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	a, _ := setupApp(t)
+
+	created, _ := a.CreateTask.Execute("Old idea", nil)
+	if _, err := a.CancelTasks.Execute([]int64{created.ID}); err != nil {
+		t.Fatalf("CancelTasks error = %v", err)
+	}
+	got, _ := a.GetTask.Execute(created.ID)
+	if got.Status != task.StatusCanceled {
+		t.Errorf("Status = %q, want canceled", got.Status)
+	}
+	done, _ := a.ListCompletedTasks.Execute(nil)
+	if got := titles(done); !slices.Equal(got, []string{"Old idea"}) {
+		t.Errorf("done = %v, want [Old idea]", got)
+	}
+
+	if _, err := a.UncompleteTasks.Execute([]int64{created.ID}); err != nil {
+		t.Fatalf("UncompleteTasks error = %v", err)
+	}
+	open, _ := a.ListTasks.Execute(nil)
+	if got := titles(open); !slices.Equal(got, []string{"Old idea"}) {
+		t.Errorf("open = %v, want [Old idea]", got)
+	}
+}
+
+func TestUncompleteRemovesRegeneratedTask(t *testing.T) {
+	a, _ := setupApp(t)
+
+	recurType := task.RecurTypeFixed
+	recurRule := `{"interval":1,"unit":"day"}`
+	created, _ := a.CreateTask.Execute("Water plants", &task.CreateOptions{RecurType: &recurType, RecurRule: &recurRule})
+	if _, err := a.CompleteTasks.Execute([]int64{created.ID}); err != nil {
+		t.Fatalf("CompleteTasks error = %v", err)
+	}
+
+	results, err := a.UncompleteTasks.Execute([]int64{created.ID})
+	if err != nil {
+		t.Fatalf("UncompleteTasks error = %v", err)
+	}
+	if results[0].Removed == nil {
+		t.Error("Removed = nil, want the regenerated task")
+	}
+	open, _ := a.ListTasks.Execute(nil)
+	if len(open) != 1 {
+		t.Errorf("open = %v, want only the reopened task", titles(open))
+	}
+}
+
+func TestTimeBlock(t *testing.T) {
+	a, dir := setupApp(t)
+
+	created, _ := a.CreateTask.Execute("Write report", nil)
+	start := time.Date(2026, 3, 12, 14, 30, 0, 0, time.Local)
+	if _, err := a.SetTimeBlock.Execute(created.ID, &start, 45); err != nil {
+		t.Fatalf("SetTimeBlock() error = %v", err)
+	}
+
+	// A fresh store reads the block back from the file
+	store, err := filestore.Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	got, err := store.Tasks.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.BlockStart == nil || !got.BlockStart.Equal(start) || got.BlockMinutes == nil || *got.BlockMinutes != 45 {
+		t.Errorf("block = %v for %v, want %v for 45", got.BlockStart, got.BlockMinutes, start)
+	}
+}
+
+func TestHolidays(t *testing.T) {
+	a, dir := setupApp(t)
+
+	date := time.Date(2026, 12, 25, 0, 0, 0, 0, time.Local)
+	if _, err := a.AddHoliday.Execute(date, "Christmas Day"); err != nil {
+		t.Fatalf("AddHoliday error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "holidays.txt"))
+	if err != nil || string(data) != "2026-12-25 Christmas Day\n" {
+		t.Errorf("holidays.txt = %q, %v", data, err)
+	}
+	if err := a.RemoveHoliday.Execute(date); err != nil {
+		t.Fatalf("RemoveHoliday error = %v", err)
+	}
+	list, _ := a.ListHolidays.Execute()
+	if len(list) != 0 {
+		t.Errorf("holidays = %v, want none", list)
+	}
+}
+
+func TestComments(t *testing.T) {
+	a, dir := setupApp(t)
+
+	created, _ := a.CreateTask.Execute("Sign contract", nil)
+	if _, err := a.AddComment.Execute(created.ID, "waiting\non legal"); err != nil {
+		t.Fatalf("AddComment error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "comments.txt"))
+	want := fmt.Sprintf("%d\t%s\t-\twaiting on legal\n", created.ID, now.Format(time.RFC3339))
+	if err != nil || string(data) != want {
+		t.Errorf("comments.txt = %q, %v; want %q", data, err, want)
+	}
+
+	if _, err := a.DeleteTasks.Execute([]int64{created.ID}); err != nil {
+		t.Fatalf("DeleteTasks error = %v", err)
+	}
+	if list, _ := a.ListComments.Execute(created.ID); len(list) != 0 {
+		t.Errorf("comments = %v after deleting the task, want none", list)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	a, dir := setupApp(t)
+	if _, err := a.CreateTask.Execute("Existing", &task.CreateOptions{}); err != nil {
+		t.Fatalf("CreateTask error = %v", err)
+	}
+
+	store := filestore.OpenReadOnly(dir)
+	ro := app.NewWithStores(app.Stores{
+		Tasks:    store.Tasks,
+		Areas:    store.Areas,
+		Holidays: store.Holidays,
+		Comments: store.Comments,
+	}, app.Options{Clock: clock.Fixed(now)})
+
+	tasks, err := ro.ListTasks.Execute(&task.ListOptions{})
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("ListTasks = %v, %v; want the existing task", tasks, err)
+	}
+	if _, err := ro.CreateTask.Execute("New", &task.CreateOptions{}); !errors.Is(err, filestore.ErrReadOnly) {
+		t.Errorf("CreateTask error = %v, want ErrReadOnly", err)
+	}
+	if _, err := ro.CreateArea.Execute("Work"); !errors.Is(err, filestore.ErrReadOnly) {
+		t.Errorf("CreateArea error = %v, want ErrReadOnly", err)
+	}
+	if _, err := ro.AddHoliday.Execute(now, ""); !errors.Is(err, filestore.ErrReadOnly) {
+		t.Errorf("AddHoliday error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestOpenReadOnlyCreatesNothing(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	store := filestore.OpenReadOnly(dir)
+	a := app.NewWithStores(app.Stores{
+		Tasks:    store.Tasks,
+		Areas:    store.Areas,
+		Holidays: store.Holidays,
+		Comments: store.Comments,
+	}, app.Options{Clock: clock.Fixed(now)})
+
+	if _, err := a.ListTasks.Execute(&task.ListOptions{}); err != nil {
+		t.Errorf("ListTasks error = %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("Stat(%s) error = %v, want the directory not to exist", dir, err)
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	a, dir := setupApp(t)
+
+	first, _ := a.CreateTask.Execute("Write copy", nil)
+	second, _ := a.CreateTask.Execute("Design page", nil)
+	third, _ := a.CreateTask.Execute("Build page", nil)
+	if _, err := a.SetDependencies.Execute(third.ID, []int64{second.ID, first.ID}); err != nil {
+		t.Fatalf("SetDependencies() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "dependencies.txt"))
+	if err != nil {
+		t.Fatalf("reading dependencies.txt: %v", err)
+	}
+	if want := fmt.Sprintf("%d %d %d\n", third.ID, first.ID, second.ID); string(data) != want {
+		t.Errorf("dependencies.txt = %q, want %q", data, want)
+	}
+
+	// Clearing the last dependencies removes the file
+	if _, err := a.SetDependencies.Execute(third.ID, nil); err != nil {
+		t.Fatalf("SetDependencies(nil) error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "dependencies.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("dependencies.txt should be removed, stat error = %v", err)
+	}
+}
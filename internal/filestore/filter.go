@@ -0,0 +1,122 @@
+package filestore
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// matchesList reports whether t belongs in the results of a List with
+// filter, following the SQLite repository's query. parent is t's project,
+// if any, and now the reference date when the filter has none.
+func matchesList(f *task.ListFilter, t *task.Task, parent *task.Task, now time.Time) bool {
+	if t.Status != task.StatusTodo {
+		return false
+	}
+	if f == nil {
+		return true
+	}
+
+	planned, due := dateKey(t.PlannedDate), dateKey(t.DueDate)
+	today := now.Format(dateFormat)
+	if f.Date != nil {
+		today = f.Date.Format(dateFormat)
+	}
+	// SQL comparisons with NULL are never true
+	onOrBefore := func(d, limit string) bool { return d != "" && d <= limit }
+	after := func(d, limit string) bool { return d != "" && d > limit }
+	between := func(d, from, to string) bool { return d != "" && d >= from && d <= to }
+
+	switch {
+	case f.TaskType != "" && t.TaskType != f.TaskType,
+		f.TagName != "" && !slices.Contains(t.Tags, f.TagName),
+		f.ParentID != nil && (t.ParentID == nil || *t.ParentID != *f.ParentID),
+		f.AreaID != nil && (t.AreaID == nil || *t.AreaID != *f.AreaID),
+		f.Series != nil && t.ID != *f.Series && (t.RecurParentID == nil || *t.RecurParentID != *f.Series),
+		f.State != "" && t.State != f.State,
+		f.Context != "" && (t.Context == nil || *t.Context != f.Context),
+		f.Assignee != "" && (t.Assignee == nil || *t.Assignee != f.Assignee),
+		f.Flagged && !t.Flagged,
+		f.Location != "" && (t.Location == nil || !strings.EqualFold(*t.Location, f.Location)),
+		f.Search != "" && !strings.Contains(strings.ToLower(t.Title), strings.ToLower(f.Search)):
+		return false
+	}
+
+	if f.Today && !onOrBefore(planned, today) && !onOrBefore(due, today) {
+		return false
+	}
+	if f.Upcoming {
+		if f.Until != nil {
+			until := f.Until.Format(dateFormat)
+			if !(after(planned, today) && planned <= until) && !(after(due, today) && due <= until) {
+				return false
+			}
+		} else if !after(planned, today) && !after(due, today) {
+			return false
+		}
+	}
+	if f.From != nil && f.To != nil {
+		from, to := f.From.Format(dateFormat), f.To.Format(dateFormat)
+		if !between(planned, from, to) && !between(due, from, to) {
+			return false
+		}
+	}
+	if f.Due {
+		if due == "" || (f.Until != nil && due > f.Until.Format(dateFormat)) {
+			return false
+		}
+	}
+	if f.Anytime {
+		scoped := t.ParentID != nil || t.AreaID != nil
+		parentActive := t.ParentID == nil || (parent != nil && parent.State == task.StateActive)
+		if planned != "" || due != "" || !scoped || t.State != task.StateActive || !parentActive {
+			return false
+		}
+	}
+	if f.Inbox {
+		if t.ParentID != nil || t.AreaID != nil || planned != "" || due != "" || t.State != task.StateActive {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesCompleted is matchesList for ListCompleted
+func matchesCompleted(f *task.CompletedFilter, t *task.Task, parent *task.Task) bool {
+	if t.Status == task.StatusTodo {
+		return false
+	}
+	if f == nil {
+		return true
+	}
+
+	completed := timestampKey(t.CompletedAt)
+	inArea := func(id int64) bool {
+		if t.AreaID != nil && *t.AreaID == id {
+			return true
+		}
+		return parent != nil && parent.AreaID != nil && *parent.AreaID == id
+	}
+	switch {
+	case f.ParentID != nil && (t.ParentID == nil || *t.ParentID != *f.ParentID),
+		f.AreaID != nil && !inArea(*f.AreaID),
+		f.TagName != "" && !slices.Contains(t.Tags, f.TagName),
+		f.Search != "" && !strings.Contains(strings.ToLower(t.Title), strings.ToLower(f.Search)),
+		f.Since != nil && (completed == "" || completed < f.Since.Format(time.RFC3339)),
+		f.Until != nil && (completed == "" || completed >= f.Until.Format(time.RFC3339)):
+		return false
+	}
+	return true
+}
+
+// sortTasks orders tasks like the SQLite repository: flagged tasks first,
+// then by the filter's sort options (by default by ID)
+func sortTasks(list []task.Task, f *task.ListFilter) {
+	var opts []task.SortOption
+	if f != nil {
+		opts = f.Sort
+	}
+	task.SortTasks(list, opts)
+}
@@ -0,0 +1,359 @@
+package filestore
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+const (
+	dateFormat = "2006-01-02"
+	delimiter  = "---"
+)
+
+// encode writes t as Markdown: its fields as YAML front matter, followed by
+// its description as the body. Unset fields are left out.
+func encode(t *task.Task) []byte {
+	var b bytes.Buffer
+	field := func(key, value string) {
+		fmt.Fprintf(&b, "%s: %s\n", key, value)
+	}
+	date := func(key string, d *time.Time) {
+		if d != nil {
+			field(key, d.Format(dateFormat))
+		}
+	}
+	str := func(key string, s *string) {
+		if s != nil {
+			field(key, scalar(*s))
+		}
+	}
+	num := func(key string, n *int64) {
+		if n != nil {
+			field(key, strconv.FormatInt(*n, 10))
+		}
+	}
+	count := func(key string, n *int) {
+		if n != nil {
+			field(key, strconv.Itoa(*n))
+		}
+	}
+
+	b.WriteString(delimiter + "\n")
+	field("id", strconv.FormatInt(t.ID, 10))
+	field("uuid", scalar(t.UUID))
+	field("type", string(t.TaskType))
+	field("title", scalar(t.Title))
+	field("status", string(t.Status))
+	field("state", string(t.State))
+	num("parent", t.ParentID)
+	num("area", t.AreaID)
+	date("planned", t.PlannedDate)
+	date("due", t.DueDate)
+	date("expires", t.Expires)
+	count("estimate", t.Estimate)
+	str("context", t.Context)
+	str("assignee", t.Assignee)
+	str("creator", t.Creator)
+	str("location", t.Location)
+	str("external_id", t.ExternalID)
+	if t.Flagged {
+		field("flagged", "true")
+	}
+	if len(t.Tags) > 0 {
+		tags := make([]string, len(t.Tags))
+		for i, tag := range t.Tags {
+			tags[i] = scalar(tag)
+		}
+		field("tags", "["+strings.Join(tags, ", ")+"]")
+	}
+	str("recur_type", t.RecurType)
+	str("recur_rule", t.RecurRule)
+	date("recur_end", t.RecurEnd)
+	count("recur_count", t.RecurCount)
+	if t.RecurPaused {
+		field("recur_paused", "true")
+	}
+	num("recur_parent", t.RecurParentID)
+	str("recur_template", t.RecurTemplate)
+	field("created", t.CreatedAt.Format(time.RFC3339))
+	if t.CompletedAt != nil {
+		field("completed", t.CompletedAt.Format(time.RFC3339))
+	}
+	str("completion_note", t.CompletionNote)
+	if t.BlockStart != nil {
+		field("block", t.BlockStart.Format(time.RFC3339))
+	}
+	count("block_minutes", t.BlockMinutes)
+	b.WriteString(delimiter + "\n")
+
+	if t.Description != nil {
+		b.WriteString("\n" + *t.Description + "\n")
+	}
+	return b.Bytes()
+}
+
+// decode parses a task file written by encode, or edited by hand. Tags may
+// also be given as a block list. Unknown keys are ignored.
+func decode(data []byte) (*task.Task, error) {
+	front, body, ok := split(data)
+	if !ok {
+		return nil, fmt.Errorf("missing %s front matter", delimiter)
+	}
+
+	var t task.Task
+	var listKey string // key of a block list being read, e.g. "tags"
+	sc := bufio.NewScanner(bytes.NewReader(front))
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if item, ok := strings.CutPrefix(trimmed, "- "); ok && listKey == "tags" {
+			t.Tags = append(t.Tags, unquote(item))
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key: value", n)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		listKey = ""
+		if value == "" {
+			listKey = key
+			continue
+		}
+		if err := setField(&t, key, value); err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", n, key, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	body = bytes.TrimPrefix(body, []byte("\n"))
+	body = bytes.TrimSuffix(body, []byte("\n"))
+	if len(body) > 0 {
+		desc := string(body)
+		t.Description = &desc
+	}
+	return &t, nil
+}
+
+// split separates the front matter from the body
+func split(data []byte) (front, body []byte, ok bool) {
+	rest, ok := bytes.CutPrefix(data, []byte(delimiter+"\n"))
+	if !ok {
+		return nil, nil, false
+	}
+	if end, ok := bytes.CutPrefix(rest, []byte(delimiter+"\n")); ok {
+		return nil, end, true
+	}
+	front, body, ok = bytes.Cut(rest, []byte("\n"+delimiter+"\n"))
+	if !ok {
+		front, ok = bytes.CutSuffix(rest, []byte("\n"+delimiter))
+	}
+	return front, body, ok
+}
+
+// setField sets the task field stored under key
+func setField(t *task.Task, key, value string) error {
+	var err error
+	str := func() *string {
+		s := unquote(value)
+		return &s
+	}
+	date := func() *time.Time {
+		d, perr := time.Parse(dateFormat, unquote(value))
+		if perr != nil {
+			err = perr
+			return nil
+		}
+		return &d
+	}
+	num := func() *int64 {
+		n, perr := strconv.ParseInt(value, 10, 64)
+		if perr != nil {
+			err = perr
+			return nil
+		}
+		return &n
+	}
+	count := func() *int {
+		n, perr := strconv.Atoi(value)
+		if perr != nil {
+			err = perr
+			return nil
+		}
+		return &n
+	}
+	timestamp := func() time.Time {
+		ts, perr := time.Parse(time.RFC3339, unquote(value))
+		if perr != nil {
+			err = perr
+		}
+		return ts
+	}
+
+	switch key {
+	case "id":
+		if id := num(); id != nil {
+			t.ID = *id
+		}
+	case "uuid":
+		t.UUID = unquote(value)
+	case "type":
+		t.TaskType = task.TaskType(unquote(value))
+	case "title":
+		t.Title = unquote(value)
+	case "status":
+		t.Status = task.Status(unquote(value))
+	case "state":
+		t.State = task.State(unquote(value))
+	case "parent":
+		t.ParentID = num()
+	case "area":
+		t.AreaID = num()
+	case "planned":
+		t.PlannedDate = date()
+	case "due":
+		t.DueDate = date()
+	case "expires":
+		t.Expires = date()
+	case "estimate":
+		t.Estimate = count()
+	case "context":
+		t.Context = str()
+	case "assignee":
+		t.Assignee = str()
+	case "creator":
+		t.Creator = str()
+	case "location":
+		t.Location = str()
+	case "external_id":
+		t.ExternalID = str()
+	case "tags":
+		t.Tags = flowList(value)
+	case "recur_type":
+		t.RecurType = str()
+	case "recur_rule":
+		t.RecurRule = str()
+	case "recur_end":
+		t.RecurEnd = date()
+	case "recur_count":
+		t.RecurCount = count()
+	case "flagged":
+		t.Flagged, err = strconv.ParseBool(value)
+	case "recur_paused":
+		t.RecurPaused, err = strconv.ParseBool(value)
+	case "recur_parent":
+		t.RecurParentID = num()
+	case "recur_template":
+		t.RecurTemplate = str()
+	case "created":
+		t.CreatedAt = timestamp()
+	case "completed":
+		ts := timestamp()
+		t.CompletedAt = &ts
+	case "completion_note":
+		t.CompletionNote = str()
+	case "block":
+		ts := timestamp()
+		t.BlockStart = &ts
+	case "block_minutes":
+		t.BlockMinutes = count()
+	}
+	return err
+}
+
+// scalar formats s as a YAML scalar, quoting it unless it would read back
+// as the same plain string
+func scalar(s string) string {
+	if needsQuotes(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func needsQuotes(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) {
+		return true
+	}
+	if strings.ContainsRune(`-?:,[]{}#&*!|>'"%@`+"`", rune(s[0])) {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return true
+		}
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	return false
+}
+
+// unquote returns the string a YAML scalar stands for
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	switch {
+	case len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"':
+		if u, err := strconv.Unquote(s); err == nil {
+			return u
+		}
+	case len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'':
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+	}
+	return s
+}
+
+// flowList parses a YAML flow sequence like [a, "b, c"]
+func flowList(s string) []string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "[")
+	s = strings.TrimSuffix(s, "]")
+
+	var items []string
+	var cur strings.Builder
+	var quote rune
+	escaped := false
+	flush := func() {
+		if item := strings.TrimSpace(cur.String()); item != "" {
+			items = append(items, unquote(item))
+		}
+		cur.Reset()
+	}
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == ',':
+			flush()
+			continue
+		}
+		cur.WriteRune(r)
+	}
+	flush()
+	return items
+}
@@ -0,0 +1,102 @@
+package filestore
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/holiday"
+)
+
+var _ holiday.Store = (*HolidayStore)(nil)
+
+// HolidayStore keeps holidays in a text file, one per line: the date and,
+// after a space, the optional name
+type HolidayStore struct {
+	path     string
+	readOnly bool
+}
+
+// load reads the holidays in date order
+func (s *HolidayStore) load() ([]holiday.Holiday, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []holiday.Holiday
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dateStr, name, _ := strings.Cut(line, " ")
+		date, err := time.ParseInLocation(dateFormat, dateStr, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", holidaysFile, n, err)
+		}
+		holidays = append(holidays, holiday.Holiday{Date: date, Name: strings.TrimSpace(name)})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(holidays, func(a, b holiday.Holiday) int { return strings.Compare(a.Key(), b.Key()) })
+	return holidays, nil
+}
+
+func (s *HolidayStore) save(holidays []holiday.Holiday) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	var b bytes.Buffer
+	for _, h := range holidays {
+		line := h.Key()
+		if name := strings.Join(strings.Fields(h.Name), " "); name != "" {
+			line += " " + name
+		}
+		b.WriteString(line + "\n")
+	}
+	return writeFile(s.path, b.Bytes())
+}
+
+// Save adds a holiday, replacing the name of an existing one on the same date
+func (s *HolidayStore) Save(h *holiday.Holiday) error {
+	holidays, err := s.load()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(holidays, func(existing holiday.Holiday) bool { return existing.Key() == h.Key() })
+	if i >= 0 {
+		holidays[i].Name = h.Name
+	} else {
+		holidays = append(holidays, *h)
+		slices.SortStableFunc(holidays, func(a, b holiday.Holiday) int { return strings.Compare(a.Key(), b.Key()) })
+	}
+	return s.save(holidays)
+}
+
+func (s *HolidayStore) List() ([]holiday.Holiday, error) {
+	return s.load()
+}
+
+func (s *HolidayStore) Delete(date time.Time) error {
+	holidays, err := s.load()
+	if err != nil {
+		return err
+	}
+	key := date.Format(dateFormat)
+	i := slices.IndexFunc(holidays, func(h holiday.Holiday) bool { return h.Key() == key })
+	if i < 0 {
+		return holiday.ErrHolidayNotFound
+	}
+	return s.save(slices.Delete(holidays, i, i+1))
+}
@@ -0,0 +1,68 @@
+package filestore
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Tag colors live in a text file next to the tasks, one tag per line: the
+// name and, after a space, the color
+
+// loadTagColors reads the tag colors
+func (s *TaskStore) loadTagColors() (map[string]string, error) {
+	colors := map[string]string{}
+	data, err := os.ReadFile(s.tagsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return colors, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, color, ok := strings.Cut(line, " ")
+		if !ok || strings.TrimSpace(color) == "" {
+			return nil, fmt.Errorf("%s:%d: expected a tag name and a color", tagsFile, n)
+		}
+		colors[name] = strings.TrimSpace(color)
+	}
+	return colors, sc.Err()
+}
+
+// SetTagColor sets the color of a tag; an empty color removes it
+func (s *TaskStore) SetTagColor(name, color string) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	colors, err := s.loadTagColors()
+	if err != nil {
+		return err
+	}
+	if color == "" {
+		delete(colors, name)
+	} else {
+		colors[name] = color
+	}
+
+	var b bytes.Buffer
+	for _, name := range slices.Sorted(maps.Keys(colors)) {
+		fmt.Fprintf(&b, "%s %s\n", name, colors[name])
+	}
+	return writeFile(s.tagsPath, b.Bytes())
+}
+
+// TagColors returns the colors of all tags that have one
+func (s *TaskStore) TagColors() (map[string]string, error) {
+	return s.loadTagColors()
+}
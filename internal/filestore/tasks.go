@@ -0,0 +1,711 @@
+package filestore
+
+import (
+	"cmp"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+var _ task.Store = (*TaskStore)(nil)
+
+// TaskStore keeps each task in a file named after its ID and title, like
+// 12-call-the-bank.md. The ID in the front matter is what counts; the file
+// name is only for finding tasks by eye.
+type TaskStore struct {
+	dir          string
+	tagsPath     string // tag colors, see SetTagColor
+	dayOrderPath string // the Today order, see SetDayOrder
+	depsPath     string // dependencies, see SetDependencies
+	areas        *AreaStore
+	comments     *CommentStore
+	clock        clock.Clock
+	readOnly     bool
+}
+
+// SetClock sets the clock the schedule filters fall back to when a filter
+// has no reference date
+func (s *TaskStore) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// entry is a stored task and the file it was read from
+type entry struct {
+	task task.Task
+	file string
+}
+
+// tasks is every stored task by ID
+type tasks map[int64]*entry
+
+// load reads all task files
+func (s *TaskStore) load() (tasks, error) {
+	files, err := os.ReadDir(s.dir)
+	if s.readOnly && errors.Is(err, os.ErrNotExist) {
+		return tasks{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	all := tasks{}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".md") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		t, err := decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name(), err)
+		}
+		if t.ID <= 0 {
+			return nil, fmt.Errorf("%s: missing id", f.Name())
+		}
+		if other, ok := all[t.ID]; ok {
+			return nil, fmt.Errorf("%s: id %d is also used by %s", f.Name(), t.ID, other.file)
+		}
+		slices.Sort(t.Tags)
+		all[t.ID] = &entry{task: *t, file: f.Name()}
+	}
+	return all, nil
+}
+
+// save writes e's task to its file, renaming the file if the title changed
+func (s *TaskStore) save(e *entry) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	name := strconv.FormatInt(e.task.ID, 10)
+	if sl := slug(e.task.Title); sl != "" {
+		name += "-" + sl
+	}
+	name += ".md"
+
+	if err := writeFile(filepath.Join(s.dir, name), encode(&e.task)); err != nil {
+		return err
+	}
+	if e.file != "" && e.file != name {
+		if err := os.Remove(filepath.Join(s.dir, e.file)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	e.file = name
+	return nil
+}
+
+// remove deletes the files of the tasks with the given IDs
+func (s *TaskStore) remove(all tasks, ids []int64) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	for _, id := range ids {
+		if err := os.Remove(filepath.Join(s.dir, all[id].file)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if s.comments != nil {
+		return s.comments.deleteTasks(ids)
+	}
+	return nil
+}
+
+// sorted returns the tasks in ID order
+func (all tasks) sorted() []*entry {
+	entries := make([]*entry, 0, len(all))
+	for _, e := range all {
+		entries = append(entries, e)
+	}
+	slices.SortFunc(entries, func(a, b *entry) int { return cmp.Compare(a.task.ID, b.task.ID) })
+	return entries
+}
+
+// parent returns t's project, or nil
+func (all tasks) parent(t *task.Task) *task.Task {
+	if t.ParentID == nil {
+		return nil
+	}
+	if p, ok := all[*t.ParentID]; ok {
+		return &p.task
+	}
+	return nil
+}
+
+// withNames returns a copy of t with its project and area names filled in,
+// the area coming from the project when t has none of its own
+func (all tasks) withNames(t *task.Task, areaNames map[int64]string) task.Task {
+	named := *t
+	named.Tags = slices.Clone(t.Tags)
+	parent := all.parent(t)
+	if parent != nil {
+		title := parent.Title
+		named.ParentName = &title
+	}
+	areaID := t.AreaID
+	if areaID == nil && parent != nil {
+		areaID = parent.AreaID
+	}
+	if areaID != nil {
+		if name, ok := areaNames[*areaID]; ok {
+			named.AreaName = &name
+		}
+	}
+	return named
+}
+
+// subtree returns id and the IDs of all tasks below it
+func (all tasks) subtree(id int64) []int64 {
+	ids := []int64{id}
+	for i := 0; i < len(ids); i++ {
+		for _, e := range all.sorted() {
+			if e.task.ParentID != nil && *e.task.ParentID == ids[i] {
+				ids = append(ids, e.task.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// checkOccurrences returns an error if a task outside ids is an occurrence
+// of a recurring series started by one of them
+func (all tasks) checkOccurrences(ids []int64) error {
+	for _, e := range all.sorted() {
+		root := e.task.RecurParentID
+		if root != nil && slices.Contains(ids, *root) && !slices.Contains(ids, e.task.ID) {
+			return fmt.Errorf("task %d started a recurring series that task %d belongs to", *root, e.task.ID)
+		}
+	}
+	return nil
+}
+
+// checkRefs returns an error if t refers to a project or area that doesn't exist
+func (s *TaskStore) checkRefs(all tasks, t *task.Task) error {
+	if t.ParentID != nil {
+		if _, ok := all[*t.ParentID]; !ok {
+			return fmt.Errorf("project %d: %w", *t.ParentID, task.ErrTaskNotFound)
+		}
+	}
+	if t.AreaID != nil {
+		if _, err := s.areas.GetByID(*t.AreaID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkProjectTitle returns an error if another project is called title
+func (all tasks) checkProjectTitle(id int64, title string) error {
+	for _, e := range all {
+		if e.task.ID != id && e.task.IsProject() && e.task.Title == title {
+			return fmt.Errorf("a project named %q already exists", title)
+		}
+	}
+	return nil
+}
+
+func (s *TaskStore) Create(t *task.Task) error {
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	taskType := t.TaskType
+	if taskType == "" {
+		taskType = task.TaskTypeTask
+	}
+	if err := s.checkRefs(all, t); err != nil {
+		return err
+	}
+	if taskType == task.TaskTypeProject {
+		if err := all.checkProjectTitle(0, t.Title); err != nil {
+			return err
+		}
+	}
+	var id int64
+	for _, e := range all {
+		if e.task.UUID == t.UUID {
+			return fmt.Errorf("uuid %s is already used by task %d", t.UUID, e.task.ID)
+		}
+		if t.ExternalID != nil && e.task.ExternalID != nil && *e.task.ExternalID == *t.ExternalID {
+			return fmt.Errorf("external id %s is already used by task %d", *t.ExternalID, e.task.ID)
+		}
+		id = max(id, e.task.ID)
+	}
+	id++
+
+	// Like an insert: the completion time and tags are set separately, and
+	// timestamps keep second precision
+	stored := *t
+	stored.ID = id
+	stored.TaskType = taskType
+	stored.CreatedAt = truncate(t.CreatedAt)
+	stored.CompletedAt = nil
+	stored.Tags = nil
+	stored.ParentName = nil
+	stored.AreaName = nil
+	if err := s.save(&entry{task: stored}); err != nil {
+		return err
+	}
+
+	t.ID = id
+	t.TaskType = taskType
+	return nil
+}
+
+func (s *TaskStore) Update(t *task.Task) error {
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	e, ok := all[t.ID]
+	if !ok {
+		return task.ErrTaskNotFound
+	}
+	if err := s.checkRefs(all, t); err != nil {
+		return err
+	}
+	if e.task.IsProject() {
+		if err := all.checkProjectTitle(t.ID, t.Title); err != nil {
+			return err
+		}
+	}
+
+	// The fields an update changes; status, tags and the series a task
+	// belongs to have their own methods
+	stored := &e.task
+	stored.Title = t.Title
+	stored.Description = t.Description
+	stored.ParentID = t.ParentID
+	stored.AreaID = t.AreaID
+	stored.PlannedDate = t.PlannedDate
+	stored.DueDate = t.DueDate
+	stored.State = t.State
+	stored.RecurType = t.RecurType
+	stored.RecurRule = t.RecurRule
+	stored.RecurEnd = t.RecurEnd
+	stored.RecurPaused = t.RecurPaused
+	stored.Estimate = t.Estimate
+	stored.Context = t.Context
+	stored.RecurTemplate = t.RecurTemplate
+	stored.RecurCount = t.RecurCount
+	stored.Expires = t.Expires
+	stored.Assignee = t.Assignee
+	stored.Location = t.Location
+	stored.Flagged = t.Flagged
+	stored.CompletionNote = t.CompletionNote
+	stored.BlockStart = t.BlockStart
+	stored.BlockMinutes = t.BlockMinutes
+	return s.save(e)
+}
+
+// Delete deletes a task, along with the tasks of a project
+func (s *TaskStore) Delete(id int64) error {
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[id]; !ok {
+		return task.ErrTaskNotFound
+	}
+
+	ids := all.subtree(id)
+	if err := all.checkOccurrences(ids); err != nil {
+		return err
+	}
+	return s.remove(all, ids)
+}
+
+// deleteInArea deletes the tasks in an area, along with the tasks of its
+// projects
+func (s *TaskStore) deleteInArea(areaID int64) error {
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	var ids []int64
+	for _, e := range all.sorted() {
+		if e.task.AreaID != nil && *e.task.AreaID == areaID {
+			for _, id := range all.subtree(e.task.ID) {
+				if !slices.Contains(ids, id) {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	if err := all.checkOccurrences(ids); err != nil {
+		return err
+	}
+	return s.remove(all, ids)
+}
+
+// GetByID returns the task with the given ID. Like the SQLite repository,
+// it returns an error matching sql.ErrNoRows if there is none.
+func (s *TaskStore) GetByID(id int64) (*task.Task, error) {
+	all, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	e, ok := all[id]
+	if !ok {
+		return nil, fmt.Errorf("task %d: %w", id, sql.ErrNoRows)
+	}
+	t := e.task
+	return &t, nil
+}
+
+// GetByName finds a task by title and type (for project lookup)
+func (s *TaskStore) GetByName(name string, taskType task.TaskType) (*task.Task, error) {
+	all, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range all.sorted() {
+		if e.task.Title == name && e.task.TaskType == taskType {
+			t := e.task
+			return &t, nil
+		}
+	}
+	return nil, task.ErrTaskNotFound
+}
+
+func (s *TaskStore) GetByExternalID(externalID string) (*task.Task, error) {
+	all, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range all {
+		if e.task.ExternalID != nil && *e.task.ExternalID == externalID {
+			t := e.task
+			return &t, nil
+		}
+	}
+	return nil, task.ErrTaskNotFound
+}
+
+func (s *TaskStore) List(filter *task.ListFilter) ([]task.Task, error) {
+	var list []task.Task
+	err := s.ListIter(filter, func(t task.Task) error {
+		list = append(list, t)
+		return nil
+	})
+	return list, err
+}
+
+// ListIter calls fn for each task List would return. It stops at the first
+// error fn returns.
+func (s *TaskStore) ListIter(filter *task.ListFilter, fn func(task.Task) error) error {
+	return s.each(func(all tasks, t *task.Task) bool {
+		return matchesList(filter, t, all.parent(t), clock.Now(s.clock))
+	}, func(list []task.Task) {
+		sortTasks(list, filter)
+	}, fn)
+}
+
+func (s *TaskStore) ListCompleted(filter *task.CompletedFilter) ([]task.Task, error) {
+	var list []task.Task
+	err := s.ListCompletedIter(filter, func(t task.Task) error {
+		list = append(list, t)
+		return nil
+	})
+	return list, err
+}
+
+// ListCompletedIter is ListIter for completed tasks, most recent first
+func (s *TaskStore) ListCompletedIter(filter *task.CompletedFilter, fn func(task.Task) error) error {
+	return s.each(func(all tasks, t *task.Task) bool {
+		return matchesCompleted(filter, t, all.parent(t))
+	}, func(list []task.Task) {
+		slices.SortStableFunc(list, func(a, b task.Task) int {
+			return strings.Compare(timestampKey(b.CompletedAt), timestampKey(a.CompletedAt))
+		})
+	}, fn)
+}
+
+// ListExpired returns open tasks whose expires date is before today
+func (s *TaskStore) ListExpired(today time.Time) ([]task.Task, error) {
+	cutoff := today.Format(dateFormat)
+	var list []task.Task
+	err := s.each(func(_ tasks, t *task.Task) bool {
+		return t.Status == task.StatusTodo && t.Expires != nil && dateKey(t.Expires) < cutoff
+	}, func(list []task.Task) {
+		slices.SortStableFunc(list, func(a, b task.Task) int {
+			return strings.Compare(dateKey(a.Expires), dateKey(b.Expires))
+		})
+	}, func(t task.Task) error {
+		list = append(list, t)
+		return nil
+	})
+	return list, err
+}
+
+// ListArchivable returns tasks completed before completedBefore and someday
+// tasks created before somedayBefore, leaving out projects and tasks that
+// started a recurring series with other occurrences. A nil cutoff leaves
+// that kind out.
+func (s *TaskStore) ListArchivable(completedBefore, somedayBefore *time.Time) ([]task.Task, error) {
+	if completedBefore == nil && somedayBefore == nil {
+		return nil, nil
+	}
+
+	var list []task.Task
+	err := s.each(func(all tasks, t *task.Task) bool {
+		if t.TaskType != task.TaskTypeTask {
+			return false
+		}
+		for _, e := range all {
+			if e.task.RecurParentID != nil && *e.task.RecurParentID == t.ID {
+				return false
+			}
+		}
+		done := completedBefore != nil && t.Status != task.StatusTodo &&
+			timestampKey(t.CompletedAt) != "" && timestampKey(t.CompletedAt) < completedBefore.Format(time.RFC3339)
+		stale := somedayBefore != nil && t.Status == task.StatusTodo && t.State == task.StateSomeday &&
+			t.CreatedAt.Format(time.RFC3339) < somedayBefore.Format(time.RFC3339)
+		return done || stale
+	}, nil, func(t task.Task) error {
+		list = append(list, t)
+		return nil
+	})
+	return list, err
+}
+
+// each calls fn, in ID order rearranged by order, for the tasks match
+// accepts, with project and area names filled in
+func (s *TaskStore) each(match func(tasks, *task.Task) bool, order func([]task.Task), fn func(task.Task) error) error {
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	areaNames, err := s.areas.names()
+	if err != nil {
+		return err
+	}
+
+	var list []task.Task
+	for _, e := range all.sorted() {
+		if match(all, &e.task) {
+			list = append(list, all.withNames(&e.task, areaNames))
+		}
+	}
+	if order != nil {
+		order(list)
+	}
+	for _, t := range list {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TaskStore) Complete(id int64, completedAt time.Time) error {
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	return s.finish(all, id, completedAt, task.StatusDone)
+}
+
+// CompleteWithChildren completes a task and all its child tasks (for projects)
+func (s *TaskStore) CompleteWithChildren(id int64, completedAt time.Time) error {
+	return s.finishWithChildren(id, completedAt, task.StatusDone)
+}
+
+// Cancel marks an open task canceled, along with the open tasks of a project
+func (s *TaskStore) Cancel(id int64, canceledAt time.Time) error {
+	return s.finishWithChildren(id, canceledAt, task.StatusCanceled)
+}
+
+// finishWithChildren closes a task and its open child tasks with status
+func (s *TaskStore) finishWithChildren(id int64, at time.Time, status task.Status) error {
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	for _, e := range all.sorted() {
+		if e.task.ParentID != nil && *e.task.ParentID == id && e.task.Status == task.StatusTodo {
+			if err := s.finish(all, e.task.ID, at, status); err != nil {
+				return err
+			}
+		}
+	}
+	return s.finish(all, id, at, status)
+}
+
+// finish closes an open task, marking it done or canceled
+func (s *TaskStore) finish(all tasks, id int64, at time.Time, status task.Status) error {
+	e, ok := all[id]
+	if !ok || e.task.Status != task.StatusTodo {
+		return task.ErrTaskNotFound
+	}
+	at = truncate(at)
+	e.task.Status = status
+	e.task.CompletedAt = &at
+	return s.save(e)
+}
+
+// Uncomplete reopens a done or canceled task
+func (s *TaskStore) Uncomplete(id int64) error {
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	e, ok := all[id]
+	if !ok || (e.task.Status != task.StatusDone && e.task.Status != task.StatusCanceled) {
+		return task.ErrTaskNotFound
+	}
+	e.task.Status = task.StatusTodo
+	e.task.CompletedAt = nil
+	e.task.CompletionNote = nil
+	return s.save(e)
+}
+
+// AddTag adds a tag to a task
+func (s *TaskStore) AddTag(taskID int64, tagName string) error {
+	return s.updateTags(taskID, func(tags []string) []string {
+		if slices.Contains(tags, tagName) {
+			return tags
+		}
+		return append(tags, tagName)
+	})
+}
+
+// RemoveTag removes a tag from a task
+func (s *TaskStore) RemoveTag(taskID int64, tagName string) error {
+	return s.updateTags(taskID, func(tags []string) []string {
+		return slices.DeleteFunc(tags, func(tag string) bool { return tag == tagName })
+	})
+}
+
+// SetTags replaces all tags on a task
+func (s *TaskStore) SetTags(taskID int64, tags []string) error {
+	return s.updateTags(taskID, func([]string) []string {
+		var set []string
+		for _, tag := range tags {
+			if !slices.Contains(set, tag) {
+				set = append(set, tag)
+			}
+		}
+		return set
+	})
+}
+
+// updateTags replaces a task's tags with what change makes of them
+func (s *TaskStore) updateTags(taskID int64, change func([]string) []string) error {
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	e, ok := all[taskID]
+	if !ok {
+		return task.ErrTaskNotFound
+	}
+	e.task.Tags = change(slices.Clone(e.task.Tags))
+	slices.Sort(e.task.Tags)
+	return s.save(e)
+}
+
+// ListTags returns all unique tags in use
+func (s *TaskStore) ListTags() ([]string, error) {
+	all, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, e := range all {
+		tags = append(tags, e.task.Tags...)
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags), nil
+}
+
+// ListLocations returns the locations of open tasks
+func (s *TaskStore) ListLocations() ([]string, error) {
+	all, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	var locations []string
+	for _, e := range all {
+		if e.task.Location != nil && e.task.Status == task.StatusTodo {
+			locations = append(locations, *e.task.Location)
+		}
+	}
+	slices.Sort(locations)
+	return slices.Compact(locations), nil
+}
+
+// CountOccurrences returns how many occurrences the recurring series started
+// by root has had, done or not
+func (s *TaskStore) CountOccurrences(root int64) (int, error) {
+	all, err := s.load()
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, e := range all {
+		if e.task.ID == root || (e.task.RecurParentID != nil && *e.task.RecurParentID == root) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Counts tallies tasks by status and projects
+func (s *TaskStore) Counts() (task.Counts, error) {
+	all, err := s.load()
+	if err != nil {
+		return task.Counts{}, err
+	}
+	var c task.Counts
+	for _, e := range all {
+		t := e.task
+		switch {
+		case t.TaskType == task.TaskTypeProject:
+			c.Projects++
+		case t.TaskType == task.TaskTypeTask && t.Status == task.StatusTodo:
+			c.Open++
+			if t.State == task.StateSomeday {
+				c.Someday++
+			}
+		case t.TaskType == task.TaskTypeTask && t.Status == task.StatusDone:
+			c.Done++
+		}
+	}
+	return c, nil
+}
+
+// truncate drops what the stored RFC 3339 timestamps can't hold
+func truncate(t time.Time) time.Time {
+	parsed, _ := time.Parse(time.RFC3339, t.Format(time.RFC3339))
+	return parsed
+}
+
+// dateKey returns a date as stored, or "" for none, so dates compare like
+// they do in SQLite
+func dateKey(d *time.Time) string {
+	if d == nil {
+		return ""
+	}
+	return d.Format(dateFormat)
+}
+
+// timestampKey is dateKey for timestamps
+func timestampKey(ts *time.Time) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.Format(time.RFC3339)
+}
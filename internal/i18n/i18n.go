@@ -0,0 +1,138 @@
+// Package i18n translates user-facing messages and month and weekday names.
+//
+// Messages are looked up by their English text, which is also the fallback,
+// so code keeps reading naturally: loc.Sprintf("Deleted #%d: %s", id, title).
+// Catalogs live in locales/<language>.toml; translations can reorder
+// arguments with explicit indexes such as %[2]s.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+//go:embed locales/*.toml
+var catalogs embed.FS
+
+// English is the built-in language: messages and names are used as written.
+var English = &Locale{Language: "en"}
+
+// Locale holds the translations for one language. A nil catalog or name
+// list falls back to English.
+type Locale struct {
+	Language      string
+	Months        []string          `toml:"months"`         // January first
+	ShortMonths   []string          `toml:"short_months"`   // January first
+	Weekdays      []string          `toml:"weekdays"`       // Sunday first, like time.Weekday
+	ShortWeekdays []string          `toml:"short_weekdays"` // Sunday first, like time.Weekday
+	Messages      map[string]string `toml:"messages"`
+}
+
+// Languages returns the supported language codes, sorted
+func Languages() []string {
+	languages := []string{English.Language}
+	entries, _ := catalogs.ReadDir("locales")
+	for _, entry := range entries {
+		languages = append(languages, strings.TrimSuffix(entry.Name(), ".toml"))
+	}
+	slices.Sort(languages)
+	return languages
+}
+
+// Load returns the locale for a language code such as "de". An empty code
+// means English.
+func Load(language string) (*Locale, error) {
+	if language == "" || language == English.Language {
+		return English, nil
+	}
+
+	data, err := catalogs.ReadFile("locales/" + language + ".toml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown language %q (available: %s)", language, strings.Join(Languages(), ", "))
+	}
+
+	l := &Locale{Language: language}
+	if _, err := toml.Decode(string(data), l); err != nil {
+		return nil, fmt.Errorf("reading %s catalog: %w", language, err)
+	}
+	for name, list := range map[string][]string{"months": l.Months, "short_months": l.ShortMonths} {
+		if list != nil && len(list) != 12 {
+			return nil, fmt.Errorf("reading %s catalog: %s needs 12 names, got %d", language, name, len(list))
+		}
+	}
+	for name, list := range map[string][]string{"weekdays": l.Weekdays, "short_weekdays": l.ShortWeekdays} {
+		if list != nil && len(list) != 7 {
+			return nil, fmt.Errorf("reading %s catalog: %s needs 7 names, got %d", language, name, len(list))
+		}
+	}
+	return l, nil
+}
+
+// T returns the translation of an English message, or the message itself
+func (l *Locale) T(message string) string {
+	if translated, ok := l.Messages[message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Sprintf translates format and formats it like fmt.Sprintf
+func (l *Locale) Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(l.T(format), args...)
+}
+
+// FormatDate formats t like time.Format with a translated layout, replacing
+// month and weekday names ("January", "Jan", "Monday", "Mon") with the
+// locale's. Layouts are translated too, so "Jan 2" can become "2. Jan".
+func (l *Locale) FormatDate(t time.Time, layout string) string {
+	layout = l.T(layout)
+	if l.Months == nil && l.ShortMonths == nil && l.Weekdays == nil && l.ShortWeekdays == nil {
+		return t.Format(layout)
+	}
+
+	var b strings.Builder
+	start := 0
+	for i := 0; i < len(layout); {
+		name, n := l.name(t, layout[i:])
+		if n == 0 {
+			i++
+			continue
+		}
+		b.WriteString(t.Format(layout[start:i]))
+		b.WriteString(name)
+		i += n
+		start = i
+	}
+	b.WriteString(t.Format(layout[start:]))
+	return b.String()
+}
+
+// name returns the localized name for a name token at the start of layout
+// and the token's length, or 0 if layout doesn't start with one
+func (l *Locale) name(t time.Time, layout string) (string, int) {
+	// Longest tokens first, so "January" isn't read as "Jan" + "uary"
+	tokens := []struct {
+		token string
+		names []string
+		index int
+	}{
+		{"January", l.Months, int(t.Month()) - 1},
+		{"Monday", l.Weekdays, int(t.Weekday())},
+		{"Jan", l.ShortMonths, int(t.Month()) - 1},
+		{"Mon", l.ShortWeekdays, int(t.Weekday())},
+	}
+	for _, tok := range tokens {
+		if strings.HasPrefix(layout, tok.token) {
+			if tok.names == nil {
+				return t.Format(tok.token), len(tok.token)
+			}
+			return tok.names[tok.index], len(tok.token)
+		}
+	}
+	return "", 0
+}
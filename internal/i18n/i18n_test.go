@@ -0,0 +1,77 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2026, 3, 3, 14, 5, 0, 0, time.UTC) // a Tuesday
+
+	tests := []struct {
+		language string
+		layout   string
+		want     string
+	}{
+		{"en", "Mon Jan 2", "Tue Mar 3"},
+		{"en", "Monday, January 2, 2006", "Tuesday, March 3, 2026"},
+		{"de", "Jan 2", "3. Mär"},
+		{"de", "Mon Jan 2, 2006", "Di, 3. Mär 2026"},
+		{"de", "Monday 15:04", "Dienstag 14:05"},
+		{"es", "Mon Jan 2", "mar 3 mar"},
+		{"es", "January 2006", "marzo 2026"},
+	}
+	for _, tt := range tests {
+		l, err := Load(tt.language)
+		if err != nil {
+			t.Fatalf("Load(%q) error = %v", tt.language, err)
+		}
+		if got := l.FormatDate(date, tt.layout); got != tt.want {
+			t.Errorf("%s: FormatDate(%q) = %q, want %q", tt.language, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	de, err := Load("de")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, want := de.Sprintf("Deleted #%d: %s", 3, "Milch"), "#3 gelöscht: Milch"; got != want {
+		t.Errorf("Sprintf() = %q, want %q", got, want)
+	}
+	// Messages without a translation fall back to English
+	if got, want := de.Sprintf("Not in the catalog: %d", 1), "Not in the catalog: 1"; got != want {
+		t.Errorf("Sprintf() = %q, want %q", got, want)
+	}
+}
+
+func TestCatalogsMatch(t *testing.T) {
+	var locales []*Locale
+	for _, language := range Languages() {
+		l, err := Load(language)
+		if err != nil {
+			t.Fatalf("Load(%q) error = %v", language, err)
+		}
+		if l != English {
+			locales = append(locales, l)
+		}
+	}
+
+	// Every catalog translates the same messages, so none lags behind
+	for _, a := range locales {
+		for _, b := range locales {
+			for message := range a.Messages {
+				if _, ok := b.Messages[message]; !ok {
+					t.Errorf("%s translates %q but %s doesn't", a.Language, message, b.Language)
+				}
+			}
+		}
+	}
+}
+
+func TestLoadUnknown(t *testing.T) {
+	if _, err := Load("xx"); err == nil {
+		t.Error("Load(\"xx\") succeeded, want error")
+	}
+}
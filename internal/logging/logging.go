@@ -0,0 +1,68 @@
+// Package logging writes tt's debug log: the commands run, SQL statements
+// with their timings and the messages the TUI handles. It is off unless
+// turned on with --verbose, TT_DEBUG=1 or debug = true in the config, and
+// then appends to tt.log in the data directory.
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// File is the name of the log in the data directory
+const File = "tt.log"
+
+var (
+	logger = slog.New(slog.DiscardHandler)
+	file   io.Closer
+)
+
+// Start appends the log to File in dir. Every record carries the process
+// ID, so runs of several tt processes can be told apart.
+func Start(dir string) (string, error) {
+	path := filepath.Join(dir, File)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return "", err
+	}
+	Stop()
+	file = f
+	logger = slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug})).With("pid", os.Getpid())
+	return path, nil
+}
+
+// Stop closes the log; later records are discarded
+func Stop() {
+	if file != nil {
+		file.Close()
+		file = nil
+	}
+	logger = slog.New(slog.DiscardHandler)
+}
+
+// Enabled reports whether records are written, for callers that would
+// otherwise do work to build them
+func Enabled() bool {
+	return file != nil
+}
+
+// Debug writes a record with the given key-value pairs
+func Debug(msg string, args ...any) {
+	logger.Debug(msg, args...)
+}
+
+// Query records an SQL statement that started at start, with its arguments
+// and error, if any
+func Query(query string, args []any, start time.Time, err error) {
+	if !Enabled() {
+		return
+	}
+	attrs := []any{"sql", query, "args", args, "duration", time.Since(start)}
+	if err != nil {
+		attrs = append(attrs, "err", err)
+	}
+	logger.Debug("query", attrs...)
+}
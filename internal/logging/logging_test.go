@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryLog(t *testing.T) {
+	// Off by default
+	Query("SELECT 1", nil, time.Now(), nil)
+	if Enabled() {
+		t.Fatal("Enabled() before Start")
+	}
+
+	path, err := Start(t.TempDir())
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	Query("SELECT id FROM tasks WHERE id = ?", []any{12}, time.Now(), errors.New("boom"))
+	Stop()
+	Debug("after stop")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	for _, want := range []string{`sql="SELECT id FROM tasks WHERE id = ?"`, "args=[12]", "err=boom", "duration="} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log %q doesn't contain %q", log, want)
+		}
+	}
+	if strings.Contains(log, "SELECT 1") || strings.Contains(log, "after stop") {
+		t.Errorf("log %q has records written while off", log)
+	}
+}
@@ -0,0 +1,41 @@
+// Package notify shows desktop notifications through the tools the platform
+// provides: notify-send on Linux and BSD, osascript on macOS.
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+)
+
+// ErrUnsupported is returned when there is no way to notify on this system
+var ErrUnsupported = errors.New("desktop notifications are not supported here")
+
+// Send shows a desktop notification with a title and body
+func Send(title, body string) error {
+	name, args, err := command(runtime.GOOS, title, body)
+	if err != nil {
+		return err
+	}
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("%w: %s not found", ErrUnsupported, name)
+	}
+	if out, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("sending notification: %v: %s", err, out)
+	}
+	return nil
+}
+
+// command returns the program and arguments that show a notification on goos
+func command(goos, title, body string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(body), strconv.Quote(title))
+		return "osascript", []string{"-e", script}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "notify-send", []string{"--app-name=tt", title, body}, nil
+	}
+	return "", nil, fmt.Errorf("%w (%s)", ErrUnsupported, goos)
+}
@@ -0,0 +1,24 @@
+package notify
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	name, args, err := command("linux", "tt", "2 overdue tasks")
+	if err != nil || name != "notify-send" || !slices.Equal(args, []string{"--app-name=tt", "tt", "2 overdue tasks"}) {
+		t.Errorf("command(linux) = %q %q, %v", name, args, err)
+	}
+
+	name, args, err = command("darwin", "tt", `Say "hi"`)
+	want := `display notification "Say \"hi\"" with title "tt"`
+	if err != nil || name != "osascript" || len(args) != 2 || args[1] != want {
+		t.Errorf("command(darwin) = %q %q, %v", name, args, err)
+	}
+
+	if _, _, err := command("plan9", "tt", "x"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("command(plan9) error = %v, want ErrUnsupported", err)
+	}
+}
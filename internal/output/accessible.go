@@ -0,0 +1,118 @@
+package output
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/recurparse"
+)
+
+// SetAccessible switches the theme to screen-reader friendly output: no
+// colors, bold or icons, and tasks as labeled sentences instead of aligned
+// columns, e.g. "Task 12: Call Bob, due tomorrow, project Work".
+func (t *Theme) SetAccessible() {
+	t.stripColors()
+	t.Header = lipgloss.NewStyle()
+	t.Icons = Icons{}
+	t.Accessible = true
+}
+
+// accessibleRow describes a task as one labeled line
+func (f *Formatter) accessibleRow(t *task.Task, showScope bool) string {
+	today := f.today()
+	var parts []string
+	if t.IsProject() {
+		parts = append(parts, tr("Project %d: %s", t.ID, sanitizeTitle(t.Title)))
+	} else {
+		parts = append(parts, tr("Task %d: %s", t.ID, sanitizeTitle(t.Title)))
+	}
+	if t.Flagged {
+		parts = append(parts, tr("flagged"))
+	}
+
+	if t.DueDate != nil {
+		if isOverdue(*t.DueDate, today) {
+			parts = append(parts, tr("overdue, was due %s", spokenDate(*t.DueDate, today)))
+		} else {
+			parts = append(parts, tr("due %s", spokenDate(*t.DueDate, today)))
+		}
+	}
+	if t.PlannedDate != nil && !f.hidePlannedDate {
+		parts = append(parts, tr("planned %s", spokenDate(*t.PlannedDate, today)))
+	}
+	if showScope {
+		if t.ParentName != nil && !t.IsProject() {
+			parts = append(parts, tr("project %s", *t.ParentName))
+		}
+		if t.AreaName != nil {
+			parts = append(parts, tr("area %s", *t.AreaName))
+		}
+	}
+	if t.RecurRule != nil && !t.IsProject() {
+		pattern := tr("unknown")
+		if rule, err := recurparse.FromJSON(*t.RecurRule); err == nil {
+			pattern = rule.Format()
+		}
+		if t.RecurPaused {
+			parts = append(parts, tr("repeats %s, paused", pattern))
+		} else {
+			parts = append(parts, tr("repeats %s", pattern))
+		}
+	}
+	if t.Estimate != nil {
+		parts = append(parts, tr("estimate %s", task.FormatEstimate(*t.Estimate)))
+	}
+	if t.Context != nil {
+		parts = append(parts, tr("context %s", *t.Context))
+	}
+	if t.Location != nil {
+		parts = append(parts, tr("at %s", *t.Location))
+	}
+	if t.Assignee != nil {
+		parts = append(parts, tr("assigned to %s", *t.Assignee))
+	}
+	if len(t.Tags) > 0 {
+		parts = append(parts, tr("tags %s", strings.Join(t.Tags, " ")))
+	}
+	if f.details && t.Description != nil {
+		if done, total := task.Checklist(*t.Description); total > 0 {
+			parts = append(parts, tr("checklist %d of %d done", done, total))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// accessibleHeader spells out scope headers like "Work > Website"
+func accessibleHeader(header string) string {
+	return strings.ReplaceAll(header, " > ", ", ")
+}
+
+// spokenDate renders a date the way it would be said relative to now:
+// "today", "tomorrow", "Friday" within the coming week, otherwise "October 20"
+func spokenDate(d, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.Local)
+
+	switch days := int(math.Round(day.Sub(today).Hours() / 24)); {
+	case days == 0:
+		return tr("today")
+	case days == 1:
+		return tr("tomorrow")
+	case days == -1:
+		return tr("yesterday")
+	case days > 1 && days < 7:
+		return formatDate(d, "Monday")
+	case d.Year() != now.Year():
+		return formatDate(d, "January 2, 2006")
+	}
+	return formatDate(d, "January 2")
+}
+
+// isOverdue reports whether d is before the day of now
+func isOverdue(d, now time.Time) bool {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.Local).Before(today)
+}
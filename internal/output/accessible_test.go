@@ -0,0 +1,58 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+func TestAccessibleTaskList(t *testing.T) {
+	now := time.Now()
+	tomorrow := now.AddDate(0, 0, 1)
+	overdue := time.Date(now.Year()-1, time.March, 3, 0, 0, 0, 0, time.Local)
+	area, project := "Work", "Website"
+	estimate := 30
+
+	theme := DefaultTheme()
+	theme.SetAccessible()
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, theme)
+	f.TaskList([]task.Task{
+		{ID: 12, Title: "Call Bob", DueDate: &tomorrow, AreaName: &area, ParentName: &project, Estimate: &estimate, Tags: []string{"phone"}},
+		{ID: 13, Title: "File taxes", DueDate: &overdue, PlannedDate: &now},
+	})
+
+	want := []string{
+		"Task 12: Call Bob, due tomorrow, project Website, area Work, estimate 30m, tags phone",
+		"Task 13: File taxes, overdue, was due " + overdue.Format("January 2, 2006") + ", planned today",
+	}
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("TaskList() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("TaskList() output contains escape sequences: %q", buf.String())
+	}
+}
+
+func TestAccessibleTaskListAfterMidnightBeforeRollover(t *testing.T) {
+	// 01:00 on Mar 11 with the day starting at 04:00 is still Mar 10
+	now := time.Date(2026, time.March, 11, 1, 0, 0, 0, time.Local)
+	due := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.Local)
+
+	theme := DefaultTheme()
+	theme.SetAccessible()
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, theme)
+	f.SetClock(clock.Fixed(now))
+	f.SetSchedule(task.ScheduleSettings{DayRolloverHour: 4})
+	f.TaskList([]task.Task{{ID: 1, Title: "Pay rent", DueDate: &due}})
+
+	if got, want := strings.TrimSpace(buf.String()), "Task 1: Pay rent, due today"; got != want {
+		t.Errorf("TaskList() = %q, want %q", got, want)
+	}
+}
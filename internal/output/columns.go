@@ -0,0 +1,280 @@
+package output
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// Column names accepted by the `columns` and `widths` config settings
+const (
+	ColumnFlag     = "flag"     // due/flagged/planned-today indicator
+	ColumnID       = "id"       // task ID, right-aligned
+	ColumnScope    = "scope"    // "Area > Project"
+	ColumnTitle    = "title"    // task or project title
+	ColumnRecur    = "recur"    // recurrence indicator and pattern
+	ColumnPlanned  = "planned"  // planned date
+	ColumnDue      = "due"      // due date
+	ColumnEstimate = "estimate" // time estimate
+	ColumnContext  = "context"  // @context
+	ColumnAssignee = "assignee" // →assignee, in shared databases
+	ColumnLocation = "location" // ⌂location
+	ColumnTags     = "tags"     // #tags
+)
+
+var defaultColumns = []string{
+	ColumnFlag, ColumnID, ColumnScope, ColumnTitle, ColumnRecur,
+	ColumnPlanned, ColumnDue, ColumnEstimate, ColumnContext, ColumnLocation, ColumnAssignee, ColumnTags,
+}
+
+// Columns returns all column names in their default order
+func Columns() []string {
+	return slices.Clone(defaultColumns)
+}
+
+// IsColumn reports whether name is a known column
+func IsColumn(name string) bool {
+	return slices.Contains(defaultColumns, name)
+}
+
+// ColumnLayout selects, orders and sizes the columns of task list rows
+type ColumnLayout struct {
+	Columns []string       // column names in display order (empty = default order)
+	Widths  map[string]int // fixed width per column; longer values are truncated with "…"
+}
+
+// FlaggedIcon marks flagged tasks that aren't due, in lists and the TUI
+const FlaggedIcon = "*"
+
+// cell is one rendered column of a task row. Text is unstyled so it can be
+// measured and truncated before the style is applied.
+type cell struct {
+	text  string
+	style *lipgloss.Style     // nil = plain text
+	paint func(string) string // styles the text instead of style, for mixed colors
+}
+
+// SetColumns sets the column layout used for task rows. Unknown column names
+// are ignored; an empty layout restores the default.
+func (f *Formatter) SetColumns(layout ColumnLayout) {
+	f.layout = layout
+}
+
+func (f *Formatter) columns() []string {
+	if len(f.layout.Columns) == 0 {
+		return defaultColumns
+	}
+	return f.layout.Columns
+}
+
+// columnSeparator returns the gap written after a column. ID and scope are
+// set apart by two spaces, everything else by one.
+func columnSeparator(name string) string {
+	switch name {
+	case ColumnID, ColumnScope:
+		return "  "
+	}
+	return " "
+}
+
+// taskCell builds the cell for one column of a task row. An empty text means
+// the column has nothing to show for this task.
+func (f *Formatter) taskCell(t *task.Task, name string, showScope bool, idWidth int) cell {
+	muted := func(s string) cell { return cell{text: s, style: &f.theme.Muted} }
+
+	switch name {
+	case ColumnFlag:
+		// Always present so IDs line up whether or not a row is flagged
+		if isDueOrOverdue(t, f.today()) {
+			return cell{text: f.theme.Icons.Due, style: &f.theme.Warning}
+		}
+		if t.Flagged {
+			return cell{text: FlaggedIcon, style: &f.theme.Warning}
+		}
+		if isPlannedForToday(t, f.today()) {
+			return cell{text: f.theme.Icons.Planned, style: &f.theme.Accent}
+		}
+		return cell{text: " "}
+	case ColumnID:
+		return cell{text: fmt.Sprintf("%*d", idWidth, t.ID), style: &f.theme.ID}
+	case ColumnScope:
+		// Projects carry their area in the title column instead
+		if showScope && !t.IsProject() {
+			return cell{text: formatScope(t.AreaName, t.ParentName), style: &f.theme.Scope}
+		}
+	case ColumnTitle:
+		if t.IsProject() {
+			if showScope {
+				return cell{text: formatProjectScope(t.AreaName, t.Title), style: &f.theme.Scope}
+			}
+			return cell{text: sanitizeTitle(t.Title), style: &f.theme.Scope}
+		}
+		return cell{text: formatTaskTitle(t)}
+	case ColumnRecur:
+		if !t.IsProject() {
+			return muted(formatRecurIndicator(t))
+		}
+	case ColumnPlanned:
+		if t.PlannedDate != nil && !f.hidePlannedDate {
+			return muted(f.theme.Icons.Date + " " + formatDate(*t.PlannedDate, "Jan 2"))
+		}
+	case ColumnDue:
+		if t.DueDate != nil {
+			return muted(f.theme.Icons.Due + " " + formatDate(*t.DueDate, "Jan 2"))
+		}
+	case ColumnEstimate:
+		if t.Estimate != nil {
+			return muted("~" + task.FormatEstimate(*t.Estimate))
+		}
+	case ColumnContext:
+		if t.Context != nil {
+			return muted("@" + *t.Context)
+		}
+	case ColumnLocation:
+		if t.Location != nil {
+			return muted("⌂" + *t.Location)
+		}
+	case ColumnAssignee:
+		if t.Assignee != nil {
+			return muted("→" + *t.Assignee)
+		}
+	case ColumnTags:
+		return cell{text: formatTagsForTable(t.Tags), paint: f.theme.RenderTags}
+	}
+	return cell{}
+}
+
+// renderTaskRow lays out a task's cells according to the column layout.
+// Empty cells are skipped unless the column has a fixed width, in which case
+// they are padded so the following columns stay aligned. When the row is
+// wider than the terminal, the title gives way: it is truncated, or wrapped
+// onto continuation lines aligned under its first line. With details on, a
+// dimmed details line follows, aligned the same way. An accessible theme gets
+// a single labeled line instead.
+func (f *Formatter) renderTaskRow(t *task.Task, indent int, showScope bool, idWidth int) []string {
+	if f.theme.Accessible {
+		return []string{f.accessibleRow(t, showScope)}
+	}
+
+	type placed struct {
+		cell
+		name  string
+		width int  // display width the cell occupies
+		pad   bool // pad to width (fixed-width column)
+	}
+
+	var cells []placed
+	for _, name := range f.columns() {
+		if !IsColumn(name) {
+			continue
+		}
+		c := f.taskCell(t, name, showScope, idWidth)
+		fixed := f.layout.Widths[name]
+		if c.text == "" && fixed <= 0 {
+			continue
+		}
+		if fixed > 0 {
+			c.text = truncate(c.text, fixed)
+		}
+		cells = append(cells, placed{cell: c, name: name, width: max(fixed, lipgloss.Width(c.text)), pad: fixed > 0})
+	}
+
+	total, titleIdx, titleOffset := indent, -1, 0
+	for i, p := range cells {
+		if i > 0 {
+			total += len(columnSeparator(cells[i-1].name))
+		}
+		if p.name == ColumnTitle {
+			titleIdx, titleOffset = i, total
+		}
+		total += p.width
+	}
+
+	var continuation []string
+	if f.width > 0 && total > f.width && titleIdx >= 0 && f.overflow != OverflowNone {
+		title := &cells[titleIdx]
+		budget := max(title.width-(total-f.width), minTitleWidth)
+		if f.overflow == OverflowWrap {
+			lines := wrapText(title.text, budget)
+			title.text, continuation = lines[0], lines[1:]
+		} else {
+			title.text = truncate(title.text, budget)
+		}
+		title.width = min(title.width, budget)
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", indent))
+	for i, p := range cells {
+		if i > 0 {
+			b.WriteString(columnSeparator(cells[i-1].name))
+		}
+		b.WriteString(p.render(p.text))
+		if p.pad {
+			b.WriteString(strings.Repeat(" ", max(p.width-lipgloss.Width(p.text), 0)))
+		}
+	}
+
+	row := b.String()
+	if len(f.layout.Widths) > 0 {
+		// Padding of the last fixed-width column is not worth keeping
+		row = strings.TrimRight(row, " ")
+	}
+
+	lines := []string{row}
+	for _, line := range continuation {
+		lines = append(lines, strings.Repeat(" ", titleOffset)+cells[titleIdx].render(line))
+	}
+	if titleIdx < 0 {
+		titleOffset = indent
+	}
+	if details := f.detailLine(t, titleOffset); details != "" {
+		lines = append(lines, strings.Repeat(" ", titleOffset)+f.theme.Muted.Render(details))
+	}
+	return lines
+}
+
+// maxExcerptWidth caps the description excerpt when the width is unknown
+const maxExcerptWidth = 80
+
+// detailLine returns the details shown under a task when enabled:
+// checklist progress like "[2/5]" followed by the first line of the
+// description that isn't a checklist item, fitted to the width left of the
+// line. Returns "" if details are off or the task has no description.
+func (f *Formatter) detailLine(t *task.Task, offset int) string {
+	if !f.details || t.Description == nil {
+		return ""
+	}
+
+	var parts []string
+	if done, total := task.Checklist(*t.Description); total > 0 {
+		parts = append(parts, fmt.Sprintf("[%d/%d]", done, total))
+	}
+	for _, line := range strings.Split(*t.Description, "\n") {
+		line = strings.TrimSpace(line)
+		if isItem, _ := task.ChecklistItem(line); line != "" && !isItem {
+			parts = append(parts, line)
+			break
+		}
+	}
+
+	width := maxExcerptWidth
+	if f.width > 0 {
+		width = min(width, f.width-offset)
+	}
+	return truncate(strings.Join(parts, " "), max(width, minTitleWidth))
+}
+
+// render applies the cell's style to text
+func (c cell) render(text string) string {
+	if c.paint != nil && text != "" {
+		return c.paint(text)
+	}
+	if c.style == nil || text == "" {
+		return text
+	}
+	return c.style.Render(text)
+}
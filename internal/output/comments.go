@@ -0,0 +1,32 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devbydaniel/tt/internal/domain/comment"
+)
+
+func (f *Formatter) CommentAdded(taskID int64) {
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Added a comment to #%d", taskID)))
+}
+
+// Comments prints the comments on a task, oldest first, under a header.
+// Nothing is printed for a task without comments.
+func (f *Formatter) Comments(comments []comment.Comment) {
+	if len(comments) == 0 {
+		return
+	}
+	fmt.Fprintln(f.w)
+	fmt.Fprintln(f.w, f.theme.Header.Render(tr("Comments")))
+	for _, c := range comments {
+		when := formatDate(c.CreatedAt.Local(), "Jan 2, 2006 15:04")
+		if c.Author != nil {
+			when = tr("%s by %s", when, sanitizeTitle(*c.Author))
+		}
+		fmt.Fprintln(f.w, "  "+f.theme.Muted.Render(when))
+		for _, line := range strings.Split(c.Body, "\n") {
+			fmt.Fprintln(f.w, "    "+sanitizeTitle(line))
+		}
+	}
+}
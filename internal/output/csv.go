@@ -0,0 +1,73 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// logbookCSVHeader lists the columns written by LogbookCSVWriter
+var logbookCSVHeader = []string{
+	"id", "title", "project", "area", "tags", "context",
+	"created_at", "completed_at", "estimate_minutes", "estimate_hours",
+}
+
+// LogbookCSVWriter writes completed tasks as CSV, one row per task, for use
+// in spreadsheets, timesheets and invoices. Rows are written as they come,
+// so large logbooks can be streamed. Timestamps are RFC 3339. tt doesn't
+// track time spent, so the only time columns are the task's estimate,
+// named as such, and empty when it has none.
+type LogbookCSVWriter struct {
+	cw *csv.Writer
+}
+
+// NewLogbookCSVWriter writes the header row and returns the writer for the rest
+func NewLogbookCSVWriter(w io.Writer) (*LogbookCSVWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(logbookCSVHeader); err != nil {
+		return nil, err
+	}
+	return &LogbookCSVWriter{cw: cw}, nil
+}
+
+// Write adds the row for t
+func (l *LogbookCSVWriter) Write(t task.Task) error {
+	completedAt := ""
+	if t.CompletedAt != nil {
+		completedAt = t.CompletedAt.Format(time.RFC3339)
+	}
+	minutes, hours := "", ""
+	if t.Estimate != nil {
+		minutes = strconv.Itoa(*t.Estimate)
+		hours = strconv.FormatFloat(float64(*t.Estimate)/60, 'f', 2, 64)
+	}
+	return l.cw.Write([]string{
+		strconv.FormatInt(t.ID, 10),
+		sanitizeTitle(t.Title),
+		deref(t.ParentName),
+		deref(t.AreaName),
+		strings.Join(t.Tags, " "),
+		deref(t.Context),
+		t.CreatedAt.Format(time.RFC3339),
+		completedAt,
+		minutes,
+		hours,
+	})
+}
+
+// Flush writes buffered rows and reports any error from writing them
+func (l *LogbookCSVWriter) Flush() error {
+	l.cw.Flush()
+	return l.cw.Error()
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
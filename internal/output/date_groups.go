@@ -0,0 +1,112 @@
+package output
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// dateGroupHeaders are the headers of date grouping, earliest first, by
+// their name in the date_groups setting
+var dateGroupHeaders = []struct{ key, header string }{
+	{"overdue", "Overdue"},
+	{"today", "Today"},
+	{"tomorrow", "Tomorrow"},
+	{"this_week", "This Week"},
+	{"this_month", "This Month"},
+	{"this_year", "This Year"},
+	{"later", "Later"},
+	{"no_date", "No Date"},
+}
+
+// DateGroupKeys returns the accepted values of the date_groups setting, in
+// their default order
+func DateGroupKeys() []string {
+	keys := make([]string, len(dateGroupHeaders))
+	for i, g := range dateGroupHeaders {
+		keys[i] = g.key
+	}
+	return keys
+}
+
+// DateGroups decides which headers date grouping shows, and in what order.
+// A group left out of the configured list is merged into the next later
+// one that's shown, or the closest earlier one if there is none, so
+// leaving out this_year lists those tasks under Later. Tasks with a date
+// only end up under No Date if it's the only group shown.
+type DateGroups struct {
+	order  []string          // headers, in display order
+	listAs map[string]string // header of every category -> header it's shown under
+}
+
+// NewDateGroups returns the date groups for the date_groups setting, the
+// default ones if keys is empty. Unknown keys are ignored.
+func NewDateGroups(keys []string) DateGroups {
+	if len(keys) == 0 {
+		keys = DateGroupKeys()
+	}
+	g := DateGroups{listAs: make(map[string]string)}
+	shown := make(map[string]bool)
+	for _, key := range keys {
+		i := slices.IndexFunc(dateGroupHeaders, func(h struct{ key, header string }) bool { return h.key == key })
+		if i < 0 || shown[key] {
+			continue
+		}
+		shown[key] = true
+		g.order = append(g.order, dateGroupHeaders[i].header)
+	}
+
+	// Tasks with a date only merge into No Date if no dated group is shown
+	noDate := len(dateGroupHeaders) - 1
+	for i, h := range dateGroupHeaders {
+		target := ""
+		for j := i; j < len(dateGroupHeaders) && target == ""; j++ {
+			if shown[dateGroupHeaders[j].key] && (j != noDate || i == noDate) {
+				target = dateGroupHeaders[j].header
+			}
+		}
+		for j := i - 1; j >= 0 && target == ""; j-- {
+			if shown[dateGroupHeaders[j].key] {
+				target = dateGroupHeaders[j].header
+			}
+		}
+		if target == "" && len(g.order) > 0 {
+			target = dateGroupHeaders[noDate].header
+		}
+		g.listAs[h.header] = target
+	}
+	return g
+}
+
+// Order returns the headers shown, in order
+func (g DateGroups) Order() []string {
+	if g.order == nil {
+		return NewDateGroups(nil).order
+	}
+	return g.order
+}
+
+// Group returns the header a task in category (e.g. "This Year") is listed
+// under
+func (g DateGroups) Group(category string) string {
+	if target, ok := g.listAs[category]; ok && target != "" {
+		return target
+	}
+	return category
+}
+
+// ValidateDateGroups reports an unknown or repeated name in a date_groups
+// setting
+func ValidateDateGroups(keys []string) error {
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if !slices.Contains(DateGroupKeys(), key) {
+			return fmt.Errorf("unknown group %q (valid: %s)", key, strings.Join(DateGroupKeys(), ", "))
+		}
+		if seen[key] {
+			return fmt.Errorf("%q is listed twice", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDateGroups(t *testing.T) {
+	g := NewDateGroups([]string{"today", "overdue", "this_month", "no_date"})
+	if got, want := g.Order(), []string{"Today", "Overdue", "This Month", "No Date"}; !slices.Equal(got, want) {
+		t.Errorf("Order() = %v, want %v", got, want)
+	}
+	tests := map[string]string{
+		"Today":     "Today",
+		"Tomorrow":  "This Month", // next later group shown
+		"This Week": "This Month",
+		"This Year": "This Month", // closest earlier, not No Date
+		"Later":     "This Month",
+	}
+	for category, want := range tests {
+		if got := g.Group(category); got != want {
+			t.Errorf("Group(%q) = %q, want %q", category, got, want)
+		}
+	}
+
+	g = NewDateGroups([]string{"overdue", "today"})
+	if got := g.Group("No Date"); got != "Today" {
+		t.Errorf("Group(No Date) = %q, want the closest earlier group Today", got)
+	}
+
+	g = NewDateGroups([]string{"no_date"})
+	if got := g.Group("Today"); got != "No Date" {
+		t.Errorf("Group(Today) = %q, want No Date when it's the only group", got)
+	}
+
+	if got := (DateGroups{}).Order(); len(got) != 8 || got[0] != "Overdue" {
+		t.Errorf("zero DateGroups Order() = %v, want all groups", got)
+	}
+}
+
+func TestValidateDateGroups(t *testing.T) {
+	if err := ValidateDateGroups(DateGroupKeys()); err != nil {
+		t.Errorf("ValidateDateGroups(defaults) = %v", err)
+	}
+	for _, keys := range [][]string{{"today", "next_week"}, {"today", "today"}} {
+		if err := ValidateDateGroups(keys); err == nil {
+			t.Errorf("ValidateDateGroups(%v) = nil, want an error", keys)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+func TestDueList(t *testing.T) {
+	today := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.Local)
+	overdue := today.AddDate(0, 0, -2)
+	soon := today.AddDate(0, 0, 3)
+
+	theme := DefaultTheme()
+	theme.SetAccessible()
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, theme)
+	f.DueList([]task.Task{
+		{ID: 1, Title: "Late", DueDate: &overdue},
+		{ID: 2, Title: "Now", DueDate: &today},
+		{ID: 3, Title: "Also now", DueDate: &today},
+		{ID: 4, Title: "Soon", DueDate: &soon},
+	}, today)
+
+	var headers []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if line != "" && !strings.HasPrefix(line, "Task ") {
+			headers = append(headers, line)
+		}
+	}
+	want := []string{
+		"Sun Mar 8  2 days overdue",
+		"Tue Mar 10  due today",
+		"Fri Mar 13  in 3 days",
+	}
+	if strings.Join(headers, "\n") != strings.Join(want, "\n") {
+		t.Errorf("DueList() headers =\n%s\nwant\n%s", strings.Join(headers, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	tests := map[int]string{
+		-3: "3 days overdue",
+		-1: "1 day overdue",
+		0:  "due today",
+		1:  "due tomorrow",
+		7:  "in 7 days",
+	}
+	for days, want := range tests {
+		if got := countdown(days); got != want {
+			t.Errorf("countdown(%d) = %q, want %q", days, got, want)
+		}
+	}
+}
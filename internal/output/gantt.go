@@ -0,0 +1,182 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// Cells of a Gantt chart
+const (
+	ganttBar   = "█"
+	ganttToday = "│"
+)
+
+// maxGanttLabel caps the width of the task column of a Gantt chart
+const maxGanttLabel = 28
+
+// maxAxisLabel is room for a date on the axis of a Gantt chart, e.g. "Sep 30"
+const maxAxisLabel = 8
+
+// ganttRow is a task's bar: from its planned date (or when it was added) to
+// its due date
+type ganttRow struct {
+	label      string
+	start, end time.Time
+	overdue    bool
+}
+
+// ProjectGantt prints the open tasks of a project as bars on a timeline,
+// one column a day, or a week when that doesn't fit. Overdue bars are
+// highlighted and today's column is marked. Tasks without a planned or due
+// date are left out.
+func (f *Formatter) ProjectGantt(project *task.Task, tasks []task.Task) {
+	fmt.Fprintln(f.w, f.theme.Header.Render(sanitizeTitle(project.Title)))
+	if len(tasks) == 0 {
+		fmt.Fprintln(f.w, "  "+tr("No open tasks"))
+		return
+	}
+
+	today := f.today()
+	dayOf := func(t time.Time) time.Time {
+		t = t.In(today.Location())
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, today.Location())
+	}
+	first, last := today, today
+	labelWidth, undated := 0, 0
+	rows := make([]ganttRow, 0, len(tasks))
+	for _, t := range tasks {
+		if t.PlannedDate == nil && t.DueDate == nil {
+			undated++
+			continue
+		}
+		row := ganttRow{label: fmt.Sprintf("#%d %s", t.ID, sanitizeTitle(t.Title))}
+		switch {
+		case t.PlannedDate != nil:
+			row.start = dayOf(*t.PlannedDate)
+		case !t.CreatedAt.IsZero():
+			row.start = dayOf(t.CreatedAt)
+		default:
+			row.start = dayOf(*t.DueDate)
+		}
+		row.end = row.start
+		if t.DueDate != nil {
+			row.end = dayOf(*t.DueDate)
+			row.overdue = row.end.Before(today)
+			if row.end.Before(row.start) {
+				row.start, row.end = row.end, row.start
+			}
+		}
+		if row.start.Before(first) {
+			first = row.start
+		}
+		if row.end.After(last) {
+			last = row.end
+		}
+		labelWidth = max(labelWidth, lipgloss.Width(row.label))
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(f.w, "  "+tr("No tasks with a planned or due date"))
+		return
+	}
+	slices.SortStableFunc(rows, func(a, b ganttRow) int { return a.start.Compare(b.start) })
+	labelWidth = min(labelWidth, maxGanttLabel)
+
+	// One column a day if it fits, else a week starting on Monday, else
+	// as many days as it takes
+	width := f.width
+	if width == 0 {
+		width = 80
+	}
+	room := max(width-labelWidth-4, 20)
+	days := func(from, to time.Time) int { return int(math.Round(to.Sub(from).Hours() / 24)) }
+	per := 1
+	if days(first, last)+1 > room {
+		first = first.AddDate(0, 0, -(int(first.Weekday())+6)%7)
+		per = 7
+		if span := days(first, last) + 1; span > room*7 {
+			per = (span + room - 1) / room
+		}
+	}
+	col := func(d time.Time) int { return days(first, d) / per }
+	cols := col(last) + 1
+
+	// Axis: dates on Mondays by the day, months by the week. The first
+	// column is labeled too unless that would crowd out the next label,
+	// and the last label may run past the bars.
+	type mark struct {
+		col   int
+		label []rune
+	}
+	var marks []mark
+	for c := 1; c < cols; c++ {
+		d := first.AddDate(0, 0, c*per)
+		switch {
+		case per == 1 && d.Weekday() == time.Monday:
+			marks = append(marks, mark{c, []rune(formatDate(d, "Jan 2"))})
+		case per > 1 && d.Month() != d.AddDate(0, 0, -per).Month():
+			marks = append(marks, mark{c, []rune(formatDate(d, "Jan"))})
+		}
+	}
+	layout := "Jan 2"
+	if per > 1 {
+		layout = "Jan"
+	}
+	if start := []rune(formatDate(first, layout)); len(marks) == 0 || marks[0].col > len(start) {
+		marks = append([]mark{{0, start}}, marks...)
+	}
+	axis := []rune(strings.Repeat(" ", cols+maxAxisLabel))
+	free := 0
+	for _, m := range marks {
+		if m.col >= free {
+			copy(axis[m.col:], m.label)
+			free = m.col + len(m.label) + 1
+		}
+	}
+	fmt.Fprintln(f.w, "  "+strings.Repeat(" ", labelWidth)+"  "+f.theme.Muted.Render(strings.TrimRight(string(axis), " ")))
+
+	now := col(today)
+	for _, row := range rows {
+		from, to := col(row.start), col(row.end)
+		style := f.theme.Accent
+		if row.overdue {
+			style = f.theme.Error
+		}
+		var b strings.Builder
+		b.WriteString("  " + padRight(truncate(row.label, labelWidth), labelWidth) + "  ")
+		for c := range max(to, now) + 1 {
+			switch {
+			case c >= from && c <= to:
+				b.WriteString(style.Render(ganttBar))
+			case c == now:
+				b.WriteString(f.theme.Muted.Render(ganttToday))
+			default:
+				b.WriteString(" ")
+			}
+		}
+		if row.overdue {
+			b.WriteString(" " + f.theme.Error.Render(tr("overdue")))
+		}
+		fmt.Fprintln(f.w, b.String())
+	}
+
+	var scale string
+	switch per {
+	case 1:
+		scale = tr("one column a day")
+	case 7:
+		scale = tr("one column a week")
+	default:
+		scale = tr("one column per %d days", per)
+	}
+	fmt.Fprintln(f.w, f.theme.Muted.Render(ganttToday+" "+tr("today")+", "+scale))
+	if undated > 0 {
+		fmt.Fprintln(f.w, f.theme.Muted.Render(tr("%d %s without a planned or due date not shown", undated, pluralize(undated, "task", "tasks"))))
+	}
+}
@@ -0,0 +1,164 @@
+package output
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/testutil"
+	"github.com/devbydaniel/tt/internal/testutil/fixture"
+)
+
+// render runs fn against a formatter with the fixture clock, a fixed width
+// and no colors, and returns what it printed
+func render(accessible bool, fn func(f *Formatter)) string {
+	theme := DefaultTheme()
+	theme.stripColors()
+	if accessible {
+		theme.SetAccessible()
+	}
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, theme)
+	f.SetClock(fixture.Clock)
+	f.SetWidth(80)
+	fn(f)
+	return buf.String()
+}
+
+func TestGoldenLists(t *testing.T) {
+	tasks := fixture.Tasks()
+	withDue := slices.DeleteFunc(slices.Clone(tasks), func(t task.Task) bool { return t.DueDate == nil })
+	slices.SortStableFunc(withDue, func(a, b task.Task) int { return a.DueDate.Compare(*b.DueDate) })
+	today := fixture.Now
+
+	tests := map[string]func(f *Formatter){
+		"list":         func(f *Formatter) { f.TaskList(tasks); f.ListFooter(tasks) },
+		"list_scope":   func(f *Formatter) { f.GroupedTaskList(tasks, "scope") },
+		"list_date":    func(f *Formatter) { f.GroupedTaskList(tasks, "date") },
+		"list_tag":     func(f *Formatter) { f.GroupedTaskList(tasks, "tag") },
+		"list_state":   func(f *Formatter) { f.GroupedTaskList(tasks, "state") },
+		"list_details": func(f *Formatter) { f.SetDetails(true); f.TaskList(tasks) },
+		"list_narrow":  func(f *Formatter) { f.SetWidth(40); f.TaskList(tasks) },
+		"due":          func(f *Formatter) { f.DueList(withDue, today) },
+		"overdue":      func(f *Formatter) { f.OverdueList(withDue[:1], today) },
+		"tree":         func(f *Formatter) { f.Tree(fixture.Areas(), tasks, 0) },
+		"load":         func(f *Formatter) { f.LoadSummary(tasks) },
+	}
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			testutil.Golden(t, name, render(false, fn))
+		})
+	}
+
+	t.Run("list_accessible", func(t *testing.T) {
+		testutil.Golden(t, "list_accessible", render(true, func(f *Formatter) { f.TaskList(tasks) }))
+	})
+}
+
+func TestGoldenLogbook(t *testing.T) {
+	// Tasks 2-5 done over the last days, one of them today and noted, and
+	// the oldest canceled
+	var done []task.Task
+	for i, tk := range fixture.Tasks()[1:5] {
+		completed := fixture.Now.Add(-time.Duration(i*20) * time.Hour)
+		tk.CompletedAt = &completed
+		tk.Status = task.StatusDone
+		done = append(done, tk)
+	}
+	note := "deployed as v1.4"
+	done[0].CompletionNote = &note
+	done[3].Status = task.StatusCanceled
+
+	tests := map[string]func(f *Formatter){
+		"log_date":    func(f *Formatter) { f.SetLogSubtotals(true); f.GroupedLogbook(done, "date") },
+		"log_summary": func(f *Formatter) { f.LogbookSummary(done, 4) },
+	}
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			testutil.Golden(t, name, render(false, fn))
+		})
+	}
+}
+
+func TestGoldenStandup(t *testing.T) {
+	tasks := fixture.Tasks()
+	note := "deployed as v1.4"
+	tasks[1].CompletionNote = &note
+	report := Standup{Date: fixture.Now, Yesterday: tasks[1:3], Today: tasks[3:5]}
+
+	tests := map[string]string{
+		"standup":        "",
+		"standup_custom": "{{.Date}}: {{range .Yesterday}}#{{.ID}} {{.Title}}{{with .Scope}} ({{.}}){{end}}{{with .Note}}, {{.}}{{end}}; {{end}}\n",
+	}
+	for name, tmpl := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteStandup(&buf, tmpl, report); err != nil {
+				t.Fatalf("WriteStandup() error = %v", err)
+			}
+			testutil.Golden(t, name, buf.String())
+		})
+	}
+}
+
+func TestGoldenBlocksICS(t *testing.T) {
+	tasks := fixture.Tasks()
+	start := time.Date(2026, 3, 11, 14, 0, 0, 0, time.UTC)
+	minutes := 90
+	tasks[2].BlockStart, tasks[2].BlockMinutes = &start, &minutes
+	tasks[3].Title = "Review copy; the long version, with a title that needs folding after 75 octets – ünïcode"
+	tasks[3].BlockStart, tasks[3].BlockMinutes = &start, &minutes
+	tasks[2].UUID = "5b1c0a4e-0000-4000-8000-000000000003"
+	tasks[3].UUID = "5b1c0a4e-0000-4000-8000-000000000004"
+
+	var buf bytes.Buffer
+	if err := WriteBlocksICS(&buf, tasks, fixture.Now); err != nil {
+		t.Fatalf("WriteBlocksICS() error = %v", err)
+	}
+	testutil.Golden(t, "blocks_ics", buf.String())
+}
+
+func TestGoldenProjectPlan(t *testing.T) {
+	tasks := fixture.Tasks()
+	day := func(d int) time.Time { return fixture.Now.AddDate(0, 0, d) }
+	plan := &task.ProjectPlan{
+		Project:  task.Task{ID: 20, Title: "Launch", TaskType: task.TaskTypeProject},
+		Capacity: 240,
+		Steps: []task.PlanStep{
+			{Task: tasks[0], Start: day(0), Finish: day(0)},
+			{Task: tasks[1], Start: day(0), Finish: day(2), DependsOn: []int64{tasks[0].ID}},
+			{Task: tasks[2], Start: day(2), Finish: day(2), DependsOn: []int64{tasks[0].ID, tasks[1].ID}},
+		},
+	}
+	testutil.Golden(t, "project_plan", render(false, func(f *Formatter) { f.ProjectPlan(plan, false) }))
+}
+
+func TestGoldenProjectGantt(t *testing.T) {
+	tasks := fixture.Tasks()
+	for i := range tasks {
+		tasks[i].CreatedAt = fixture.Now.AddDate(0, 0, -14)
+	}
+	project := task.Task{ID: 20, Title: "Launch", TaskType: task.TaskTypeProject}
+
+	// #7 is planned weeks ahead, so the chart only fits by the week
+	soon := slices.DeleteFunc(slices.Clone(tasks), func(t task.Task) bool { return t.ID == 7 })
+	testutil.Golden(t, "project_gantt", render(false, func(f *Formatter) { f.ProjectGantt(&project, soon) }))
+	testutil.Golden(t, "project_gantt_weeks", render(false, func(f *Formatter) { f.ProjectGantt(&project, tasks) }))
+}
+
+func TestGoldenShare(t *testing.T) {
+	withDue := slices.DeleteFunc(fixture.Tasks(), func(t task.Task) bool { return t.DueDate == nil })
+	tasks := append(withDue, fixture.Tasks()[2])
+
+	for _, format := range ShareFormats() {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteShare(&buf, tasks, format, fixture.Now); err != nil {
+				t.Fatalf("WriteShare() error = %v", err)
+			}
+			testutil.Golden(t, "share_"+format, buf.String())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// icsTimeFormat is the UTC DATE-TIME format of iCalendar
+const icsTimeFormat = "20060102T150405Z"
+
+// WriteBlocksICS writes the time blocks of tasks as the events of an
+// iCalendar file, for subscribing to or importing into a calendar. Tasks
+// without a block are skipped. Each event's UID is the task's UUID, so
+// importing again updates the events instead of adding copies.
+func WriteBlocksICS(w io.Writer, tasks []task.Task, now time.Time) error {
+	out := bufio.NewWriter(w)
+	line := func(s string) {
+		// Lines longer than 75 octets are folded, without splitting a
+		// UTF-8 sequence
+		for len(s) > 75 {
+			cut := 75
+			for cut > 0 && s[cut]&0xC0 == 0x80 {
+				cut--
+			}
+			out.WriteString(s[:cut] + "\r\n")
+			s = " " + s[cut:]
+		}
+		out.WriteString(s + "\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//tt//time blocks//EN")
+	line("CALSCALE:GREGORIAN")
+	for _, t := range tasks {
+		if t.BlockStart == nil || t.BlockMinutes == nil {
+			continue
+		}
+		end := t.BlockStart.Add(time.Duration(*t.BlockMinutes) * time.Minute)
+		line("BEGIN:VEVENT")
+		line("UID:" + t.UUID + "@tt")
+		line("DTSTAMP:" + now.UTC().Format(icsTimeFormat))
+		line("DTSTART:" + t.BlockStart.UTC().Format(icsTimeFormat))
+		line("DTEND:" + end.UTC().Format(icsTimeFormat))
+		line("SUMMARY:" + escapeICS(t.Title))
+		description := fmt.Sprintf("#%d", t.ID)
+		if scope := taskScope(t); scope != "" {
+			description += " in " + scope
+		}
+		line("DESCRIPTION:" + escapeICS(description))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return out.Flush()
+}
+
+// escapeICS escapes text for an iCalendar text value
+func escapeICS(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
@@ -11,3 +11,42 @@ func WriteJSON(w io.Writer, data any) error {
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
 }
+
+// JSONArrayWriter writes a JSON array one element at a time, formatted like
+// WriteJSON, so large exports can be streamed. Close ends the array.
+type JSONArrayWriter struct {
+	w     io.Writer
+	count int
+}
+
+func NewJSONArrayWriter(w io.Writer) *JSONArrayWriter {
+	return &JSONArrayWriter{w: w}
+}
+
+// Write appends v to the array
+func (a *JSONArrayWriter) Write(v any) error {
+	data, err := json.MarshalIndent(v, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	sep := ",\n  "
+	if a.count == 0 {
+		sep = "[\n  "
+	}
+	a.count++
+	if _, err := io.WriteString(a.w, sep); err != nil {
+		return err
+	}
+	_, err = a.w.Write(data)
+	return err
+}
+
+// Close ends the array; an array without elements is written as []
+func (a *JSONArrayWriter) Close() error {
+	end := "\n]\n"
+	if a.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(a.w, end)
+	return err
+}
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"time"
+
+	"github.com/devbydaniel/tt/internal/i18n"
+)
+
+// locale translates messages and dates. It is process-wide, like the
+// terminal's color profile, and set once from the config at startup.
+var locale = i18n.English
+
+// SetLanguage switches messages and month and weekday names to a language
+// from i18n.Languages
+func SetLanguage(language string) error {
+	l, err := i18n.Load(language)
+	if err != nil {
+		return err
+	}
+	locale = l
+	return nil
+}
+
+// tr translates format and formats it like fmt.Sprintf
+func tr(format string, args ...any) string {
+	return locale.Sprintf(format, args...)
+}
+
+// formatDate formats t with a layout like "Mon Jan 2", in the current language
+func formatDate(t time.Time, layout string) string {
+	return locale.FormatDate(t, layout)
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+func TestScheduleHeaderIsTranslated(t *testing.T) {
+	if err := SetLanguage("de"); err != nil {
+		t.Fatalf("SetLanguage() error = %v", err)
+	}
+	defer SetLanguage("en")
+
+	theme := DefaultTheme()
+	theme.SetAccessible()
+	var buf bytes.Buffer
+	NewFormatter(&buf, theme).ScheduleHeader("Upcoming")
+	if got := strings.TrimSpace(buf.String()); got != "Demnächst" {
+		t.Errorf("ScheduleHeader() = %q, want %q", got, "Demnächst")
+	}
+}
+
+func TestSuggestionReasonsAreTranslated(t *testing.T) {
+	if err := SetLanguage("de"); err != nil {
+		t.Fatalf("SetLanguage() error = %v", err)
+	}
+	defer SetLanguage("en")
+
+	theme := DefaultTheme()
+	theme.SetAccessible()
+	var buf bytes.Buffer
+	NewFormatter(&buf, theme).Suggestion(&task.Suggestion{
+		Task: task.Task{ID: 1, Title: "Report"},
+		Reasons: []task.SuggestionReason{
+			{Kind: task.ReasonOverdue, Days: 1},
+			{Kind: task.ReasonWaiting, Days: 14},
+		},
+	})
+	if want := "1 Tag überfällig, wartet schon 14 Tage"; !strings.Contains(buf.String(), want) {
+		t.Errorf("Suggestion() = %q, want it to contain %q", buf.String(), want)
+	}
+}
+
+func TestDefaultStandupIsTranslated(t *testing.T) {
+	if err := SetLanguage("de"); err != nil {
+		t.Fatalf("SetLanguage() error = %v", err)
+	}
+	defer SetLanguage("en")
+
+	var buf bytes.Buffer
+	if err := WriteStandup(&buf, "", Standup{Today: []task.Task{{Title: "Report"}}}); err != nil {
+		t.Fatalf("WriteStandup() error = %v", err)
+	}
+	want := "*Gestern*\n- Nichts\n\n*Heute*\n- Report\n\n*Blocker*\n- Keine\n"
+	if got := buf.String(); got != want {
+		t.Errorf("WriteStandup() = %q, want %q", got, want)
+	}
+}
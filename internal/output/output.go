@@ -7,8 +7,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/internal/buildinfo"
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/domain/area"
+	"github.com/devbydaniel/tt/internal/domain/holiday"
 	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/domain/token"
 	"github.com/devbydaniel/tt/internal/recurparse"
 )
 
@@ -16,14 +21,59 @@ type Formatter struct {
 	w               io.Writer
 	hidePlannedDate bool
 	hideScope       bool
+	capacity        int // daily capacity in minutes (0 = no limit)
+	layout          ColumnLayout
+	width           int    // line width rows are fitted to (0 = unlimited)
+	overflow        string // how titles that don't fit are handled (see OverflowModes)
+	details         bool   // show description excerpt and checklist progress under rows
 	theme           *Theme
+	clock           clock.Clock // decides what today is, e.g. for overdue flags
+	schedule        task.ScheduleSettings
+	dateGroups      DateGroups        // headers of date grouping, see the date_groups setting
+	groupSort       []task.SortOption // sort within scope groups (nil = as given)
+	logSubtotals    bool              // per-project counts under the days of the logbook
 }
 
 func NewFormatter(w io.Writer, theme *Theme) *Formatter {
 	if theme == nil {
 		theme = DefaultTheme()
 	}
-	return &Formatter{w: w, theme: theme}
+	return &Formatter{w: w, theme: theme, width: TerminalWidth(w), clock: clock.System}
+}
+
+// SetClock sets the clock relative dates and overdue flags are based on
+func (f *Formatter) SetClock(c clock.Clock) {
+	f.clock = c
+}
+
+// SetSchedule sets the schedule settings whose day rollover decides which
+// date counts as today
+func (f *Formatter) SetSchedule(s task.ScheduleSettings) {
+	f.schedule = s
+}
+
+// SetDateGroups sets which headers date grouping shows, and in what order,
+// from the date_groups setting (empty = all, earliest first)
+func (f *Formatter) SetDateGroups(keys []string) {
+	f.dateGroups = NewDateGroups(keys)
+}
+
+// SetGroupSort sets how tasks are sorted within each group of the scope
+// grouping, instead of keeping the order they're given in
+func (f *Formatter) SetGroupSort(opts []task.SortOption) {
+	f.groupSort = opts
+}
+
+// SetLogSubtotals sets whether the logbook grouped by date shows how many
+// tasks were completed per project under each day
+func (f *Formatter) SetLogSubtotals(on bool) {
+	f.logSubtotals = on
+}
+
+// today returns the start of the formatter's current day, after the day
+// rollover
+func (f *Formatter) today() time.Time {
+	return f.schedule.Today(clock.Now(f.clock))
 }
 
 func (f *Formatter) SetHidePlannedDate(hide bool) {
@@ -34,13 +84,30 @@ func (f *Formatter) SetHideScope(hide bool) {
 	f.hideScope = hide
 }
 
+// SetDetails shows a dimmed line under each task with its checklist progress
+// and the start of its description
+func (f *Formatter) SetDetails(details bool) {
+	f.details = details
+}
+
+// SetCapacity sets the daily capacity (minutes) above which day totals are shown as warnings
+func (f *Formatter) SetCapacity(minutes int) {
+	f.capacity = minutes
+}
+
 func (f *Formatter) TaskCreated(t *task.Task) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Created task #%d: %s", t.ID, sanitizeTitle(t.Title))))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Created task #%d: %s", t.ID, sanitizeTitle(t.Title))))
+}
+
+// TaskUpserted reports a task that add updated rather than created, because
+// it had been added with the same external ID before
+func (f *Formatter) TaskUpserted(t *task.Task) {
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Updated task #%d: %s", t.ID, sanitizeTitle(t.Title))))
 }
 
 func (f *Formatter) TaskList(tasks []task.Task) {
 	if len(tasks) == 0 {
-		fmt.Fprintln(f.w, "No tasks")
+		fmt.Fprintln(f.w, tr("No tasks"))
 		return
 	}
 
@@ -57,7 +124,7 @@ func (f *Formatter) GroupedTaskList(tasks []task.Task, groupBy string) {
 	}
 
 	if len(tasks) == 0 {
-		fmt.Fprintln(f.w, "No tasks")
+		fmt.Fprintln(f.w, tr("No tasks"))
 		return
 	}
 
@@ -66,6 +133,10 @@ func (f *Formatter) GroupedTaskList(tasks []task.Task, groupBy string) {
 		f.groupedByScope(tasks)
 	case "date":
 		f.groupedByDate(tasks)
+	case "tag":
+		f.groupedByTag(tasks)
+	case "state":
+		f.groupedByState(tasks)
 	default:
 		f.TaskList(tasks)
 	}
@@ -115,6 +186,13 @@ func (f *Formatter) groupedByScope(tasks []task.Task) {
 		groups[header] = append(groups[header], t)
 	}
 
+	if f.groupSort != nil {
+		task.SortTasks(noScopeTasks, f.groupSort)
+		for _, group := range groups {
+			task.SortTasks(group, f.groupSort)
+		}
+	}
+
 	// Build project scope map for sorting
 	projectsByScope := make(map[string]*task.Task)
 	for i := range projects {
@@ -128,7 +206,7 @@ func (f *Formatter) groupedByScope(tasks []task.Task) {
 
 	// Render: No Scope first
 	if len(noScopeTasks) > 0 {
-		fmt.Fprintln(f.w, f.theme.Header.Render("No Scope"))
+		fmt.Fprintln(f.w, f.theme.Header.Render(tr("No Scope")))
 		f.renderTaskRows(noScopeTasks, 0, !f.hideScope, idWidth)
 	}
 
@@ -148,6 +226,9 @@ func (f *Formatter) groupedByScope(tasks []task.Task) {
 			// Render project as header-style line (no ID, with metadata)
 			f.renderProjectHeaderLine(proj)
 		} else if tasks, isGroup := groups[header]; isGroup {
+			if f.theme.Accessible {
+				header = accessibleHeader(header)
+			}
 			fmt.Fprintln(f.w, f.theme.Header.Render(header))
 			f.renderTaskRows(tasks, 0, !f.hideScope, idWidth)
 		}
@@ -158,41 +239,86 @@ func (f *Formatter) groupedByScope(tasks []task.Task) {
 func (f *Formatter) groupedByDate(tasks []task.Task) {
 	idWidth := maxIDWidth(tasks)
 
-	// Define date categories
-	dateGroups := map[string][]task.Task{
-		"Overdue":    {},
-		"Today":      {},
-		"Tomorrow":   {},
-		"This Week":  {},
-		"This Month": {},
-		"This Year":  {},
-		"Later":      {},
-		"No Date":    {},
-	}
-	orderedCategories := []string{"Overdue", "Today", "Tomorrow", "This Week", "This Month", "This Year", "Later", "No Date"}
-
-	now := time.Now()
-	todayYear, todayMonth, todayDay := now.Date()
-	today := time.Date(todayYear, todayMonth, todayDay, 0, 0, 0, 0, time.Local)
+	dateGroups := make(map[string][]task.Task)
+
+	today := f.today()
+	todayYear, todayMonth, _ := today.Date()
 	tomorrow := today.AddDate(0, 0, 1)
 	endOfWeek := today.AddDate(0, 0, 7-int(today.Weekday()))
 	endOfMonth := time.Date(todayYear, todayMonth+1, 0, 0, 0, 0, 0, time.Local) // Last day of current month
 	endOfYear := time.Date(todayYear, 12, 31, 0, 0, 0, 0, time.Local)
 
 	for _, t := range tasks {
-		category := getDateCategory(t.PlannedDate, t.DueDate, today, tomorrow, endOfWeek, endOfMonth, endOfYear)
+		category := f.dateGroups.Group(getDateCategory(t.PlannedDate, t.DueDate, today, tomorrow, endOfWeek, endOfMonth, endOfYear))
 		dateGroups[category] = append(dateGroups[category], t)
 	}
 
 	// Render each category
-	for _, category := range orderedCategories {
+	for _, category := range f.dateGroups.Order() {
 		if len(dateGroups[category]) > 0 {
-			fmt.Fprintln(f.w, f.theme.Header.Render(category))
+			fmt.Fprintln(f.w, f.theme.Header.Render(tr(category)))
 			f.renderTaskRows(dateGroups[category], 0, true, idWidth)
 		}
 	}
 }
 
+// groupedByTag displays tasks under each of their tags, tags sorted
+// alphabetically, with untagged tasks last under "No Tag"
+func (f *Formatter) groupedByTag(tasks []task.Task) {
+	idWidth := maxIDWidth(tasks)
+
+	groups := make(map[string][]task.Task)
+	var untagged []task.Task
+	for _, t := range tasks {
+		if len(t.Tags) == 0 {
+			untagged = append(untagged, t)
+			continue
+		}
+		for _, tag := range t.Tags {
+			groups[tag] = append(groups[tag], t)
+		}
+	}
+
+	tags := make([]string, 0, len(groups))
+	for tag := range groups {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	for _, tag := range tags {
+		fmt.Fprintln(f.w, f.theme.Header.Render(tag))
+		f.renderTaskRows(groups[tag], 0, !f.hideScope, idWidth)
+	}
+	if len(untagged) > 0 {
+		fmt.Fprintln(f.w, f.theme.Header.Render(tr("No Tag")))
+		f.renderTaskRows(untagged, 0, !f.hideScope, idWidth)
+	}
+}
+
+// groupedByState displays tasks under Active and Someday, skipping an
+// empty group
+func (f *Formatter) groupedByState(tasks []task.Task) {
+	idWidth := maxIDWidth(tasks)
+
+	var active, someday []task.Task
+	for _, t := range tasks {
+		if t.State == task.StateSomeday {
+			someday = append(someday, t)
+		} else {
+			active = append(active, t)
+		}
+	}
+	for _, group := range []struct {
+		header string
+		tasks  []task.Task
+	}{{"Active", active}, {"Someday", someday}} {
+		if len(group.tasks) > 0 {
+			fmt.Fprintln(f.w, f.theme.Header.Render(tr(group.header)))
+			f.renderTaskRows(group.tasks, 0, !f.hideScope, idWidth)
+		}
+	}
+}
+
 func getDateCategory(planned, due *time.Time, today, tomorrow, endOfWeek, endOfMonth, endOfYear time.Time) string {
 	var d *time.Time
 	isPlanned := false
@@ -249,54 +375,10 @@ func maxIDWidth(tasks []task.Task) int {
 
 // renderTaskRows renders task rows with optional indentation
 func (f *Formatter) renderTaskRows(tasks []task.Task, indent int, showScope bool, idWidth int) {
-	indentStr := strings.Repeat(" ", indent)
 	for _, t := range tasks {
-		prefix := "  "
-		if isDueOrOverdue(&t) {
-			prefix = f.theme.Warning.Render(f.theme.Icons.Due) + " "
-		} else if isPlannedForToday(&t) {
-			prefix = f.theme.Accent.Render(f.theme.Icons.Planned) + " "
-		}
-
-		// ID styled with padding
-		idStr := fmt.Sprintf("%*d", idWidth, t.ID)
-		id := f.theme.ID.Render(idStr)
-
-		// Build display differently for projects vs tasks
-		var display string
-		if t.IsProject() {
-			// For projects: when hiding scope, show only project name (no area)
-			if showScope {
-				display = f.theme.Scope.Render(formatProjectScope(t.AreaName, t.Title))
-			} else {
-				display = f.theme.Scope.Render(sanitizeTitle(t.Title))
-			}
-		} else {
-			// For regular tasks: optional scope prefix + title
-			if showScope {
-				scope := formatScope(t.AreaName, t.ParentName)
-				if scope != "" {
-					display = f.theme.Scope.Render(scope) + "  "
-				}
-			}
-			display += formatTaskTitle(&t)
-			if recur := formatRecurIndicator(&t); recur != "" {
-				display += " " + f.theme.Muted.Render(recur)
-			}
-		}
-
-		// Add dates and tags (common to both projects and tasks)
-		if t.PlannedDate != nil && !f.hidePlannedDate {
-			display += " " + f.theme.Muted.Render(f.theme.Icons.Date+" "+t.PlannedDate.Format("Jan 2"))
-		}
-		if t.DueDate != nil {
-			display += " " + f.theme.Muted.Render(f.theme.Icons.Due+" "+t.DueDate.Format("Jan 2"))
+		for _, line := range f.renderTaskRow(&t, indent, showScope, idWidth) {
+			fmt.Fprintln(f.w, line)
 		}
-		if len(t.Tags) > 0 {
-			display += " " + f.theme.Muted.Render(formatTagsForTable(t.Tags))
-		}
-
-		fmt.Fprintf(f.w, "%s%s%s  %s\n", indentStr, prefix, id, display)
 	}
 }
 
@@ -327,6 +409,11 @@ func formatProjectScope(areaName *string, projectTitle string) string {
 // renderProjectHeaderLine renders a project as a standalone header-style line
 // for scope-grouped views. Format: [Area > ProjectName]  [planned] [due] [tags]
 func (f *Formatter) renderProjectHeaderLine(p *task.Task) {
+	if f.theme.Accessible {
+		fmt.Fprintln(f.w, f.accessibleRow(p, true))
+		return
+	}
+
 	// Show full scope: "Area > ProjectName" or just "ProjectName"
 	scope := sanitizeTitle(p.Title)
 	if p.AreaName != nil {
@@ -335,18 +422,36 @@ func (f *Formatter) renderProjectHeaderLine(p *task.Task) {
 	parts := []string{f.theme.Header.Render(scope)}
 
 	if p.PlannedDate != nil && !f.hidePlannedDate {
-		parts = append(parts, f.theme.Muted.Render(f.theme.Icons.Date+" "+p.PlannedDate.Format("Jan 2")))
+		parts = append(parts, f.theme.Muted.Render(f.theme.Icons.Date+" "+formatDate(*p.PlannedDate, "Jan 2")))
 	}
 	if p.DueDate != nil {
-		parts = append(parts, f.theme.Muted.Render(f.theme.Icons.Due+" "+p.DueDate.Format("Jan 2")))
+		parts = append(parts, f.theme.Muted.Render(f.theme.Icons.Due+" "+formatDate(*p.DueDate, "Jan 2")))
 	}
 	if len(p.Tags) > 0 {
-		parts = append(parts, f.theme.Muted.Render(formatTagsForTable(p.Tags)))
+		parts = append(parts, f.theme.RenderTags(formatTagsForTable(p.Tags)))
 	}
 
 	fmt.Fprintln(f.w, strings.Join(parts, "  "))
 }
 
+// ScheduleHeader prints the header of a schedule group such as "Today" or
+// "Someday" in grouped lists
+func (f *Formatter) ScheduleHeader(name string) {
+	fmt.Fprintln(f.w, f.theme.Header.Render(tr(name)))
+}
+
+// ProjectOverview prints a project's header line followed by its
+// description, as the heading of the project view
+func (f *Formatter) ProjectOverview(p *task.Task) {
+	f.renderProjectHeaderLine(p)
+	if p.Description != nil {
+		for _, line := range strings.Split(strings.TrimSpace(*p.Description), "\n") {
+			fmt.Fprintln(f.w, f.theme.Muted.Render(strings.TrimRight("  "+line, " \r")))
+		}
+	}
+	fmt.Fprintln(f.w)
+}
+
 func formatRecurIndicator(t *task.Task) string {
 	if t.RecurType == nil {
 		return ""
@@ -394,25 +499,21 @@ func sanitizeTitle(title string) string {
 	return strings.TrimSpace(title)
 }
 
-func isPlannedForToday(t *task.Task) bool {
+// isPlannedForToday reports whether t is planned for today or earlier
+func isPlannedForToday(t *task.Task, today time.Time) bool {
 	if t.PlannedDate == nil {
 		return false
 	}
-	now := time.Now()
-	todayYear, todayMonth, todayDay := now.Date()
-	today := time.Date(todayYear, todayMonth, todayDay, 0, 0, 0, 0, time.Local)
 	dateYear, dateMonth, dateDay := t.PlannedDate.Date()
 	plannedDate := time.Date(dateYear, dateMonth, dateDay, 0, 0, 0, 0, time.Local)
 	return !plannedDate.After(today)
 }
 
-func isDueOrOverdue(t *task.Task) bool {
+// isDueOrOverdue reports whether t is due today or earlier
+func isDueOrOverdue(t *task.Task, today time.Time) bool {
 	if t.DueDate == nil {
 		return false
 	}
-	now := time.Now()
-	todayYear, todayMonth, todayDay := now.Date()
-	today := time.Date(todayYear, todayMonth, todayDay, 0, 0, 0, 0, time.Local)
 	dateYear, dateMonth, dateDay := t.DueDate.Date()
 	dueDate := time.Date(dateYear, dateMonth, dateDay, 0, 0, 0, 0, time.Local)
 	return !dueDate.After(today)
@@ -432,84 +533,617 @@ func formatTagsForTable(tags []string) string {
 	return result
 }
 
-func formatTaskDate(planned, due *time.Time) string {
-	now := time.Now()
-	todayYear, todayMonth, todayDay := now.Date()
+// WeekView displays a week day by day with per-day task counts
+func (f *Formatter) WeekView(week *task.Week) {
+	total := 0
+	var all []task.Task
+	for _, day := range week.Days {
+		total += len(day)
+		all = append(all, day...)
+	}
+	idWidth := maxIDWidth(all)
+
+	end := week.Start.AddDate(0, 0, 6)
+	fmt.Fprintln(f.w, f.theme.Header.Render(tr("Week of %s – %s", formatDate(week.Start, "Jan 2"), formatDate(end, "Jan 2")))+
+		f.theme.Muted.Render(fmt.Sprintf("  %d %s", total, pluralize(total, "task", "tasks"))))
+
+	today := f.today().Format("2006-01-02")
+	for i, tasks := range week.Days {
+		date := week.Start.AddDate(0, 0, i)
+		header := formatDate(date, "Mon Jan 2")
+		if date.Format("2006-01-02") == today {
+			header = f.theme.Accent.Bold(true).Render(header + tr(" (today)"))
+		} else {
+			header = f.theme.Header.Render(header)
+		}
 
-	// Prefer planned date, fall back to due date
-	var d *time.Time
-	if planned != nil {
-		d = planned
-	} else if due != nil {
-		d = due
+		fmt.Fprintln(f.w)
+		fmt.Fprintln(f.w, header+f.theme.Muted.Render(fmt.Sprintf("  %d", len(tasks)))+f.formatLoad(task.TotalEstimate(tasks)))
+		if len(tasks) == 0 {
+			if f.theme.Accessible {
+				fmt.Fprintln(f.w, tr("No tasks"))
+			} else {
+				fmt.Fprintln(f.w, f.theme.Muted.Render("  —"))
+			}
+			continue
+		}
+		f.renderTaskRows(tasks, 0, true, idWidth)
 	}
+}
 
-	if d == nil {
-		return ""
+// DueList prints tasks with due dates under one header per date, each with
+// a countdown such as "in 3 days" or "2 days overdue" relative to today.
+// Tasks must be sorted by due date.
+func (f *Formatter) DueList(tasks []task.Task, today time.Time) {
+	if len(tasks) == 0 {
+		fmt.Fprintln(f.w, tr("Nothing due"))
+		return
 	}
 
-	dateYear, dateMonth, dateDay := d.Date()
+	idWidth := maxIDWidth(tasks)
+	for start := 0; start < len(tasks); {
+		due := *tasks[start].DueDate
+		end := start + 1
+		for end < len(tasks) && sameDay(*tasks[end].DueDate, due) {
+			end++
+		}
 
-	// Compare dates without time component
-	if dateYear == todayYear && dateMonth == todayMonth && dateDay == todayDay {
-		return "today"
+		days := task.DaysBetween(today, due)
+		header := f.theme.Header.Render(formatDate(due, "Mon Jan 2"))
+		note := f.theme.Muted.Render("  " + countdown(days))
+		if days <= 0 {
+			note = f.theme.Warning.Render("  " + countdown(days))
+		}
+		if start > 0 {
+			fmt.Fprintln(f.w)
+		}
+		fmt.Fprintln(f.w, header+note)
+		f.renderTaskRows(tasks[start:end], 0, !f.hideScope, idWidth)
+		start = end
 	}
+}
 
-	tomorrow := now.AddDate(0, 0, 1)
-	tomorrowYear, tomorrowMonth, tomorrowDay := tomorrow.Date()
-	if dateYear == tomorrowYear && dateMonth == tomorrowMonth && dateDay == tomorrowDay {
-		return "tomorrow"
+// countdown describes a due date days away from today, e.g. "in 3 days"
+func countdown(days int) string {
+	switch {
+	case days == 0:
+		return tr("due today")
+	case days == 1:
+		return tr("due tomorrow")
+	case days == -1:
+		return tr("1 day overdue")
+	case days < 0:
+		return tr("%d days overdue", -days)
+	}
+	return tr("in %d days", days)
+}
+
+// sameDay reports whether a and b fall on the same calendar date
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// LoadSummary prints the estimated effort per day for tasks with estimates.
+// Overdue tasks count toward today, since that is when the work will land.
+func (f *Formatter) LoadSummary(tasks []task.Task) {
+	today := f.today()
+
+	loads := map[string]int{}
+	var days []string
+	for _, t := range tasks {
+		if t.Estimate == nil {
+			continue
+		}
+		date := t.PlannedDate
+		if date == nil {
+			date = t.DueDate
+		}
+		day := today
+		if date != nil {
+			if d := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local); d.After(today) {
+				day = d
+			}
+		}
+		key := day.Format("2006-01-02")
+		if _, ok := loads[key]; !ok {
+			days = append(days, key)
+		}
+		loads[key] += *t.Estimate
+	}
+	if len(days) == 0 {
+		return
 	}
+	sort.Strings(days)
 
-	// Check if overdue (before today)
-	today := time.Date(todayYear, todayMonth, todayDay, 0, 0, 0, 0, time.UTC)
-	dateOnly := time.Date(dateYear, dateMonth, dateDay, 0, 0, 0, 0, time.UTC)
-	if dateOnly.Before(today) {
-		return "overdue"
+	fmt.Fprintln(f.w)
+	for _, key := range days {
+		day, _ := time.ParseInLocation("2006-01-02", key, time.Local)
+		label := formatDate(day, "Mon Jan 2")
+		if day.Equal(today) {
+			label = tr("Today")
+		}
+		fmt.Fprintf(f.w, "%s%s\n", f.theme.Header.Render(padRight(label, 10)), f.formatLoad(loads[key]))
+	}
+}
+
+// ListFooter prints a one-line summary under a list, e.g.
+// "14 tasks · 3 due · 5 planned today". Nothing is printed for an empty list.
+func (f *Formatter) ListFooter(tasks []task.Task) {
+	if len(tasks) == 0 {
+		return
 	}
 
-	// Within 7 days, show weekday
-	weekFromNow := today.AddDate(0, 0, 7)
-	if dateOnly.Before(weekFromNow) {
-		return d.Format("Mon")
+	due, planned := 0, 0
+	today := f.today()
+	for i := range tasks {
+		if isDueOrOverdue(&tasks[i], today) {
+			due++
+		}
+		if isPlannedForToday(&tasks[i], today) {
+			planned++
+		}
 	}
 
-	// Show date
-	return d.Format("Jan 2")
+	parts := []string{fmt.Sprintf("%d %s", len(tasks), pluralize(len(tasks), "task", "tasks"))}
+	if due > 0 {
+		parts = append(parts, tr("%d due", due))
+	}
+	if planned > 0 {
+		parts = append(parts, tr("%d planned today", planned))
+	}
+	fmt.Fprintln(f.w)
+	separator := " · "
+	if f.theme.Accessible {
+		separator = ", "
+	}
+	fmt.Fprintln(f.w, f.theme.Muted.Render(strings.Join(parts, separator)))
+}
+
+// formatLoad renders a day's estimated minutes, against capacity when one is set.
+// Returns an empty string when nothing is estimated.
+func (f *Formatter) formatLoad(minutes int) string {
+	if minutes == 0 {
+		return ""
+	}
+	text := "  " + task.FormatEstimate(minutes)
+	if f.capacity > 0 {
+		text += " / " + task.FormatEstimate(f.capacity)
+		if minutes > f.capacity {
+			return f.theme.Warning.Render(text + tr(" over capacity"))
+		}
+	}
+	return f.theme.Muted.Render(text)
+}
+
+// pluralize returns singular when n is 1, plural otherwise, translated
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return locale.T(singular)
+	}
+	return locale.T(plural)
 }
 
 func (f *Formatter) TasksCompleted(results []task.CompleteResult) {
 	for _, r := range results {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Completed #%d: %s", r.Completed.ID, sanitizeTitle(r.Completed.Title))))
-		if r.NextTask != nil {
-			nextDate := r.NextTask.PlannedDate
-			if nextDate == nil {
-				nextDate = r.NextTask.DueDate
-			}
-			if nextDate != nil {
-				fmt.Fprintf(f.w, "  Next: #%d on %s\n", r.NextTask.ID, nextDate.Format("Jan 2"))
-			} else {
-				fmt.Fprintf(f.w, "  Next: #%d\n", r.NextTask.ID)
-			}
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Completed #%d: %s", r.Completed.ID, sanitizeTitle(r.Completed.Title))))
+		f.nextOccurrence(r.NextTask)
+	}
+}
+
+func (f *Formatter) TasksCanceled(results []task.CompleteResult) {
+	for _, r := range results {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Canceled #%d: %s", r.Completed.ID, sanitizeTitle(r.Completed.Title))))
+		f.nextOccurrence(r.NextTask)
+	}
+}
+
+// nextOccurrence notes the task a recurring one was followed by, if any
+func (f *Formatter) nextOccurrence(next *task.Task) {
+	if next == nil {
+		return
+	}
+	nextDate := next.PlannedDate
+	if nextDate == nil {
+		nextDate = next.DueDate
+	}
+	if nextDate != nil {
+		fmt.Fprintln(f.w, "  "+tr("Next: #%d on %s", next.ID, formatDate(*nextDate, "Jan 2")))
+	} else {
+		fmt.Fprintln(f.w, "  "+tr("Next: #%d", next.ID))
+	}
+}
+
+// Suggestion displays the task picked by tt next, with the reasons it was chosen
+func (f *Formatter) Suggestion(s *task.Suggestion) {
+	if s == nil {
+		fmt.Fprintln(f.w, tr("Nothing to do"))
+		return
+	}
+
+	tasks := []task.Task{s.Task}
+	f.renderTaskRows(tasks, 0, !f.hideScope, maxIDWidth(tasks))
+	if len(s.Reasons) > 0 {
+		reasons := make([]string, len(s.Reasons))
+		for i, r := range s.Reasons {
+			reasons[i] = suggestionReason(r)
 		}
+		fmt.Fprintln(f.w, f.theme.Muted.Render("  "+strings.Join(reasons, ", ")))
 	}
 }
 
-func (f *Formatter) TasksUncompleted(tasks []task.Task) {
-	for _, t := range tasks {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Uncompleted #%d: %s", t.ID, sanitizeTitle(t.Title))))
+// suggestionReason describes why tt next picked a task
+func suggestionReason(r task.SuggestionReason) string {
+	switch r.Kind {
+	case task.ReasonOverdue:
+		return tr("overdue by %d %s", r.Days, pluralize(r.Days, "day", "days"))
+	case task.ReasonDueToday:
+		return tr("due today")
+	case task.ReasonDueSoon:
+		return tr("due in %d %s", r.Days, pluralize(r.Days, "day", "days"))
+	case task.ReasonPlanned:
+		return tr("planned")
+	case task.ReasonWaiting:
+		return tr("waiting %d %s", r.Days, pluralize(r.Days, "day", "days"))
+	case task.ReasonFitsCapacity:
+		return tr("fits remaining capacity")
+	case task.ReasonQuickWin:
+		return tr("quick win")
+	}
+	return r.Kind
+}
+
+func (f *Formatter) TasksUncompleted(results []task.UncompleteResult) {
+	for _, r := range results {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Uncompleted #%d: %s", r.Reopened.ID, sanitizeTitle(r.Reopened.Title))))
+		if r.Removed != nil {
+			fmt.Fprintln(f.w, "  "+tr("Removed next occurrence #%d", r.Removed.ID))
+		}
 	}
 }
 
 func (f *Formatter) TasksDeleted(tasks []task.Task) {
 	for _, t := range tasks {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Deleted #%d: %s", t.ID, sanitizeTitle(t.Title))))
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Deleted #%d: %s", t.ID, sanitizeTitle(t.Title))))
+	}
+}
+
+// TodayOrdered confirms the order pinned for today; n = 0 means it was cleared
+// TaskSplit reports the tasks a task was split into, and whether the
+// original was kept open or completed
+func (f *Formatter) TaskSplit(original *task.Task, created []task.Task, kept bool) {
+	for i := range created {
+		f.TaskCreated(&created[i])
+	}
+	if kept {
+		fmt.Fprintln(f.w, tr("Kept #%d open: %s", original.ID, sanitizeTitle(original.Title)))
+		return
+	}
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Completed #%d: %s", original.ID, sanitizeTitle(original.Title))))
+}
+
+func (f *Formatter) TodayOrdered(n int) {
+	if n == 0 {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Cleared today's order")))
+		return
+	}
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Pinned %d %s at the top of today", n, pluralize(n, "task", "tasks"))))
+}
+
+func (f *Formatter) TasksFlagged(tasks []task.Task, flagged bool) {
+	for _, t := range tasks {
+		if flagged {
+			fmt.Fprintln(f.w, f.theme.Success.Render(tr("Flagged #%d: %s", t.ID, sanitizeTitle(t.Title))))
+		} else {
+			fmt.Fprintln(f.w, f.theme.Success.Render(tr("Unflagged #%d: %s", t.ID, sanitizeTitle(t.Title))))
+		}
+	}
+}
+
+// TasksExpired reports tasks that maintenance expired: moved to someday, or
+// deleted when action is task.ExpireDelete
+func (f *Formatter) TasksExpired(tasks []task.Task, action string) {
+	if len(tasks) == 0 {
+		fmt.Fprintln(f.w, tr("No expired tasks"))
+		return
+	}
+	where := tr("moved to someday")
+	if action == task.ExpireDelete {
+		where = tr("deleted")
+	}
+	for _, t := range tasks {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Expired #%d: %s (%s)", t.ID, sanitizeTitle(t.Title), where)))
+	}
+}
+
+// VacationPlanned reports what pausing for a vacation changed, or with
+// dryRun set, would change
+func (f *Formatter) VacationPlanned(plan *task.VacationPlan, dryRun bool) {
+	header := tr("Paused until %s", formatDate(plan.Until, "Jan 2"))
+	if dryRun {
+		header = tr("Would pause until %s", formatDate(plan.Until, "Jan 2"))
+	}
+	fmt.Fprintln(f.w, f.theme.Header.Render(header))
+	for _, t := range plan.Paused {
+		fmt.Fprintln(f.w, "  "+tr("Recurrence paused for #%d: %s", t.ID, sanitizeTitle(t.Title)))
+	}
+	for _, t := range plan.Shifted {
+		fmt.Fprintln(f.w, "  "+tr("Planned #%d for %s: %s", t.ID, formatDate(*t.PlannedDate, "Jan 2"), sanitizeTitle(t.Title)))
 	}
+	if len(plan.Paused) == 0 && len(plan.Shifted) == 0 {
+		fmt.Fprintln(f.w, "  "+tr("Nothing recurs or is planned until then"))
+	}
+	if dryRun {
+		fmt.Fprintln(f.w, f.theme.Muted.Render(tr("Nothing changed; run without --dry-run to pause")))
+	}
+}
+
+// VacationEnded reports the recurrences a vacation pause resumed
+func (f *Formatter) VacationEnded(resumed []task.Task) {
+	n := len(resumed)
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Pause over: resumed %d %s", n, pluralize(n, "recurrence", "recurrences"))))
+}
+
+// TaskDependenciesSet reports the tasks a task now waits for
+func (f *Formatter) TaskDependenciesSet(t *task.Task, dependsOn []int64) {
+	if len(dependsOn) == 0 {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Cleared dependencies of #%d: %s", t.ID, sanitizeTitle(t.Title))))
+		return
+	}
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("#%d waits for %s: %s", t.ID, taskRefs(dependsOn), sanitizeTitle(t.Title))))
+}
+
+// ProjectPlan prints a proposed project schedule, one task per line with
+// its days, and when it would be done
+func (f *Formatter) ProjectPlan(plan *task.ProjectPlan, applied bool) {
+	fmt.Fprintln(f.w, f.theme.Header.Render(tr("Plan for %s, %s a day", sanitizeTitle(plan.Project.Title), task.FormatEstimate(plan.Capacity))))
+	if len(plan.Steps) == 0 {
+		fmt.Fprintln(f.w, "  "+tr("No open tasks to plan"))
+		return
+	}
+
+	days := make([]string, len(plan.Steps))
+	width := 0
+	for i, s := range plan.Steps {
+		days[i] = formatDate(s.Start, "Mon Jan 2")
+		if !s.Finish.Equal(s.Start) {
+			days[i] += "–" + formatDate(s.Finish, "Mon Jan 2")
+		}
+		width = max(width, lipgloss.Width(days[i]))
+	}
+	for i, s := range plan.Steps {
+		estimate := tr("no estimate")
+		if s.Task.Estimate != nil {
+			estimate = task.FormatEstimate(*s.Task.Estimate)
+		}
+		line := fmt.Sprintf("  %s  #%d %s ", padRight(days[i], width), s.Task.ID, sanitizeTitle(s.Task.Title))
+		line += f.theme.Muted.Render("(" + estimate + ")")
+		if len(s.DependsOn) > 0 {
+			line += f.theme.Muted.Render(" " + tr("after %s", taskRefs(s.DependsOn)))
+		}
+		fmt.Fprintln(f.w, line)
+	}
+	fmt.Fprintln(f.w, tr("Done by %s", formatDate(plan.Finish(), "Mon Jan 2")))
+	if applied {
+		n := len(plan.Steps)
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Planned %d %s", n, pluralize(n, "task", "tasks"))))
+	} else {
+		fmt.Fprintln(f.w, f.theme.Muted.Render(tr("Nothing changed; run with --apply to plan the tasks for these days")))
+	}
+}
+
+// taskRefs formats task IDs as "#3, #4"
+func taskRefs(ids []int64) string {
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		refs[i] = fmt.Sprintf("#%d", id)
+	}
+	return strings.Join(refs, ", ")
+}
+
+// MaintenanceReport is what a tt maintain run did
+type MaintenanceReport struct {
+	Expired      []task.Task
+	ExpireAction string      // task.ExpireSomeday or task.ExpireDelete
+	Archived     []task.Task // written to ArchivePath and removed
+	ArchivePath  string
+	Backup       string   // backup written ("" = backups are off)
+	Pruned       []string // old backups removed
+	Optimized    bool     // false without a database, e.g. with file storage
+	SizeBefore   int64    // database size in bytes before optimizing
+	SizeAfter    int64
+}
+
+// MaintenanceSummary prints what tt maintain did, one line per chore
+func (f *Formatter) MaintenanceSummary(r MaintenanceReport) {
+	f.TasksExpired(r.Expired, r.ExpireAction)
+	if n := len(r.Archived); n > 0 {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Archived %d %s to %s", n, pluralize(n, "task", "tasks"), r.ArchivePath)))
+	}
+	if r.Backup != "" {
+		line := tr("Backed up to %s", r.Backup)
+		if n := len(r.Pruned); n > 0 {
+			line += tr(" (removed %d old %s)", n, pluralize(n, "backup", "backups"))
+		}
+		fmt.Fprintln(f.w, f.theme.Success.Render(line))
+	}
+	if r.Optimized {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Optimized database: %s -> %s", formatBytes(r.SizeBefore), formatBytes(r.SizeAfter))))
+	}
+}
+
+// DoctorReport is what tt doctor found out about the environment. Empty
+// strings are shown as "-"; Issues lists everything that looks wrong.
+type DoctorReport struct {
+	ConfigPath   string
+	ConfigExists bool
+	DataDir      string
+	Database     string
+	DatabaseSize int64 // bytes, -1 if the file doesn't exist
+	Schema       string
+	Counts       *task.Counts // nil if the database couldn't be read
+	ColorProfile string
+	Width        string
+	Hyperlinks   string
+	Language     string
+	SystemLocale string
+	Issues       []string
+}
+
+// DoctorSummary prints the tt doctor report in labeled sections
+func (f *Formatter) DoctorSummary(r DoctorReport) {
+	config := r.ConfigPath
+	if !r.ConfigExists {
+		config += " " + tr("(not found, using defaults)")
+	}
+	size := tr("not created yet")
+	if r.DatabaseSize >= 0 {
+		size = formatBytes(r.DatabaseSize)
+	}
+	var counts string
+	if c := r.Counts; c != nil {
+		counts = tr("%d open (%d someday), %d done, %d %s", c.Open, c.Someday, c.Done, c.Projects, pluralize(c.Projects, "project", "projects"))
+	}
+
+	sections := []struct {
+		title  string
+		fields [][2]string
+	}{
+		{tr("Config"), [][2]string{{tr("Path"), config}}},
+		{tr("Data"), [][2]string{
+			{tr("Directory"), r.DataDir},
+			{tr("Database"), r.Database},
+			{tr("Size"), size},
+			{tr("Schema"), r.Schema},
+			{tr("Tasks"), counts},
+		}},
+		{tr("Terminal"), [][2]string{
+			{tr("Colors"), r.ColorProfile},
+			{tr("Width"), r.Width},
+			{tr("Hyperlinks"), r.Hyperlinks},
+		}},
+		{tr("Locale"), [][2]string{
+			{tr("Language"), r.Language},
+			{tr("System"), r.SystemLocale},
+		}},
+	}
+	for _, section := range sections {
+		fmt.Fprintln(f.w, f.theme.Header.Render(section.title))
+		for _, field := range section.fields {
+			value := field[1]
+			if value == "" {
+				value = "-"
+			}
+			fmt.Fprintf(f.w, "  %s %s\n", f.theme.Muted.Render(padRight(field[0]+":", 12)), value)
+		}
+		fmt.Fprintln(f.w)
+	}
+
+	if len(r.Issues) == 0 {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("No issues found")))
+		return
+	}
+	for _, issue := range r.Issues {
+		f.Warning(issue)
+	}
+}
+
+// LintReport prints what tt lint found, a section per kind of finding
+func (f *Formatter) LintReport(r *task.LintReport, emptyDays int) {
+	if r.Len() == 0 {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("No issues found")))
+		return
+	}
+
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintln(f.w, f.theme.Header.Render(title))
+		for _, line := range lines {
+			fmt.Fprintln(f.w, "  "+line)
+		}
+		fmt.Fprintln(f.w)
+	}
+	projectLines := func(projects []task.Task) []string {
+		var lines []string
+		for _, p := range projects {
+			lines = append(lines, fmt.Sprintf("#%d %s", p.ID, sanitizeTitle(p.Title)))
+		}
+		return lines
+	}
+
+	var conflicts []string
+	for _, c := range r.AreaConflicts {
+		conflicts = append(conflicts, fmt.Sprintf("#%d %s ", c.Task.ID, sanitizeTitle(c.Task.Title))+
+			f.theme.Muted.Render(tr("(area %s, but project %s is in %s)", c.Area, c.Project, c.ProjectArea)))
+	}
+	section(tr("Tasks in a project of another area"), conflicts)
+	section(tr("Projects without an area"), projectLines(r.ProjectsNoArea))
+	section(tr("Projects without open tasks for over %d %s", emptyDays, pluralize(emptyDays, "day", "days")), projectLines(r.EmptyProjects))
+	var tags []string
+	for _, tag := range r.SingleUseTags {
+		tags = append(tags, "#"+tag)
+	}
+	section(tr("Tags used only once"), tags)
+}
+
+// VersionInfo prints tt version: the version, then build details
+func (f *Formatter) VersionInfo(info buildinfo.Info, schema int) {
+	commit := info.Commit
+	if commit != "" && info.Modified {
+		commit += " " + tr("(modified)")
+	}
+	fmt.Fprintln(f.w, f.theme.Header.Render("tt "+info.Version))
+	fields := [][2]string{
+		{tr("Commit"), commit},
+		{tr("Built"), info.Date},
+		{tr("Go"), info.GoVersion},
+		{tr("Schema"), fmt.Sprint(schema)},
+	}
+	for _, field := range fields {
+		value := field[1]
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(f.w, "  %s %s\n", f.theme.Muted.Render(padRight(field[0]+":", 8)), value)
+	}
+}
+
+// UpdateStatus tells whether a newer release than current is available
+func (f *Formatter) UpdateStatus(current string, latest *buildinfo.Release) {
+	if !buildinfo.Newer(latest.Version, current) {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("tt is up to date (latest release: %s)", latest.Version)))
+		return
+	}
+	fmt.Fprintln(f.w, f.theme.Warning.Render(tr("Update available: %s", latest.Version)))
+	if latest.URL != "" {
+		fmt.Fprintf(f.w, "  %s\n", latest.URL)
+	}
+}
+
+// formatBytes renders a size like "48 KB"
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%d KB", n>>10)
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// ExpiredNotice is the one-line note shown when tasks expire on startup
+func (f *Formatter) ExpiredNotice(n int, action string) {
+	where := tr("moved to someday")
+	if action == task.ExpireDelete {
+		where = tr("deleted")
+	}
+	fmt.Fprintln(f.w, f.theme.Muted.Render(tr("Expired %d %s (%s)", n, pluralize(n, "task", "tasks"), where)))
 }
 
 func (f *Formatter) Logbook(tasks []task.Task) {
 	if len(tasks) == 0 {
-		fmt.Fprintln(f.w, "No completed tasks")
+		fmt.Fprintln(f.w, tr("No completed tasks"))
 		return
 	}
 
@@ -518,12 +1152,12 @@ func (f *Formatter) Logbook(tasks []task.Task) {
 		if t.CompletedAt != nil {
 			completedAt = t.CompletedAt.Format("2006-01-02 15:04")
 		}
-		fmt.Fprintf(f.w, "%d  %s  %s\n", t.ID, completedAt, sanitizeTitle(t.Title))
+		fmt.Fprintf(f.w, "%d  %s  %s\n", t.ID, completedAt, f.logTitle(t))
 	}
 }
 
 // GroupedLogbook displays completed tasks grouped by the specified field.
-// For date grouping, it uses CompletedAt instead of PlannedDate.
+// For date and week grouping, it uses CompletedAt instead of PlannedDate.
 func (f *Formatter) GroupedLogbook(tasks []task.Task, groupBy string) {
 	if groupBy == "none" || groupBy == "" {
 		f.Logbook(tasks)
@@ -531,7 +1165,7 @@ func (f *Formatter) GroupedLogbook(tasks []task.Task, groupBy string) {
 	}
 
 	if len(tasks) == 0 {
-		fmt.Fprintln(f.w, "No completed tasks")
+		fmt.Fprintln(f.w, tr("No completed tasks"))
 		return
 	}
 
@@ -540,6 +1174,8 @@ func (f *Formatter) GroupedLogbook(tasks []task.Task, groupBy string) {
 		f.logbookByScope(tasks)
 	case "date":
 		f.logbookByDate(tasks)
+	case "week":
+		f.logbookByWeek(tasks)
 	default:
 		f.Logbook(tasks)
 	}
@@ -567,7 +1203,7 @@ func (f *Formatter) logbookByScope(tasks []task.Task) {
 	}
 
 	if len(noScopeTasks) > 0 {
-		fmt.Fprintln(f.w, f.theme.Header.Render("No Scope"))
+		fmt.Fprintln(f.w, f.theme.Header.Render(tr("No Scope")))
 		f.renderLogbookRows(noScopeTasks)
 	}
 
@@ -578,7 +1214,11 @@ func (f *Formatter) logbookByScope(tasks []task.Task) {
 	sort.Strings(headers)
 
 	for _, header := range headers {
-		fmt.Fprintln(f.w, f.theme.Header.Render(header))
+		label := header
+		if f.theme.Accessible {
+			label = accessibleHeader(header)
+		}
+		fmt.Fprintln(f.w, f.theme.Header.Render(label))
 		f.renderLogbookRows(groups[header])
 	}
 }
@@ -603,28 +1243,166 @@ func (f *Formatter) logbookByDate(tasks []task.Task) {
 	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
 
 	for _, date := range dates {
-		fmt.Fprintln(f.w, f.theme.Header.Render(date))
+		fmt.Fprintln(f.w, f.theme.Header.Render(date)+f.theme.Muted.Render("  "+logTotal(dateGroups[date])))
+		if subtotals := f.projectSubtotals(dateGroups[date]); f.logSubtotals && subtotals != "" {
+			fmt.Fprintln(f.w, f.theme.Muted.Render("  "+subtotals))
+		}
 		f.renderLogbookRows(dateGroups[date])
 	}
 }
 
+// logTotal describes a day's completed tasks: how many, and their summed
+// estimate if any have one. Canceled tasks aren't counted.
+func logTotal(tasks []task.Task) string {
+	tasks = completedOnly(tasks)
+	total := fmt.Sprintf("%d", len(tasks))
+	if minutes := task.TotalEstimate(tasks); minutes > 0 {
+		total += " · ~" + task.FormatEstimate(minutes)
+	}
+	return total
+}
+
+// projectSubtotals counts completed tasks per project, most first, e.g.
+// "Website 3 · Home 1 · No project 1"
+func (f *Formatter) projectSubtotals(tasks []task.Task) string {
+	counts := make(map[string]int)
+	for _, t := range completedOnly(tasks) {
+		name := tr("No project")
+		if t.ParentName != nil {
+			name = *t.ParentName
+		}
+		counts[name]++
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %d", name, counts[name])
+	}
+	separator := " · "
+	if f.theme.Accessible {
+		separator = ", "
+	}
+	return strings.Join(parts, separator)
+}
+
+// LogbookSummary prints how many tasks were completed on each of the last
+// days days, today first, days without any included
+func (f *Formatter) LogbookSummary(tasks []task.Task, days int) {
+	counts := make(map[string]int)
+	for _, t := range completedOnly(tasks) {
+		if t.CompletedAt != nil {
+			counts[t.CompletedAt.Format("2006-01-02")]++
+		}
+	}
+
+	today := f.today()
+	total := 0
+	for i := range days {
+		day := today.AddDate(0, 0, -i)
+		n := counts[day.Format("2006-01-02")]
+		total += n
+		line := fmt.Sprintf("%s  %s  %3d", day.Format("2006-01-02"), formatDate(day, "Mon"), n)
+		if n > 0 && !f.theme.Accessible {
+			line += "  " + f.theme.Accent.Render(strings.Repeat("■", min(n, 40)))
+		}
+		fmt.Fprintln(f.w, line)
+	}
+	fmt.Fprintln(f.w)
+	fmt.Fprintln(f.w, f.theme.Muted.Render(tr("%d completed in %d days", total, days)))
+}
+
+// logbookByWeek groups completed tasks by the week (Monday to Sunday) they were completed in
+func (f *Formatter) logbookByWeek(tasks []task.Task) {
+	weekGroups := make(map[string][]task.Task)
+
+	for _, t := range tasks {
+		weekKey := "Unknown"
+		if t.CompletedAt != nil {
+			day := t.CompletedAt.Local()
+			offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+			weekKey = day.AddDate(0, 0, -offset).Format("2006-01-02")
+		}
+		weekGroups[weekKey] = append(weekGroups[weekKey], t)
+	}
+
+	// Most recent week first
+	weeks := make([]string, 0, len(weekGroups))
+	for w := range weekGroups {
+		weeks = append(weeks, w)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(weeks)))
+
+	for _, week := range weeks {
+		header := week
+		if start, err := time.ParseInLocation("2006-01-02", week, time.Local); err == nil {
+			header = tr("Week of %s", formatDate(start, "Jan 2, 2006"))
+		}
+		fmt.Fprintln(f.w, f.theme.Header.Render(header)+f.theme.Muted.Render(fmt.Sprintf("  %d", len(completedOnly(weekGroups[week])))))
+		for _, t := range weekGroups[week] {
+			completedAt := ""
+			if t.CompletedAt != nil {
+				completedAt = formatDate(*t.CompletedAt, "Mon 15:04")
+			}
+			fmt.Fprintf(f.w, "  %d  %s  %s\n", t.ID, completedAt, f.logTitle(t))
+		}
+	}
+}
+
 func (f *Formatter) renderLogbookRows(tasks []task.Task) {
 	for _, t := range tasks {
 		completedAt := ""
 		if t.CompletedAt != nil {
 			completedAt = t.CompletedAt.Format("15:04")
 		}
-		fmt.Fprintf(f.w, "  %d  %s  %s\n", t.ID, completedAt, sanitizeTitle(t.Title))
+		fmt.Fprintf(f.w, "  %d  %s  %s\n", t.ID, completedAt, f.logTitle(t))
+	}
+}
+
+// logTitle is a task's title in the logbook: marked if it was canceled,
+// and followed by its completion note
+func (f *Formatter) logTitle(t task.Task) string {
+	title := sanitizeTitle(t.Title)
+	if t.Status == task.StatusCanceled {
+		if f.theme.Icons.Canceled != "" {
+			title = f.theme.Muted.Render(f.theme.Icons.Canceled + " " + title)
+		} else {
+			title += " " + tr("(canceled)")
+		}
+	}
+	if t.CompletionNote != nil {
+		title += f.theme.Muted.Render("  — " + sanitizeTitle(*t.CompletionNote))
 	}
+	return title
+}
+
+// completedOnly leaves out canceled tasks, which don't count as completed
+func completedOnly(tasks []task.Task) []task.Task {
+	var done []task.Task
+	for _, t := range tasks {
+		if t.Status != task.StatusCanceled {
+			done = append(done, t)
+		}
+	}
+	return done
 }
 
 func (f *Formatter) AreaCreated(a *area.Area) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Created area: %s", a.Name)))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Created area: %s", a.Name)))
 }
 
 func (f *Formatter) AreaList(areas []area.Area) {
 	if len(areas) == 0 {
-		fmt.Fprintln(f.w, "No areas")
+		fmt.Fprintln(f.w, tr("No areas"))
 		return
 	}
 
@@ -634,163 +1412,284 @@ func (f *Formatter) AreaList(areas []area.Area) {
 }
 
 func (f *Formatter) AreaDeleted(a *area.Area) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Deleted area: %s", a.Name)))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Deleted area: %s", a.Name)))
+}
+
+func (f *Formatter) HolidayList(holidays []holiday.Holiday) {
+	if len(holidays) == 0 {
+		fmt.Fprintln(f.w, tr("No holidays"))
+		return
+	}
+
+	for _, h := range holidays {
+		date := formatDate(h.Date, "Mon Jan 2, 2006")
+		if h.Name == "" {
+			fmt.Fprintln(f.w, date)
+			continue
+		}
+		fmt.Fprintf(f.w, "%s  %s\n", date, f.theme.Muted.Render(h.Name))
+	}
+}
+
+func (f *Formatter) HolidayAdded(h *holiday.Holiday) {
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Added holiday: %s", formatDate(h.Date, "Mon Jan 2, 2006"))))
+}
+
+func (f *Formatter) HolidayRemoved(date time.Time) {
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Removed holiday: %s", formatDate(date, "Mon Jan 2, 2006"))))
+}
+
+func (f *Formatter) TokenList(tokens []token.Token) {
+	if len(tokens) == 0 {
+		fmt.Fprintln(f.w, tr("No API tokens"))
+		return
+	}
+
+	width := 0
+	for _, t := range tokens {
+		width = max(width, lipgloss.Width(t.Name))
+	}
+	for _, t := range tokens {
+		used := tr("never used")
+		if t.LastUsedAt != nil {
+			used = tr("last used %s", formatDate(*t.LastUsedAt, "Jan 2, 2006"))
+		}
+		details := tr("created %s", formatDate(t.CreatedAt, "Jan 2, 2006")) + ", " + used
+		fmt.Fprintf(f.w, "%s  %-11s %s\n", padRight(t.Name, width), strings.Join(t.Scopes, ","), f.theme.Muted.Render(details))
+	}
+}
+
+// TokenCreated shows a new token's secret, the only time it can be seen
+func (f *Formatter) TokenCreated(t *token.Token, secret string) {
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Created API token %s (%s)", t.Name, strings.Join(t.Scopes, ", "))))
+	fmt.Fprintln(f.w, secret)
+	fmt.Fprintln(f.w, f.theme.Warning.Render(tr("Copy it now: it's stored hashed and can't be shown again.")))
+}
+
+func (f *Formatter) TokenRevoked(name string) {
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Revoked API token %s", name)))
+}
+
+func (f *Formatter) HolidaysImported(n int, file string) {
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Imported %d %s from %s", n, pluralize(n, "holiday", "holidays"), file)))
+}
+
+func (f *Formatter) BundleExported(path string, files int, encrypted bool) {
+	line := tr("Exported %d %s to %s", files, pluralize(files, "file", "files"), path)
+	if encrypted {
+		line += tr(" (encrypted)")
+	}
+	fmt.Fprintln(f.w, f.theme.Success.Render(line))
+}
+
+// BundleImported reports a restored bundle and where the data it replaced
+// was kept
+func (f *Formatter) BundleImported(path string, files int, replaced []string) {
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Imported %d %s from %s", files, pluralize(files, "file", "files"), path)))
+	for _, p := range replaced {
+		fmt.Fprintln(f.w, f.theme.Muted.Render(tr("Previous data kept in %s", p+".bak")))
+	}
 }
 
 func (f *Formatter) ProjectCreated(p *task.Task) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Created project: %s", p.Title)))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Created project: %s", p.Title)))
 }
 
 func (f *Formatter) ProjectDeleted(p *task.Task) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Deleted project: %s", p.Title)))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Deleted project: %s", p.Title)))
 }
 
 func (f *Formatter) AreaRenamed(oldName, newName string) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Renamed area: %s -> %s", oldName, newName)))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Renamed area: %s -> %s", oldName, newName)))
 }
 
 func (f *Formatter) ProjectRenamed(oldName, newName string) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Renamed project: %s -> %s", oldName, newName)))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Renamed project: %s -> %s", oldName, newName)))
 }
 
 func (f *Formatter) ProjectMoved(p *task.Task, areaName string) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Moved project '%s' to area: %s", p.Title, areaName)))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Moved project '%s' to area: %s", p.Title, areaName)))
 }
 
 func (f *Formatter) ProjectAreaCleared(p *task.Task) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Cleared area from project: %s", p.Title)))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Cleared area from project: %s", p.Title)))
 }
 
 func (f *Formatter) ProjectsCompleted(results []task.CompleteResult) {
 	for _, r := range results {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Completed project: %s", sanitizeTitle(r.Completed.Title))))
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Completed project: %s", sanitizeTitle(r.Completed.Title))))
 	}
 }
 
-func (f *Formatter) ProjectsUncompleted(projects []task.Task) {
-	for _, p := range projects {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Uncompleted project: %s", sanitizeTitle(p.Title))))
+func (f *Formatter) ProjectsUncompleted(results []task.UncompleteResult) {
+	for _, r := range results {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Uncompleted project: %s", sanitizeTitle(r.Reopened.Title))))
 	}
 }
 
 func (f *Formatter) ProjectEdited(name string, changes []string) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Updated project '%s': %s", name, joinChanges(changes))))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Updated project '%s': %s", name, joinChanges(changes))))
 }
 
 func (f *Formatter) ProjectDetails(p *task.Task) {
-	fmt.Fprintf(f.w, "Project: %s\n", sanitizeTitle(p.Title))
+	fmt.Fprintln(f.w, tr("Project: %s", sanitizeTitle(p.Title)))
 
 	if p.Description != nil && *p.Description != "" {
-		fmt.Fprintf(f.w, "  Description: %s\n", *p.Description)
+		fmt.Fprintln(f.w, "  "+tr("Description: %s", *p.Description))
 	}
 	if p.AreaName != nil {
-		fmt.Fprintf(f.w, "  Area: %s\n", *p.AreaName)
+		fmt.Fprintln(f.w, "  "+tr("Area: %s", *p.AreaName))
 	}
 	if p.PlannedDate != nil {
-		fmt.Fprintf(f.w, "  Planned: %s\n", p.PlannedDate.Format("Jan 2, 2006"))
+		fmt.Fprintln(f.w, "  "+tr("Planned: %s", formatDate(*p.PlannedDate, "Jan 2, 2006")))
 	}
 	if p.DueDate != nil {
-		fmt.Fprintf(f.w, "  Due: %s\n", p.DueDate.Format("Jan 2, 2006"))
+		fmt.Fprintln(f.w, "  "+tr("Due: %s", formatDate(*p.DueDate, "Jan 2, 2006")))
 	}
 	if p.State == task.StateSomeday {
-		fmt.Fprintln(f.w, "  State: someday")
+		fmt.Fprintln(f.w, "  "+tr("State: someday"))
 	}
 	if len(p.Tags) > 0 {
-		fmt.Fprintf(f.w, "  Tags: %s\n", formatTagList(p.Tags))
+		fmt.Fprintln(f.w, "  "+tr("Tags: %s", formatTagList(p.Tags)))
 	}
 }
 
 func (f *Formatter) TaskPlannedDateSet(t *task.Task) {
 	if t.PlannedDate != nil {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Planned #%d for %s: %s", t.ID, t.PlannedDate.Format("Jan 2"), sanitizeTitle(t.Title))))
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Planned #%d for %s: %s", t.ID, formatDate(*t.PlannedDate, "Jan 2"), sanitizeTitle(t.Title))))
 	} else {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Cleared planned date for #%d: %s", t.ID, sanitizeTitle(t.Title))))
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Cleared planned date for #%d: %s", t.ID, sanitizeTitle(t.Title))))
 	}
 }
 
 func (f *Formatter) TaskDueDateSet(t *task.Task) {
 	if t.DueDate != nil {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Due #%d on %s: %s", t.ID, t.DueDate.Format("Jan 2"), sanitizeTitle(t.Title))))
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Due #%d on %s: %s", t.ID, formatDate(*t.DueDate, "Jan 2"), sanitizeTitle(t.Title))))
+	} else {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Cleared due date for #%d: %s", t.ID, sanitizeTitle(t.Title))))
+	}
+}
+
+func (f *Formatter) TaskTimeBlockSet(t *task.Task) {
+	if t.BlockStart != nil {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Blocked %s for #%d: %s", timeBlock(t), t.ID, sanitizeTitle(t.Title))))
 	} else {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Cleared due date for #%d: %s", t.ID, sanitizeTitle(t.Title))))
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Cleared time block for #%d: %s", t.ID, sanitizeTitle(t.Title))))
+	}
+}
+
+// timeBlock formats a task's time block, e.g. "Jul 3 14:00–15:30"
+func timeBlock(t *task.Task) string {
+	start := *t.BlockStart
+	end := start
+	if t.BlockMinutes != nil {
+		end = start.Add(time.Duration(*t.BlockMinutes) * time.Minute)
 	}
+	return formatDate(start, "Jan 2") + " " + start.Format("15:04") + "–" + end.Format("15:04")
 }
 
 func (f *Formatter) TaskRecurrenceSet(t *task.Task) {
 	if t.RecurRule != nil {
 		rule, err := recurparse.FromJSON(*t.RecurRule)
 		if err != nil {
-			fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Set recurrence for #%d: %s", t.ID, sanitizeTitle(t.Title))))
+			fmt.Fprintln(f.w, f.theme.Success.Render(tr("Set recurrence for #%d: %s", t.ID, sanitizeTitle(t.Title))))
 			return
 		}
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Set recurrence for #%d (%s): %s", t.ID, rule.Format(), sanitizeTitle(t.Title))))
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Set recurrence for #%d (%s): %s", t.ID, rule.Format(), sanitizeTitle(t.Title))))
 	} else {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Cleared recurrence for #%d: %s", t.ID, sanitizeTitle(t.Title))))
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Cleared recurrence for #%d: %s", t.ID, sanitizeTitle(t.Title))))
 	}
 }
 
 func (f *Formatter) TaskRecurrencePaused(t *task.Task) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Paused recurrence for #%d: %s", t.ID, sanitizeTitle(t.Title))))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Paused recurrence for #%d: %s", t.ID, sanitizeTitle(t.Title))))
 }
 
 func (f *Formatter) TaskRecurrenceResumed(t *task.Task) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Resumed recurrence for #%d: %s", t.ID, sanitizeTitle(t.Title))))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Resumed recurrence for #%d: %s", t.ID, sanitizeTitle(t.Title))))
 }
 
 func (f *Formatter) TaskRecurrenceEndSet(t *task.Task) {
 	if t.RecurEnd != nil {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Set recurrence end date for #%d to %s: %s", t.ID, t.RecurEnd.Format("Jan 2"), sanitizeTitle(t.Title))))
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Set recurrence end date for #%d to %s: %s", t.ID, formatDate(*t.RecurEnd, "Jan 2"), sanitizeTitle(t.Title))))
 	} else {
-		fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Cleared recurrence end date for #%d: %s", t.ID, sanitizeTitle(t.Title))))
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Cleared recurrence end date for #%d: %s", t.ID, sanitizeTitle(t.Title))))
+	}
+}
+
+func (f *Formatter) TaskRecurrenceCountSet(t *task.Task) {
+	if t.RecurCount != nil {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Set recurrence of #%d to end after %d %s: %s", t.ID, *t.RecurCount, pluralize(*t.RecurCount, "occurrence", "occurrences"), sanitizeTitle(t.Title))))
+	} else {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Cleared recurrence count for #%d: %s", t.ID, sanitizeTitle(t.Title))))
 	}
 }
 
 func (f *Formatter) TaskRecurrenceInfo(t *task.Task) {
 	if t.RecurRule == nil {
-		fmt.Fprintf(f.w, "#%d: %s (no recurrence)\n", t.ID, sanitizeTitle(t.Title))
+		fmt.Fprintln(f.w, tr("#%d: %s (no recurrence)", t.ID, sanitizeTitle(t.Title)))
 		return
 	}
 
 	rule, err := recurparse.FromJSON(*t.RecurRule)
-	ruleStr := "unknown"
+	ruleStr := tr("unknown")
 	if err == nil {
 		ruleStr = rule.Format()
 	}
 
 	status := ""
 	if t.RecurPaused {
-		status = " (paused)"
+		status = tr(" (paused)")
 	}
 
 	endStr := ""
+	if t.RecurCount != nil {
+		endStr = tr(" for %d times", *t.RecurCount)
+	}
 	if t.RecurEnd != nil {
-		endStr = fmt.Sprintf(" until %s", t.RecurEnd.Format("Jan 2, 2006"))
+		endStr += tr(" until %s", formatDate(*t.RecurEnd, "Jan 2, 2006"))
 	}
 
-	fmt.Fprintf(f.w, "#%d: %s\n  Recurs: %s%s%s\n", t.ID, sanitizeTitle(t.Title), ruleStr, endStr, status)
+	fmt.Fprintf(f.w, "#%d: %s\n", t.ID, sanitizeTitle(t.Title))
+	fmt.Fprintln(f.w, "  "+tr("Recurs: %s", ruleStr+endStr+status))
 }
 
-func (f *Formatter) TagList(tags []string) {
+// TagList prints the tags in use, each in its color with the color named
+// after it
+func (f *Formatter) TagList(tags []string, colors map[string]string) {
 	if len(tags) == 0 {
-		fmt.Fprintln(f.w, "No tags")
+		fmt.Fprintln(f.w, tr("No tags"))
 		return
 	}
 
 	for _, tag := range tags {
-		fmt.Fprintln(f.w, tag)
+		color, ok := colors[tag]
+		if !ok {
+			fmt.Fprintln(f.w, tag)
+			continue
+		}
+		fmt.Fprintln(f.w, f.theme.TagStyle(tag).Render(tag)+"  "+f.theme.Muted.Render(color))
+	}
+}
+
+func (f *Formatter) TagColorSet(tagName, color string) {
+	if color == "" {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Removed the color of tag '%s'", tagName)))
+		return
 	}
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Tag '%s' is now shown in %s", tagName, color)))
 }
 
 func (f *Formatter) TaskTagAdded(t *task.Task, tagName string) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Added tag '%s' to #%d: %s", tagName, t.ID, sanitizeTitle(t.Title))))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Added tag '%s' to #%d: %s", tagName, t.ID, sanitizeTitle(t.Title))))
 }
 
 func (f *Formatter) TaskTagRemoved(t *task.Task, tagName string) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Removed tag '%s' from #%d: %s", tagName, t.ID, sanitizeTitle(t.Title))))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Removed tag '%s' from #%d: %s", tagName, t.ID, sanitizeTitle(t.Title))))
 }
 
 func (f *Formatter) TaskEdited(id int64, changes []string) {
-	fmt.Fprintln(f.w, f.theme.Success.Render(fmt.Sprintf("Updated #%d: %s", id, joinChanges(changes))))
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Updated #%d: %s", id, joinChanges(changes))))
 }
 
 func joinChanges(changes []string) string {
@@ -812,19 +1711,52 @@ func (f *Formatter) TaskDetails(t *task.Task) {
 	fmt.Fprintf(f.w, "#%d: %s\n", t.ID, sanitizeTitle(t.Title))
 
 	if t.Description != nil && *t.Description != "" {
-		fmt.Fprintf(f.w, "  Description: %s\n", *t.Description)
+		fmt.Fprintln(f.w, "  "+tr("Description: %s", *t.Description))
 	}
 	if t.PlannedDate != nil {
-		fmt.Fprintf(f.w, "  Planned: %s\n", t.PlannedDate.Format("Jan 2, 2006"))
+		fmt.Fprintln(f.w, "  "+tr("Planned: %s", formatDate(*t.PlannedDate, "Jan 2, 2006")))
 	}
 	if t.DueDate != nil {
-		fmt.Fprintf(f.w, "  Due: %s\n", t.DueDate.Format("Jan 2, 2006"))
+		fmt.Fprintln(f.w, "  "+tr("Due: %s", formatDate(*t.DueDate, "Jan 2, 2006")))
+	}
+	if t.Expires != nil {
+		fmt.Fprintln(f.w, "  "+tr("Expires: %s", formatDate(*t.Expires, "Jan 2, 2006")))
+	}
+	if t.BlockStart != nil {
+		fmt.Fprintln(f.w, "  "+tr("Time block: %s", timeBlock(t)))
+	}
+	if t.Estimate != nil {
+		fmt.Fprintln(f.w, "  "+tr("Estimate: %s", task.FormatEstimate(*t.Estimate)))
+	}
+	if t.Context != nil {
+		fmt.Fprintln(f.w, "  "+tr("Context: %s", *t.Context))
+	}
+	if t.Location != nil {
+		fmt.Fprintln(f.w, "  "+tr("Location: %s", *t.Location))
+	}
+	if t.Assignee != nil {
+		fmt.Fprintln(f.w, "  "+tr("Assignee: %s", *t.Assignee))
+	}
+	if t.Creator != nil {
+		fmt.Fprintln(f.w, "  "+tr("Created by: %s", *t.Creator))
+	}
+	if t.ExternalID != nil {
+		fmt.Fprintln(f.w, "  "+tr("External ID: %s", *t.ExternalID))
+	}
+	if t.CompletionNote != nil {
+		fmt.Fprintln(f.w, "  "+tr("Completion note: %s", *t.CompletionNote))
 	}
 	if t.State == task.StateSomeday {
-		fmt.Fprintln(f.w, "  State: someday")
+		fmt.Fprintln(f.w, "  "+tr("State: someday"))
+	}
+	if t.Flagged {
+		fmt.Fprintln(f.w, "  "+tr("Flagged"))
+	}
+	if t.Status == task.StatusCanceled {
+		fmt.Fprintln(f.w, "  "+tr("Canceled"))
 	}
 	if len(t.Tags) > 0 {
-		fmt.Fprintf(f.w, "  Tags: %s\n", formatTagList(t.Tags))
+		fmt.Fprintln(f.w, "  "+tr("Tags: %s", formatTagList(t.Tags)))
 	}
 }
 
@@ -839,6 +1771,72 @@ func formatTagList(tags []string) string {
 	return result
 }
 
+func (f *Formatter) ConfigCreated(path string) {
+	fmt.Fprintln(f.w, f.theme.Success.Render(tr("Created config file: %s", path)))
+}
+
+// ThemeList prints the available themes, marking the active one
+func (f *Formatter) ThemeList(themes []ThemeInfo, current string) {
+	for _, t := range themes {
+		marker := "  "
+		if t.Name == current {
+			marker = f.theme.Accent.Render("*") + " "
+		}
+		line := marker + t.Name
+		if t.Custom {
+			line += " " + f.theme.Muted.Render("(custom)")
+		}
+		fmt.Fprintln(f.w, line)
+	}
+}
+
+// ThemePreview renders a sample task list and status messages in the formatter's theme
+func (f *Formatter) ThemePreview(name string) {
+	fmt.Fprintln(f.w, f.theme.Header.Render(name))
+	f.TaskList(previewTasks(f.clock.Now()))
+	fmt.Fprintln(f.w, "  "+f.theme.Success.Render("Completed #3: Book dentist")+"  "+f.theme.Error.Render("Error: task not found"))
+}
+
+// previewTasks returns sample tasks exercising every themed element
+func previewTasks(now time.Time) []task.Task {
+	work, home := "Work", "Home"
+	launch := "Launch"
+	yesterday := now.AddDate(0, 0, -1)
+	nextWeek := now.AddDate(0, 0, 7)
+	estimate := 30
+
+	return []task.Task{
+		{ID: 1, Title: "Write release notes", AreaName: &work, ParentName: &launch, PlannedDate: &now, Estimate: &estimate, Tags: []string{"writing"}},
+		{ID: 2, Title: "Renew passport", AreaName: &home, DueDate: &yesterday},
+		{ID: 3, Title: "Book dentist", PlannedDate: &nextWeek},
+	}
+}
+
+// ConfigCheck reports the result of validating the config file at path
+func (f *Formatter) ConfigCheck(path string, problems []string) {
+	if len(problems) == 0 {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Config OK: %s", path)))
+		return
+	}
+	for _, p := range problems {
+		f.Warning(p)
+	}
+}
+
+func (f *Formatter) DatabaseCheck(path string, problems []string) {
+	if len(problems) == 0 {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Database OK: %s", path)))
+		return
+	}
+	for _, p := range problems {
+		f.Warning(p)
+	}
+}
+
+func (f *Formatter) Warning(msg string) {
+	fmt.Fprintln(f.w, f.theme.Warning.Render(tr("Warning: %s", msg)))
+}
+
 func (f *Formatter) Error(msg string) {
-	fmt.Fprintln(f.w, f.theme.Error.Render(fmt.Sprintf("Error: %s", msg)))
+	fmt.Fprintln(f.w, f.theme.Error.Render(tr("Error: %s", msg)))
 }
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// overdueBucket groups overdue tasks by how late they are
+type overdueBucket struct {
+	label   string // header, e.g. "1–3 days late"
+	summary string // count phrase for summaries, with a %d for the count
+	maxDays int    // latest day in the bucket (0 = no limit)
+}
+
+// overdueBuckets escalate from a little late to badly late, in the order they
+// are shown: the worst first
+var overdueBuckets = []overdueBucket{
+	{label: "More than a week late", summary: "%d more than a week late"},
+	{label: "Up to a week late", summary: "%d up to a week late", maxDays: 7},
+	{label: "1–3 days late", summary: "%d up to 3 days late", maxDays: 3},
+}
+
+// bucketOverdue splits overdue tasks into overdueBuckets by days since their
+// due date, keeping their order within each bucket
+func bucketOverdue(tasks []task.Task, today time.Time) [][]task.Task {
+	groups := make([][]task.Task, len(overdueBuckets))
+	for _, t := range tasks {
+		late := -task.DaysBetween(today, *t.DueDate)
+		i := 0
+		for j, b := range overdueBuckets {
+			if b.maxDays == 0 || late <= b.maxDays {
+				i = j
+			}
+		}
+		groups[i] = append(groups[i], t)
+	}
+	return groups
+}
+
+// OverdueList prints overdue tasks grouped by how late they are, the latest
+// first, with a summary line on top. Tasks should be sorted by due date.
+func (f *Formatter) OverdueList(tasks []task.Task, today time.Time) {
+	if len(tasks) == 0 {
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Nothing overdue")))
+		return
+	}
+
+	fmt.Fprintln(f.w, f.theme.Warning.Render(OverdueSummary(tasks, today)))
+	idWidth := maxIDWidth(tasks)
+	for i, group := range bucketOverdue(tasks, today) {
+		if len(group) == 0 {
+			continue
+		}
+		fmt.Fprintln(f.w)
+		fmt.Fprintln(f.w, f.theme.Header.Render(tr(overdueBuckets[i].label))+f.theme.Muted.Render(fmt.Sprintf("  %d", len(group))))
+		f.renderTaskRows(group, 0, !f.hideScope, idWidth)
+	}
+}
+
+// OverdueSummary describes overdue tasks in one plain line, e.g.
+// "3 overdue tasks: 1 more than a week late, 2 up to 3 days late"
+func OverdueSummary(tasks []task.Task, today time.Time) string {
+	var parts []string
+	for i, group := range bucketOverdue(tasks, today) {
+		if len(group) > 0 {
+			parts = append(parts, tr(overdueBuckets[i].summary, len(group)))
+		}
+	}
+	return tr("%d overdue %s: %s", len(tasks), pluralize(len(tasks), "task", "tasks"), strings.Join(parts, ", "))
+}
@@ -0,0 +1,35 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+func TestOverdueSummary(t *testing.T) {
+	today := time.Date(2026, time.March, 20, 0, 0, 0, 0, time.Local)
+	due := func(daysLate int) *time.Time {
+		d := today.AddDate(0, 0, -daysLate)
+		return &d
+	}
+	tasks := []task.Task{
+		{ID: 1, DueDate: due(30)},
+		{ID: 2, DueDate: due(8)},
+		{ID: 3, DueDate: due(7)},
+		{ID: 4, DueDate: due(3)},
+		{ID: 5, DueDate: due(1)},
+	}
+
+	groups := bucketOverdue(tasks, today)
+	for i, want := range []int{2, 1, 2} {
+		if len(groups[i]) != want {
+			t.Errorf("bucket %q has %d tasks, want %d", overdueBuckets[i].label, len(groups[i]), want)
+		}
+	}
+
+	want := "5 overdue tasks: 2 more than a week late, 1 up to a week late, 2 up to 3 days late"
+	if got := OverdueSummary(tasks, today); got != want {
+		t.Errorf("OverdueSummary() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,62 @@
+package output
+
+import "fmt"
+
+// SetupWelcome opens the first-run setup
+func (f *Formatter) SetupWelcome() {
+	fmt.Fprintln(f.w, f.theme.Header.Render(tr("Welcome to tt!")))
+	fmt.Fprintln(f.w, tr("A few questions to get you started. Press Enter to accept the default."))
+}
+
+// SetupThemes lists the themes to pick from by number, marking current as
+// the default
+func (f *Formatter) SetupThemes(themes []string, current string) {
+	fmt.Fprintln(f.w)
+	fmt.Fprintln(f.w, f.theme.Header.Render(tr("Color theme")))
+	for i, name := range themes {
+		line := fmt.Sprintf("  %d. %s", i+1, name)
+		if name == current {
+			line += f.theme.Muted.Render(" " + tr("(default)"))
+		}
+		fmt.Fprintln(f.w, line)
+	}
+	fmt.Fprintln(f.w, f.theme.Muted.Render("  "+tr("Preview them with tt theme preview.")))
+}
+
+// SetupAreas introduces the areas question
+func (f *Formatter) SetupAreas() {
+	fmt.Fprintln(f.w)
+	fmt.Fprintln(f.w, f.theme.Header.Render(tr("Areas")))
+	fmt.Fprintln(f.w, tr("Areas are the parts of your life tasks and projects belong to, like Work or Health."))
+}
+
+// SetupDone ends the setup, telling where the config is: written if
+// written is true, kept otherwise. An empty path means there is no config
+// file location.
+func (f *Formatter) SetupDone(path string, written bool) {
+	fmt.Fprintln(f.w)
+	switch {
+	case written:
+		fmt.Fprintln(f.w, f.theme.Success.Render(tr("Wrote starter config to %s", path)))
+		fmt.Fprintln(f.w, f.theme.Muted.Render(tr("Every setting is explained there; change it with tt config edit.")))
+	case path != "":
+		fmt.Fprintln(f.w, f.theme.Muted.Render(tr("Kept your config file %s", path)))
+	}
+	fmt.Fprintln(f.w, tr(`Add your first task with tt add "Something to do", or press a in the app.`))
+}
+
+// Prompt asks a question, leaving the cursor on the same line for the answer
+func (f *Formatter) Prompt(format string, args ...any) {
+	fmt.Fprint(f.w, f.theme.Accent.Render(tr(format, args...)))
+}
+
+// ChoiceOutOfRange rejects an answer that isn't one of the n numbered choices
+func (f *Formatter) ChoiceOutOfRange(n int) {
+	fmt.Fprintln(f.w, tr("Please enter a number from 1 to %d.", n))
+}
+
+// PromptAbandoned ends the line of a prompt that got no answer because the
+// input ended
+func (f *Formatter) PromptAbandoned() {
+	fmt.Fprintln(f.w)
+}
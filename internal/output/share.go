@@ -0,0 +1,52 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// Formats of tt share
+const (
+	ShareSlack    = "slack"    // Slack mrkdwn
+	ShareMarkdown = "markdown" // GitHub-flavored task list
+	SharePlain    = "plain"    // plain text
+)
+
+// ShareFormats returns the formats tt share can write
+func ShareFormats() []string {
+	return []string{ShareSlack, ShareMarkdown, SharePlain}
+}
+
+// WriteShare writes tasks as a checklist for pasting into a chat or a
+// document, each with its deadline. Overdue deadlines are marked.
+func WriteShare(w io.Writer, tasks []task.Task, format string, today time.Time) error {
+	var box, bold string
+	switch format {
+	case ShareSlack:
+		box, bold = "☐", "*"
+	case ShareMarkdown:
+		box, bold = "- [ ]", "**"
+	case SharePlain:
+		box = "[ ]"
+	default:
+		return fmt.Errorf("unknown share format %q (use slack, markdown or plain)", format)
+	}
+
+	out := bufio.NewWriter(w)
+	for _, t := range tasks {
+		line := box + " " + sanitizeTitle(t.Title)
+		if t.DueDate != nil {
+			due := tr("due %s", formatDate(*t.DueDate, "Mon Jan 2"))
+			if t.DueDate.Format("2006-01-02") < today.Format("2006-01-02") {
+				due = tr("overdue since %s", formatDate(*t.DueDate, "Mon Jan 2"))
+			}
+			line += " — " + bold + due + bold
+		}
+		fmt.Fprintln(out, line)
+	}
+	return out.Flush()
+}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// DefaultStandupTemplate returns what tt standup prints unless
+// standup_template is set: Slack-style markdown, ready to paste, with
+// headings in the current language
+func DefaultStandupTemplate() string {
+	return fmt.Sprintf(`*%s*
+{{range .Yesterday}}- {{.Title}}
+{{else}}- %s
+{{end}}
+*%s*
+{{range .Today}}- {{.Title}}
+{{else}}- %s
+{{end}}
+*%s*
+{{range .Blockers}}- {{.Title}}
+{{else}}- %s
+{{end}}`, tr("Yesterday"), tr("Nothing"), tr("Today"), tr("Nothing planned"), tr("Blockers"), tr("None"))
+}
+
+// StandupTask is a task as a standup template sees it
+type StandupTask struct {
+	ID    int64
+	Title string
+	Scope string // "Area > Project", whichever are set
+	Note  string // completion note, for finished tasks
+}
+
+// Standup holds the tasks of a standup report
+type Standup struct {
+	Date      time.Time
+	Yesterday []task.Task // finished since the last standup
+	Today     []task.Task // planned for today
+	Blockers  []task.Task // open tasks with the blocker tag
+}
+
+// standupFields are the values a standup template can use
+type standupFields struct {
+	Date      string // e.g. "Mon Jan 2"
+	Yesterday []StandupTask
+	Today     []StandupTask
+	Blockers  []StandupTask
+}
+
+// ParseStandupTemplate parses a standup template, the default one if tmpl
+// is empty
+func ParseStandupTemplate(tmpl string) (*template.Template, error) {
+	if tmpl == "" {
+		tmpl = DefaultStandupTemplate()
+	}
+	return template.New("standup").Option("missingkey=error").Parse(tmpl)
+}
+
+// WriteStandup renders a standup report with the template tmpl, the default
+// one if empty
+func WriteStandup(w io.Writer, tmpl string, s Standup) error {
+	parsed, err := ParseStandupTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+	fields := standupFields{
+		Date:      formatDate(s.Date, "Mon Jan 2"),
+		Yesterday: standupTasks(s.Yesterday),
+		Today:     standupTasks(s.Today),
+		Blockers:  standupTasks(s.Blockers),
+	}
+	return parsed.Execute(w, fields)
+}
+
+// standupTasks converts tasks to the fields a standup template uses
+func standupTasks(tasks []task.Task) []StandupTask {
+	items := make([]StandupTask, len(tasks))
+	for i, t := range tasks {
+		items[i] = StandupTask{ID: t.ID, Title: t.Title, Scope: taskScope(t)}
+		if t.CompletionNote != nil {
+			items[i].Note = *t.CompletionNote
+		}
+	}
+	return items
+}
+
+// taskScope returns "Area > Project" for a task, whichever are set
+func taskScope(t task.Task) string {
+	var scope []string
+	if t.AreaName != nil {
+		scope = append(scope, *t.AreaName)
+	}
+	if t.ParentName != nil {
+		scope = append(scope, *t.ParentName)
+	}
+	return strings.Join(scope, " > ")
+}
@@ -1,6 +1,13 @@
 package output
 
 import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/devbydaniel/tt/config"
 )
@@ -16,14 +23,20 @@ type Theme struct {
 	ID      lipgloss.Style
 	Scope   lipgloss.Style
 	Icons   Icons
+
+	Accessible bool // labeled plain lines for screen readers (see SetAccessible)
+
+	tagStyles map[string]lipgloss.Style // per-tag chip colors, see SetTagColors
+	colorless bool                      // colors were stripped (no_color, NO_COLOR)
 }
 
 // Icons holds customizable icon characters
 type Icons struct {
-	Planned string
-	Due     string
-	Date    string
-	Done    string
+	Planned  string
+	Due      string
+	Date     string
+	Done     string
+	Canceled string
 }
 
 // themeColors holds the raw color values for a theme preset
@@ -104,22 +117,25 @@ var presets = map[string]themeColors{
 	},
 }
 
-// DefaultTheme returns the default theme matching the original hardcoded values
+// DefaultTheme returns the default theme. Colors adapt to the terminal
+// background: the dark variants are the original hardcoded values, the light
+// variants are darker shades that stay readable on a white background.
 func DefaultTheme() *Theme {
 	return &Theme{
-		Muted:   lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
-		Accent:  lipgloss.NewStyle().Foreground(lipgloss.Color("226")),
-		Warning: lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
-		Success: lipgloss.NewStyle().Foreground(lipgloss.Color("82")),
-		Error:   lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		Muted:   lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "244", Dark: "241"}),
+		Accent:  lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "136", Dark: "226"}),
+		Warning: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "160", Dark: "196"}),
+		Success: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "28", Dark: "82"}),
+		Error:   lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "160", Dark: "196"}),
 		Header:  lipgloss.NewStyle().Bold(true),
-		ID:      lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
+		ID:      lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "244", Dark: "241"}),
 		Scope:   lipgloss.NewStyle(),
 		Icons: Icons{
-			Planned: "★",
-			Due:     "⚑",
-			Date:    "›",
-			Done:    "✓",
+			Planned:  "★",
+			Due:      "⚑",
+			Date:     "›",
+			Done:     "✓",
+			Canceled: "✗",
 		},
 	}
 }
@@ -132,43 +148,22 @@ func NewTheme(cfg *config.ThemeConfig) *Theme {
 		return theme
 	}
 
-	// Apply preset if specified
-	if preset, ok := presets[cfg.Name]; ok {
-		theme.Muted = lipgloss.NewStyle().Foreground(parseColor(preset.Muted))
-		theme.Accent = lipgloss.NewStyle().Foreground(parseColor(preset.Accent))
-		theme.Warning = lipgloss.NewStyle().Foreground(parseColor(preset.Warning))
-		theme.Success = lipgloss.NewStyle().Foreground(parseColor(preset.Success))
-		theme.Error = lipgloss.NewStyle().Foreground(parseColor(preset.Error))
-		theme.Header = lipgloss.NewStyle().Bold(true).Foreground(parseColor(preset.Header))
-		theme.ID = lipgloss.NewStyle().Foreground(parseColor(preset.ID))
-		theme.Scope = lipgloss.NewStyle().Foreground(parseColor(preset.Scope))
+	// Apply preset if specified (user-defined presets shadow built-ins)
+	if preset, ok := lookupPreset(cfg); ok {
+		theme.applyColors(preset)
 	}
 
 	// Apply custom color overrides (can override preset values)
-	if cfg.Muted != "" {
-		theme.Muted = lipgloss.NewStyle().Foreground(parseColor(cfg.Muted))
-	}
-	if cfg.Accent != "" {
-		theme.Accent = lipgloss.NewStyle().Foreground(parseColor(cfg.Accent))
-	}
-	if cfg.Warning != "" {
-		theme.Warning = lipgloss.NewStyle().Foreground(parseColor(cfg.Warning))
-	}
-	if cfg.Header != "" {
-		theme.Header = lipgloss.NewStyle().Bold(true).Foreground(parseColor(cfg.Header))
-	}
-	if cfg.ID != "" {
-		theme.ID = lipgloss.NewStyle().Foreground(parseColor(cfg.ID))
-	}
-	if cfg.Scope != "" {
-		theme.Scope = lipgloss.NewStyle().Foreground(parseColor(cfg.Scope))
-	}
-	if cfg.Success != "" {
-		theme.Success = lipgloss.NewStyle().Foreground(parseColor(cfg.Success))
-	}
-	if cfg.Error != "" {
-		theme.Error = lipgloss.NewStyle().Foreground(parseColor(cfg.Error))
-	}
+	theme.applyColors(themeColors{
+		Muted:   cfg.Muted,
+		Accent:  cfg.Accent,
+		Warning: cfg.Warning,
+		Success: cfg.Success,
+		Error:   cfg.Error,
+		Header:  cfg.Header,
+		ID:      cfg.ID,
+		Scope:   cfg.Scope,
+	})
 
 	// Apply icon overrides
 	if cfg.Icons.Planned != "" {
@@ -183,17 +178,189 @@ func NewTheme(cfg *config.ThemeConfig) *Theme {
 	if cfg.Icons.Done != "" {
 		theme.Icons.Done = cfg.Icons.Done
 	}
+	if cfg.Icons.Canceled != "" {
+		theme.Icons.Canceled = cfg.Icons.Canceled
+	}
+
+	if cfg.NoColor || NoColorRequested() {
+		theme.stripColors()
+	}
 
 	return theme
 }
 
-// parseColor converts a color string to a Lipgloss color
-// Supports ANSI codes (0-255) and hex colors (#RRGGBB)
-func parseColor(s string) lipgloss.TerminalColor {
+// applyColors sets every non-empty color in c, leaving the rest unchanged
+func (t *Theme) applyColors(c themeColors) {
+	if c.Muted != "" {
+		t.Muted = lipgloss.NewStyle().Foreground(ParseColor(c.Muted))
+	}
+	if c.Accent != "" {
+		t.Accent = lipgloss.NewStyle().Foreground(ParseColor(c.Accent))
+	}
+	if c.Warning != "" {
+		t.Warning = lipgloss.NewStyle().Foreground(ParseColor(c.Warning))
+	}
+	if c.Success != "" {
+		t.Success = lipgloss.NewStyle().Foreground(ParseColor(c.Success))
+	}
+	if c.Error != "" {
+		t.Error = lipgloss.NewStyle().Foreground(ParseColor(c.Error))
+	}
+	if c.Header != "" {
+		t.Header = lipgloss.NewStyle().Bold(true).Foreground(ParseColor(c.Header))
+	}
+	if c.ID != "" {
+		t.ID = lipgloss.NewStyle().Foreground(ParseColor(c.ID))
+	}
+	if c.Scope != "" {
+		t.Scope = lipgloss.NewStyle().Foreground(ParseColor(c.Scope))
+	}
+}
+
+// NoColorRequested reports whether the NO_COLOR convention (https://no-color.org)
+// asks for uncolored output
+func NoColorRequested() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// stripColors removes all colors, keeping bold headers and the icons
+func (t *Theme) stripColors() {
+	t.colorless = true
+	t.tagStyles = nil
+	plain := lipgloss.NewStyle()
+	t.Muted = plain
+	t.Accent = plain
+	t.Warning = plain
+	t.Success = plain
+	t.Error = plain
+	t.Header = plain.Bold(true)
+	t.ID = plain
+	t.Scope = plain
+}
+
+// SetTagColors sets the colors tag chips are shown in, by tag name (as set
+// with tt tag color). Invalid colors are skipped, and a colorless theme
+// stays colorless.
+func (t *Theme) SetTagColors(colors map[string]string) {
+	if t.colorless {
+		return
+	}
+	t.tagStyles = make(map[string]lipgloss.Style, len(colors))
+	for name, color := range colors {
+		if ValidateColor(color) == nil {
+			t.tagStyles[name] = lipgloss.NewStyle().Foreground(ParseColor(color))
+		}
+	}
+}
+
+// TagStyle returns the style of a tag's chips: its color, or muted
+func (t *Theme) TagStyle(name string) lipgloss.Style {
+	if style, ok := t.tagStyles[name]; ok {
+		return style
+	}
+	return t.Muted
+}
+
+// RenderTags styles tag chips like "#urgent #home" in their colors, and
+// anything else, such as a chip cut short by truncation, muted
+func (t *Theme) RenderTags(chips string) string {
+	words := strings.Split(chips, " ")
+	for i, word := range words {
+		words[i] = t.TagStyle(strings.TrimPrefix(word, "#")).Render(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// namedColors maps the 16 standard terminal color names to their ANSI codes
+var namedColors = map[string]string{
+	"black": "0", "red": "1", "green": "2", "yellow": "3",
+	"blue": "4", "magenta": "5", "cyan": "6", "white": "7",
+	"bright-black": "8", "bright-red": "9", "bright-green": "10", "bright-yellow": "11",
+	"bright-blue": "12", "bright-magenta": "13", "bright-cyan": "14", "bright-white": "15",
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ParseColor converts a color string to a Lipgloss color.
+// Supports ANSI codes (0-255), hex colors (#RRGGBB), the 16 standard color
+// names ("red", "bright-blue"), and "light|dark" pairs that adapt to the
+// terminal background.
+func ParseColor(s string) lipgloss.TerminalColor {
 	if s == "" {
 		return lipgloss.NoColor{}
 	}
-	return lipgloss.Color(s)
+	if light, dark, ok := strings.Cut(s, "|"); ok {
+		return lipgloss.AdaptiveColor{Light: normalizeColor(light), Dark: normalizeColor(dark)}
+	}
+	return lipgloss.Color(normalizeColor(s))
+}
+
+// normalizeColor resolves color names to ANSI codes
+func normalizeColor(s string) string {
+	s = strings.TrimSpace(s)
+	if code, ok := namedColors[strings.ToLower(s)]; ok {
+		return code
+	}
+	return s
+}
+
+// ValidateColor returns a descriptive error if s is not a color parseColor understands
+func ValidateColor(s string) error {
+	parts := strings.Split(s, "|")
+	if len(parts) > 2 {
+		return fmt.Errorf("invalid color %q: use at most one \"|\" to separate light and dark variants", s)
+	}
+	for _, part := range parts {
+		part = normalizeColor(part)
+		if n, err := strconv.Atoi(part); err == nil {
+			if n < 0 || n > 255 {
+				return fmt.Errorf("invalid color %q: ANSI codes range from 0 to 255", s)
+			}
+			continue
+		}
+		if !hexColor.MatchString(part) {
+			return fmt.Errorf("invalid color %q: use an ANSI code (0-255), a hex color (#RRGGBB), a name like \"red\" or \"bright-blue\", or \"light|dark\"", s)
+		}
+	}
+	return nil
+}
+
+// lookupPreset finds the colors of the preset named in cfg
+func lookupPreset(cfg *config.ThemeConfig) (themeColors, bool) {
+	if custom, ok := cfg.Presets[cfg.Name]; ok {
+		return themeColors(custom), true
+	}
+	preset, ok := presets[cfg.Name]
+	return preset, ok
+}
+
+// ThemeInfo describes a selectable theme preset
+type ThemeInfo struct {
+	Name   string
+	Custom bool // defined in the config file rather than built in
+}
+
+// Themes lists the built-in presets followed by the user-defined ones from
+// cfg in alphabetical order. A custom preset reusing a built-in name replaces it.
+func Themes(cfg *config.ThemeConfig) []ThemeInfo {
+	var custom []string
+	if cfg != nil {
+		for name := range cfg.Presets {
+			custom = append(custom, name)
+		}
+	}
+	slices.Sort(custom)
+
+	var themes []ThemeInfo
+	for _, name := range AvailableThemes() {
+		if !slices.Contains(custom, name) {
+			themes = append(themes, ThemeInfo{Name: name})
+		}
+	}
+	for _, name := range custom {
+		themes = append(themes, ThemeInfo{Name: name, Custom: true})
+	}
+	return themes
 }
 
 // AvailableThemes returns a list of available preset theme names
@@ -0,0 +1,136 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/devbydaniel/tt/internal/domain/area"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// treeNode is one line of the tree: an area, a project or a task
+type treeNode struct {
+	id       int64 // task ID, 0 for areas and projects
+	label    string
+	count    int // open tasks below, shown for areas and projects
+	someday  bool
+	children []*treeNode
+}
+
+// Tree prints areas, their projects and all open tasks as a hierarchy with
+// box-drawing connectors and the number of open tasks at each level. Tasks
+// without an area come last, under "No area". depth limits how many levels
+// are shown (0 = all): 1 shows areas, 2 adds projects and area tasks.
+func (f *Formatter) Tree(areas []area.Area, tasks []task.Task, depth int) {
+	roots := buildTree(areas, tasks)
+	if len(roots) == 0 {
+		fmt.Fprintln(f.w, tr("No tasks"))
+		return
+	}
+	for _, root := range roots {
+		fmt.Fprintln(f.w, f.treeLabel(root, true))
+		f.renderTreeChildren(root, "", 2, depth)
+	}
+}
+
+// buildTree arranges tasks under their projects and areas
+func buildTree(areas []area.Area, tasks []task.Task) []*treeNode {
+	byArea := map[int64]*treeNode{}
+	var roots []*treeNode
+	for _, a := range areas {
+		node := &treeNode{label: a.Name}
+		byArea[a.ID] = node
+		roots = append(roots, node)
+	}
+	noArea := &treeNode{label: tr("No area")}
+
+	// Projects first, so tasks can find them regardless of order
+	byProject := map[int64]*treeNode{}
+	projectArea := map[int64]*treeNode{}
+	for _, t := range tasks {
+		if !t.IsProject() {
+			continue
+		}
+		node := &treeNode{label: sanitizeTitle(t.Title), someday: t.State == task.StateSomeday}
+		parent := noArea
+		if t.AreaID != nil && byArea[*t.AreaID] != nil {
+			parent = byArea[*t.AreaID]
+		}
+		parent.children = append(parent.children, node)
+		byProject[t.ID] = node
+		projectArea[t.ID] = parent
+	}
+
+	for _, t := range tasks {
+		if t.IsProject() {
+			continue
+		}
+		node := &treeNode{id: t.ID, label: sanitizeTitle(t.Title), someday: t.State == task.StateSomeday}
+		if t.ParentID != nil && byProject[*t.ParentID] != nil {
+			project := byProject[*t.ParentID]
+			project.children = append(project.children, node)
+			project.count++
+			projectArea[*t.ParentID].count++
+			continue
+		}
+		parent := noArea
+		if t.AreaID != nil && byArea[*t.AreaID] != nil {
+			parent = byArea[*t.AreaID]
+		}
+		parent.children = append(parent.children, node)
+		parent.count++
+	}
+
+	if len(noArea.children) > 0 {
+		roots = append(roots, noArea)
+	}
+	return roots
+}
+
+// renderTreeChildren prints the children of node at the given level, each
+// line prefixed by the connectors of its ancestors
+func (f *Formatter) renderTreeChildren(node *treeNode, prefix string, level, depth int) {
+	if depth > 0 && level > depth {
+		return
+	}
+	for i, child := range node.children {
+		last := i == len(node.children)-1
+		connector, continuation := "├── ", "│   "
+		if last {
+			connector, continuation = "└── ", "    "
+		}
+		if f.theme.Accessible {
+			connector, continuation = "  ", "  "
+		}
+		fmt.Fprintln(f.w, f.theme.Muted.Render(prefix+connector)+f.treeLabel(child, false))
+		f.renderTreeChildren(child, prefix+continuation, level+1, depth)
+	}
+}
+
+// treeLabel styles a node's label: tasks get their ID in front, areas and
+// projects their open count after
+func (f *Formatter) treeLabel(node *treeNode, root bool) string {
+	label := node.label
+	if node.someday {
+		label = f.theme.Muted.Render(label + " " + tr("(someday)"))
+	}
+
+	if node.id != 0 {
+		if f.theme.Accessible {
+			return tr("Task %d: %s", node.id, label)
+		}
+		return f.theme.ID.Render(fmt.Sprint(node.id)) + "  " + label
+	}
+
+	if !node.someday {
+		if root {
+			label = f.theme.Header.Render(label)
+		} else {
+			label = f.theme.Scope.Render(label)
+		}
+	}
+	separator := "  "
+	if f.theme.Accessible {
+		separator = ", "
+	}
+	return label + f.theme.Muted.Render(separator+tr("%d open", node.count))
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/devbydaniel/tt/internal/domain/area"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+func TestTree(t *testing.T) {
+	work := int64(1)
+	project := int64(10)
+	areas := []area.Area{{ID: work, Name: "Work"}, {ID: 2, Name: "Home"}}
+	tasks := []task.Task{
+		{ID: 10, Title: "Website", TaskType: task.TaskTypeProject, AreaID: &work},
+		{ID: 11, Title: "Fix header", ParentID: &project},
+		{ID: 12, Title: "Deploy", ParentID: &project},
+		{ID: 13, Title: "Email Sam", AreaID: &work},
+		{ID: 14, Title: "Buy milk", State: task.StateSomeday},
+	}
+
+	theme := DefaultTheme()
+	theme.stripColors()
+	var buf bytes.Buffer
+	NewFormatter(&buf, theme).Tree(areas, tasks, 0)
+
+	want := `Work  3 open
+├── Website  2 open
+│   ├── 11  Fix header
+│   └── 12  Deploy
+└── 13  Email Sam
+Home  0 open
+No area  1 open
+└── 14  Buy milk (someday)
+`
+	if buf.String() != want {
+		t.Errorf("Tree() =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	NewFormatter(&buf, theme).Tree(areas, tasks, 1)
+	if got := strings.Count(buf.String(), "\n"); got != 3 {
+		t.Errorf("Tree(depth 1) printed %d lines, want 3:\n%s", got, buf.String())
+	}
+}
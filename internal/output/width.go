@@ -0,0 +1,108 @@
+package output
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/rivo/uniseg"
+)
+
+// Overflow modes for rows wider than the terminal
+const (
+	OverflowTruncate = "truncate" // cut the title with "…" (default)
+	OverflowWrap     = "wrap"     // wrap the title onto indented continuation lines
+	OverflowNone     = "none"     // leave long rows to the terminal
+)
+
+// OverflowModes returns the accepted values of the `overflow` config setting
+func OverflowModes() []string {
+	return []string{OverflowTruncate, OverflowWrap, OverflowNone}
+}
+
+// minTitleWidth is the narrowest a title is squeezed to; below that the row
+// overflows rather than showing an unreadable stub.
+const minTitleWidth = 10
+
+// TerminalWidth returns the width of the terminal w writes to, or 0 when w
+// is not a terminal, so piped output is never cut.
+func TerminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(f.Fd()) {
+		return 0
+	}
+	width, _, err := term.GetSize(f.Fd())
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// SetWidth sets the line width task rows are fitted to (0 = unlimited).
+// NewFormatter defaults it to the terminal width.
+func (f *Formatter) SetWidth(width int) {
+	f.width = width
+}
+
+// SetOverflow sets how titles that don't fit the width are handled:
+// OverflowTruncate, OverflowWrap or OverflowNone.
+func (f *Formatter) SetOverflow(mode string) {
+	f.overflow = mode
+}
+
+// truncate shortens s to at most width display cells, marking the cut with "…"
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	head, _ := cut(s, width-1)
+	return head + "…"
+}
+
+// cut splits s after at most width display cells. It never splits a
+// grapheme cluster, so emoji sequences and combining marks stay whole, and a
+// wide character that doesn't fit goes to the tail.
+func cut(s string, width int) (head, tail string) {
+	rest, used, state := s, 0, -1
+	for rest != "" {
+		_, next, w, newState := uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+w > width {
+			break
+		}
+		rest, used, state = next, used+w, newState
+	}
+	return s[:len(s)-len(rest)], rest
+}
+
+// padRight pads s with spaces to width display cells
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
+
+// wrapText breaks s into lines of at most width display cells, splitting at
+// spaces where possible. Words longer than a line are split hard.
+func wrapText(s string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		if line != "" && lipgloss.Width(line)+1+lipgloss.Width(word) <= width {
+			line += " " + word
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		for lipgloss.Width(word) > width {
+			var head string
+			head, word = cut(word, width)
+			lines = append(lines, head)
+		}
+		line = word
+	}
+	return append(lines, line)
+}
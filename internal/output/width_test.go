@@ -0,0 +1,76 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+func TestTruncateWideCharacters(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		width int
+		want  string
+	}{
+		{"ascii", "buy groceries", 8, "buy gro…"},
+		{"cjk", "買い物リスト", 7, "買い物…"},
+		{"cjk wide char doesn't fit", "買い物リスト", 6, "買い…"},
+		{"emoji", "🎉🎉🎉 party", 5, "🎉🎉…"},
+		{"emoji sequence stays whole", "👩‍💻👩‍💻 code", 4, "👩‍💻…"},
+		{"flag stays whole", "🇩🇪🇪🇸 trip", 5, "🇩🇪🇪🇸…"},
+		{"combining mark stays whole", "café au lait", 5, "café…"},
+		{"fits", "買い物", 6, "買い物"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.in, tt.width)
+			if got != tt.want {
+				t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+			}
+			if w := lipgloss.Width(got); w > tt.width {
+				t.Errorf("truncate(%q, %d) is %d cells wide", tt.in, tt.width, w)
+			}
+		})
+	}
+}
+
+func TestWrapTextWideCharacters(t *testing.T) {
+	for _, line := range wrapText("日本語のタイトルはとても長いです", 10) {
+		if w := lipgloss.Width(line); w > 10 {
+			t.Errorf("wrapped line %q is %d cells wide, want at most 10", line, w)
+		}
+	}
+}
+
+func TestTaskRowsAlignWithWideTitles(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, nil)
+	f.SetColumns(ColumnLayout{
+		Columns: []string{ColumnID, ColumnTitle, ColumnContext},
+		Widths:  map[string]int{ColumnTitle: 12},
+	})
+	ctx := "deep"
+	f.TaskList([]task.Task{
+		{ID: 1, Title: "plain title", Context: &ctx},
+		{ID: 2, Title: "日本語のタイトル", Context: &ctx},
+		{ID: 3, Title: "🎉 party", Context: &ctx},
+	})
+
+	var columns []int
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		i := strings.Index(line, "@deep")
+		if i < 0 {
+			t.Fatalf("row %q has no context", line)
+		}
+		columns = append(columns, lipgloss.Width(line[:i]))
+	}
+	for i, c := range columns {
+		if c != columns[0] {
+			t.Errorf("row %d: context starts at cell %d, want %d\n%s", i+1, c, columns[0], buf.String())
+		}
+	}
+}
@@ -2,8 +2,10 @@ package recurparse
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -11,10 +13,35 @@ import (
 
 // Rule represents a parsed recurrence rule.
 type Rule struct {
-	Interval int      `json:"interval"`          // e.g., 1, 2, 3
-	Unit     string   `json:"unit"`              // "day", "week", "month", "year"
-	Weekdays []string `json:"weekdays,omitempty"` // e.g., ["mon", "wed", "fri"]
-	Day      int      `json:"day,omitempty"`      // day of month (1-31)
+	Interval int       `json:"interval"`           // e.g., 1, 2, 3
+	Unit     string    `json:"unit"`               // "day", "week", "month", "year"
+	Weekdays []string  `json:"weekdays,omitempty"` // e.g., ["mon", "wed", "fri"]
+	Day      int       `json:"day,omitempty"`      // day of month (1-31)
+	Rotate   *Rotation `json:"rotate,omitempty"`   // who each new occurrence goes to
+}
+
+// Rotation fields of a rule
+const (
+	RotateTag      = "tag"
+	RotateAssignee = "assignee"
+)
+
+// Rotation hands each new occurrence of a recurring task to the next of a
+// list of people, e.g. for household chores, by tag or by assignee.
+type Rotation struct {
+	Field  string   `json:"field"`  // RotateTag or RotateAssignee
+	Values []string `json:"values"` // in turn order
+}
+
+// Next returns the value that follows current, the first one if current
+// isn't in the rotation.
+func (r *Rotation) Next(current string) string {
+	for i, v := range r.Values {
+		if v == current {
+			return r.Values[(i+1)%len(r.Values)]
+		}
+	}
+	return r.Values[0]
 }
 
 // Type indicates whether recurrence is fixed (schedule-based) or relative (from completion).
@@ -27,8 +54,9 @@ const (
 
 // ParseResult contains the parsed rule and its type.
 type ParseResult struct {
-	Rule *Rule
-	Type Type
+	Rule  *Rule
+	Type  Type
+	Count int // number of occurrences from a "for N times" suffix (0 = no limit)
 }
 
 // Parse parses a natural language recurrence string.
@@ -38,11 +66,125 @@ type ParseResult struct {
 //   - daily, weekly, monthly, yearly, biweekly
 //   - every N days/weeks/months/years
 //   - every monday, every mon,wed,fri
+//   - every weekday, every weekend
+//   - every 2 weeks on monday, every 3 weeks on tue,thu
 //   - every 1st, every 15th (day of month)
 //   - 3d after done, 2w after done (relative)
+//
+// Any of these may be followed by "rotate anna,ben,cara" to tag each new
+// occurrence with the next name in turn, or "rotate assignee anna,ben" to
+// assign it, and end in "for N times" (or "N times", "for N occurrences") to
+// limit the number of occurrences.
 func Parse(s string) (*ParseResult, error) {
-	s = strings.TrimSpace(strings.ToLower(s))
+	rotate, s, err := parseRotation(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+	s = strings.ToLower(s)
+
+	count, s, err := parseCount(s)
+	if err != nil {
+		return nil, err
+	}
+	result, err := parseRule(s)
+	if err != nil {
+		return nil, err
+	}
+	result.Count = count
+	result.Rule.Rotate = rotate
+	if err := Validate(result.Rule); err != nil {
+		return nil, fmt.Errorf("cannot parse recurrence: %s (%w)", s, err)
+	}
+	return result, nil
+}
+
+// MaxInterval caps the interval of a rule, e.g. every 999 days
+const MaxInterval = 999
+
+// Validate checks that a rule can be scheduled: a known unit, an interval
+// from 1 to MaxInterval, weekdays only for weekly rules and a day of month
+// only for monthly ones, and a rotation through at least 2 names. Parse
+// and FromJSON validate the rules they return, so a typo or a hand-edited
+// rule is rejected instead of recurring on the wrong day.
+func Validate(r *Rule) error {
+	if r == nil {
+		return errors.New("no rule")
+	}
+	switch r.Unit {
+	case "day", "week", "month", "year":
+	default:
+		return fmt.Errorf("unknown unit %q", r.Unit)
+	}
+	if r.Interval < 1 || r.Interval > MaxInterval {
+		return fmt.Errorf("interval must be from 1 to %d, not %d", MaxInterval, r.Interval)
+	}
+	if len(r.Weekdays) > 0 && r.Unit != "week" {
+		return fmt.Errorf("weekdays only go with weekly rules, not %s", r.Unit)
+	}
+	for _, wd := range r.Weekdays {
+		if _, ok := weekdayMap[wd]; !ok {
+			return fmt.Errorf("unknown weekday %q", wd)
+		}
+	}
+	if r.Day != 0 && (r.Unit != "month" || r.Day < 1 || r.Day > 31) {
+		return fmt.Errorf("day of month %d needs a monthly rule and a day from 1 to 31", r.Day)
+	}
+	if r.Rotate != nil {
+		if r.Rotate.Field != RotateTag && r.Rotate.Field != RotateAssignee {
+			return fmt.Errorf("unknown rotation field %q", r.Rotate.Field)
+		}
+		if len(r.Rotate.Values) < 2 {
+			return errors.New("a rotation needs at least 2 names")
+		}
+	}
+	return nil
+}
+
+// rotationPart matches "rotate anna,ben" and "rotate assignee anna, ben"
+// after a pattern. Names keep their case.
+var rotationPart = regexp.MustCompile(`(?i)\s+rotate\s+(?:(tag|assignee)\s+)?([^\s,]+(?:\s*,\s*[^\s,]+)*)`)
+
+// parseRotation strips a rotation from s, returning it (nil if absent) and
+// the rest of the pattern.
+func parseRotation(s string) (*Rotation, string, error) {
+	matches := rotationPart.FindStringSubmatchIndex(s)
+	if matches == nil {
+		return nil, s, nil
+	}
+	r := &Rotation{Field: RotateTag}
+	if matches[2] >= 0 {
+		r.Field = strings.ToLower(s[matches[2]:matches[3]])
+	}
+	for _, v := range strings.Split(s[matches[4]:matches[5]], ",") {
+		if v = strings.TrimSpace(v); !slices.Contains(r.Values, v) {
+			r.Values = append(r.Values, v)
+		}
+	}
+	if len(r.Values) < 2 {
+		return nil, s, fmt.Errorf("cannot parse recurrence: %s (a rotation needs at least 2 names)", s)
+	}
+	return r, s[:matches[0]] + s[matches[1]:], nil
+}
+
+// countSuffix matches "for 10 times", "10 times", "for 3 occurrences"
+var countSuffix = regexp.MustCompile(`\s+(?:for\s+)?(\d+)\s+(?:times|occurrences)$`)
+
+// parseCount strips a "for N times" suffix from s, returning N (0 if absent)
+// and the rest of the pattern.
+func parseCount(s string) (int, string, error) {
+	matches := countSuffix.FindStringSubmatchIndex(s)
+	if matches == nil {
+		return 0, s, nil
+	}
+	n, _ := strconv.Atoi(s[matches[2]:matches[3]])
+	if n < 1 {
+		return 0, s, fmt.Errorf("cannot parse recurrence: %s (need at least 1 occurrence)", s)
+	}
+	return n, strings.TrimSpace(s[:matches[0]]), nil
+}
 
+// parseRule parses a recurrence pattern without its count suffix.
+func parseRule(s string) (*ParseResult, error) {
 	// Check for relative pattern: "Nd after done" or "Nw after done"
 	if result, ok := parseRelative(s); ok {
 		return result, nil
@@ -57,6 +199,10 @@ func Parse(s string) (*ParseResult, error) {
 		return result, nil
 	}
 
+	if result, ok := parseEveryWeeksOn(s); ok {
+		return result, nil
+	}
+
 	if result, ok := parseEveryWeekday(s); ok {
 		return result, nil
 	}
@@ -77,17 +223,33 @@ func (r *Rule) ToJSON() (string, error) {
 	return string(b), nil
 }
 
-// FromJSON parses a JSON string into a Rule.
+// FromJSON parses a JSON string into a Rule, and validates it.
 func FromJSON(s string) (*Rule, error) {
 	var r Rule
 	if err := json.Unmarshal([]byte(s), &r); err != nil {
 		return nil, err
 	}
+	if err := Validate(&r); err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %w", err)
+	}
 	return &r, nil
 }
 
 // Format returns a human-readable string for the rule.
 func (r *Rule) Format() string {
+	s := r.formatSchedule()
+	if r.Rotate != nil {
+		s += " rotate "
+		if r.Rotate.Field == RotateAssignee {
+			s += RotateAssignee + " "
+		}
+		s += strings.Join(r.Rotate.Values, ",")
+	}
+	return s
+}
+
+// formatSchedule formats when the rule recurs
+func (r *Rule) formatSchedule() string {
 	switch r.Unit {
 	case "day":
 		if r.Interval == 1 {
@@ -96,7 +258,10 @@ func (r *Rule) Format() string {
 		return fmt.Sprintf("every %d days", r.Interval)
 	case "week":
 		if len(r.Weekdays) > 0 {
-			return "every " + strings.Join(r.Weekdays, ",")
+			if r.Interval > 1 {
+				return fmt.Sprintf("every %d weeks on %s", r.Interval, formatWeekdays(r.Weekdays, true))
+			}
+			return "every " + formatWeekdays(r.Weekdays, false)
 		}
 		if r.Interval == 1 {
 			return "weekly"
@@ -201,34 +366,111 @@ func parseEveryInterval(s string) (*ParseResult, bool) {
 	return nil, false
 }
 
-// parseEveryWeekday handles "every monday", "every mon,wed,fri"
+// parseEveryWeekday handles "every monday", "every mon,wed,fri", "every weekday"
 func parseEveryWeekday(s string) (*ParseResult, bool) {
 	if !strings.HasPrefix(s, "every ") {
 		return nil, false
 	}
-	rest := strings.TrimPrefix(s, "every ")
 
-	// Check if it's a comma-separated list of weekdays
-	parts := strings.Split(rest, ",")
-	var weekdays []string
+	weekdays := parseWeekdayList(strings.TrimPrefix(s, "every "))
+	if weekdays == nil {
+		return nil, false
+	}
+	return &ParseResult{
+		Rule: &Rule{Interval: 1, Unit: "week", Weekdays: weekdays},
+		Type: TypeFixed,
+	}, true
+}
+
+// parseEveryWeeksOn handles "every 2 weeks on monday", "every week on tue,thu"
+func parseEveryWeeksOn(s string) (*ParseResult, bool) {
+	re := regexp.MustCompile(`^every\s+(?:(\d+)\s+weeks?|week)\s+on\s+(.+)$`)
+	matches := re.FindStringSubmatch(s)
+	if matches == nil {
+		return nil, false
+	}
 
-	for _, p := range parts {
+	n := 1
+	if matches[1] != "" {
+		n, _ = strconv.Atoi(matches[1])
+	}
+	weekdays := parseWeekdayList(matches[2])
+	if n < 1 || weekdays == nil {
+		return nil, false
+	}
+	return &ParseResult{
+		Rule: &Rule{Interval: n, Unit: "week", Weekdays: weekdays},
+		Type: TypeFixed,
+	}, true
+}
+
+// parseWeekdayList parses a comma-separated list of weekdays, where
+// "weekday(s)" and "weekend(s)" stand for Monday-Friday and Saturday-Sunday.
+// Repeated days are dropped. Returns nil if any entry isn't a weekday.
+func parseWeekdayList(s string) []string {
+	var weekdays []string
+	add := func(days ...string) {
+		for _, wd := range days {
+			if !slices.Contains(weekdays, wd) {
+				weekdays = append(weekdays, wd)
+			}
+		}
+	}
+	for _, p := range strings.Split(s, ",") {
 		p = strings.TrimSpace(p)
-		if wd := normalizeWeekday(p); wd != "" {
-			weekdays = append(weekdays, wd)
-		} else {
-			return nil, false
+		switch p {
+		case "weekday", "weekdays":
+			add(workWeek...)
+			continue
+		case "weekend", "weekends":
+			add(weekend...)
+			continue
+		}
+		wd := normalizeWeekday(p)
+		if wd == "" {
+			return nil
 		}
+		add(wd)
 	}
+	return weekdays
+}
 
-	if len(weekdays) > 0 {
-		return &ParseResult{
-			Rule: &Rule{Interval: 1, Unit: "week", Weekdays: weekdays},
-			Type: TypeFixed,
-		}, true
+var (
+	workWeek = []string{"mon", "tue", "wed", "thu", "fri"}
+	weekend  = []string{"sat", "sun"}
+)
+
+// formatWeekdays joins weekdays for display, naming the work week and the
+// weekend. plural selects "weekdays" over "weekday" to follow "on".
+func formatWeekdays(weekdays []string, plural bool) string {
+	name := ""
+	switch {
+	case sameWeekdays(weekdays, workWeek):
+		name = "weekday"
+	case sameWeekdays(weekdays, weekend):
+		name = "weekend"
+	default:
+		return strings.Join(weekdays, ",")
+	}
+	if plural {
+		name += "s"
 	}
+	return name
+}
 
-	return nil, false
+// sameWeekdays reports whether a and b hold the same set of weekdays
+func sameWeekdays(a, b []string) bool {
+	for _, wd := range a {
+		if !slices.Contains(b, wd) {
+			return false
+		}
+	}
+	for _, wd := range b {
+		if !slices.Contains(a, wd) {
+			return false
+		}
+	}
+	return true
 }
 
 // parseEveryDayOfMonth handles "every 1st", "every 15th"
@@ -302,12 +544,11 @@ func ordinal(n int) string {
 }
 
 // NextOccurrence calculates the next occurrence date based on the rule.
-// For fixed rules, it calculates from today.
+// For fixed rules, fromDate should be today.
 // For relative rules, fromDate should be the completion date.
 func NextOccurrence(rule *Rule, recurrenceType Type, fromDate time.Time) time.Time {
 	// Normalize to start of day
 	from := time.Date(fromDate.Year(), fromDate.Month(), fromDate.Day(), 0, 0, 0, 0, fromDate.Location())
-	today := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, fromDate.Location())
 
 	switch recurrenceType {
 	case TypeRelative:
@@ -316,19 +557,57 @@ func NextOccurrence(rule *Rule, recurrenceType Type, fromDate time.Time) time.Ti
 
 	case TypeFixed:
 		// For fixed, find the next valid occurrence after today
-		if len(rule.Weekdays) > 0 {
-			return nextWeekdayOccurrence(today, rule.Weekdays)
-		}
-		if rule.Day > 0 {
-			return nextDayOfMonth(today, rule.Day)
-		}
-		// Simple interval: find next occurrence after today
-		return addInterval(today, rule)
+		return nextFixed(rule, from)
 	}
 
 	return addInterval(from, rule)
 }
 
+// NextAfter calculates the occurrence of a fixed rule that follows date, for
+// scheduling occurrences ahead of time one after another.
+func NextAfter(rule *Rule, date time.Time) time.Time {
+	return nextFixed(rule, time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()))
+}
+
+// nextFixed finds the next valid occurrence of a fixed rule after from.
+func nextFixed(rule *Rule, from time.Time) time.Time {
+	if len(rule.Weekdays) > 0 {
+		if rule.Interval > 1 {
+			return nextWeekdayInterval(from, rule.Weekdays, rule.Interval)
+		}
+		return nextWeekdayOccurrence(from, rule.Weekdays)
+	}
+	if rule.Day > 0 {
+		return nextDayOfMonth(from, rule.Day)
+	}
+	// Simple interval: find next occurrence after from
+	return addInterval(from, rule)
+}
+
+// maxBlackoutSteps bounds how far Blackout.Apply moves a date, in case every
+// candidate date is blocked.
+const maxBlackoutSteps = 366
+
+// Blackout keeps occurrences off dates such as public holidays.
+type Blackout struct {
+	Dates map[string]bool // blocked dates as YYYY-MM-DD
+	Shift bool            // move to the next free day instead of skipping the occurrence
+}
+
+// Apply moves date off blocked dates. Fixed rules skip to their next
+// occurrence that isn't blocked, or with Shift set, to the next free day.
+// Relative rules always move to the next free day.
+func (b Blackout) Apply(rule *Rule, recurrenceType Type, date time.Time) time.Time {
+	for i := 0; i < maxBlackoutSteps && b.Dates[date.Format("2006-01-02")]; i++ {
+		if b.Shift || recurrenceType == TypeRelative {
+			date = date.AddDate(0, 0, 1)
+		} else {
+			date = NextAfter(rule, date)
+		}
+	}
+	return date
+}
+
 // addInterval adds the rule's interval to a date.
 func addInterval(from time.Time, rule *Rule) time.Time {
 	switch rule.Unit {
@@ -344,14 +623,15 @@ func addInterval(from time.Time, rule *Rule) time.Time {
 	return from
 }
 
+// weekdayMap maps short weekday names to time.Weekday.
+var weekdayMap = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday,
+	"wed": time.Wednesday, "thu": time.Thursday, "fri": time.Friday,
+	"sat": time.Saturday,
+}
+
 // nextWeekdayOccurrence finds the next occurrence of any of the given weekdays.
 func nextWeekdayOccurrence(from time.Time, weekdays []string) time.Time {
-	weekdayMap := map[string]time.Weekday{
-		"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday,
-		"wed": time.Wednesday, "thu": time.Thursday, "fri": time.Friday,
-		"sat": time.Saturday,
-	}
-
 	// Find the nearest upcoming weekday
 	minDays := 8
 	for _, wd := range weekdays {
@@ -368,6 +648,36 @@ func nextWeekdayOccurrence(from time.Time, weekdays []string) time.Time {
 	return from.AddDate(0, 0, minDays)
 }
 
+// nextWeekdayInterval finds the next of the given weekdays for rules like
+// "every 2 weeks on monday": a later matching day in from's week, or else the
+// first matching day interval weeks on. Weeks start on Monday.
+func nextWeekdayInterval(from time.Time, weekdays []string, interval int) time.Time {
+	matches := func(d time.Time) bool {
+		for _, wd := range weekdays {
+			if weekdayMap[wd] == d.Weekday() {
+				return true
+			}
+		}
+		return false
+	}
+
+	sinceMonday := (int(from.Weekday()) + 6) % 7
+	monday := from.AddDate(0, 0, -sinceMonday)
+	for d := sinceMonday + 1; d < 7; d++ {
+		if day := monday.AddDate(0, 0, d); matches(day) {
+			return day
+		}
+	}
+
+	next := monday.AddDate(0, 0, 7*interval)
+	for d := 0; d < 7; d++ {
+		if day := next.AddDate(0, 0, d); matches(day) {
+			return day
+		}
+	}
+	return next
+}
+
 // nextDayOfMonth finds the next occurrence of a specific day of month.
 func nextDayOfMonth(from time.Time, day int) time.Time {
 	// If we're before that day this month, use this month
@@ -379,8 +689,9 @@ func nextDayOfMonth(from time.Time, day int) time.Time {
 		}
 	}
 
-	// Otherwise, try next month
-	next := from.AddDate(0, 1, 0)
+	// Otherwise, try next month. Counting from its first day, since adding
+	// a month to the 31st may skip a shorter month altogether.
+	next := time.Date(from.Year(), from.Month()+1, 1, 0, 0, 0, 0, from.Location())
 	result := time.Date(next.Year(), next.Month(), day, 0, 0, 0, 0, from.Location())
 
 	// If day overflows (e.g., 31st in a 30-day month), use last day of month
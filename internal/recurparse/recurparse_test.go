@@ -1,6 +1,8 @@
 package recurparse
 
 import (
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -81,6 +83,8 @@ func TestParseEveryWeekday(t *testing.T) {
 		{"every mon", []string{"mon"}},
 		{"every mon,wed,fri", []string{"mon", "wed", "fri"}},
 		{"every tuesday", []string{"tue"}},
+		{"every weekday", []string{"mon", "tue", "wed", "thu", "fri"}},
+		{"every weekend", []string{"sat", "sun"}},
 	}
 
 	for _, tt := range tests {
@@ -99,6 +103,43 @@ func TestParseEveryWeekday(t *testing.T) {
 	}
 }
 
+func TestParseEveryWeeksOn(t *testing.T) {
+	tests := []struct {
+		input        string
+		wantInterval int
+		wantWeekdays []string
+	}{
+		{"every 2 weeks on monday", 2, []string{"mon"}},
+		{"every 3 weeks on tue,thu", 3, []string{"tue", "thu"}},
+		{"every week on friday", 1, []string{"fri"}},
+		{"every 2 weeks on weekends", 2, []string{"sat", "sun"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			if result.Type != TypeFixed {
+				t.Errorf("Type = %v, want fixed", result.Type)
+			}
+			if result.Rule.Interval != tt.wantInterval {
+				t.Errorf("Interval = %d, want %d", result.Rule.Interval, tt.wantInterval)
+			}
+			if strings.Join(result.Rule.Weekdays, ",") != strings.Join(tt.wantWeekdays, ",") {
+				t.Errorf("Weekdays = %v, want %v", result.Rule.Weekdays, tt.wantWeekdays)
+			}
+		})
+	}
+
+	for _, input := range []string{"every 0 weeks on monday", "every 2 weeks on someday"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q) should fail", input)
+		}
+	}
+}
+
 func TestParseEveryDayOfMonth(t *testing.T) {
 	tests := []struct {
 		input   string
@@ -161,6 +202,83 @@ func TestParseRelative(t *testing.T) {
 	}
 }
 
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantCount int
+		wantRule  string
+	}{
+		{"weekly for 10 times", 10, "weekly"},
+		{"every monday 3 times", 3, "every mon"},
+		{"every 2 weeks on friday for 4 occurrences", 4, "every 2 weeks on fri"},
+		{"3d after done for 5 times", 5, "every 3 days"},
+		{"daily", 0, "daily"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			if result.Count != tt.wantCount {
+				t.Errorf("Count = %d, want %d", result.Count, tt.wantCount)
+			}
+			if got := result.Rule.Format(); got != tt.wantRule {
+				t.Errorf("Rule.Format() = %q, want %q", got, tt.wantRule)
+			}
+		})
+	}
+
+	if _, err := Parse("weekly for 0 times"); err == nil {
+		t.Error("Parse(\"weekly for 0 times\") should fail")
+	}
+}
+
+func TestParseRotation(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantField  string
+		wantValues []string
+		wantRule   string
+		wantCount  int
+	}{
+		{"weekly rotate Anna,Ben,Cara", RotateTag, []string{"Anna", "Ben", "Cara"}, "weekly rotate Anna,Ben,Cara", 0},
+		{"every saturday rotate assignee anna, ben for 6 times", RotateAssignee, []string{"anna", "ben"}, "every sat rotate assignee anna,ben", 6},
+		{"3d after done ROTATE TAG anna,ben", RotateTag, []string{"anna", "ben"}, "every 3 days rotate anna,ben", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			r := result.Rule.Rotate
+			if r == nil || r.Field != tt.wantField || !slices.Equal(r.Values, tt.wantValues) {
+				t.Fatalf("Rotate = %+v, want %s %v", r, tt.wantField, tt.wantValues)
+			}
+			if result.Count != tt.wantCount {
+				t.Errorf("Count = %d, want %d", result.Count, tt.wantCount)
+			}
+			if got := result.Rule.Format(); got != tt.wantRule {
+				t.Errorf("Rule.Format() = %q, want %q", got, tt.wantRule)
+			}
+		})
+	}
+
+	if _, err := Parse("weekly rotate anna"); err == nil {
+		t.Error("Parse(\"weekly rotate anna\") should fail")
+	}
+
+	r := &Rotation{Field: RotateTag, Values: []string{"anna", "ben", "cara"}}
+	for current, want := range map[string]string{"anna": "ben", "cara": "anna", "": "anna", "dave": "anna"} {
+		if got := r.Next(current); got != want {
+			t.Errorf("Next(%q) = %q, want %q", current, got, want)
+		}
+	}
+}
+
 func TestParseInvalid(t *testing.T) {
 	invalids := []string{
 		"",
@@ -168,6 +286,10 @@ func TestParseInvalid(t *testing.T) {
 		"every",
 		"every foo",
 		"sometimes",
+		"every 0 days",
+		"0d after done",
+		"every 1000 weeks",
+		"every 99999999999999999999 days",
 	}
 
 	for _, input := range invalids {
@@ -218,6 +340,10 @@ func TestRuleFormat(t *testing.T) {
 		{&Rule{Interval: 1, Unit: "month", Day: 15}, "every 15th"},
 		{&Rule{Interval: 1, Unit: "year"}, "yearly"},
 		{&Rule{Interval: 1, Unit: "week", Weekdays: []string{"mon", "wed"}}, "every mon,wed"},
+		{&Rule{Interval: 1, Unit: "week", Weekdays: []string{"mon", "tue", "wed", "thu", "fri"}}, "every weekday"},
+		{&Rule{Interval: 1, Unit: "week", Weekdays: []string{"sat", "sun"}}, "every weekend"},
+		{&Rule{Interval: 2, Unit: "week", Weekdays: []string{"mon"}}, "every 2 weeks on mon"},
+		{&Rule{Interval: 2, Unit: "week", Weekdays: []string{"sat", "sun"}}, "every 2 weeks on weekends"},
 	}
 
 	for _, tt := range tests {
@@ -283,3 +409,182 @@ func TestNextOccurrenceRelative(t *testing.T) {
 		})
 	}
 }
+
+func TestNextAfter(t *testing.T) {
+	// Wednesday 2025-01-15
+	date := time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rule *Rule
+		want string
+	}{
+		{"weekly", &Rule{Interval: 1, Unit: "week"}, "2025-01-22"},
+		{"every monday", &Rule{Interval: 1, Unit: "week", Weekdays: []string{"mon"}}, "2025-01-20"},
+		{"every wednesday", &Rule{Interval: 1, Unit: "week", Weekdays: []string{"wed"}}, "2025-01-22"},
+		{"15th of month", &Rule{Interval: 1, Unit: "month", Day: 15}, "2025-02-15"},
+		{"31st of month", &Rule{Interval: 1, Unit: "month", Day: 31}, "2025-01-31"},
+		{"every weekday", &Rule{Interval: 1, Unit: "week", Weekdays: []string{"mon", "tue", "wed", "thu", "fri"}}, "2025-01-16"},
+		{"every weekend", &Rule{Interval: 1, Unit: "week", Weekdays: []string{"sat", "sun"}}, "2025-01-18"},
+		{"every 2 weeks on monday", &Rule{Interval: 2, Unit: "week", Weekdays: []string{"mon"}}, "2025-01-27"},
+		{"every 2 weeks on wed,fri", &Rule{Interval: 2, Unit: "week", Weekdays: []string{"wed", "fri"}}, "2025-01-17"},
+		{"every 2 weeks on wednesday", &Rule{Interval: 2, Unit: "week", Weekdays: []string{"wed"}}, "2025-01-29"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextAfter(tt.rule, date).Format("2006-01-02"); got != tt.want {
+				t.Errorf("NextAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlackoutApply(t *testing.T) {
+	monday := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	everyMonday := &Rule{Interval: 1, Unit: "week", Weekdays: []string{"mon"}}
+	blocked := map[string]bool{"2025-01-20": true, "2025-01-21": true}
+
+	tests := []struct {
+		name     string
+		blackout Blackout
+		typ      Type
+		want     string
+	}{
+		{"free date", Blackout{}, TypeFixed, "2025-01-20"},
+		{"skip to next occurrence", Blackout{Dates: blocked}, TypeFixed, "2025-01-27"},
+		{"shift to next free day", Blackout{Dates: blocked, Shift: true}, TypeFixed, "2025-01-22"},
+		{"relative always shifts", Blackout{Dates: blocked}, TypeRelative, "2025-01-22"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.blackout.Apply(everyMonday, tt.typ, monday).Format("2006-01-02"); got != tt.want {
+				t.Errorf("Apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []*Rule{
+		{Interval: 1, Unit: "day"},
+		{Interval: 2, Unit: "week", Weekdays: []string{"mon", "thu"}},
+		{Interval: 1, Unit: "month", Day: 31},
+		{Interval: 1, Unit: "week", Rotate: &Rotation{Field: RotateAssignee, Values: []string{"anna", "ben"}}},
+	}
+	for _, r := range valid {
+		if err := Validate(r); err != nil {
+			t.Errorf("Validate(%+v) error = %v", r, err)
+		}
+	}
+
+	invalid := []*Rule{
+		nil,
+		{Interval: 0, Unit: "day"},
+		{Interval: MaxInterval + 1, Unit: "day"},
+		{Interval: 1, Unit: "fortnight"},
+		{Interval: 1, Unit: "day", Weekdays: []string{"mon"}},
+		{Interval: 1, Unit: "week", Weekdays: []string{"funday"}},
+		{Interval: 1, Unit: "week", Day: 3},
+		{Interval: 1, Unit: "month", Day: 32},
+		{Interval: 1, Unit: "day", Rotate: &Rotation{Field: RotateTag, Values: []string{"anna"}}},
+		{Interval: 1, Unit: "day", Rotate: &Rotation{Field: "color", Values: []string{"red", "blue"}}},
+	}
+	for _, r := range invalid {
+		if err := Validate(r); err == nil {
+			t.Errorf("Validate(%+v) should error", r)
+		}
+	}
+
+	if _, err := FromJSON(`{"interval":0,"unit":"day"}`); err == nil {
+		t.Error("FromJSON() should reject an invalid rule")
+	}
+}
+
+func TestNextDayOfMonthSkipsNoMonth(t *testing.T) {
+	// Every day of two years, for every day of the month: the next
+	// occurrence is in this month or the next, on that day or the last day
+	// of a shorter month
+	for day := 1; day <= 31; day++ {
+		rule := &Rule{Interval: 1, Unit: "month", Day: day}
+		for from := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC); from.Year() < 2029; from = from.AddDate(0, 0, 1) {
+			next := NextOccurrence(rule, TypeFixed, from)
+			if !next.After(from) {
+				t.Fatalf("every %s from %s: %s isn't after it", ordinal(day), from.Format("2006-01-02"), next.Format("2006-01-02"))
+			}
+			if months := int(next.Month()-from.Month()+12) % 12; months > 1 {
+				t.Fatalf("every %s from %s: %s skips a month", ordinal(day), from.Format("2006-01-02"), next.Format("2006-01-02"))
+			}
+			last := time.Date(next.Year(), next.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+			if next.Day() != min(day, last) {
+				t.Fatalf("every %s from %s: got %s", ordinal(day), from.Format("2006-01-02"), next.Format("2006-01-02"))
+			}
+		}
+	}
+}
+
+func TestParseDropsRepeatedWeekdays(t *testing.T) {
+	result, err := Parse("every weekday,mon,weekdays")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(result.Rule.Weekdays, workWeek) {
+		t.Errorf("Weekdays = %v, want %v", result.Rule.Weekdays, workWeek)
+	}
+}
+
+// FuzzParse checks properties of every pattern Parse accepts: the rule is
+// valid, formats to a pattern parsing to the same rule, and always recurs
+// strictly after the date it's scheduled from
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"daily", "weekly", "monthly", "yearly", "biweekly",
+		"every 3 days", "every 2 weeks on tue,thu", "every weekday", "every weekend",
+		"every mon,wed,fri", "every 31st", "every 1st for 3 times",
+		"3d after done", "2 weeks after completion", "after 1 month",
+		"every week rotate anna,ben", "weekly rotate assignee Anna, Ben for 4 times",
+		"every 0 days", "every 999 years", "every 2 weeks on weekday,weekend",
+	} {
+		f.Add(seed)
+	}
+	from := []time.Time{
+		time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 12, 31, 18, 30, 0, 0, time.UTC),
+		time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), // a Sunday
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		result, err := Parse(s)
+		if err != nil {
+			return
+		}
+		if err := Validate(result.Rule); err != nil {
+			t.Fatalf("Parse(%q) returned an invalid rule: %v", s, err)
+		}
+
+		formatted := result.Rule.Format()
+		again, err := Parse(formatted)
+		if err != nil {
+			t.Fatalf("Parse(%q) = %q, which doesn't parse: %v", s, formatted, err)
+		}
+		a, _ := result.Rule.ToJSON()
+		b, _ := again.Rule.ToJSON()
+		if a != b {
+			t.Fatalf("Parse(%q) = %s, but Parse(%q) = %s", s, a, formatted, b)
+		}
+
+		for _, d := range from {
+			day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
+			if next := NextOccurrence(result.Rule, result.Type, d); !next.After(day) {
+				t.Fatalf("%q from %s: next occurrence %s isn't after it", s, d.Format("2006-01-02"), next.Format("2006-01-02"))
+			}
+			if result.Type == TypeFixed {
+				if next := NextAfter(result.Rule, d); !next.After(day) {
+					t.Fatalf("%q after %s: %s isn't after it", s, d.Format("2006-01-02"), next.Format("2006-01-02"))
+				}
+			}
+		}
+	})
+}
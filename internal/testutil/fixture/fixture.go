@@ -0,0 +1,48 @@
+// Package fixture provides a fixed moment and a seeded set of areas and
+// tasks around it, for rendering tests with stable output.
+package fixture
+
+import (
+	"time"
+
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/area"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// Now is the moment fixtures are built around: Wednesday, March 11, 2026
+var Now = time.Date(2026, time.March, 11, 9, 30, 0, 0, time.Local)
+
+// Clock is stopped at Now
+var Clock = clock.Fixed(Now)
+
+// Areas returns the areas the tasks refer to
+func Areas() []area.Area {
+	return []area.Area{{ID: 1, Name: "Home"}, {ID: 2, Name: "Work"}}
+}
+
+// Tasks returns open tasks covering the states rendering distinguishes:
+// overdue, due and planned today, future dates, no dates, a project with
+// tasks, recurrence, estimates, contexts, tags and someday
+func Tasks() []task.Task {
+	day := func(offset int) *time.Time {
+		d := time.Date(Now.Year(), Now.Month(), Now.Day()+offset, 0, 0, 0, 0, time.Local)
+		return &d
+	}
+	str := func(s string) *string { return &s }
+	num := func(n int) *int { return &n }
+	id := func(n int64) *int64 { return &n }
+
+	return []task.Task{
+		{ID: 1, Title: "Website relaunch", TaskType: task.TaskTypeProject, AreaID: id(2), AreaName: str("Work"), DueDate: day(9), State: task.StateActive},
+		{ID: 2, Title: "Send invoice", AreaID: id(2), AreaName: str("Work"), DueDate: day(-3), Tags: []string{"money"}, State: task.StateActive},
+		{ID: 3, Title: "Fix header layout", ParentID: id(1), ParentName: str("Website relaunch"), AreaName: str("Work"), PlannedDate: day(0), Estimate: num(45), Context: str("deep"), State: task.StateActive},
+		{ID: 4, Title: "Review copy", ParentID: id(1), ParentName: str("Website relaunch"), AreaName: str("Work"), DueDate: day(0), State: task.StateActive},
+		{ID: 5, Title: "Water plants", AreaID: id(1), AreaName: str("Home"), PlannedDate: day(1), RecurType: str(task.RecurTypeFixed), RecurRule: str(`{"interval":3,"unit":"day"}`), State: task.StateActive},
+		{ID: 6, Title: "Book dentist", AreaID: id(1), AreaName: str("Home"), PlannedDate: day(5), DueDate: day(12), State: task.StateActive},
+		{ID: 7, Title: "Call Sam about the long-promised weekend trip to the mountains", PlannedDate: day(40), Tags: []string{"phone", "friends"}, State: task.StateActive},
+		{ID: 8, Title: "Sort photos", AreaID: id(1), AreaName: str("Home"), State: task.StateActive},
+		{ID: 9, Title: "Read inbox zero", Description: str("- [x] chapter 1\n- [ ] chapter 2\nFrom the library"), State: task.StateActive},
+		{ID: 10, Title: "Learn the cello", AreaID: id(1), AreaName: str("Home"), State: task.StateSomeday},
+	}
+}
@@ -1,15 +1,21 @@
 package tui
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/dateparse"
 	"github.com/devbydaniel/tt/internal/domain/area"
 	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/recurparse"
 	"github.com/sahilm/fuzzy"
 )
 
@@ -23,19 +29,30 @@ const (
 	AddFieldPlanned
 	AddFieldDue
 	AddFieldTags
+	AddFieldRecur
+	AddFieldSomeday
+
+	addFieldCount = AddFieldSomeday + 1
 )
 
 // AddModal handles task creation with multiple fields
 type AddModal struct {
 	// Text inputs
 	titleInput textinput.Model
-	descInput  textinput.Model
+	descInput  textarea.Model // grows with its lines, see fitDescription
 	tagsInput  textinput.Model
 
 	// Date inputs
 	plannedInput textinput.Model
 	dueInput     textinput.Model
 
+	// Recurrence pattern, checked as it's typed
+	recurInput textinput.Model
+	recur      *recurparse.ParseResult
+	recurErr   error
+
+	someday bool
+
 	// Scope selector
 	scopeInput     textinput.Model
 	allScopes      []MoveItem
@@ -48,10 +65,15 @@ type AddModal struct {
 	active      bool
 	err         error
 
+	// What the form starts out with, from [defaults]
+	defaults config.TaskDefaults
+
 	// Styling and dimensions
-	styles *Styles
-	width  int
-	height int
+	styles   *Styles
+	clock    clock.Clock // what relative dates like "tomorrow" count from
+	schedule task.ScheduleSettings
+	width    int
+	height   int
 }
 
 // AddResult represents the outcome of the add modal
@@ -63,6 +85,10 @@ type AddResult struct {
 	PlannedDate *time.Time
 	DueDate     *time.Time
 	Tags        []string
+	Someday     bool
+	RecurType   *string
+	RecurRule   *string // JSON rule
+	RecurCount  *int
 	Canceled    bool
 }
 
@@ -72,9 +98,12 @@ func NewAddModal(styles *Styles) AddModal {
 	titleInput.Placeholder = "Task title (required)"
 	titleInput.CharLimit = 500
 
-	descInput := textinput.New()
+	descInput := textarea.New()
 	descInput.Placeholder = "Description (optional)"
 	descInput.CharLimit = 2000
+	descInput.ShowLineNumbers = false
+	descInput.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	descInput.SetHeight(1)
 
 	scopeInput := textinput.New()
 	scopeInput.Placeholder = "Type to filter projects/areas..."
@@ -92,6 +121,10 @@ func NewAddModal(styles *Styles) AddModal {
 	tagsInput.Placeholder = "tag1, tag2, tag3"
 	tagsInput.CharLimit = 200
 
+	recurInput := textinput.New()
+	recurInput.Placeholder = "daily, every monday, 3d after done..."
+	recurInput.CharLimit = 100
+
 	return AddModal{
 		titleInput:   titleInput,
 		descInput:    descInput,
@@ -99,10 +132,36 @@ func NewAddModal(styles *Styles) AddModal {
 		plannedInput: plannedInput,
 		dueInput:     dueInput,
 		tagsInput:    tagsInput,
+		recurInput:   recurInput,
 		styles:       styles,
+		clock:        clock.System,
 	}
 }
 
+// SetClock sets the clock relative dates are based on
+func (m AddModal) SetClock(clk clock.Clock) AddModal {
+	m.clock = clk
+	return m
+}
+
+// SetSchedule sets the schedule settings whose day rollover decides which
+// date "today" means
+func (m AddModal) SetSchedule(s task.ScheduleSettings) AddModal {
+	m.schedule = s
+	return m
+}
+
+// SetDefaults sets what the form is filled in with when it opens
+func (m AddModal) SetDefaults(d config.TaskDefaults) AddModal {
+	m.defaults = d
+	return m
+}
+
+// today returns the date relative dates count from, after the day rollover
+func (m AddModal) today() time.Time {
+	return m.schedule.Today(clock.Now(m.clock))
+}
+
 // buildScopes creates the list of selectable scopes
 func (m AddModal) buildScopes(projects []task.Task, areas []area.Area) []MoveItem {
 	items := []MoveItem{
@@ -134,7 +193,8 @@ func (m AddModal) buildScopes(projects []task.Task, areas []area.Area) []MoveIte
 	return items
 }
 
-// Open shows the modal with optional pre-filled scope from sidebar context
+// Open shows the modal with optional pre-filled scope from sidebar context,
+// and the configured defaults in the other fields
 func (m AddModal) Open(projects []task.Task, areas []area.Area, sidebarItem *SidebarItem) AddModal {
 	m.active = true
 	m.activeField = AddFieldTitle
@@ -143,10 +203,17 @@ func (m AddModal) Open(projects []task.Task, areas []area.Area, sidebarItem *Sid
 	// Reset all inputs
 	m.titleInput.SetValue("")
 	m.descInput.SetValue("")
+	m.descInput.SetHeight(1)
 	m.scopeInput.SetValue("")
 	m.plannedInput.SetValue("")
 	m.dueInput.SetValue("")
-	m.tagsInput.SetValue("")
+	m.tagsInput.SetValue(strings.Join(m.defaults.Tags, ", "))
+	m.recurInput.SetValue("")
+	m.recur, m.recurErr = nil, nil
+	m.someday = m.defaults.State == string(task.StateSomeday)
+	if !m.someday {
+		m.plannedInput.SetValue(m.defaults.Planned)
+	}
 
 	// Build scope list
 	m.allScopes = m.buildScopes(projects, areas)
@@ -154,7 +221,16 @@ func (m AddModal) Open(projects []task.Task, areas []area.Area, sidebarItem *Sid
 	m.scopeSelected = 0
 	m.selectedScope = nil
 
-	// Pre-fill scope based on sidebar context
+	// Pre-fill scope based on sidebar context, else the default project
+	if m.defaults.Project != "" {
+		for i, item := range m.allScopes {
+			if item.Type == "project" && item.Name == m.defaults.Project {
+				m.scopeSelected = i
+				m.selectedScope = &m.allScopes[i]
+				break
+			}
+		}
+	}
 	if sidebarItem != nil {
 		switch sidebarItem.Type {
 		case "project":
@@ -189,6 +265,7 @@ func (m AddModal) Close() AddModal {
 	m.plannedInput.Blur()
 	m.dueInput.Blur()
 	m.tagsInput.Blur()
+	m.recurInput.Blur()
 	return m
 }
 
@@ -198,11 +275,13 @@ func (m AddModal) SetSize(width, height int) AddModal {
 	m.height = height
 	inputWidth := 40
 	m.titleInput.Width = inputWidth
-	m.descInput.Width = inputWidth
+	m.descInput.SetWidth(inputWidth)
+	m = m.fitDescription()
 	m.scopeInput.Width = inputWidth
 	m.plannedInput.Width = inputWidth
 	m.dueInput.Width = inputWidth
 	m.tagsInput.Width = inputWidth
+	m.recurInput.Width = inputWidth
 	return m
 }
 
@@ -213,13 +292,13 @@ func (m AddModal) Active() bool {
 
 // nextField moves to the next field
 func (m AddModal) nextField() AddModal {
-	m.activeField = (m.activeField + 1) % 6
+	m.activeField = (m.activeField + 1) % addFieldCount
 	return m.updateFocus()
 }
 
 // prevField moves to the previous field
 func (m AddModal) prevField() AddModal {
-	m.activeField = (m.activeField - 1 + 6) % 6
+	m.activeField = (m.activeField - 1 + addFieldCount) % addFieldCount
 	return m.updateFocus()
 }
 
@@ -232,6 +311,7 @@ func (m AddModal) updateFocus() AddModal {
 	m.plannedInput.Blur()
 	m.dueInput.Blur()
 	m.tagsInput.Blur()
+	m.recurInput.Blur()
 
 	// Focus the active field
 	switch m.activeField {
@@ -247,7 +327,21 @@ func (m AddModal) updateFocus() AddModal {
 		m.dueInput.Focus()
 	case AddFieldTags:
 		m.tagsInput.Focus()
+	case AddFieldRecur:
+		m.recurInput.Focus()
+	}
+	return m.fitDescription()
+}
+
+// fitDescription sizes the description to its lines: up to what the modal
+// leaves room for while it's being edited, a few lines otherwise
+func (m AddModal) fitDescription() AddModal {
+	const otherRows = 16 // title, other fields, scope list and border
+	maxHeight := 3
+	if m.activeField == AddFieldDescription {
+		maxHeight = max(m.height-otherRows, 3)
 	}
+	m.descInput.SetHeight(min(max(m.descInput.LineCount(), 1), maxHeight))
 	return m
 }
 
@@ -297,7 +391,17 @@ func (m AddModal) Update(msg tea.Msg) (AddModal, *AddResult) {
 			m = m.prevField()
 			return m, nil
 
+		case tea.KeyCtrlS:
+			return m.trySubmit()
+
 		case tea.KeyEnter:
+			if msg.Alt {
+				return m.trySubmit()
+			}
+			if m.activeField == AddFieldDescription {
+				// Enter starts a new line in the description
+				break
+			}
 			if m.activeField == AddFieldScope {
 				// Select current scope and move to next field
 				if len(m.filteredScopes) > 0 && m.scopeSelected < len(m.filteredScopes) {
@@ -310,6 +414,12 @@ func (m AddModal) Update(msg tea.Msg) (AddModal, *AddResult) {
 			// Try to submit the form
 			return m.trySubmit()
 
+		case tea.KeySpace:
+			if m.activeField == AddFieldSomeday {
+				m.someday = !m.someday
+				return m, nil
+			}
+
 		case tea.KeyUp:
 			if m.activeField == AddFieldScope {
 				if m.scopeSelected > 0 {
@@ -342,6 +452,7 @@ func (m AddModal) updateActiveInput(msg tea.Msg) AddModal {
 		m.titleInput, cmd = m.titleInput.Update(msg)
 	case AddFieldDescription:
 		m.descInput, cmd = m.descInput.Update(msg)
+		m = m.fitDescription()
 	case AddFieldScope:
 		prevValue := m.scopeInput.Value()
 		m.scopeInput, cmd = m.scopeInput.Update(msg)
@@ -356,6 +467,12 @@ func (m AddModal) updateActiveInput(msg tea.Msg) AddModal {
 		m.dueInput, cmd = m.dueInput.Update(msg)
 	case AddFieldTags:
 		m.tagsInput, cmd = m.tagsInput.Update(msg)
+	case AddFieldRecur:
+		m.recurInput, cmd = m.recurInput.Update(msg)
+		m.recur, m.recurErr = nil, nil
+		if v := strings.TrimSpace(m.recurInput.Value()); v != "" {
+			m.recur, m.recurErr = recurparse.Parse(v)
+		}
 	}
 	_ = cmd
 
@@ -387,7 +504,7 @@ func (m AddModal) trySubmit() (AddModal, *AddResult) {
 
 	// Parse planned date
 	if v := strings.TrimSpace(m.plannedInput.Value()); v != "" {
-		parsed, err := dateparse.Parse(v)
+		parsed, err := dateparse.ParseFrom(v, m.today())
 		if err != nil {
 			m.err = errInvalidPlannedDate
 			return m, nil
@@ -397,7 +514,7 @@ func (m AddModal) trySubmit() (AddModal, *AddResult) {
 
 	// Parse due date
 	if v := strings.TrimSpace(m.dueInput.Value()); v != "" {
-		parsed, err := dateparse.Parse(v)
+		parsed, err := dateparse.ParseFrom(v, m.today())
 		if err != nil {
 			m.err = errInvalidDueDate
 			return m, nil
@@ -415,6 +532,27 @@ func (m AddModal) trySubmit() (AddModal, *AddResult) {
 		}
 	}
 
+	// Recurrence was parsed while typing
+	if m.recurErr != nil {
+		m.err = errInvalidRecurrence
+		return m, nil
+	}
+	if m.recur != nil {
+		ruleJSON, err := m.recur.Rule.ToJSON()
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		recurType := string(m.recur.Type)
+		result.RecurType = &recurType
+		result.RecurRule = &ruleJSON
+		if m.recur.Count > 0 {
+			count := m.recur.Count
+			result.RecurCount = &count
+		}
+	}
+	result.Someday = m.someday
+
 	m = m.Close()
 	return m, result
 }
@@ -435,6 +573,11 @@ func (m AddModal) View() string {
 		m.renderField("Planned", m.plannedInput.View(), AddFieldPlanned),
 		m.renderField("Due", m.dueInput.View(), AddFieldDue),
 		m.renderField("Tags", m.tagsInput.View(), AddFieldTags),
+		m.renderField("Repeat", m.recurInput.View(), AddFieldRecur),
+		m.renderSomedayField(),
+	}
+	if preview := m.recurPreview(); preview != "" {
+		fields = slices.Insert(fields, len(fields)-1, preview)
 	}
 
 	// Error display
@@ -466,7 +609,40 @@ func (m AddModal) renderField(label, input string, field AddModalField) string {
 	if m.activeField == field {
 		prefix = "> "
 	}
-	return prefix + label + ": " + input
+	// Multi-line inputs line up below their first line
+	return lipgloss.JoinHorizontal(lipgloss.Top, prefix+label+": ", input)
+}
+
+// recurPreview describes the recurrence as understood so far, or why it
+// can't be, below the Repeat field. Empty while the field is.
+func (m AddModal) recurPreview() string {
+	const indent = "          "
+	switch {
+	case m.recurErr != nil:
+		return indent + m.styles.Theme.Error.Render(m.recurErr.Error())
+	case m.recur == nil:
+		return ""
+	}
+	preview := "↻ " + m.recur.Rule.Format()
+	if m.recur.Type == recurparse.TypeRelative {
+		preview += " after done"
+	}
+	if m.recur.Count > 0 {
+		preview += fmt.Sprintf(", %d times", m.recur.Count)
+	}
+	return indent + m.styles.Theme.Muted.Render(preview)
+}
+
+// renderSomedayField renders the someday toggle
+func (m AddModal) renderSomedayField() string {
+	box := "[ ]"
+	if m.someday {
+		box = "[x]"
+	}
+	if m.activeField == AddFieldSomeday {
+		box += m.styles.Theme.Muted.Render("  space to toggle")
+	}
+	return m.renderField("Someday", box, AddFieldSomeday)
 }
 
 // renderScopeField renders the scope selector field
@@ -520,9 +696,10 @@ func (m AddModal) renderScopeList() string {
 
 // Error messages
 var (
-	errTitleRequired     = &addModalError{"Title is required"}
+	errTitleRequired      = &addModalError{"Title is required"}
 	errInvalidPlannedDate = &addModalError{"Invalid planned date"}
-	errInvalidDueDate    = &addModalError{"Invalid due date"}
+	errInvalidDueDate     = &addModalError{"Invalid due date"}
+	errInvalidRecurrence  = &addModalError{"Invalid recurrence"}
 )
 
 type addModalError struct {
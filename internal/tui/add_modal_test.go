@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/devbydaniel/tt/internal/output"
+)
+
+func TestAddModalRecurrenceAndSomeday(t *testing.T) {
+	m := NewAddModal(NewStyles(output.DefaultTheme(), nil)).SetSize(100, 40).Open(nil, nil, nil)
+	typeText := func(s string) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	}
+	tab := func(n int) {
+		for range n {
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+		}
+	}
+
+	typeText("Water plants")
+	tab(int(AddFieldRecur))
+	typeText("every fortnight")
+	if view := m.View(); !strings.Contains(view, "cannot parse recurrence") {
+		t.Errorf("View() doesn't flag an invalid pattern:\n%s", view)
+	}
+	if _, result := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); result != nil {
+		t.Fatal("submitted with an invalid recurrence")
+	}
+
+	m.recurInput.SetValue("")
+	typeText("every monday for 4 times")
+	if view := m.View(); !strings.Contains(view, "every mon, 4 times") {
+		t.Errorf("View() doesn't preview the pattern:\n%s", view)
+	}
+	tab(1)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+
+	_, result := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if result == nil {
+		t.Fatal("Update() didn't submit")
+	}
+	if !result.Someday || result.RecurType == nil || *result.RecurType != "fixed" || result.RecurCount == nil || *result.RecurCount != 4 {
+		t.Errorf("result = %+v, want a someday task repeating 4 times", result)
+	}
+}
+
+func TestAddModalMultilineDescription(t *testing.T) {
+	m := NewAddModal(NewStyles(output.DefaultTheme(), nil)).SetSize(100, 40).Open(nil, nil, nil)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Plan trip")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Book flights")})
+	m, result := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if result != nil {
+		t.Fatal("enter in the description submitted the form")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Find a hotel"), Paste: true})
+	if got := m.descInput.Height(); got != 2 {
+		t.Errorf("description height = %d, want 2 for two lines", got)
+	}
+
+	_, result = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if result == nil {
+		t.Fatal("ctrl+s didn't submit")
+	}
+	if want := "Book flights\nFind a hotel"; result.Description != want {
+		t.Errorf("Description = %q, want %q", result.Description, want)
+	}
+}
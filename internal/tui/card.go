@@ -1,6 +1,10 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Card renders a bordered box with a title and content
 type Card struct {
@@ -14,22 +18,38 @@ func NewCard(styles *Styles) *Card {
 
 // Render creates a bordered card with title and content at fixed dimensions
 func (c *Card) Render(title, content string, width, height int, focused bool) string {
+	return c.RenderStatus(title, "", content, width, height, focused)
+}
+
+// RenderStatus renders a card like Render, with status right-aligned on the
+// title's line. The status is left out if the line is too narrow for it.
+func (c *Card) RenderStatus(title, status, content string, width, height int, focused bool) string {
 	// Select border style based on focus
 	borderStyle := c.styles.UnfocusedSection
 	if focused {
 		borderStyle = c.styles.FocusedSection
 	}
 
-	// Render header
+	// Render header (without borders, the title color shows focus instead)
 	header := c.styles.Theme.Header.Bold(true).Render(title)
+	if focused && c.styles.Borderless {
+		header = c.styles.FocusedTitle.Render(title)
+	}
+
+	// Inner dimensions accounting for border and padding(2)
+	innerWidth := width - borderStyle.GetHorizontalFrameSize() - 2
+	innerHeight := height - borderStyle.GetVerticalFrameSize()
+
+	if status != "" {
+		space := innerWidth - lipgloss.Width(header) - lipgloss.Width(status)
+		if space >= 1 {
+			header += strings.Repeat(" ", space) + c.styles.Theme.Muted.Render(status)
+		}
+	}
 
 	// Combine header and content with blank line between
 	innerContent := header + "\n\n" + content
 
-	// Inner dimensions accounting for border(2) and padding(2)
-	innerWidth := width - 4
-	innerHeight := height - 2
-
 	if innerWidth < 1 {
 		innerWidth = 1
 	}
@@ -44,7 +64,7 @@ func (c *Card) Render(title, content string, width, height int, focused bool) st
 	// MaxWidth truncates lines that are too long (handles ANSI codes properly)
 	padded := lipgloss.NewStyle().
 		Padding(0, 1).
-		MaxWidth(width - 2). // Total width minus border
+		MaxWidth(width - borderStyle.GetHorizontalFrameSize()). // Total width minus border
 		Render(placed)
 
 	return borderStyle.Render(padded)
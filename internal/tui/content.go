@@ -2,31 +2,45 @@ package tui
 
 import (
 	"fmt"
+	"maps"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
 	"github.com/devbydaniel/tt/internal/recurparse"
+	"github.com/rivo/uniseg"
 )
 
 // Content displays the task list in the right panel
 type Content struct {
-	title          string
-	displayTasks   []task.Task      // tasks in display order (computed once when set)
-	taskSchedules  map[int64]string // task ID -> schedule name (for schedule grouping)
-	groupBy        string           // grouping mode: none, scope, date, schedule
-	hideScope      bool             // whether to hide the project/area column
-	width          int
-	height         int
-	viewport       viewport.Model
-	ready          bool
-	styles         *Styles
-	card           *Card
-	focused        bool // whether content panel has focus
-	showSelection  bool // whether to show selection indicator (even when not focused)
-	selectedIndex  int  // index into displayTasks (-1 = none)
+	title         string
+	displayTasks  []task.Task      // tasks in display order (computed once when set)
+	taskSchedules map[int64]string // task ID -> schedule name (for schedule grouping)
+	taskTags      []string         // tag of each entry in displayTasks (for tag grouping)
+	groupBy       string           // grouping mode: none, scope, date, schedule, tag, state
+	hideScope     bool             // whether to hide the project/area column
+	overflow      string           // long titles: truncate (default) or none; wrap truncates too
+	width         int
+	height        int
+	viewport      viewport.Model
+	ready         bool
+	styles        *Styles
+	card          *Card
+	focused       bool            // whether content panel has focus
+	showSelection bool            // whether to show selection indicator (even when not focused)
+	selectedIndex int             // index into displayTasks (-1 = none)
+	onHeader      bool            // the selection is on the header of the group starting at selectedIndex
+	collapsed     map[string]bool // groups folded to their header, by name
+	clock         clock.Clock     // decides what today is for date groups and flags
+	dateGroups    output.DateGroups
+	groupSort     []task.SortOption // sort within scope groups (nil = as loaded)
+	schedule      task.ScheduleSettings
+	input         string // line shown below the list, e.g. the quick-add input
 }
 
 // NewContent creates a new content panel
@@ -36,9 +50,45 @@ func NewContent(styles *Styles) Content {
 		styles:        styles,
 		card:          NewCard(styles),
 		selectedIndex: -1,
+		clock:         clock.System,
 	}
 }
 
+// SetClock sets the clock date groups and due/planned flags are based on
+func (c Content) SetClock(clk clock.Clock) Content {
+	c.clock = clk
+	return c
+}
+
+// SetDateGroups sets which headers date grouping shows, and in what order,
+// from the date_groups setting, from the next SetTasks on
+func (c Content) SetDateGroups(keys []string) Content {
+	c.dateGroups = output.NewDateGroups(keys)
+	return c
+}
+
+// SetGroupSort sets how tasks are sorted within each group of the scope
+// grouping, from the group_sort setting, from the next SetTasks on
+func (c Content) SetGroupSort(spec string) Content {
+	c.groupSort = nil
+	if spec != "" {
+		c.groupSort, _ = task.ParseSort(spec)
+	}
+	return c
+}
+
+// SetSchedule sets the schedule settings whose day rollover decides which
+// date counts as today
+func (c Content) SetSchedule(s task.ScheduleSettings) Content {
+	c.schedule = s
+	return c
+}
+
+// today returns the start of the current day, after the day rollover
+func (c Content) today() time.Time {
+	return c.schedule.Today(clock.Now(c.clock))
+}
+
 // SetSize updates content dimensions
 func (c Content) SetSize(width, height int) Content {
 	c.width = width
@@ -47,6 +97,9 @@ func (c Content) SetSize(width, height int) Content {
 	// Content dimensions: width - border(2) - horizontal padding(2), height - border(2) - header with blank line(2)
 	contentWidth := width - 4
 	contentHeight := height - 4
+	if c.input != "" {
+		contentHeight--
+	}
 
 	if contentWidth < 1 {
 		contentWidth = 1
@@ -62,20 +115,39 @@ func (c Content) SetSize(width, height int) Content {
 	} else {
 		c.viewport.Width = contentWidth
 		c.viewport.Height = contentHeight
+		// Titles are fitted to the width, so rows change with it
+		c.viewport.SetContent(c.buildTaskList())
 	}
 
 	return c
 }
 
+// SetInput shows a line below the list, such as the quick-add input, or
+// removes it if line is empty. The list gives up a row for it.
+func (c Content) SetInput(line string) Content {
+	resize := (line == "") != (c.input == "")
+	c.input = line
+	if resize && c.width > 0 {
+		c = c.SetSize(c.width, c.height).ensureSelectionVisible()
+	}
+	return c
+}
+
 // SetTasks updates the displayed tasks with optional grouping
 func (c Content) SetTasks(tasks []task.Task, title string, groupBy string, hideScope bool) Content {
+	if title != c.title || groupBy != c.groupBy {
+		// Collapsed groups stay collapsed until the view changes
+		c.collapsed = nil
+	}
 	c.title = title
 	c.groupBy = groupBy
 	c.hideScope = hideScope
-	c.displayTasks = c.computeDisplayOrder(tasks, groupBy)
+	c.displayTasks, c.taskTags = c.computeDisplayOrder(tasks, groupBy)
 	// Reset selection when tasks change
+	c.onHeader = false
 	if c.focused && len(c.displayTasks) > 0 {
 		c.selectedIndex = 0
+		c = c.fixSelection()
 	} else {
 		c.selectedIndex = -1
 	}
@@ -86,8 +158,19 @@ func (c Content) SetTasks(tasks []task.Task, title string, groupBy string, hideS
 	return c
 }
 
+// SetOverflow sets how titles wider than the panel are handled, from the
+// next SetTasks on. Rows are always one line in the TUI, so "wrap"
+// truncates like the default.
+func (c Content) SetOverflow(mode string) Content {
+	c.overflow = mode
+	return c
+}
+
 // SetScheduleGroups updates the content with pre-grouped schedule data
 func (c Content) SetScheduleGroups(groups ScheduleGroups, title string, hideScope bool) Content {
+	if title != c.title || c.groupBy != "schedule" {
+		c.collapsed = nil
+	}
 	c.groupBy = "schedule"
 	c.hideScope = hideScope
 	c.title = title
@@ -112,8 +195,10 @@ func (c Content) SetScheduleGroups(groups ScheduleGroups, title string, hideScop
 	}
 	c.displayTasks = all
 	// Reset selection when tasks change
+	c.onHeader = false
 	if c.focused && len(c.displayTasks) > 0 {
 		c.selectedIndex = 0
+		c = c.fixSelection()
 	} else {
 		c.selectedIndex = -1
 	}
@@ -133,10 +218,9 @@ func (c Content) buildTaskList() string {
 	switch c.groupBy {
 	case "scope":
 		return c.buildGroupedByScope()
-	case "date":
-		return c.buildGroupedByDate()
-	case "schedule":
-		return c.buildGroupedBySchedule()
+	case "date", "schedule", "tag", "state":
+		getGroup, _ := c.grouping()
+		return c.buildGroupedList(getGroup)
 	default:
 		return c.buildFlatTaskList()
 	}
@@ -205,12 +289,14 @@ func (c Content) buildGroupedByScope() string {
 
 	// Render: No Scope first
 	if len(noScopeTasks) > 0 {
-		header := c.styles.Theme.Header.Render("No Scope")
+		header := c.renderGroupHeader("No Scope", regularIndices[noScopeTasks[0]], len(noScopeTasks))
 		var rows []string
-		for _, t := range noScopeTasks {
-			rows = append(rows, c.renderTaskRow(t, regularIndices[t]))
+		if !c.collapsed["No Scope"] {
+			for _, t := range noScopeTasks {
+				rows = append(rows, c.renderTaskRow(t, regularIndices[t]))
+			}
 		}
-		sections = append(sections, header+"\n"+strings.Join(rows, "\n"))
+		sections = append(sections, groupSection(header, rows))
 	}
 
 	// Combine all headers (group headers + project scopes) and sort
@@ -229,72 +315,71 @@ func (c Content) buildGroupedByScope() string {
 			// Render project as header-style line (no ID, with metadata)
 			sections = append(sections, c.renderProjectHeaderLine(proj, projectIndices[proj]))
 		} else if tasks, isGroup := groups[header]; isGroup {
-			headerLine := c.styles.Theme.Header.Render(header)
+			headerLine := c.renderGroupHeader(header, regularIndices[tasks[0]], len(tasks))
 			var rows []string
-			for _, t := range tasks {
-				rows = append(rows, c.renderTaskRow(t, regularIndices[t]))
+			if !c.collapsed[header] {
+				for _, t := range tasks {
+					rows = append(rows, c.renderTaskRow(t, regularIndices[t]))
+				}
 			}
-			sections = append(sections, headerLine+"\n"+strings.Join(rows, "\n"))
+			sections = append(sections, groupSection(headerLine, rows))
 		}
 	}
 
 	return strings.Join(sections, "\n\n")
 }
 
-// buildGroupedByDate groups tasks by date categories
-func (c Content) buildGroupedByDate() string {
-	now := time.Now()
-	todayYear, todayMonth, todayDay := now.Date()
-	today := time.Date(todayYear, todayMonth, todayDay, 0, 0, 0, 0, time.Local)
-	tomorrow := today.AddDate(0, 0, 1)
-	endOfWeek := today.AddDate(0, 0, 7-int(today.Weekday()))
-	endOfMonth := time.Date(todayYear, todayMonth+1, 0, 0, 0, 0, 0, time.Local)
-	endOfYear := time.Date(todayYear, 12, 31, 0, 0, 0, 0, time.Local)
-
-	return c.buildGroupedList(func(t *task.Task) string {
-		return c.getDateCategory(t.PlannedDate, t.DueDate, today, tomorrow, endOfWeek, endOfMonth, endOfYear)
-	})
-}
-
-// buildGroupedBySchedule renders pre-grouped schedule data
-func (c Content) buildGroupedBySchedule() string {
-	return c.buildGroupedList(func(t *task.Task) string {
-		if sched, ok := c.taskSchedules[t.ID]; ok {
-			return sched
-		}
-		return "Unknown"
-	})
-}
-
 // buildGroupedList renders displayTasks with headers when group changes
-func (c Content) buildGroupedList(getGroup func(*task.Task) string) string {
+func (c Content) buildGroupedList(getGroup func(i int) string) string {
 	var sections []string
 	var currentGroup string
 	var currentRows []string
+	start, count := 0, 0
 
+	flush := func() {
+		if count > 0 {
+			sections = append(sections, groupSection(c.renderGroupHeader(currentGroup, start, count), currentRows))
+		}
+	}
 	for i := range c.displayTasks {
 		t := &c.displayTasks[i]
-		group := getGroup(t)
+		group := getGroup(i)
 
 		if group != currentGroup {
-			// Flush previous group
-			if len(currentRows) > 0 {
-				header := c.styles.Theme.Header.Render(currentGroup)
-				sections = append(sections, header+"\n"+strings.Join(currentRows, "\n"))
-			}
+			flush()
 			currentGroup = group
 			currentRows = nil
+			start, count = i, 0
+		}
+		count++
+		if !c.collapsed[group] {
+			currentRows = append(currentRows, c.renderTaskRow(t, i))
 		}
-		currentRows = append(currentRows, c.renderTaskRow(t, i))
 	}
+	flush()
+
+	return strings.Join(sections, "\n\n")
+}
 
-	// Flush last group
-	if len(currentRows) > 0 {
-		header := c.styles.Theme.Header.Render(currentGroup)
-		sections = append(sections, header+"\n"+strings.Join(currentRows, "\n"))
+// renderGroupHeader renders the header of the group of count tasks starting
+// at index start. A collapsed group shows how many tasks it hides.
+func (c Content) renderGroupHeader(group string, start, count int) string {
+	label := group
+	if c.collapsed[group] {
+		label = fmt.Sprintf("▸ %s (%d)", group, count)
 	}
+	if (c.focused || c.showSelection) && c.onHeader && start == c.selectedIndex {
+		return c.styles.SelectedItem.Render("> " + label)
+	}
+	return c.styles.Theme.Header.Render(label)
+}
 
-	return strings.Join(sections, "\n\n")
+// groupSection joins a group header and its task rows, if it shows any
+func groupSection(header string, rows []string) string {
+	if len(rows) == 0 {
+		return header
+	}
+	return header + "\n" + strings.Join(rows, "\n")
 }
 
 // getDateCategory determines which date category a task belongs to
@@ -348,8 +433,24 @@ func (c Content) View() string {
 	} else {
 		content = c.buildTaskList()
 	}
+	if c.input != "" {
+		content += "\n" + c.input
+	}
+
+	return c.card.RenderStatus(c.title, c.Position(), content, c.width, c.height, c.focused)
+}
 
-	return c.card.Render(c.title, content, c.width, c.height, c.focused)
+// Position describes where in the list the panel is: the selected task's
+// place among all tasks ("12/87"), or how far the list is scrolled when
+// nothing is selected and it doesn't fit. Empty otherwise.
+func (c Content) Position() string {
+	if c.selectedIndex >= 0 && c.selectedIndex < len(c.displayTasks) && (c.focused || c.showSelection) {
+		return fmt.Sprintf("%d/%d", c.selectedIndex+1, c.TotalLines())
+	}
+	if c.AtTop() && c.AtBottom() {
+		return ""
+	}
+	return fmt.Sprintf("%d%%", int(c.ScrollPercent()*100))
 }
 
 // ScrollUp scrolls the content up
@@ -410,6 +511,42 @@ func (c Content) ViewportHeight() int {
 	return c.viewport.Height
 }
 
+// ScrollOffset returns the first visible line of the task list
+func (c Content) ScrollOffset() int {
+	if !c.ready {
+		return 0
+	}
+	return c.viewport.YOffset
+}
+
+// SetScrollOffset scrolls so the list starts at the given line, as far as
+// it reaches, keeping the selection visible
+func (c Content) SetScrollOffset(offset int) Content {
+	if c.ready {
+		c.viewport.SetYOffset(offset)
+		c = c.ensureSelectionVisible()
+	}
+	return c
+}
+
+// SelectTask selects the task with the given ID, reporting false if it
+// isn't in the list. The selection shows once the panel has focus.
+func (c Content) SelectTask(id int64) (Content, bool) {
+	for i := range c.displayTasks {
+		if c.displayTasks[i].ID == id {
+			c.selectedIndex = i
+			c.onHeader = false
+			c = c.fixSelection()
+			if c.ready {
+				c.viewport.SetContent(c.buildTaskList())
+				c = c.ensureSelectionVisible()
+			}
+			return c, true
+		}
+	}
+	return c, false
+}
+
 // TotalLines returns total content lines
 func (c Content) TotalLines() int {
 	return len(c.displayTasks)
@@ -418,14 +555,19 @@ func (c Content) TotalLines() int {
 // renderTaskRow formats a single task row
 func (c Content) renderTaskRow(t *task.Task, index int) string {
 	theme := c.styles.Theme
-	isSelected := (c.focused || c.showSelection) && index == c.selectedIndex
+	isSelected := (c.focused || c.showSelection) && index == c.selectedIndex && !c.onHeader
 
-	// Prefix: selection indicator, check for done, flag for due, star for planned today
+	// Prefix: check for done, cross for canceled, flag for due, asterisk for
+	// flagged, star for planned today
 	prefix := "  "
 	if t.Status == task.StatusDone {
 		prefix = theme.Success.Render(theme.Icons.Done) + " "
+	} else if t.Status == task.StatusCanceled {
+		prefix = theme.Muted.Render(theme.Icons.Canceled) + " "
 	} else if c.isDueOrOverdue(t) {
 		prefix = theme.Warning.Render(theme.Icons.Due) + " "
+	} else if t.Flagged {
+		prefix = theme.Warning.Render(output.FlaggedIcon) + " "
 	} else if c.isPlannedForToday(t) {
 		prefix = theme.Accent.Render(theme.Icons.Planned) + " "
 	}
@@ -433,13 +575,16 @@ func (c Content) renderTaskRow(t *task.Task, index int) string {
 	// ID
 	id := theme.ID.Render(fmt.Sprintf("%d", t.ID))
 
-	// Build scope and title differently for projects vs tasks
+	// Build scope and title differently for projects vs tasks.
+	// The title stays unstyled until it has been fitted to the panel width.
 	var scope, title string
+	var titleStyle *lipgloss.Style
 	if t.IsProject() {
 		// For projects in scope-hidden view (e.g., area view), show project name as title with scope styling
 		// Otherwise, show full scope (Area > ProjectName)
 		if c.hideScope {
-			title = theme.Scope.Render(c.sanitizeTitle(t.Title))
+			title = c.sanitizeTitle(t.Title)
+			titleStyle = &theme.Scope
 		} else {
 			scope = c.formatProjectScope(t.AreaName, t.Title)
 			scope = theme.Scope.Render(scope)
@@ -470,23 +615,41 @@ func (c Content) renderTaskRow(t *task.Task, index int) string {
 		extras = append(extras, theme.Muted.Render(theme.Icons.Due+" "+t.DueDate.Format("Jan 2")))
 	}
 
-	if len(t.Tags) > 0 {
-		extras = append(extras, theme.Muted.Render(c.formatTags(t.Tags)))
+	if t.Context != nil {
+		extras = append(extras, theme.Muted.Render("@"+*t.Context))
 	}
 
-	// Build row
-	parts := []string{prefix + id}
-	if scope != "" && !c.hideScope {
-		parts = append(parts, scope)
+	if t.Location != nil {
+		extras = append(extras, theme.Muted.Render("⌂"+*t.Location))
 	}
-	if title != "" {
-		parts = append(parts, title)
+
+	if len(t.Tags) > 0 {
+		extras = append(extras, theme.RenderTags(c.formatTags(t.Tags)))
 	}
-	if len(extras) > 0 {
-		parts = append(parts, strings.Join(extras, " "))
+
+	// Build row
+	build := func(title string) string {
+		parts := []string{prefix + id}
+		if scope != "" && !c.hideScope {
+			parts = append(parts, scope)
+		}
+		if title != "" && titleStyle != nil {
+			parts = append(parts, titleStyle.Render(title))
+		} else if title != "" {
+			parts = append(parts, title)
+		}
+		if len(extras) > 0 {
+			parts = append(parts, strings.Join(extras, " "))
+		}
+		return strings.Join(parts, "  ")
 	}
+	row := build(title)
 
-	row := strings.Join(parts, "  ")
+	// Shorten the title rather than letting the card clip the dates and tags
+	available := c.viewport.Width - 2 // selection indicator
+	if over := lipgloss.Width(row) - available; available > 0 && over > 0 && title != "" && c.overflow != "none" {
+		row = build(truncate(title, max(lipgloss.Width(title)-over, minTitleWidth)))
+	}
 
 	// Apply selection highlighting
 	if isSelected {
@@ -540,7 +703,7 @@ func (c Content) renderProjectHeaderLine(t *task.Task, index int) string {
 		parts = append(parts, theme.Muted.Render(theme.Icons.Due+" "+t.DueDate.Format("Jan 2")))
 	}
 	if len(t.Tags) > 0 {
-		parts = append(parts, theme.Muted.Render(c.formatTags(t.Tags)))
+		parts = append(parts, theme.RenderTags(c.formatTags(t.Tags)))
 	}
 
 	row := strings.Join(parts, "  ")
@@ -594,9 +757,7 @@ func (c Content) isPlannedForToday(t *task.Task) bool {
 	if t.PlannedDate == nil {
 		return false
 	}
-	now := time.Now()
-	todayYear, todayMonth, todayDay := now.Date()
-	today := time.Date(todayYear, todayMonth, todayDay, 0, 0, 0, 0, time.Local)
+	today := c.today()
 	dateYear, dateMonth, dateDay := t.PlannedDate.Date()
 	plannedDate := time.Date(dateYear, dateMonth, dateDay, 0, 0, 0, 0, time.Local)
 	return !plannedDate.After(today)
@@ -606,9 +767,7 @@ func (c Content) isDueOrOverdue(t *task.Task) bool {
 	if t.DueDate == nil {
 		return false
 	}
-	now := time.Now()
-	todayYear, todayMonth, todayDay := now.Date()
-	today := time.Date(todayYear, todayMonth, todayDay, 0, 0, 0, 0, time.Local)
+	today := c.today()
 	dateYear, dateMonth, dateDay := t.DueDate.Date()
 	dueDate := time.Date(dateYear, dateMonth, dateDay, 0, 0, 0, 0, time.Local)
 	return !dueDate.After(today)
@@ -620,6 +779,7 @@ func (c Content) SetFocused(focused bool) Content {
 	if focused && len(c.displayTasks) > 0 {
 		if c.selectedIndex < 0 {
 			c.selectedIndex = 0
+			c = c.fixSelection()
 		}
 	} else if !c.showSelection {
 		c.selectedIndex = -1
@@ -639,30 +799,186 @@ func (c Content) SetShowSelection(show bool) Content {
 	return c
 }
 
-// MoveUp moves selection up
+// MoveUp moves selection up, onto group headers too
 func (c Content) MoveUp() Content {
-	if c.selectedIndex > 0 {
-		c.selectedIndex--
-		if c.ready {
-			c.viewport.SetContent(c.buildTaskList())
-			c = c.ensureSelectionVisible()
-		}
+	rows := c.rows()
+	if i := c.selectedRow(rows); i > 0 {
+		return c.selectRow(rows[i-1])
 	}
 	return c
 }
 
-// MoveDown moves selection down
+// MoveDown moves selection down, onto group headers too
 func (c Content) MoveDown() Content {
-	if c.selectedIndex < len(c.displayTasks)-1 {
-		c.selectedIndex++
-		if c.ready {
-			c.viewport.SetContent(c.buildTaskList())
-			c = c.ensureSelectionVisible()
+	rows := c.rows()
+	if i := c.selectedRow(rows); i < len(rows)-1 {
+		return c.selectRow(rows[i+1])
+	}
+	return c
+}
+
+// MoveTo selects the task at index, clamped to the list, or its group's
+// header if the group is collapsed
+func (c Content) MoveTo(index int) Content {
+	if len(c.displayTasks) == 0 {
+		return c
+	}
+	c.selectedIndex = max(min(index, len(c.displayTasks)-1), 0)
+	c.onHeader = false
+	return c.selectRow(listRow{index: c.selectedIndex})
+}
+
+// listRow is a line of the task list the selection can be on: the task at
+// index, or the header of the group starting there
+type listRow struct {
+	index  int
+	header bool
+}
+
+// rows returns the lines of the list the selection moves through, in
+// order. The tasks of a collapsed group are left out, its header stands in
+// for them.
+func (c Content) rows() []listRow {
+	getGroup, isProjectItem := c.grouping()
+	rows := make([]listRow, 0, len(c.displayTasks))
+	current := ""
+	for i := range c.displayTasks {
+		if getGroup == nil {
+			rows = append(rows, listRow{index: i})
+			continue
+		}
+		group := getGroup(i)
+		if isProjectItem(&c.displayTasks[i]) {
+			// A project in the scope view is a header-style line of its own
+			rows = append(rows, listRow{index: i})
+			current = group
+			continue
 		}
+		if group != current {
+			rows = append(rows, listRow{index: i, header: true})
+			current = group
+		}
+		if !c.collapsed[group] {
+			rows = append(rows, listRow{index: i})
+		}
+	}
+	return rows
+}
+
+// selectedRow returns the position of the selection in rows, or -1
+func (c Content) selectedRow(rows []listRow) int {
+	for i, row := range rows {
+		if row.index == c.selectedIndex && row.header == c.onHeader {
+			return i
+		}
+	}
+	return -1
+}
+
+// groupStart returns the index of the first task in the group of the task
+// at index
+func (c Content) groupStart(index int) int {
+	getGroup, _ := c.grouping()
+	if getGroup == nil {
+		return index
+	}
+	group := getGroup(index)
+	for index > 0 && getGroup(index-1) == group {
+		index--
+	}
+	return index
+}
+
+// fixSelection moves the selection from a task in a collapsed group to the
+// group's header
+func (c Content) fixSelection() Content {
+	if c.onHeader || c.selectedIndex < 0 || c.selectedIndex >= len(c.displayTasks) {
+		return c
+	}
+	getGroup, isProjectItem := c.grouping()
+	if getGroup == nil || isProjectItem(&c.displayTasks[c.selectedIndex]) {
+		return c
+	}
+	if c.collapsed[getGroup(c.selectedIndex)] {
+		c.selectedIndex = c.groupStart(c.selectedIndex)
+		c.onHeader = true
 	}
 	return c
 }
 
+// selectRow moves the selection to row
+func (c Content) selectRow(row listRow) Content {
+	c.selectedIndex = row.index
+	c.onHeader = row.header
+	c = c.fixSelection()
+	if c.ready {
+		c.viewport.SetContent(c.buildTaskList())
+		c = c.ensureSelectionVisible()
+	}
+	return c
+}
+
+// MoveToTop selects the first task
+func (c Content) MoveToTop() Content {
+	return c.MoveTo(0)
+}
+
+// MoveToBottom selects the last task
+func (c Content) MoveToBottom() Content {
+	return c.MoveTo(len(c.displayTasks) - 1)
+}
+
+// MoveHalfPageDown moves the selection down by half the visible lines
+func (c Content) MoveHalfPageDown() Content {
+	return c.MoveTo(c.selectedIndex + c.halfPage())
+}
+
+// MoveHalfPageUp moves the selection up by half the visible lines
+func (c Content) MoveHalfPageUp() Content {
+	return c.MoveTo(c.selectedIndex - c.halfPage())
+}
+
+func (c Content) halfPage() int {
+	return max(c.ViewportHeight()/2, 1)
+}
+
+// MoveToNextGroup selects the first task of the next group, or the last
+// task if there is none
+func (c Content) MoveToNextGroup() Content {
+	getGroup := c.section()
+	if getGroup == nil || c.selectedIndex < 0 {
+		return c.MoveToBottom()
+	}
+	current := getGroup(c.selectedIndex)
+	for i := c.selectedIndex + 1; i < len(c.displayTasks); i++ {
+		if getGroup(i) != current {
+			return c.MoveTo(i)
+		}
+	}
+	return c.MoveToBottom()
+}
+
+// MoveToPrevGroup selects the first task of the current group, or of the
+// previous group if the selection is already there
+func (c Content) MoveToPrevGroup() Content {
+	getGroup := c.section()
+	if getGroup == nil || c.selectedIndex <= 0 {
+		return c.MoveToTop()
+	}
+	start := func(i int) int {
+		group := getGroup(i)
+		for i > 0 && getGroup(i-1) == group {
+			i--
+		}
+		return i
+	}
+	i := start(c.selectedIndex)
+	if i == c.selectedIndex {
+		i = start(i - 1)
+	}
+	return c.MoveTo(i)
+}
+
 // selectedTaskLine calculates the line number of the selected task in rendered output
 func (c Content) selectedTaskLine() int {
 	if c.selectedIndex < 0 || len(c.displayTasks) == 0 {
@@ -675,12 +991,37 @@ func (c Content) selectedTaskLine() int {
 	}
 
 	// For grouped lists, count headers and blank lines
-	var getGroup func(*task.Task) string
-	var isProjectItem func(*task.Task) bool
+	getGroup, _ := c.grouping()
+	if getGroup == nil {
+		return c.selectedIndex
+	}
+
+	line := 0
+	currentGroup := ""
+	for _, row := range c.rows() {
+		group := getGroup(row.index)
+		if group != currentGroup {
+			if currentGroup != "" {
+				line++ // blank line between groups
+			}
+			currentGroup = group
+		}
+		if row.index == c.selectedIndex && row.header == c.onHeader {
+			return line
+		}
+		line++ // header, task or project line
+	}
+	return line
+}
 
+// grouping returns the group each entry of displayTasks is listed under,
+// by index, and whether a task is a project heading its own group, or nil
+// for a flat list
+func (c Content) grouping() (getGroup func(i int) string, isProjectItem func(*task.Task) bool) {
 	switch c.groupBy {
 	case "scope":
-		getGroup = func(t *task.Task) string {
+		getGroup = func(i int) string {
+			t := &c.displayTasks[i]
 			// Projects are their own groups
 			if t.IsProject() {
 				scope := c.sanitizeTitle(t.Title)
@@ -704,51 +1045,51 @@ func (c Content) selectedTaskLine() int {
 			return t.IsProject()
 		}
 	case "schedule":
-		getGroup = func(t *task.Task) string {
-			if sched, ok := c.taskSchedules[t.ID]; ok {
+		getGroup = func(i int) string {
+			if sched, ok := c.taskSchedules[c.displayTasks[i].ID]; ok {
 				return sched
 			}
 			return "Unknown"
 		}
 		isProjectItem = func(t *task.Task) bool { return false }
 	case "date":
-		now := time.Now()
-		todayYear, todayMonth, todayDay := now.Date()
-		today := time.Date(todayYear, todayMonth, todayDay, 0, 0, 0, 0, time.Local)
+		today := c.today()
+		todayYear, todayMonth, _ := today.Date()
 		tomorrow := today.AddDate(0, 0, 1)
 		endOfWeek := today.AddDate(0, 0, 7-int(today.Weekday()))
 		endOfMonth := time.Date(todayYear, todayMonth+1, 0, 0, 0, 0, 0, time.Local)
 		endOfYear := time.Date(todayYear, 12, 31, 0, 0, 0, 0, time.Local)
-		getGroup = func(t *task.Task) string {
-			return c.getDateCategory(t.PlannedDate, t.DueDate, today, tomorrow, endOfWeek, endOfMonth, endOfYear)
+		getGroup = func(i int) string {
+			t := &c.displayTasks[i]
+			return c.dateGroups.Group(c.getDateCategory(t.PlannedDate, t.DueDate, today, tomorrow, endOfWeek, endOfMonth, endOfYear))
 		}
 		isProjectItem = func(t *task.Task) bool { return false }
-	default:
-		return c.selectedIndex
-	}
-
-	line := 0
-	currentGroup := ""
-	for i := 0; i <= c.selectedIndex; i++ {
-		t := &c.displayTasks[i]
-		group := getGroup(t)
-		if group != currentGroup {
-			if currentGroup != "" {
-				line++ // blank line between groups
-			}
-			// For projects in scope view, the "header" IS the selectable item
-			// For regular groups, there's a header line followed by task lines
-			if !isProjectItem(t) {
-				line++ // header line (only for non-project groups)
-			}
-			currentGroup = group
+	case "tag":
+		// A task is listed once per tag, so the group depends on the entry
+		getGroup = func(i int) string {
+			return c.taskTags[i]
 		}
-		if i == c.selectedIndex {
-			return line
+		isProjectItem = func(t *task.Task) bool { return false }
+	case "state":
+		getGroup = func(i int) string {
+			return stateGroup(&c.displayTasks[i])
 		}
-		line++ // task/project line
+		isProjectItem = func(t *task.Task) bool { return false }
+	}
+	return getGroup, isProjectItem
+}
+
+// section returns the group an entry of displayTasks is listed under for
+// jumping between groups, where a project in the scope view heads the group
+// of its tasks. Nil for a flat list.
+func (c Content) section() func(i int) string {
+	getGroup, _ := c.grouping()
+	if getGroup == nil {
+		return nil
+	}
+	return func(i int) string {
+		return strings.TrimPrefix(getGroup(i), "project:")
 	}
-	return line
 }
 
 // ensureSelectionVisible scrolls viewport to keep selected task visible
@@ -770,14 +1111,42 @@ func (c Content) ensureSelectionVisible() Content {
 	return c
 }
 
-// SelectedTask returns the currently selected task, or nil if none
+// SelectedTask returns the currently selected task, or nil if none or the
+// selection is on a group header
 func (c Content) SelectedTask() *task.Task {
-	if !c.focused || c.selectedIndex < 0 || c.selectedIndex >= len(c.displayTasks) {
+	if !c.focused || c.onHeader || c.selectedIndex < 0 || c.selectedIndex >= len(c.displayTasks) {
 		return nil
 	}
 	return &c.displayTasks[c.selectedIndex]
 }
 
+// OnHeader returns whether the selection is on a group header
+func (c Content) OnHeader() bool {
+	return c.focused && c.onHeader
+}
+
+// ToggleGroup collapses the group whose header is selected to just the
+// header, or expands it again
+func (c Content) ToggleGroup() Content {
+	if !c.onHeader {
+		return c
+	}
+	getGroup, _ := c.grouping()
+	group := getGroup(c.selectedIndex)
+	// Copied, as earlier copies of the panel share the map
+	collapsed := maps.Clone(c.collapsed)
+	if collapsed == nil {
+		collapsed = make(map[string]bool)
+	}
+	collapsed[group] = !collapsed[group]
+	c.collapsed = collapsed
+	if c.ready {
+		c.viewport.SetContent(c.buildTaskList())
+		c = c.ensureSelectionVisible()
+	}
+	return c
+}
+
 // UpdateTaskStatus updates a task's status in-place and refreshes the viewport
 func (c Content) UpdateTaskStatus(taskID int64, done bool) Content {
 	for i := range c.displayTasks {
@@ -787,7 +1156,6 @@ func (c Content) UpdateTaskStatus(taskID int64, done bool) Content {
 			} else {
 				c.displayTasks[i].Status = task.StatusTodo
 			}
-			break
 		}
 	}
 	if c.ready {
@@ -796,15 +1164,21 @@ func (c Content) UpdateTaskStatus(taskID int64, done bool) Content {
 	return c
 }
 
-// computeDisplayOrder returns tasks sorted by the given grouping mode
-func (c Content) computeDisplayOrder(tasks []task.Task, groupBy string) []task.Task {
+// computeDisplayOrder returns tasks sorted by the given grouping mode. For
+// tag grouping, which lists a task under each of its tags, it also returns
+// the tag of each entry.
+func (c Content) computeDisplayOrder(tasks []task.Task, groupBy string) ([]task.Task, []string) {
 	switch groupBy {
 	case "scope":
-		return c.orderByScope(tasks)
+		return c.orderByScope(tasks), nil
 	case "date":
-		return c.orderByDate(tasks)
+		return c.orderByDate(tasks), nil
+	case "tag":
+		return orderByTag(tasks)
+	case "state":
+		return orderByState(tasks), nil
 	default:
-		return tasks
+		return tasks, nil
 	}
 }
 
@@ -841,6 +1215,13 @@ func (c Content) orderByScope(tasks []task.Task) []task.Task {
 		groups[header] = append(groups[header], t)
 	}
 
+	if c.groupSort != nil {
+		task.SortTasks(noScope, c.groupSort)
+		for _, group := range groups {
+			task.SortTasks(group, c.groupSort)
+		}
+	}
+
 	var result []task.Task
 	result = append(result, noScope...)
 
@@ -866,28 +1247,104 @@ func (c Content) orderByScope(tasks []task.Task) []task.Task {
 
 // orderByDate sorts tasks by date category
 func (c Content) orderByDate(tasks []task.Task) []task.Task {
-	now := time.Now()
-	todayYear, todayMonth, todayDay := now.Date()
-	today := time.Date(todayYear, todayMonth, todayDay, 0, 0, 0, 0, time.Local)
+	today := c.today()
+	todayYear, todayMonth, _ := today.Date()
 	tomorrow := today.AddDate(0, 0, 1)
 	endOfWeek := today.AddDate(0, 0, 7-int(today.Weekday()))
 	endOfMonth := time.Date(todayYear, todayMonth+1, 0, 0, 0, 0, 0, time.Local)
 	endOfYear := time.Date(todayYear, 12, 31, 0, 0, 0, 0, time.Local)
 
-	dateGroups := map[string][]task.Task{
-		"Overdue": {}, "Today": {}, "Tomorrow": {}, "This Week": {},
-		"This Month": {}, "This Year": {}, "Later": {}, "No Date": {},
-	}
-
+	dateGroups := make(map[string][]task.Task)
 	for _, t := range tasks {
-		category := c.getDateCategory(t.PlannedDate, t.DueDate, today, tomorrow, endOfWeek, endOfMonth, endOfYear)
+		category := c.dateGroups.Group(c.getDateCategory(t.PlannedDate, t.DueDate, today, tomorrow, endOfWeek, endOfMonth, endOfYear))
 		dateGroups[category] = append(dateGroups[category], t)
 	}
 
-	orderedCategories := []string{"Overdue", "Today", "Tomorrow", "This Week", "This Month", "This Year", "Later", "No Date"}
 	var result []task.Task
-	for _, category := range orderedCategories {
+	for _, category := range c.dateGroups.Order() {
 		result = append(result, dateGroups[category]...)
 	}
 	return result
 }
+
+// orderByTag lists tasks under each of their tags, tags in alphabetical
+// order, and untagged tasks last under "No Tag"
+func orderByTag(tasks []task.Task) ([]task.Task, []string) {
+	groups := make(map[string][]task.Task)
+	var untagged []task.Task
+	for _, t := range tasks {
+		if len(t.Tags) == 0 {
+			untagged = append(untagged, t)
+			continue
+		}
+		for _, tag := range t.Tags {
+			groups[tag] = append(groups[tag], t)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for tag := range groups {
+		names = append(names, tag)
+	}
+	sort.Strings(names)
+
+	var result []task.Task
+	var tags []string
+	for _, tag := range names {
+		for _, t := range groups[tag] {
+			result = append(result, t)
+			tags = append(tags, tag)
+		}
+	}
+	for _, t := range untagged {
+		result = append(result, t)
+		tags = append(tags, "No Tag")
+	}
+	return result, tags
+}
+
+// stateGroup returns the header a task is listed under when grouping by state
+func stateGroup(t *task.Task) string {
+	if t.State == task.StateSomeday {
+		return "Someday"
+	}
+	return "Active"
+}
+
+// orderByState lists active tasks before someday tasks, keeping the order
+// within each
+func orderByState(tasks []task.Task) []task.Task {
+	result := make([]task.Task, 0, len(tasks))
+	for _, group := range []string{"Active", "Someday"} {
+		for _, t := range tasks {
+			if stateGroup(&t) == group {
+				result = append(result, t)
+			}
+		}
+	}
+	return result
+}
+
+// minTitleWidth is the narrowest a title is squeezed to before the row is
+// left to be clipped instead
+const minTitleWidth = 10
+
+// truncate shortens s to at most width display cells, marking the cut with
+// "…". Grapheme clusters such as emoji sequences are never split.
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	rest, used, state := s, 0, -1
+	for rest != "" {
+		_, next, w, newState := uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+w > width-1 {
+			break
+		}
+		rest, used, state = next, used+w, newState
+	}
+	return s[:len(s)-len(rest)] + "…"
+}
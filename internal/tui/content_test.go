@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/devbydaniel/tt/internal/testutil/fixture"
+)
+
+func TestTruncateWideCharacters(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"買い物リスト", 7, "買い物…"},
+		{"買い物リスト", 6, "買い…"},
+		{"👩‍💻👩‍💻 code", 4, "👩‍💻…"},
+		{"short", 10, "short"},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.in, tt.width)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+		if w := lipgloss.Width(got); w > tt.width {
+			t.Errorf("truncate(%q, %d) is %d cells wide", tt.in, tt.width, w)
+		}
+	}
+}
+
+func TestContentPosition(t *testing.T) {
+	c := NewContent(NewStyles(output.DefaultTheme(), nil)).SetClock(fixture.Clock)
+	c = c.SetSize(60, 8).SetTasks(fixture.Tasks(), "All", "none", false)
+
+	if got := c.Position(); got != "0%" {
+		t.Errorf("Position() without a selection = %q, want 0%%", got)
+	}
+	c = c.SetFocused(true).MoveDown().MoveDown()
+	want := fmt.Sprintf("3/%d", len(fixture.Tasks()))
+	if got := c.Position(); got != want {
+		t.Errorf("Position() = %q, want %q", got, want)
+	}
+	if view := c.View(); !strings.Contains(view, want) {
+		t.Errorf("View() doesn't show the position %q:\n%s", want, view)
+	}
+}
+
+func TestContentJumps(t *testing.T) {
+	c := NewContent(NewStyles(output.DefaultTheme(), nil)).SetClock(fixture.Clock)
+	c = c.SetSize(60, 12).SetTasks(fixture.Tasks(), "All", "scope", false).SetFocused(true)
+
+	last := len(c.displayTasks) - 1
+	if c = c.MoveToBottom(); c.selectedIndex != last {
+		t.Errorf("MoveToBottom() selected %d, want %d", c.selectedIndex, last)
+	}
+	if c = c.MoveToTop(); c.selectedIndex != 0 {
+		t.Errorf("MoveToTop() selected %d, want 0", c.selectedIndex)
+	}
+	if c = c.MoveHalfPageDown(); c.selectedIndex != c.ViewportHeight()/2 {
+		t.Errorf("MoveHalfPageDown() selected %d, want %d", c.selectedIndex, c.ViewportHeight()/2)
+	}
+
+	section := c.section()
+	c = c.MoveToTop().MoveToNextGroup()
+	next := c.selectedIndex
+	if next == 0 || section(next) == section(0) || section(next-1) != section(0) {
+		t.Errorf("MoveToNextGroup() selected %d, want the first task after the first group", next)
+	}
+	if c = c.MoveDown().MoveToPrevGroup(); c.selectedIndex != next {
+		t.Errorf("MoveToPrevGroup() selected %d, want the group's first task %d", c.selectedIndex, next)
+	}
+	if c = c.MoveTo(next).MoveToPrevGroup(); c.selectedIndex != 0 {
+		t.Errorf("MoveToPrevGroup() from a group's first task selected %d, want 0", c.selectedIndex)
+	}
+}
+
+func TestContentCollapseGroup(t *testing.T) {
+	c := NewContent(NewStyles(output.DefaultTheme(), nil)).SetClock(fixture.Clock)
+	c = c.SetSize(70, 40).SetTasks(fixture.Tasks(), "All", "date", false).SetFocused(true)
+
+	// Down from Overdue's only task lands on the Today header
+	c = c.MoveDown()
+	if !c.OnHeader() || c.SelectedTask() != nil {
+		t.Fatalf("MoveDown() from the last task of a group should select the next header")
+	}
+	c = c.ToggleGroup()
+	list := c.buildTaskList()
+	if !strings.Contains(list, "▸ Today (2)") || strings.Contains(list, "Fix header layout") {
+		t.Errorf("collapsed Today group still shows its tasks:\n%s", list)
+	}
+
+	// The collapsed group's tasks are skipped
+	if c = c.MoveDown(); !c.OnHeader() || c.displayTasks[c.selectedIndex].Title != "Water plants" {
+		t.Errorf("MoveDown() from a collapsed header should select the Tomorrow header")
+	}
+	if c = c.MoveUp(); !c.OnHeader() {
+		t.Errorf("MoveUp() should return to the collapsed header")
+	}
+
+	// Collapsed groups survive a reload of the same view
+	c = c.SetTasks(fixture.Tasks(), "All", "date", false)
+	if !strings.Contains(c.buildTaskList(), "▸ Today (2)") {
+		t.Error("reloading the view expanded the collapsed group")
+	}
+	c = c.MoveTo(1)
+	if !c.OnHeader() {
+		t.Error("MoveTo() a task in a collapsed group should select its header")
+	}
+	if c = c.ToggleGroup(); !strings.Contains(c.buildTaskList(), "Fix header layout") {
+		t.Error("ToggleGroup() on a collapsed header doesn't expand it")
+	}
+}
+
+func TestContentDateGroups(t *testing.T) {
+	c := NewContent(NewStyles(output.DefaultTheme(), nil)).SetClock(fixture.Clock)
+	c = c.SetDateGroups([]string{"no_date", "today", "this_week", "this_month", "overdue"})
+	c = c.SetSize(70, 40).SetTasks(fixture.Tasks(), "All", "date", false)
+
+	list := c.buildTaskList()
+	if strings.Contains(list, "This Year") || strings.Contains(list, "Tomorrow") {
+		t.Errorf("left-out groups are still shown:\n%s", list)
+	}
+	if !strings.HasPrefix(list, c.styles.Theme.Header.Render("No Date")) {
+		t.Errorf("groups aren't in the configured order:\n%s", list)
+	}
+	if c.displayTasks[len(c.displayTasks)-1].Title != "Send invoice" {
+		t.Errorf("last task = %q, want Overdue's task", c.displayTasks[len(c.displayTasks)-1].Title)
+	}
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/devbydaniel/tt/internal/buildinfo"
+)
+
+// recentMessages is how many of the last messages a crash report lists
+const recentMessages = 20
+
+// crash is a panic caught in the TUI, with what led up to it
+type crash struct {
+	value  any
+	stack  []byte
+	recent []string
+	at     time.Time
+}
+
+// crashState is shared by the copies of guard bubbletea passes around, so
+// Run can see a panic once the program has stopped
+type crashState struct {
+	crash  *crash
+	recent []string
+	quit   func()
+}
+
+// guard wraps the model so a panic in Update, View or a command returned
+// by Update stops the program cleanly, restoring the terminal, instead of
+// leaving it in raw mode. The panic is kept for the crash report.
+type guard struct {
+	model tea.Model
+	state *crashState
+}
+
+func (g guard) Init() tea.Cmd {
+	return g.protect(g.model.Init())
+}
+
+func (g guard) Update(msg tea.Msg) (m tea.Model, cmd tea.Cmd) {
+	if c, ok := msg.(crashMsg); ok {
+		g.state.record(c.value, c.stack)
+		return g, tea.Quit
+	}
+
+	g.state.recent = append(g.state.recent, describeMsg(msg))
+	if len(g.state.recent) > recentMessages {
+		g.state.recent = g.state.recent[1:]
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			g.state.record(r, debug.Stack())
+			m, cmd = g, tea.Quit
+		}
+	}()
+	model, cmd := g.model.Update(msg)
+	g.model = model
+	return g, g.protect(cmd)
+}
+
+func (g guard) View() (view string) {
+	if g.state.crash != nil {
+		return ""
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			g.state.record(r, debug.Stack())
+			view = ""
+			// Sending from the event loop would block it
+			go g.state.quit()
+		}
+	}()
+	return g.model.View()
+}
+
+// crashMsg carries a panic in a command back to the event loop
+type crashMsg struct {
+	value any
+	stack []byte
+}
+
+// protect makes a panic in cmd end the program like one in Update.
+// Commands batched inside cmd are left to bubbletea, which also restores
+// the terminal but only prints the panic.
+func (g guard) protect(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = crashMsg{value: r, stack: debug.Stack()}
+			}
+		}()
+		return cmd()
+	}
+}
+
+// record keeps the first panic; later ones are usually its consequences
+func (s *crashState) record(value any, stack []byte) {
+	if s.crash != nil {
+		return
+	}
+	s.crash = &crash{value: value, stack: stack, recent: append([]string(nil), s.recent...), at: time.Now()}
+}
+
+// describeMsg names a message for the debug log and crash reports
+func describeMsg(msg tea.Msg) string {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		return "key " + key.String()
+	}
+	return fmt.Sprintf("%T", msg)
+}
+
+// writeReport saves the crash to a file in dir and returns its path
+func (c *crash) writeReport(dir string) (string, error) {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	info := buildinfo.Get()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "tt %s (%s) crashed at %s\n\n", info.Version, info.Commit, c.at.Format(time.RFC3339))
+	fmt.Fprintf(&b, "panic: %v\n\n%s\n", c.value, c.stack)
+	fmt.Fprintf(&b, "Last %d messages, oldest first:\n", len(c.recent))
+	for _, msg := range c.recent {
+		fmt.Fprintf(&b, "  %s\n", msg)
+	}
+
+	path := filepath.Join(dir, "crash-"+c.at.Format("20060102-150405")+".txt")
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
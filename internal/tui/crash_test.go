@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// panicky panics on the message "boom" and in commands it returns for "cmd"
+type panicky struct{}
+
+func (panicky) Init() tea.Cmd { return nil }
+
+func (p panicky) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg {
+	case "boom":
+		panic("boom")
+	case "cmd":
+		return p, func() tea.Msg { panic("in command") }
+	}
+	return p, nil
+}
+
+func (panicky) View() string { return "ok" }
+
+func TestGuardRecoversFromPanics(t *testing.T) {
+	state := &crashState{}
+	var m tea.Model = guard{model: panicky{}, state: state}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m, cmd := m.Update("boom")
+	if state.crash == nil || state.crash.value != "boom" {
+		t.Fatalf("crash = %v, want the panic recorded", state.crash)
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Update() after a panic doesn't quit")
+	}
+	if got := m.View(); got != "" {
+		t.Errorf("View() after a crash = %q, want empty", got)
+	}
+
+	path, err := state.crash.writeReport(t.TempDir())
+	if err != nil {
+		t.Fatalf("writeReport() error = %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"panic: boom", "key j", "goroutine"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("report doesn't contain %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestGuardRecoversFromPanicsInCommands(t *testing.T) {
+	state := &crashState{}
+	var m tea.Model = guard{model: panicky{}, state: state}
+
+	m, cmd := m.Update("cmd")
+	msg := cmd()
+	if _, ok := msg.(crashMsg); !ok {
+		t.Fatalf("panicking command returned %T, want crashMsg", msg)
+	}
+	_, cmd = m.Update(msg)
+	if state.crash == nil || state.crash.value != "in command" {
+		t.Fatalf("crash = %v, want the command's panic", state.crash)
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("a command's panic doesn't quit")
+	}
+}
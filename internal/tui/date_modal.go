@@ -7,10 +7,18 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/dateparse"
+	"github.com/devbydaniel/tt/internal/domain/task"
 	datepicker "github.com/ethanefung/bubble-datepicker"
 )
 
+// dateModalTextWidth is the width messages under the input wrap at
+const dateModalTextWidth = 40
+
+// quickPickHint lists the quick-pick keys under the calendar
+const quickPickHint = "t today · m tomorrow · w next week · e weekend · 1-9 in N days · c clear"
+
 // DateModalMode indicates whether we're setting planned or due date
 type DateModalMode int
 
@@ -26,9 +34,12 @@ type DateModal struct {
 	mode       DateModalMode
 	taskID     int64
 	active     bool
-	focusInput bool // true = input focused, false = picker focused
+	focusInput bool       // true = input focused, false = picker focused
+	preview    *time.Time // date the input currently parses to
 	err        error
 	styles     *Styles
+	clock      clock.Clock // what relative dates like "tomorrow" count from
+	schedule   task.ScheduleSettings
 	width      int
 	height     int
 }
@@ -44,30 +55,50 @@ type DateResult struct {
 // NewDateModal creates a new date modal
 func NewDateModal(styles *Styles) DateModal {
 	ti := textinput.New()
-	ti.Placeholder = "today, tomorrow, +3d, monday, 2025-01-15"
+	ti.Placeholder = "fri, next week, jul 15, +3d"
 	ti.CharLimit = 30
 
 	dp := datepicker.New(time.Now())
-	dp.Styles = datepicker.DefaultStyles()
+	dp.Styles = styles.DatePicker
 
 	return DateModal{
 		input:      ti,
 		datepicker: dp,
 		focusInput: true,
 		styles:     styles,
+		clock:      clock.System,
 	}
 }
 
+// SetClock sets the clock relative dates and the initial date are based on
+func (m DateModal) SetClock(clk clock.Clock) DateModal {
+	m.clock = clk
+	return m
+}
+
+// SetSchedule sets the schedule settings whose day rollover decides which
+// date "today" means
+func (m DateModal) SetSchedule(s task.ScheduleSettings) DateModal {
+	m.schedule = s
+	return m
+}
+
+// today returns the date relative dates count from, after the day rollover
+func (m DateModal) today() time.Time {
+	return m.schedule.Today(clock.Now(m.clock))
+}
+
 // Open shows the modal for the given task and mode
 func (m DateModal) Open(taskID int64, mode DateModalMode, currentDate *time.Time) DateModal {
 	m.active = true
 	m.taskID = taskID
 	m.mode = mode
 	m.err = nil
+	m.preview = nil
 	m.focusInput = true
 
 	// Set initial date
-	initialDate := time.Now()
+	initialDate := m.today()
 	if currentDate != nil {
 		initialDate = *currentDate
 		m.input.SetValue(currentDate.Format("2006-01-02"))
@@ -75,6 +106,7 @@ func (m DateModal) Open(taskID int64, mode DateModalMode, currentDate *time.Time
 		m.input.SetValue("")
 	}
 
+	m.datepicker.Styles = m.styles.DatePicker
 	m.datepicker.SetTime(initialDate)
 	m.datepicker.SelectDate()
 	m.input.Prompt = "> "
@@ -125,6 +157,15 @@ func (m DateModal) Update(msg tea.Msg) (DateModal, *DateResult) {
 			}
 			return m, nil
 
+		case tea.KeyRunes:
+			// Quick picks: plain keys on the calendar, with alt while typing
+			if msg.Alt == m.focusInput {
+				if date, ok := m.quickPick(string(msg.Runes)); ok {
+					m = m.Close()
+					return m, &DateResult{TaskID: m.taskID, Date: date, Mode: m.mode}
+				}
+			}
+
 		case tea.KeyEnter:
 			if m.focusInput {
 				// Parse input and submit
@@ -139,7 +180,7 @@ func (m DateModal) Update(msg tea.Msg) (DateModal, *DateResult) {
 					}
 				}
 
-				parsed, err := dateparse.Parse(value)
+				parsed, err := dateparse.ParseFrom(value, m.today())
 				if err != nil {
 					m.err = err
 					return m, nil
@@ -170,6 +211,13 @@ func (m DateModal) Update(msg tea.Msg) (DateModal, *DateResult) {
 			m.input, cmd = m.input.Update(msg)
 			_ = cmd
 			m.err = nil // Clear error on typing
+
+			// Preview the date as it's typed and move the picker to it
+			m.preview = nil
+			if parsed, err := dateparse.ParseFrom(m.input.Value(), m.today()); err == nil {
+				m.preview = &parsed
+				m.datepicker.SetTime(parsed)
+			}
 		} else {
 			// Pass to datepicker
 			var cmd tea.Cmd
@@ -181,6 +229,30 @@ func (m DateModal) Update(msg tea.Msg) (DateModal, *DateResult) {
 	return m, nil
 }
 
+// quickPick returns the date a quick-pick key stands for, nil for the key
+// that clears it. The weekend is the coming Saturday, today on a Saturday.
+func (m DateModal) quickPick(k string) (*time.Time, bool) {
+	today := m.today()
+	var date time.Time
+	switch k {
+	case "t":
+		date = today
+	case "m":
+		date = today.AddDate(0, 0, 1)
+	case "w":
+		date, _ = dateparse.ParseFrom("next week", today)
+	case "e":
+		date = today.AddDate(0, 0, (int(time.Saturday)-int(today.Weekday())+7)%7)
+	case "c":
+		return nil, true
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		date = today.AddDate(0, 0, int(k[0]-'0'))
+	default:
+		return nil, false
+	}
+	return &date, true
+}
+
 // View renders the modal
 func (m DateModal) View() string {
 	if !m.active {
@@ -197,12 +269,17 @@ func (m DateModal) View() string {
 	// Input field (textinput already has "> " prompt when focused)
 	input := m.input.View()
 
-	// Error message
-	var errView string
+	// Error message, or the date the input stands for
+	var message string
 	if m.err != nil {
-		errView = m.styles.Theme.Error.Render(m.err.Error())
+		message = m.styles.Theme.Error.Width(dateModalTextWidth).Render(m.err.Error())
+	} else if m.preview != nil && m.focusInput {
+		message = m.styles.Theme.Muted.Render("→ " + m.preview.Format("Mon, Jan 2 2006"))
 	}
 
+	// The styles are swapped in place on a theme reload, so pick them up
+	m.datepicker.Styles = m.styles.DatePicker
+
 	// Datepicker with focus indicator
 	var picker string
 	if !m.focusInput {
@@ -214,10 +291,10 @@ func (m DateModal) View() string {
 	// Build content
 	var parts []string
 	parts = append(parts, title, "", input)
-	if errView != "" {
-		parts = append(parts, errView)
+	if message != "" {
+		parts = append(parts, message)
 	}
-	parts = append(parts, "", picker)
+	parts = append(parts, "", picker, "", m.styles.Theme.Muted.Width(dateModalTextWidth).Align(lipgloss.Center).Render(quickPickHint))
 
 	content := lipgloss.JoinVertical(lipgloss.Center, parts...)
 	modal := m.styles.ModalBorder.Render(content)
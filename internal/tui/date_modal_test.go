@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/output"
+)
+
+func TestDateModalQuickPicks(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2026, 7, 1, 10, 0, 0, 0, time.Local)
+	open := func() DateModal {
+		return NewDateModal(NewStyles(output.DefaultTheme(), nil)).SetClock(clock.Fixed(now)).SetSize(80, 30).Open(7, DateModalDue, nil)
+	}
+	picker := func(k string) *DateResult {
+		m, _ := open().Update(tea.KeyMsg{Type: tea.KeyTab})
+		_, result := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return result
+	}
+	day := func(d int) time.Time { return time.Date(2026, 7, d, 0, 0, 0, 0, time.Local) }
+
+	for k, want := range map[string]time.Time{"t": day(1), "m": day(2), "w": day(6), "e": day(4), "3": day(4)} {
+		result := picker(k)
+		if result == nil || result.Date == nil || !result.Date.Equal(want) {
+			t.Errorf("quick pick %q = %+v, want %s", k, result, want.Format("2006-01-02"))
+		}
+	}
+	if result := picker("c"); result == nil || result.Date != nil {
+		t.Errorf("quick pick c = %+v, want the date cleared", result)
+	}
+
+	// While typing, letters go into the input unless alt is held
+	if _, result := open().Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")}); result != nil {
+		t.Errorf("typing t picked %+v", result)
+	}
+	_, result := open().Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m"), Alt: true})
+	if result == nil || result.Date == nil || !result.Date.Equal(day(2)) || result.TaskID != 7 {
+		t.Errorf("alt+m = %+v, want tomorrow for task 7", result)
+	}
+}
+
+func TestDateModalFollowsTheme(t *testing.T) {
+	styles := NewStyles(output.DefaultTheme(), &config.TUIStyleConfig{Selected: "#ff0000"})
+	m := NewDateModal(styles)
+	if got := m.datepicker.Styles.FocusedText.GetForeground(); got != lipgloss.Color("#ff0000") {
+		t.Errorf("focused day color = %v, want the selection color", got)
+	}
+
+	// A theme reload replaces the styles in place
+	*styles = *NewStyles(output.DefaultTheme(), &config.TUIStyleConfig{Selected: "#00ff00"})
+	m = m.SetSize(80, 30).Open(1, DateModalPlanned, nil)
+	if got := m.datepicker.Styles.FocusedText.GetForeground(); got != lipgloss.Color("#00ff00") {
+		t.Errorf("reloaded focused day color = %v, want the new selection color", got)
+	}
+}
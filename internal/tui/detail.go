@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/devbydaniel/tt/internal/domain/comment"
 	"github.com/devbydaniel/tt/internal/domain/task"
 )
 
@@ -23,6 +24,7 @@ const (
 // DetailPane displays task details in a third column
 type DetailPane struct {
 	task         *task.Task
+	comments     []comment.Comment
 	focusedField DetailField
 	width        int
 	height       int
@@ -50,10 +52,26 @@ func (d DetailPane) SetSize(width, height int) DetailPane {
 // SetTask sets the task to display
 func (d DetailPane) SetTask(t *task.Task) DetailPane {
 	d.task = t
+	d.comments = nil
 	d.focusedField = DetailFieldTitle
 	return d
 }
 
+// Preview returns the pane showing t, without comments unless it already
+// shows t
+func (d DetailPane) Preview(t *task.Task) DetailPane {
+	if t != nil && d.task != nil && d.task.ID == t.ID {
+		return d
+	}
+	return d.SetTask(t)
+}
+
+// SetComments sets the comments shown below the task's fields
+func (d DetailPane) SetComments(comments []comment.Comment) DetailPane {
+	d.comments = comments
+	return d
+}
+
 // SetFocused sets whether the detail pane has focus
 func (d DetailPane) SetFocused(focused bool) DetailPane {
 	d.focused = focused
@@ -90,7 +108,10 @@ func (d DetailPane) PrevField() DetailPane {
 // View renders the detail pane
 func (d DetailPane) View() string {
 	if d.task == nil {
-		return ""
+		if d.width == 0 {
+			return ""
+		}
+		return d.card.Render("Details", "", d.width, d.height, d.focused)
 	}
 
 	content := d.buildContent()
@@ -154,9 +175,31 @@ func (d DetailPane) buildContent() string {
 	}
 	sections = append(sections, d.renderField(DetailFieldTags, "Tags", tags))
 
+	// Comments, oldest first; they're added with tt comment
+	if len(d.comments) > 0 {
+		sections = append(sections, d.renderComments())
+	}
+
 	return strings.Join(sections, "\n\n")
 }
 
+// renderComments renders the comments section, which isn't a focusable field
+func (d DetailPane) renderComments() string {
+	theme := d.styles.Theme
+	maxWidth := max(d.width-6, 10)
+
+	lines := []string{"  " + theme.Muted.Render("Comments")}
+	for _, c := range d.comments {
+		when := c.CreatedAt.Local().Format("Jan 2 15:04")
+		if c.Author != nil {
+			when += " " + *c.Author
+		}
+		lines = append(lines, "    "+theme.Muted.Render(truncate(when, maxWidth)))
+		lines = append(lines, "    "+truncate(c.Body, maxWidth))
+	}
+	return strings.Join(lines, "\n")
+}
+
 // renderField renders a single field with label and value
 func (d DetailPane) renderField(field DetailField, label, value string) string {
 	theme := d.styles.Theme
@@ -190,15 +233,13 @@ func (d DetailPane) renderField(field DetailField, label, value string) string {
 				truncated = append(truncated, theme.Muted.Render("..."))
 				break
 			}
-			if len(line) > maxWidth {
-				line = line[:maxWidth-3] + "..."
-			}
+			line = truncate(line, maxWidth)
 			truncated = append(truncated, line)
 		}
 		// Indent continuation lines
 		valueStr = strings.Join(truncated, "\n    ")
-	} else if len(valueStr) > maxWidth && value != "None" {
-		valueStr = valueStr[:maxWidth-3] + "..."
+	} else if value != "None" {
+		valueStr = truncate(valueStr, maxWidth)
 	}
 
 	// Selection indicator - only on label line
@@ -0,0 +1,225 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/internal/clock"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// FocusView shows a single task full-screen, without the sidebar or list,
+// so there is nothing to look at except the work itself
+type FocusView struct {
+	active bool
+	task   *task.Task
+	styles *Styles
+	width  int
+	height int
+	clock  clock.Clock
+
+	// The timer measures the current session only; it is not stored
+	running bool
+	since   time.Time     // when the timer was last started
+	elapsed time.Duration // time counted before the last start
+	timerID int           // tells ticks of an earlier start apart
+}
+
+// focusTickMsg redraws the running timer of the start with the given ID
+type focusTickMsg struct {
+	timerID int
+}
+
+// focusTick schedules the next redraw of the timer
+func focusTick(timerID int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return focusTickMsg{timerID: timerID} })
+}
+
+// FocusResult represents an action taken in focus mode
+type FocusResult struct {
+	TaskID int64
+	Status task.Status // status before the action, for toggling
+	Toggle bool
+	Snooze bool
+	Closed bool
+	Timer  bool // the timer started; the caller keeps it ticking with focusTick
+}
+
+// NewFocusView creates a new focus view
+func NewFocusView(styles *Styles) FocusView {
+	return FocusView{styles: styles}
+}
+
+// SetClock sets the clock the timer reads
+func (f FocusView) SetClock(clk clock.Clock) FocusView {
+	f.clock = clk
+	return f
+}
+
+// Open shows the focus view for a task with the timer stopped at zero
+func (f FocusView) Open(t *task.Task) FocusView {
+	f.active = true
+	f.task = t
+	f.running = false
+	f.elapsed = 0
+	return f
+}
+
+// Close hides the focus view
+func (f FocusView) Close() FocusView {
+	f.active = false
+	f.task = nil
+	return f
+}
+
+// SetSize updates the view dimensions
+func (f FocusView) SetSize(width, height int) FocusView {
+	f.width = width
+	f.height = height
+	return f
+}
+
+// Update handles key events. Toggling done or snoozing also closes the view.
+func (f FocusView) Update(msg tea.Msg) (FocusView, *FocusResult) {
+	if !f.active || f.task == nil {
+		return f, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return f, nil
+	}
+
+	taskID, status := f.task.ID, f.task.Status
+	switch keyMsg.String() {
+	case "esc", "f":
+		f = f.Close()
+		return f, &FocusResult{TaskID: taskID, Closed: true}
+	case " ":
+		f = f.Close()
+		return f, &FocusResult{TaskID: taskID, Status: status, Toggle: true}
+	case "z":
+		f = f.Close()
+		return f, &FocusResult{TaskID: taskID, Snooze: true}
+	case "t":
+		if f.running {
+			f.elapsed = f.Elapsed()
+			f.running = false
+			return f, nil
+		}
+		f.running = true
+		f.since = clock.Now(f.clock)
+		f.timerID++
+		return f, &FocusResult{TaskID: taskID, Timer: true}
+	case "r":
+		f.elapsed = 0
+		f.since = clock.Now(f.clock)
+	}
+
+	return f, nil
+}
+
+// Elapsed returns the time counted by the timer
+func (f FocusView) Elapsed() time.Duration {
+	if !f.running {
+		return f.elapsed
+	}
+	return f.elapsed + clock.Now(f.clock).Sub(f.since)
+}
+
+// Ticking reports whether a tick of the given start should keep redrawing
+func (f FocusView) Ticking(timerID int) bool {
+	return f.active && f.running && timerID == f.timerID
+}
+
+// View renders the focused task centered on screen
+func (f FocusView) View() string {
+	if !f.active || f.task == nil {
+		return ""
+	}
+
+	t := f.task
+
+	textWidth := min(f.width-8, 72)
+	if textWidth < 20 {
+		textWidth = 20
+	}
+	wrap := lipgloss.NewStyle().Width(textWidth)
+
+	var sections []string
+	sections = append(sections, wrap.Bold(true).Render(strings.TrimSpace(t.Title)))
+
+	if meta := f.metaLine(); meta != "" {
+		sections = append(sections, wrap.Render(meta))
+	}
+
+	if t.Description != nil && strings.TrimSpace(*t.Description) != "" {
+		sections = append(sections, wrap.Render(*t.Description))
+	}
+
+	sections = append(sections, f.timerLine())
+
+	body := strings.Join(sections, "\n\n")
+	return lipgloss.Place(f.width, f.height, lipgloss.Center, lipgloss.Center, body)
+}
+
+// timerLine renders the timer as minutes and seconds, against the
+// estimate if there is one
+func (f FocusView) timerLine() string {
+	theme := f.styles.Theme
+	elapsed := f.Elapsed().Truncate(time.Second)
+	line := fmt.Sprintf("%02d:%02d", int(elapsed.Minutes()), int(elapsed.Seconds())%60)
+	if f.task.Estimate != nil {
+		line += " / ~" + task.FormatEstimate(*f.task.Estimate)
+	}
+	if !f.running {
+		if elapsed == 0 {
+			return theme.Muted.Render(line)
+		}
+		return theme.Muted.Render(line + " (paused)")
+	}
+	if f.task.Estimate != nil && elapsed > time.Duration(*f.task.Estimate)*time.Minute {
+		return theme.Warning.Render(line)
+	}
+	return theme.Accent.Render(line)
+}
+
+// metaLine renders scope, dates, estimate, context and tags on one muted line
+func (f FocusView) metaLine() string {
+	theme := f.styles.Theme
+	t := f.task
+
+	var parts []string
+	if t.ParentName != nil {
+		parts = append(parts, theme.Scope.Render(*t.ParentName))
+	} else if t.AreaName != nil {
+		parts = append(parts, theme.Scope.Render(*t.AreaName))
+	}
+	if t.PlannedDate != nil {
+		parts = append(parts, theme.Muted.Render(theme.Icons.Date+" "+t.PlannedDate.Format("Jan 2")))
+	}
+	if t.DueDate != nil {
+		parts = append(parts, theme.Muted.Render(theme.Icons.Due+" "+t.DueDate.Format("Jan 2")))
+	}
+	if t.Estimate != nil {
+		parts = append(parts, theme.Muted.Render("~"+task.FormatEstimate(*t.Estimate)))
+	}
+	if t.Context != nil {
+		parts = append(parts, theme.Muted.Render("@"+*t.Context))
+	}
+	if t.Location != nil {
+		parts = append(parts, theme.Muted.Render("⌂"+*t.Location))
+	}
+	for _, tag := range t.Tags {
+		parts = append(parts, theme.RenderTags("#"+tag))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// Active returns whether the focus view is shown
+func (f FocusView) Active() bool {
+	return f.active
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+)
+
+// manualClock is a clock the test moves forward by hand
+type manualClock struct{ now time.Time }
+
+func (c *manualClock) Now() time.Time { return c.now }
+
+func TestFocusTimer(t *testing.T) {
+	clk := &manualClock{now: time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)}
+	f := NewFocusView(NewStyles(output.DefaultTheme(), nil)).SetClock(clk)
+	f = f.Open(&task.Task{ID: 1, Title: "Write report"})
+	press := func(key string) *FocusResult {
+		var result *FocusResult
+		f, result = f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		return result
+	}
+
+	if result := press("t"); result == nil || !result.Timer {
+		t.Fatalf("starting the timer returned %+v, want Timer", result)
+	}
+	first := f.timerID
+	clk.now = clk.now.Add(90 * time.Second)
+	if got := f.Elapsed(); got != 90*time.Second {
+		t.Errorf("Elapsed() = %v, want 1m30s", got)
+	}
+
+	press("t") // pause
+	clk.now = clk.now.Add(time.Hour)
+	if got := f.Elapsed(); got != 90*time.Second {
+		t.Errorf("Elapsed() while paused = %v, want 1m30s", got)
+	}
+	if f.Ticking(first) {
+		t.Error("paused timer still ticking")
+	}
+
+	press("t") // resume
+	if f.Ticking(first) || !f.Ticking(f.timerID) {
+		t.Error("ticks of the first start should stop, ticks of the new start continue")
+	}
+	clk.now = clk.now.Add(30 * time.Second)
+	if got := f.Elapsed(); got != 2*time.Minute {
+		t.Errorf("Elapsed() after resuming = %v, want 2m0s", got)
+	}
+
+	press("r")
+	if got := f.Elapsed(); got != 0 {
+		t.Errorf("Elapsed() after reset = %v, want 0", got)
+	}
+}
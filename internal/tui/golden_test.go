@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/devbydaniel/tt/internal/testutil"
+	"github.com/devbydaniel/tt/internal/testutil/fixture"
+)
+
+// renderContent builds the task list panel for the fixture tasks at the
+// fixture clock, with the selection on the first task
+func renderContent(width int, set func(c Content) Content) string {
+	c := NewContent(NewStyles(output.DefaultTheme(), nil)).SetClock(fixture.Clock)
+	c = c.SetSize(width, 40)
+	c = set(c).SetFocused(true)
+	return c.buildTaskList() + "\n"
+}
+
+func TestGoldenContent(t *testing.T) {
+	tasks := fixture.Tasks()
+	var groups ScheduleGroups
+	for _, tk := range tasks {
+		switch {
+		case tk.State == task.StateSomeday:
+			groups.Someday = append(groups.Someday, tk)
+		case tk.PlannedDate == nil && tk.DueDate == nil:
+			groups.Anytime = append(groups.Anytime, tk)
+		case tk.ID <= 4:
+			groups.Today = append(groups.Today, tk)
+		default:
+			groups.Upcoming = append(groups.Upcoming, tk)
+		}
+	}
+
+	tests := map[string]func(c Content) Content{
+		"content":          func(c Content) Content { return c.SetTasks(tasks, "All", "none", false) },
+		"content_scope":    func(c Content) Content { return c.SetTasks(tasks, "All", "scope", false) },
+		"content_date":     func(c Content) Content { return c.SetTasks(tasks, "All", "date", false) },
+		"content_tag":      func(c Content) Content { return c.SetTasks(tasks, "All", "tag", false) },
+		"content_state":    func(c Content) Content { return c.SetTasks(tasks, "All", "state", false) },
+		"content_schedule": func(c Content) Content { return c.SetScheduleGroups(groups, "Work", true) },
+		"content_empty":    func(c Content) Content { return c.SetTasks(nil, "Inbox", "none", false) },
+	}
+	for name, set := range tests {
+		t.Run(name, func(t *testing.T) {
+			testutil.Golden(t, name, renderContent(70, set))
+		})
+	}
+}
@@ -3,26 +3,41 @@ package tui
 import "github.com/charmbracelet/bubbles/key"
 
 type keyMap struct {
-	Up           key.Binding
-	Down         key.Binding
-	Tab          key.Binding
-	ShiftTab     key.Binding
-	Enter        key.Binding
-	Escape       key.Binding
-	FocusSidebar key.Binding
-	FocusContent key.Binding
-	Rename       key.Binding
-	Move         key.Binding
-	Planned      key.Binding
-	Due          key.Binding
-	Tags         key.Binding
-	Add          key.Binding
-	AddProject   key.Binding
-	AddArea      key.Binding
-	Toggle       key.Binding
-	Someday      key.Binding
-	Delete       key.Binding
-	Quit         key.Binding
+	Up            key.Binding
+	Down          key.Binding
+	Top           key.Binding
+	Bottom        key.Binding
+	HalfPageUp    key.Binding
+	HalfPageDown  key.Binding
+	PrevGroup     key.Binding
+	NextGroup     key.Binding
+	Tab           key.Binding
+	ShiftTab      key.Binding
+	Enter         key.Binding
+	Escape        key.Binding
+	FocusSidebar  key.Binding
+	FocusContent  key.Binding
+	ToggleSidebar key.Binding
+	Rename        key.Binding
+	Move          key.Binding
+	Planned       key.Binding
+	Due           key.Binding
+	Tags          key.Binding
+	Add           key.Binding
+	QuickAdd      key.Binding
+	AddProject    key.Binding
+	AddArea       key.Binding
+	Toggle        key.Binding
+	Someday       key.Binding
+	Delete        key.Binding
+	Planning      key.Binding
+	Context       key.Binding
+	Location      key.Binding
+	Flag          key.Binding
+	Yank          key.Binding
+	Focus         key.Binding
+	ReloadConfig  key.Binding
+	Quit          key.Binding
 }
 
 // sidebarKeyMap provides help bindings when sidebar is focused
@@ -33,7 +48,7 @@ func (k sidebarKeyMap) ShortHelp() []key.Binding {
 }
 
 func (k sidebarKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{keys.Up, keys.Down, keys.Tab, keys.ShiftTab, keys.FocusContent, keys.Quit}}
+	return [][]key.Binding{{keys.Up, keys.Down, keys.Tab, keys.ShiftTab, keys.FocusContent, keys.ToggleSidebar, keys.ReloadConfig, keys.Quit}}
 }
 
 // sidebarProjectKeyMap provides help bindings when a project is selected in sidebar
@@ -51,11 +66,11 @@ func (k sidebarProjectKeyMap) FullHelp() [][]key.Binding {
 type contentKeyMap struct{}
 
 func (k contentKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{keys.Up, keys.Down, keys.FocusSidebar, keys.Rename, keys.Move, keys.Planned, keys.Due, keys.Tags, keys.Add, keys.Toggle, keys.Someday, keys.Delete, keys.Quit}
+	return []key.Binding{keys.Up, keys.Down, keys.FocusSidebar, keys.Rename, keys.Move, keys.Planned, keys.Due, keys.Tags, keys.Add, keys.QuickAdd, keys.Toggle, keys.Someday, keys.Context, keys.Flag, keys.Location, keys.Focus, keys.Delete, keys.Planning, keys.Quit}
 }
 
 func (k contentKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{keys.Up, keys.Down, keys.FocusSidebar, keys.Rename, keys.Move, keys.Planned, keys.Due, keys.Tags, keys.Toggle, keys.Someday, keys.Delete, keys.Quit}}
+	return [][]key.Binding{{keys.Up, keys.Down, keys.Top, keys.Bottom, keys.HalfPageUp, keys.HalfPageDown, keys.PrevGroup, keys.NextGroup, keys.FocusSidebar, keys.Rename, keys.Move, keys.Planned, keys.Due, keys.Tags, keys.QuickAdd, keys.Toggle, keys.Someday, keys.Context, keys.Flag, keys.Yank, keys.Location, keys.Focus, keys.Delete, keys.Planning, keys.ToggleSidebar, keys.ReloadConfig, keys.Quit}}
 }
 
 // renameKeyMap provides help bindings for rename modal
@@ -100,6 +115,7 @@ type dateInputKeyMap struct{}
 func (k dateInputKeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{
 		key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "date picker")),
+		key.NewBinding(key.WithKeys("alt+t"), key.WithHelp("alt+key", "quick pick")),
 		keys.Enter,
 		keys.Escape,
 	}
@@ -116,6 +132,7 @@ func (k datePickerKeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{
 		key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "input")),
 		key.NewBinding(key.WithKeys("left", "up", "right", "down"), key.WithHelp("←↑→↓", "navigate")),
+		key.NewBinding(key.WithKeys("t"), key.WithHelp("t/m/w/e/1-9/c", "quick pick")),
 		keys.Enter,
 		keys.Escape,
 	}
@@ -133,6 +150,7 @@ func (k addKeyMap) ShortHelp() []key.Binding {
 		key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next field")),
 		key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("s-tab", "prev field")),
 		keys.Enter,
+		key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "add")),
 		keys.Escape,
 	}
 }
@@ -232,6 +250,53 @@ func (k sidebarScopesKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{{keys.Up, keys.Down, keys.AddProject, keys.AddArea, keys.FocusContent, keys.Quit}}
 }
 
+// planningKeyMap provides help bindings for the planning view
+type planningKeyMap struct{}
+
+func (k planningKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Up,
+		keys.Down,
+		key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7"), key.WithHelp("1-7", "plan on day")),
+		keys.Escape,
+	}
+}
+
+func (k planningKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// focusKeyMap provides help bindings for focus mode
+type focusKeyMap struct{}
+
+func (k focusKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		keys.Toggle,
+		key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "snooze to tomorrow")),
+		key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "start/pause timer")),
+		key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reset timer")),
+		keys.Escape,
+	}
+}
+
+func (k focusKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// quickAddKeyMap provides help bindings for the quick-add input
+type quickAddKeyMap struct{}
+
+func (k quickAddKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "add")),
+		key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "done")),
+	}
+}
+
+func (k quickAddKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
 var (
 	sidebarKeys        = sidebarKeyMap{}
 	sidebarProjectKeys = sidebarProjectKeyMap{}
@@ -249,6 +314,9 @@ var (
 	confirmKeys        = confirmKeyMap{}
 	createAreaKeys     = createAreaKeyMap{}
 	createProjectKeys  = createProjectKeyMap{}
+	planningKeys       = planningKeyMap{}
+	focusKeys          = focusKeyMap{}
+	quickAddKeys       = quickAddKeyMap{}
 )
 
 var keys = keyMap{
@@ -260,6 +328,30 @@ var keys = keyMap{
 		key.WithKeys("j", "down"),
 		key.WithHelp("j/down", "move down"),
 	),
+	Top: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("gg", "first task"),
+	),
+	Bottom: key.NewBinding(
+		key.WithKeys("G"),
+		key.WithHelp("G", "last task"),
+	),
+	HalfPageUp: key.NewBinding(
+		key.WithKeys("ctrl+u"),
+		key.WithHelp("ctrl+u", "half page up"),
+	),
+	HalfPageDown: key.NewBinding(
+		key.WithKeys("ctrl+d"),
+		key.WithHelp("ctrl+d", "half page down"),
+	),
+	PrevGroup: key.NewBinding(
+		key.WithKeys("{"),
+		key.WithHelp("{", "previous group"),
+	),
+	NextGroup: key.NewBinding(
+		key.WithKeys("}"),
+		key.WithHelp("}", "next group"),
+	),
 	Tab: key.NewBinding(
 		key.WithKeys("tab"),
 		key.WithHelp("tab", "next section"),
@@ -284,6 +376,10 @@ var keys = keyMap{
 		key.WithKeys("l"),
 		key.WithHelp("l", "content"),
 	),
+	ToggleSidebar: key.NewBinding(
+		key.WithKeys("\\"),
+		key.WithHelp("\\", "toggle sidebar"),
+	),
 	Rename: key.NewBinding(
 		key.WithKeys("r"),
 		key.WithHelp("r", "rename"),
@@ -308,6 +404,10 @@ var keys = keyMap{
 		key.WithKeys("a"),
 		key.WithHelp("a", "add"),
 	),
+	QuickAdd: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "quick add"),
+	),
 	AddProject: key.NewBinding(
 		key.WithKeys("a"),
 		key.WithHelp("a", "project"),
@@ -328,6 +428,34 @@ var keys = keyMap{
 		key.WithKeys("backspace"),
 		key.WithHelp("bksp", "delete"),
 	),
+	Context: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "cycle context"),
+	),
+	Flag: key.NewBinding(
+		key.WithKeys("*"),
+		key.WithHelp("*", "flag"),
+	),
+	Yank: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy"),
+	),
+	Location: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "filter by location"),
+	),
+	Focus: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "focus"),
+	),
+	Planning: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "plan week"),
+	),
+	ReloadConfig: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "reload config"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
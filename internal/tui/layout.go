@@ -0,0 +1,162 @@
+package tui
+
+import "github.com/charmbracelet/lipgloss"
+
+// layoutMode is how the panes are arranged for the terminal's width
+type layoutMode int
+
+const (
+	// layoutNormal shows the sidebar and task list, and the detail pane
+	// once it's opened
+	layoutNormal layoutMode = iota
+	// layoutCompact shows one pane at a time, the focused one, across the
+	// full width
+	layoutCompact
+	// layoutWide always shows the detail pane, following the selected task
+	layoutWide
+)
+
+const (
+	// compactWidth is the width below which panes no longer fit side by side
+	compactWidth = 80
+	// wideWidth is the width from which the detail pane stays open
+	wideWidth = 160
+
+	minSidebarWidth = 20
+	maxSidebarWidth = 40
+)
+
+// layoutFor returns the layout mode for a terminal width
+func layoutFor(width int) layoutMode {
+	switch {
+	case width < compactWidth:
+		return layoutCompact
+	case width >= wideWidth:
+		return layoutWide
+	}
+	return layoutNormal
+}
+
+// showDetail returns whether the detail pane takes up room in the layout
+func (m Model) showDetail() bool {
+	return m.detailVisible || m.layout == layoutWide
+}
+
+// showSidebar returns whether the sidebar is drawn next to the task list
+func (m Model) showSidebar() bool {
+	return m.layout != layoutCompact && !m.sidebarHidden
+}
+
+// toggleSidebar shows or hides the sidebar. In the compact layout, where
+// only one pane fits, it switches between the sidebar and the task list.
+func (m Model) toggleSidebar() Model {
+	if m.layout == layoutCompact {
+		if m.focusArea == FocusSidebar {
+			return m.focusContent()
+		}
+		return m.focusSidebar()
+	}
+
+	m.sidebarHidden = !m.sidebarHidden
+	if m.sidebarHidden && m.focusArea == FocusSidebar {
+		return m.focusContent()
+	}
+	return m.recalculateLayout()
+}
+
+// focusSidebar moves focus to the sidebar, closing the detail pane
+func (m Model) focusSidebar() Model {
+	m.focusArea = FocusSidebar
+	m.sidebarHidden = false
+	m.sidebar = m.sidebar.SetFocused(true)
+	m.detailPane = m.detailPane.SetFocused(false)
+	m.content = m.content.SetShowSelection(false)
+	m.content = m.content.SetFocused(false)
+	m.detailVisible = false
+	return m.recalculateLayout()
+}
+
+// focusContent moves focus to the task list, closing the detail pane
+func (m Model) focusContent() Model {
+	m.focusArea = FocusContent
+	m.sidebar = m.sidebar.SetFocused(false)
+	m.detailPane = m.detailPane.SetFocused(false)
+	m.detailVisible = false
+	m.content = m.content.SetShowSelection(false)
+	m.content = m.content.SetFocused(true)
+	return m.recalculateLayout()
+}
+
+// recalculateLayout recalculates component sizes based on current state
+func (m Model) recalculateLayout() Model {
+	if m.width == 0 || m.height == 0 {
+		return m
+	}
+	m.layout = layoutFor(m.width)
+
+	// Reserve 1 row for help bar at the bottom, and keep the height evenly
+	// divisible by the sidebar's 3 sections so all columns end at the same row
+	height := max((m.height-1)/3*3, 3)
+
+	if m.layout == layoutCompact {
+		m.sidebar = m.sidebar.SetSize(m.width, height)
+		m.content = m.content.SetSize(m.width, height)
+		m.detailPane = m.detailPane.SetSize(m.width, height)
+		m.gap = 0
+		return m
+	}
+
+	gap := 1
+	sidebarWidth := 0
+	if m.showSidebar() {
+		// 1/4 of total, constrained between min/max
+		sidebarWidth = min(max(m.width/4, minSidebarWidth), maxSidebarWidth)
+		m.sidebar = m.sidebar.SetSize(sidebarWidth, height)
+	}
+
+	contentWidth := m.width - sidebarWidth
+	if sidebarWidth > 0 {
+		contentWidth -= gap
+	}
+	if m.showDetail() {
+		// Split the rest between content (60%) and detail (40%)
+		remaining := contentWidth - gap
+		contentWidth = remaining * 60 / 100
+		m.detailPane = m.detailPane.SetSize(remaining-contentWidth, height)
+	}
+	m.content = m.content.SetSize(contentWidth, height)
+	m.gap = gap
+
+	return m
+}
+
+// mainView renders the panes of the current layout side by side
+func (m Model) mainView() string {
+	if m.layout == layoutCompact {
+		switch m.focusArea {
+		case FocusSidebar:
+			return m.sidebar.View()
+		case FocusDetail:
+			return m.detailPane.View()
+		}
+		return m.content.View()
+	}
+
+	var panes []string
+	margin := 0
+	if m.showSidebar() {
+		panes = append(panes, m.sidebar.View())
+		margin = m.gap
+	}
+	panes = append(panes, lipgloss.NewStyle().MarginLeft(margin).Render(m.content.View()))
+
+	if m.showDetail() {
+		detail := m.detailPane
+		if m.focusArea != FocusDetail {
+			// Follow the selection while the detail pane isn't in use
+			detail = detail.Preview(m.content.SelectedTask())
+		}
+		panes = append(panes, lipgloss.NewStyle().MarginLeft(m.gap).Render(detail.View()))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, panes...)
+}
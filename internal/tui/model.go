@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -10,9 +11,11 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/devbydaniel/tt/config"
 	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/clock"
 	"github.com/devbydaniel/tt/internal/domain/area"
+	"github.com/devbydaniel/tt/internal/domain/comment"
 	"github.com/devbydaniel/tt/internal/domain/task"
-	taskusecases "github.com/devbydaniel/tt/internal/domain/task/usecases"
+	"github.com/devbydaniel/tt/internal/logging"
 	"github.com/devbydaniel/tt/internal/output"
 )
 
@@ -54,28 +57,38 @@ type Model struct {
 	confirmModal       ConfirmModal
 	createProjectModal CreateProjectModal
 	createAreaModal    CreateAreaModal
+	quickAdd           QuickAdd
+	planningView       PlanningView
+	focusView          FocusView
 	help               help.Model
 	focusArea          FocusArea
 	detailVisible      bool // whether the detail pane is shown
+	sidebarHidden      bool // whether the sidebar is toggled off
+	layout             layoutMode
+	pendingG           bool   // whether g was pressed, waiting for a second g
+	status             string // shown in place of the help bar until the next key
 
 	// Cached data
 	areas    []area.Area
 	projects []task.Task
 	tags     []string
 
+	// location limits the task lists to one location (empty = all)
+	location string
+
+	// restore is the saved session to return to once data has loaded
+	restore *Session
+
 	// Error state
 	err error
 }
 
 // NewModel creates a new TUI model
 func NewModel(application *app.App, theme *output.Theme, cfg *config.Config) Model {
-	styles := NewStyles(theme)
+	styles := NewStyles(theme, &cfg.Theme.TUI)
 
 	// Initialize help with theme-matching styles
-	helpModel := help.New()
-	helpModel.Styles.ShortKey = theme.Accent
-	helpModel.Styles.ShortDesc = theme.Muted
-	helpModel.Styles.ShortSeparator = theme.Muted
+	helpModel := themedHelp(help.New(), theme)
 
 	return Model{
 		app:                application,
@@ -83,21 +96,29 @@ func NewModel(application *app.App, theme *output.Theme, cfg *config.Config) Mod
 		styles:             styles,
 		gap:                1, // Default gap, adjusted on resize
 		sidebar:            NewSidebar(styles),
-		content:            NewContent(styles),
+		content:            NewContent(styles).SetClock(application.Clock).SetSchedule(application.Schedule).SetDateGroups(cfg.DateGroups),
 		detailPane:         NewDetailPane(styles),
 		renameModal:        NewRenameModal(styles),
 		moveModal:          NewMoveModal(styles),
-		dateModal:          NewDateModal(styles),
-		addModal:           NewAddModal(styles),
+		dateModal:          NewDateModal(styles).SetClock(application.Clock).SetSchedule(application.Schedule),
+		addModal:           NewAddModal(styles).SetClock(application.Clock).SetSchedule(application.Schedule).SetDefaults(cfg.Defaults),
 		tagModal:           NewTagModal(styles),
 		descriptionModal:   NewDescriptionModal(styles),
 		confirmModal:       NewConfirmModal(styles),
 		createProjectModal: NewCreateProjectModal(styles),
 		createAreaModal:    NewCreateAreaModal(styles),
+		quickAdd:           NewQuickAdd(styles),
+		planningView:       NewPlanningView(styles),
+		focusView:          NewFocusView(styles).SetClock(application.Clock),
 		help:               helpModel,
 	}
 }
 
+// today returns the date considered today by the app's clock and schedule
+func (m Model) today() time.Time {
+	return m.app.Schedule.Today(clock.Now(m.app.Clock))
+}
+
 // configKeyForSelection returns the config key for the current sidebar selection
 func (m Model) configKeyForSelection() string {
 	item := m.sidebar.SelectedItem()
@@ -107,7 +128,7 @@ func (m Model) configKeyForSelection() string {
 	case "project":
 		return "project"
 	case "area":
-		return "area"
+		return "area:" + item.Key
 	case "tag":
 		return "tag"
 	}
@@ -159,22 +180,28 @@ type loadDataMsg struct {
 	projects []task.Task
 	tags     []string
 	tasks    []task.Task
+	wip      []task.WIPCount
 	err      error
 }
 
 // loadData fetches initial data
 func (m Model) loadData() tea.Msg {
-	areas, err := m.app.ListAreas.Execute()
+	areas, err := m.app.Areas.List()
+	if err != nil {
+		return loadDataMsg{err: err}
+	}
+
+	projects, err := m.app.Projects.ListWithArea()
 	if err != nil {
 		return loadDataMsg{err: err}
 	}
 
-	projects, err := m.app.ListProjectsWithArea.Execute()
+	tags, err := m.app.Tasks.Tags()
 	if err != nil {
 		return loadDataMsg{err: err}
 	}
 
-	tags, err := m.app.ListTags.Execute()
+	wip, err := m.app.Tasks.WIP()
 	if err != nil {
 		return loadDataMsg{err: err}
 	}
@@ -182,7 +209,7 @@ func (m Model) loadData() tea.Msg {
 	// Load today's tasks by default with sort from config
 	sortStr := m.config.GetSort("today")
 	sortOpts, _ := task.ParseSort(sortStr)
-	tasks, err := m.app.ListTasks.Execute(&task.ListOptions{Schedule: "today", Sort: sortOpts})
+	tasks, err := m.app.Tasks.List(&task.ListOptions{Schedule: "today", Sort: sortOpts})
 	if err != nil {
 		return loadDataMsg{err: err}
 	}
@@ -192,13 +219,66 @@ func (m Model) loadData() tea.Msg {
 		projects: projects,
 		tags:     tags,
 		tasks:    tasks,
+		wip:      wip,
+	}
+}
+
+// wipLoadedMsg carries the counts of areas and projects with a WIP limit
+type wipLoadedMsg struct {
+	counts []task.WIPCount
+	err    error
+}
+
+// loadWIP fetches the WIP counts shown in the sidebar
+func (m Model) loadWIP() tea.Msg {
+	counts, err := m.app.Tasks.WIP()
+	return wipLoadedMsg{counts: counts, err: err}
+}
+
+// logMsg records a message in the debug log, leaving out timer ticks
+func logMsg(msg tea.Msg) {
+	if _, ok := msg.(focusTickMsg); !ok {
+		logging.Debug("tui message", "msg", describeMsg(msg))
 	}
 }
 
 // Update implements tea.Model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if logging.Enabled() {
+		logMsg(msg)
+	}
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Route keys to planning view when active
+		if m.planningView.Active() {
+			var result *PlanningResult
+			m.planningView, result = m.planningView.Update(msg)
+			if result != nil {
+				if result.Closed {
+					return m, m.loadTasksForSelection
+				}
+				return m, m.planTaskOnDay(result.TaskID, result.Date)
+			}
+			return m, nil
+		}
+
+		// Route keys to focus view when active
+		if m.focusView.Active() {
+			var result *FocusResult
+			m.focusView, result = m.focusView.Update(msg)
+			if result != nil {
+				switch {
+				case result.Toggle:
+					return m, m.toggleTask(result.TaskID, result.Status)
+				case result.Snooze:
+					return m, m.snoozeTask(result.TaskID)
+				case result.Timer:
+					return m, focusTick(m.focusView.timerID)
+				}
+			}
+			return m, nil
+		}
+
 		// Route keys to add modal when active
 		if m.addModal.Active() {
 			var result *AddResult
@@ -228,6 +308,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var result *MoveResult
 			m.moveModal, result = m.moveModal.Update(msg)
 			if result != nil && !result.Canceled {
+				if result.NewProject {
+					return m, m.moveToNewProject(result.TaskID, result.Name, result.AreaName)
+				}
 				return m, m.moveTask(result.TaskID, result.ItemType, result.Name)
 			}
 			return m, nil
@@ -293,18 +376,87 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		m.status = ""
+
+		// Route keys to the quick-add input when open
+		if m.quickAdd.Active() {
+			var result *QuickAddResult
+			m.quickAdd, result = m.quickAdd.Update(msg)
+			m.content = m.content.SetInput(m.quickAdd.View())
+			if result != nil && !result.Closed {
+				return m, m.quickAddTask(result.Title)
+			}
+			return m, nil
+		}
+
+		// g waits for a second g to jump to the top
+		pendingG := m.pendingG
+		m.pendingG = false
+
 		switch {
 		case key.Matches(msg, keys.Quit):
 			return m, tea.Quit
 
+		case key.Matches(msg, keys.Top):
+			if m.focusArea == FocusContent {
+				if pendingG {
+					m.content = m.content.MoveToTop()
+				} else {
+					m.pendingG = true
+				}
+				return m, nil
+			}
+
+		case key.Matches(msg, keys.Yank):
+			if m.focusArea == FocusContent {
+				if selectedTask := m.content.SelectedTask(); selectedTask != nil {
+					return m, m.yankTask(selectedTask)
+				}
+			}
+
+		case key.Matches(msg, keys.Bottom):
+			if m.focusArea == FocusContent {
+				m.content = m.content.MoveToBottom()
+				return m, nil
+			}
+
+		case key.Matches(msg, keys.HalfPageUp):
+			if m.focusArea == FocusContent {
+				m.content = m.content.MoveHalfPageUp()
+				return m, nil
+			}
+
+		case key.Matches(msg, keys.HalfPageDown):
+			if m.focusArea == FocusContent {
+				m.content = m.content.MoveHalfPageDown()
+				return m, nil
+			}
+
+		case key.Matches(msg, keys.PrevGroup):
+			if m.focusArea == FocusContent {
+				m.content = m.content.MoveToPrevGroup()
+				return m, nil
+			}
+
+		case key.Matches(msg, keys.NextGroup):
+			if m.focusArea == FocusContent {
+				m.content = m.content.MoveToNextGroup()
+				return m, nil
+			}
+
+		case key.Matches(msg, keys.ReloadConfig):
+			return m, reloadConfig
+
 		case key.Matches(msg, keys.Enter):
 			if m.focusArea == FocusSidebar {
-				m.focusArea = FocusContent
-				m.sidebar = m.sidebar.SetFocused(false)
-				m.content = m.content.SetFocused(true)
-				return m, nil
+				return m.focusContent(), nil
 			}
 			if m.focusArea == FocusContent {
+				if m.content.OnHeader() {
+					// Enter on a group header collapses or expands the group
+					m.content = m.content.ToggleGroup()
+					return m, nil
+				}
 				// Enter from content opens detail pane
 				return m.openDetailPane()
 			}
@@ -316,30 +468,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, keys.Escape), key.Matches(msg, keys.FocusSidebar):
 			if m.focusArea == FocusDetail {
 				// Close detail pane, return to content
-				m.focusArea = FocusContent
-				m.detailPane = m.detailPane.SetFocused(false)
-				m.detailVisible = false
-				m.content = m.content.SetShowSelection(false)
-				m.content = m.content.SetFocused(true)
-				m = m.recalculateLayout()
-				return m, nil
+				return m.focusContent(), nil
 			}
 			if m.focusArea == FocusContent {
-				m.focusArea = FocusSidebar
-				m.sidebar = m.sidebar.SetFocused(true)
-				m.content = m.content.SetShowSelection(false)
-				m.content = m.content.SetFocused(false)
-				m.detailVisible = false
-				m = m.recalculateLayout()
-				return m, nil
+				return m.focusSidebar(), nil
 			}
 
+		case key.Matches(msg, keys.ToggleSidebar):
+			return m.toggleSidebar(), nil
+
 		case key.Matches(msg, keys.FocusContent):
 			if m.focusArea == FocusSidebar {
-				m.focusArea = FocusContent
-				m.sidebar = m.sidebar.SetFocused(false)
-				m.content = m.content.SetFocused(true)
-				return m, nil
+				return m.focusContent(), nil
 			}
 			if m.focusArea == FocusContent {
 				// l from content opens detail pane
@@ -451,6 +591,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.addModal = m.addModal.Open(m.projects, m.areas, prefill)
 			return m, nil
 
+		case key.Matches(msg, keys.QuickAdd):
+			if m.focusArea == FocusContent {
+				m.quickAdd = m.quickAdd.Open(m.content.width - 4)
+				m.content = m.content.SetInput(m.quickAdd.View())
+				return m, nil
+			}
+
 		case key.Matches(msg, keys.AddArea):
 			// Only works when sidebar is focused and scopes section is active
 			if m.focusArea == FocusSidebar && m.sidebar.IsScopesSectionActive() {
@@ -459,6 +606,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+		case key.Matches(msg, keys.Planning):
+			m.planningView = m.planningView.SetSize(m.width, m.height-1)
+			m.planningView = m.planningView.Open()
+			return m, m.loadPlanning
+
+		case key.Matches(msg, keys.Focus):
+			if m.focusArea == FocusContent {
+				if selectedTask := m.content.SelectedTask(); selectedTask != nil {
+					m.focusView = m.focusView.SetSize(m.width, m.height-1)
+					m.focusView = m.focusView.Open(selectedTask)
+					return m, nil
+				}
+			}
+
 		case key.Matches(msg, keys.Toggle):
 			if m.focusArea == FocusContent {
 				if selectedTask := m.content.SelectedTask(); selectedTask != nil {
@@ -478,6 +639,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		case key.Matches(msg, keys.Context):
+			if m.focusArea == FocusContent {
+				if selectedTask := m.content.SelectedTask(); selectedTask != nil {
+					return m, m.cycleTaskContext(selectedTask.ID, selectedTask.Context)
+				}
+			}
+
+		case key.Matches(msg, keys.Flag):
+			if m.focusArea == FocusContent {
+				if selectedTask := m.content.SelectedTask(); selectedTask != nil {
+					return m, m.toggleTaskFlag(selectedTask.ID, selectedTask.Flagged)
+				}
+			}
+
+		case key.Matches(msg, keys.Location):
+			if m.focusArea == FocusContent || m.focusArea == FocusSidebar {
+				return m, m.cycleLocation
+			}
+
 		case key.Matches(msg, keys.Delete):
 			if m.focusArea == FocusContent {
 				if selectedTask := m.content.SelectedTask(); selectedTask != nil {
@@ -546,93 +726,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-
-		// Reserve 1 row for help bar at the bottom
-		helpHeight := 1
-		availableHeight := m.height - helpHeight
-
-		// Calculate sidebar width: 1/4 of total, constrained between min/max
-		sidebarWidth := m.width / 4
-		minSidebar := 20
-		maxSidebar := 40
-
-		if sidebarWidth < minSidebar {
-			sidebarWidth = minSidebar
-		}
-		if sidebarWidth > maxSidebar {
-			sidebarWidth = maxSidebar
-		}
-
-		// Gap between panels (can be reduced for tight spaces)
-		gap := 1
-		minContentWidth := 20
-		minDetailWidth := 25
-
-		// Calculate widths based on whether detail pane is visible
-		var contentWidth, detailWidth int
-		if m.detailVisible {
-			// Three-column layout: sidebar | content | detail
-			remainingWidth := m.width - sidebarWidth - gap*2
-			// Split remaining between content (60%) and detail (40%)
-			contentWidth = remainingWidth * 60 / 100
-			detailWidth = remainingWidth - contentWidth
-
-			// Ensure minimum widths
-			if contentWidth < minContentWidth {
-				contentWidth = minContentWidth
-			}
-			if detailWidth < minDetailWidth {
-				detailWidth = minDetailWidth
-			}
-
-			// If we exceed available space, reduce proportionally
-			totalNeeded := sidebarWidth + contentWidth + detailWidth + gap*2
-			if totalNeeded > m.width {
-				// Reduce sidebar first
-				sidebarWidth = m.width - contentWidth - detailWidth - gap*2
-				if sidebarWidth < 10 {
-					sidebarWidth = 10
-					gap = 0
-					contentWidth = (m.width - sidebarWidth - minDetailWidth) * 60 / 100
-					detailWidth = m.width - sidebarWidth - contentWidth
-				}
-			}
-		} else {
-			// Two-column layout: sidebar | content
-			contentWidth = m.width - sidebarWidth - gap
-
-			// If content would be too small, shrink sidebar to give content more room
-			if contentWidth < minContentWidth {
-				sidebarWidth = m.width - minContentWidth - gap
-				if sidebarWidth < 10 { // Absolute minimum sidebar
-					sidebarWidth = 10
-					gap = 0 // Remove gap entirely when very tight
-					contentWidth = m.width - sidebarWidth
-				} else {
-					contentWidth = minContentWidth
-				}
-			}
-		}
-
-		// Ensure nothing goes negative
-		if sidebarWidth < 1 {
-			sidebarWidth = 1
-		}
-		if contentWidth < 1 {
-			contentWidth = 1
-		}
-
-		// Calculate sidebar height that's evenly divisible by 3 (number of sections)
-		// This ensures all columns end at the same row
-		sidebarHeight := (availableHeight / 3) * 3
-
-		m.sidebar = m.sidebar.SetSize(sidebarWidth, sidebarHeight)
-		m.content = m.content.SetSize(contentWidth, sidebarHeight)
-		if m.detailVisible {
-			m.detailPane = m.detailPane.SetSize(detailWidth, sidebarHeight)
-		}
+		m = m.recalculateLayout()
+		m.planningView = m.planningView.SetSize(m.width, m.height-1)
+		m.focusView = m.focusView.SetSize(m.width, m.height-1)
 		m.help.Width = m.width
-		m.gap = gap // Store gap for View()
 		return m, nil
 
 	case loadDataMsg:
@@ -643,27 +740,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.areas = msg.areas
 		m.projects = msg.projects
 		m.tags = msg.tags
-		m.sidebar = m.sidebar.SetData(msg.areas, msg.projects, msg.tags)
+		m.sidebar = m.sidebar.SetData(msg.areas, msg.projects, msg.tags).SetWIP(msg.wip)
 		// Get groupBy and hideScope for initial "today" view
 		groupBy := m.config.GetGroup("today")
 		hideScope := m.config.GetHideScope("today")
+		m.content = m.content.SetOverflow(m.config.GetOverflow("today")).SetGroupSort(m.config.GetGroupSort("today"))
 		m.content = m.content.SetTasks(msg.tasks, "Today", groupBy, hideScope)
-		return m, nil
+		if restored, ok := m.restoreView(); ok {
+			return restored, restored.loadTasksForSelection
+		}
+		return m.restoreSelection(), nil
 
 	case tasksLoadedMsg:
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
+		m.content = m.content.SetOverflow(m.config.GetOverflow(m.configKeyForSelection())).SetGroupSort(m.config.GetGroupSort(m.configKeyForSelection()))
 		m.content = m.content.SetTasks(msg.tasks, msg.title, msg.groupBy, msg.hideScope)
-		return m, nil
+		// Tasks are reloaded after every change, which may change WIP counts
+		return m.restoreSelection(), m.loadWIP
 
 	case scheduleTasksLoadedMsg:
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
+		m.content = m.content.SetOverflow(m.config.GetOverflow(m.configKeyForSelection()))
 		m.content = m.content.SetScheduleGroups(msg.groups, msg.title, msg.hideScope)
+		return m.restoreSelection(), m.loadWIP
+
+	case wipLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.sidebar = m.sidebar.SetWIP(msg.counts)
 		return m, nil
 
 	case taskRenamedMsg:
@@ -699,7 +811,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.detailVisible && m.detailPane.Task() != nil && m.detailPane.Task().ID == msg.task.ID {
 			m.detailPane = m.detailPane.UpdateTask(msg.task)
 		}
-		// If a project was moved, reload sidebar too
+		// If a project was moved or created, reload sidebar too, staying
+		// in the current view
+		if msg.newProject {
+			s := m.Session()
+			m.restore = &s
+			return m, m.loadData
+		}
 		if m.isProjectID(msg.task.ID) {
 			return m, m.loadData
 		}
@@ -771,6 +889,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Reload tasks to reflect the state change
 		return m, m.loadTasksForSelection
 
+	case taskContextUpdatedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if m.detailVisible && m.detailPane.Task() != nil && m.detailPane.Task().ID == msg.task.ID {
+			m.detailPane = m.detailPane.UpdateTask(msg.task)
+		}
+		return m, m.loadTasksForSelection
+
+	case taskFlaggedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if m.detailVisible && m.detailPane.Task() != nil && m.detailPane.Task().ID == msg.task.ID {
+			m.detailPane = m.detailPane.UpdateTask(msg.task)
+		}
+		// Reload so the task moves to or from the top of the list
+		return m, m.loadTasksForSelection
+
 	case taskTagsUpdatedMsg:
 		if msg.err != nil {
 			m.err = msg.err
@@ -799,6 +938,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Reload tasks to reflect the description change
 		return m, m.loadTasksForSelection
 
+	case locationChangedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.location = msg.location
+		return m, m.loadTasksForSelection
+
+	case commentsLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if m.detailPane.Task() != nil && m.detailPane.Task().ID == msg.taskID {
+			m.detailPane = m.detailPane.SetComments(msg.comments)
+		}
+		return m, nil
+
 	case itemDeletedMsg:
 		if msg.err != nil {
 			m.err = msg.err
@@ -818,9 +975,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, m.loadTasksForSelection
 
+	case configReloadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m = m.applyConfig(msg.cfg)
+		return m, m.loadTasksForSelection
+
+	case yankedMsg:
+		if msg.err != nil {
+			m.status = "Copying failed: " + msg.err.Error()
+		} else {
+			m.status = "Copied: " + msg.text
+		}
+		return m, nil
+
+	case focusTickMsg:
+		if m.focusView.Ticking(msg.timerID) {
+			return m, focusTick(msg.timerID)
+		}
+		return m, nil
+
+	case planningLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.planningView = m.planningView.SetData(msg.backlog, msg.week)
+		return m, nil
+
+	case taskPlannedOnDayMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		// Reload so the task moves from the backlog onto its day
+		return m, m.loadPlanning
+
 	case tagsAndTasksUpdatedMsg:
 		m.tags = msg.tags
 		m.sidebar = m.sidebar.SetData(m.areas, m.projects, msg.tags)
+		m.content = m.content.SetOverflow(m.config.GetOverflow(m.configKeyForSelection())).SetGroupSort(m.config.GetGroupSort(m.configKeyForSelection()))
 		m.content = m.content.SetTasks(msg.tasks, msg.title, msg.groupBy, msg.hideScope)
 		return m, nil
 	}
@@ -867,8 +1063,9 @@ type areaRenamedMsg struct {
 
 // taskMovedMsg carries the result of a task move
 type taskMovedMsg struct {
-	task *task.Task
-	err  error
+	task       *task.Task
+	newProject bool // the task went into a project created for it
+	err        error
 }
 
 // taskDateUpdatedMsg carries the result of a date update
@@ -896,18 +1093,43 @@ type taskStateUpdatedMsg struct {
 	err  error
 }
 
+// taskContextUpdatedMsg carries the result of cycling a task's context
+type taskContextUpdatedMsg struct {
+	task *task.Task
+	err  error
+}
+
 // taskTagsUpdatedMsg carries the result of updating tags
 type taskTagsUpdatedMsg struct {
 	task *task.Task
 	err  error
 }
 
+// taskFlaggedMsg carries the result of flagging or unflagging a task
+type taskFlaggedMsg struct {
+	task *task.Task
+	err  error
+}
+
 // taskDescriptionUpdatedMsg carries the result of updating description
 type taskDescriptionUpdatedMsg struct {
 	task *task.Task
 	err  error
 }
 
+// locationChangedMsg carries the next location filter
+type locationChangedMsg struct {
+	location string
+	err      error
+}
+
+// commentsLoadedMsg carries the comments on the task in the detail pane
+type commentsLoadedMsg struct {
+	taskID   int64
+	comments []comment.Comment
+	err      error
+}
+
 // itemDeletedMsg carries the result of a delete operation
 type itemDeletedMsg struct {
 	target     DeleteTarget
@@ -928,6 +1150,25 @@ type areaCreatedMsg struct {
 	err  error
 }
 
+// configReloadedMsg carries a freshly loaded config
+type configReloadedMsg struct {
+	cfg *config.Config
+	err error
+}
+
+// planningLoadedMsg carries data for the planning view
+type planningLoadedMsg struct {
+	backlog []task.Task
+	week    *task.Week
+	err     error
+}
+
+// taskPlannedOnDayMsg carries the result of planning a task from the planning view
+type taskPlannedOnDayMsg struct {
+	task *task.Task
+	err  error
+}
+
 // formatProjectTitle builds a project title with metadata for the content header.
 // Format: "ProjectName  📅 Jan 2  🏁 Jan 15  #tag1 #tag2"
 func (m Model) formatProjectTitle(proj *task.Task) string {
@@ -966,6 +1207,10 @@ func (m Model) loadTasksForSelection() tea.Msg {
 		}
 	}
 
+	if m.location != "" {
+		title += " ⌂" + m.location
+	}
+
 	// Get sort, group, and hideScope settings from config
 	configKey := m.configKeyForSelection()
 	groupBy := m.config.GetGroup(configKey)
@@ -975,14 +1220,14 @@ func (m Model) loadTasksForSelection() tea.Msg {
 
 	// Schedule grouping requires 4 separate queries
 	if groupBy == "schedule" {
-		return m.loadScheduleGroups(item, title, sortOpts, hideScope)
+		return m.loadScheduleGroups(item, title, sortStr, hideScope)
 	}
 
 	// Build list options based on selection
 	opts := m.buildListOptions(item)
 	opts.Sort = sortOpts
 
-	tasks, err := m.app.ListTasks.Execute(opts)
+	tasks, err := m.app.Tasks.List(opts)
 	if err != nil {
 		return tasksLoadedMsg{err: err}
 	}
@@ -1004,12 +1249,14 @@ func (m Model) buildListOptions(item SidebarItem) *task.ListOptions {
 	case "tag":
 		opts.TagName = item.Key
 	}
+	opts.Location = m.location
 
 	return opts
 }
 
-// loadScheduleGroups loads tasks grouped by schedule (4 separate queries)
-func (m Model) loadScheduleGroups(item SidebarItem, title string, sortOpts []task.SortOption, hideScope bool) tea.Msg {
+// loadScheduleGroups loads tasks grouped by schedule (4 separate queries),
+// each sorted by its schedule_sort entry or else the view's sort
+func (m Model) loadScheduleGroups(item SidebarItem, title string, sortStr string, hideScope bool) tea.Msg {
 	var groups ScheduleGroups
 
 	schedules := []struct {
@@ -1025,9 +1272,10 @@ func (m Model) loadScheduleGroups(item SidebarItem, title string, sortOpts []tas
 	for _, sched := range schedules {
 		opts := m.buildListOptions(item)
 		opts.Schedule = sched.schedule
-		opts.Sort = sortOpts
+		opts.AllUpcoming = true // every task lands in one of the groups
+		opts.Sort, _ = task.ParseSort(m.config.GetScheduleSort(sched.schedule, sortStr))
 
-		tasks, err := m.app.ListTasks.Execute(opts)
+		tasks, err := m.app.Tasks.List(opts)
 		if err != nil {
 			return scheduleTasksLoadedMsg{err: err}
 		}
@@ -1037,10 +1285,103 @@ func (m Model) loadScheduleGroups(item SidebarItem, title string, sortOpts []tas
 	return scheduleTasksLoadedMsg{groups: groups, title: title, hideScope: hideScope}
 }
 
+// cycleLocation advances the location filter through the locations of open
+// tasks (all → first → ... → last → all)
+func (m Model) cycleLocation() tea.Msg {
+	locations, err := m.app.Tasks.Locations()
+	if err != nil {
+		return locationChangedMsg{err: err}
+	}
+	next := ""
+	if i := slices.Index(locations, m.location); i+1 < len(locations) {
+		next = locations[i+1]
+	}
+	return locationChangedMsg{location: next}
+}
+
+// reloadConfig re-reads the config file
+func reloadConfig() tea.Msg {
+	cfg, err := config.Load()
+	return configReloadedMsg{cfg: cfg, err: err}
+}
+
+// applyConfig swaps in a reloaded config, rebuilds the theme and rewires
+// the app with the new schedule and recurrence settings. Components share
+// the styles pointer, so updating it in place restyles all of them.
+func (m Model) applyConfig(cfg *config.Config) Model {
+	// --no-color and --accessible aren't in the file, so carry them over
+	// from the running config
+	cfg.Theme.NoColor = cfg.Theme.NoColor || m.config.Theme.NoColor
+	cfg.Accessible = cfg.Accessible || m.config.Accessible
+	// The open database or task directory can't be swapped while running,
+	// which also keeps a --db override
+	cfg.Database, cfg.Storage, cfg.Files = m.config.Database, m.config.Storage, m.config.Files
+	*m.config = *cfg
+
+	theme := output.NewTheme(&cfg.Theme)
+	if cfg.Accessible {
+		theme.SetAccessible()
+	}
+	// Tag colors are kept with the tasks, so this picks up changes too
+	if colors, err := m.app.Tasks.TagColors(); err == nil {
+		theme.SetTagColors(colors)
+	}
+	*m.styles = *NewStyles(theme, &cfg.Theme.TUI)
+	m.help = themedHelp(m.help, theme)
+
+	m.app.Reconfigure(app.ConfigOptions(cfg))
+	m.content = m.content.SetSchedule(m.app.Schedule).SetDateGroups(m.config.DateGroups)
+	m.dateModal = m.dateModal.SetSchedule(m.app.Schedule)
+	m.addModal = m.addModal.SetSchedule(m.app.Schedule).SetDefaults(cfg.Defaults)
+	return m
+}
+
+// themedHelp applies theme colors to the help bar
+func themedHelp(h help.Model, theme *output.Theme) help.Model {
+	h.Styles.ShortKey = theme.Accent
+	h.Styles.ShortDesc = theme.Muted
+	h.Styles.ShortSeparator = theme.Muted
+	return h
+}
+
+// loadPlanning loads unscheduled tasks (inbox, then anytime) and the next seven days
+func (m Model) loadPlanning() tea.Msg {
+	var backlog []task.Task
+	for _, schedule := range []string{"inbox", "anytime"} {
+		tasks, err := m.app.Tasks.List(&task.ListOptions{Schedule: schedule})
+		if err != nil {
+			return planningLoadedMsg{err: err}
+		}
+		backlog = append(backlog, tasks...)
+	}
+
+	today := m.today()
+	week, err := m.app.Tasks.WeekFrom(today, nil)
+	if err != nil {
+		return planningLoadedMsg{err: err}
+	}
+
+	return planningLoadedMsg{backlog: backlog, week: week}
+}
+
+// planTaskOnDay creates a command to set a task's planned date from the planning view
+func (m Model) planTaskOnDay(taskID int64, date time.Time) tea.Cmd {
+	return func() tea.Msg {
+		updated, err := m.app.Tasks.SetPlannedDate(taskID, &date)
+		return taskPlannedOnDayMsg{task: updated, err: err}
+	}
+}
+
+// snoozeTask creates a command to plan a task for tomorrow
+func (m Model) snoozeTask(taskID int64) tea.Cmd {
+	tomorrow := m.today().AddDate(0, 0, 1)
+	return m.setTaskDate(taskID, &tomorrow, DateModalPlanned)
+}
+
 // renameTask creates a command to rename a task
 func (m Model) renameTask(taskID int64, newTitle string) tea.Cmd {
 	return func() tea.Msg {
-		updated, err := m.app.SetTaskTitle.Execute(taskID, newTitle)
+		updated, err := m.app.Tasks.SetTitle(taskID, newTitle)
 		return taskRenamedMsg{task: updated, err: err}
 	}
 }
@@ -1048,7 +1389,7 @@ func (m Model) renameTask(taskID int64, newTitle string) tea.Cmd {
 // renameArea creates a command to rename an area
 func (m Model) renameArea(oldName, newName string) tea.Cmd {
 	return func() tea.Msg {
-		updated, err := m.app.RenameArea.Execute(oldName, newName)
+		updated, err := m.app.Areas.Rename(oldName, newName)
 		return areaRenamedMsg{area: updated, err: err}
 	}
 }
@@ -1061,15 +1402,27 @@ func (m Model) moveTask(taskID int64, itemType, name string) tea.Cmd {
 
 		switch itemType {
 		case "project":
-			updated, err = m.app.SetTaskProject.Execute(taskID, name)
+			updated, err = m.app.Tasks.SetProject(taskID, name)
 		case "area":
-			updated, err = m.app.SetTaskArea.Execute(taskID, name)
+			updated, err = m.app.Tasks.SetArea(taskID, name)
 		}
 
 		return taskMovedMsg{task: updated, err: err}
 	}
 }
 
+// moveToNewProject creates a command to create a project and move a task
+// into it
+func (m Model) moveToNewProject(taskID int64, name, areaName string) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := m.app.Projects.Create(name, &task.CreateProjectOptions{AreaName: areaName}); err != nil {
+			return taskMovedMsg{err: err}
+		}
+		updated, err := m.app.Tasks.SetProject(taskID, name)
+		return taskMovedMsg{task: updated, newProject: true, err: err}
+	}
+}
+
 // setTaskDate creates a command to set a task's planned or due date
 func (m Model) setTaskDate(taskID int64, date *time.Time, mode DateModalMode) tea.Cmd {
 	return func() tea.Msg {
@@ -1078,9 +1431,9 @@ func (m Model) setTaskDate(taskID int64, date *time.Time, mode DateModalMode) te
 
 		switch mode {
 		case DateModalPlanned:
-			updated, err = m.app.SetPlannedDate.Execute(taskID, date)
+			updated, err = m.app.Tasks.SetPlannedDate(taskID, date)
 		case DateModalDue:
-			updated, err = m.app.SetDueDate.Execute(taskID, date)
+			updated, err = m.app.Tasks.SetDueDate(taskID, date)
 		}
 
 		return taskDateUpdatedMsg{task: updated, err: err}
@@ -1097,9 +1450,41 @@ func (m Model) createTask(result *AddResult) tea.Cmd {
 			PlannedDate: result.PlannedDate,
 			DueDate:     result.DueDate,
 			Tags:        result.Tags,
+			Someday:     result.Someday,
+			RecurType:   result.RecurType,
+			RecurRule:   result.RecurRule,
+			RecurCount:  result.RecurCount,
 		}
 
-		created, err := m.app.CreateTask.Execute(result.Title, opts)
+		created, err := m.app.Tasks.Create(result.Title, opts)
+		return taskCreatedMsg{task: created, err: err}
+	}
+}
+
+// quickAddTask creates a command to add a task in the current view: in the
+// selected project or area, with the selected tag, or planned for today
+// from Today and in someday from Someday
+func (m Model) quickAddTask(title string) tea.Cmd {
+	opts := &task.CreateOptions{}
+	item := m.sidebar.SelectedItem()
+	switch item.Type {
+	case "project":
+		opts.ProjectName = item.Key
+	case "area":
+		opts.AreaName = item.Key
+	case "tag":
+		opts.Tags = []string{item.Key}
+	case "static":
+		switch item.Key {
+		case "today":
+			today := m.today()
+			opts.PlannedDate = &today
+		case "someday":
+			opts.Someday = true
+		}
+	}
+	return func() tea.Msg {
+		created, err := m.app.Tasks.Create(title, opts)
 		return taskCreatedMsg{task: created, err: err}
 	}
 }
@@ -1107,10 +1492,10 @@ func (m Model) createTask(result *AddResult) tea.Cmd {
 // createProject creates a command to create a new project
 func (m Model) createProject(result *CreateProjectResult) tea.Cmd {
 	return func() tea.Msg {
-		opts := &taskusecases.CreateProjectOptions{
+		opts := &task.CreateProjectOptions{
 			AreaName: result.AreaName,
 		}
-		created, err := m.app.CreateProject.Execute(result.Name, opts)
+		created, err := m.app.Projects.Create(result.Name, opts)
 		return projectCreatedMsg{project: created, err: err}
 	}
 }
@@ -1118,7 +1503,7 @@ func (m Model) createProject(result *CreateProjectResult) tea.Cmd {
 // createArea creates a command to create a new area
 func (m Model) createArea(result *CreateAreaResult) tea.Cmd {
 	return func() tea.Msg {
-		created, err := m.app.CreateArea.Execute(result.Name)
+		created, err := m.app.Areas.Create(result.Name)
 		return areaCreatedMsg{area: created, err: err}
 	}
 }
@@ -1127,13 +1512,13 @@ func (m Model) createArea(result *CreateAreaResult) tea.Cmd {
 func (m Model) toggleTask(taskID int64, currentStatus task.Status) tea.Cmd {
 	return func() tea.Msg {
 		var err error
-		if currentStatus == task.StatusDone {
-			// Uncomplete the task
-			_, err = m.app.UncompleteTasks.Execute([]int64{taskID})
+		if currentStatus != task.StatusTodo {
+			// Uncomplete the task, or reopen a canceled one
+			_, err = m.app.Tasks.Uncomplete([]int64{taskID})
 			return taskToggledMsg{taskID: taskID, done: false, err: err}
 		}
 		// Complete the task
-		_, err = m.app.CompleteTasks.Execute([]int64{taskID})
+		_, err = m.app.Tasks.Complete([]int64{taskID})
 		return taskToggledMsg{taskID: taskID, done: true, err: err}
 	}
 }
@@ -1144,18 +1529,41 @@ func (m Model) toggleTaskState(taskID int64, currentState task.State) tea.Cmd {
 		var updated *task.Task
 		var err error
 		if currentState == task.StateSomeday {
-			updated, err = m.app.ActivateTask.Execute(taskID)
+			updated, err = m.app.Tasks.Activate(taskID)
 		} else {
-			updated, err = m.app.DeferTask.Execute(taskID)
+			updated, err = m.app.Tasks.Defer(taskID)
 		}
 		return taskStateUpdatedMsg{task: updated, err: err}
 	}
 }
 
+// cycleTaskContext creates a command to advance a task's context label (none → deep → ... → none)
+func (m Model) cycleTaskContext(taskID int64, current *string) tea.Cmd {
+	return func() tea.Msg {
+		next := ""
+		if c := task.NextContext(current); c != nil {
+			next = *c
+		}
+		updated, err := m.app.Tasks.SetContext(taskID, next)
+		return taskContextUpdatedMsg{task: updated, err: err}
+	}
+}
+
+// toggleTaskFlag creates a command to flag or unflag a task
+func (m Model) toggleTaskFlag(taskID int64, flagged bool) tea.Cmd {
+	return func() tea.Msg {
+		changed, err := m.app.Tasks.SetFlagged([]int64{taskID}, !flagged)
+		if err != nil {
+			return taskFlaggedMsg{err: err}
+		}
+		return taskFlaggedMsg{task: &changed[0]}
+	}
+}
+
 // setTaskTags creates a command to set a task's tags
 func (m Model) setTaskTags(taskID int64, tags []string) tea.Cmd {
 	return func() tea.Msg {
-		updated, err := m.app.SetTags.Execute(taskID, tags)
+		updated, err := m.app.Tasks.SetTags(taskID, tags)
 		return taskTagsUpdatedMsg{task: updated, err: err}
 	}
 }
@@ -1163,7 +1571,7 @@ func (m Model) setTaskTags(taskID int64, tags []string) tea.Cmd {
 // setTaskDescription creates a command to set a task's description
 func (m Model) setTaskDescription(taskID int64, description *string) tea.Cmd {
 	return func() tea.Msg {
-		updated, err := m.app.SetTaskDescription.Execute(taskID, description)
+		updated, err := m.app.Tasks.SetDescription(taskID, description)
 		return taskDescriptionUpdatedMsg{task: updated, err: err}
 	}
 }
@@ -1175,9 +1583,9 @@ func (m Model) deleteItem(result *ConfirmResult) tea.Cmd {
 		switch result.Target {
 		case DeleteTargetTask, DeleteTargetProject:
 			// Both tasks and projects use DeleteTasks
-			_, err = m.app.DeleteTasks.Execute([]int64{result.TargetID})
+			_, err = m.app.Tasks.Delete([]int64{result.TargetID})
 		case DeleteTargetArea:
-			_, err = m.app.DeleteArea.Execute(result.TargetName)
+			_, err = m.app.Areas.Delete(result.TargetName)
 		}
 		return itemDeletedMsg{
 			target:     result.Target,
@@ -1205,92 +1613,15 @@ func (m Model) openDetailPane() (tea.Model, tea.Cmd) {
 	// Recalculate layout for three-column mode
 	m = m.recalculateLayout()
 
-	return m, nil
+	return m, m.loadComments(selectedTask.ID)
 }
 
-// recalculateLayout recalculates component sizes based on current state
-func (m Model) recalculateLayout() Model {
-	if m.width == 0 || m.height == 0 {
-		return m
-	}
-
-	// Reserve 1 row for help bar at the bottom
-	helpHeight := 1
-	availableHeight := m.height - helpHeight
-
-	// Calculate sidebar width: 1/4 of total, constrained between min/max
-	sidebarWidth := m.width / 4
-	minSidebar := 20
-	maxSidebar := 40
-
-	if sidebarWidth < minSidebar {
-		sidebarWidth = minSidebar
-	}
-	if sidebarWidth > maxSidebar {
-		sidebarWidth = maxSidebar
-	}
-
-	// Gap between panels
-	gap := 1
-	minContentWidth := 20
-	minDetailWidth := 25
-
-	var contentWidth, detailWidth int
-	if m.detailVisible {
-		// Three-column layout
-		remainingWidth := m.width - sidebarWidth - gap*2
-		contentWidth = remainingWidth * 60 / 100
-		detailWidth = remainingWidth - contentWidth
-
-		if contentWidth < minContentWidth {
-			contentWidth = minContentWidth
-		}
-		if detailWidth < minDetailWidth {
-			detailWidth = minDetailWidth
-		}
-
-		totalNeeded := sidebarWidth + contentWidth + detailWidth + gap*2
-		if totalNeeded > m.width {
-			sidebarWidth = m.width - contentWidth - detailWidth - gap*2
-			if sidebarWidth < 10 {
-				sidebarWidth = 10
-				gap = 0
-				contentWidth = (m.width - sidebarWidth - minDetailWidth) * 60 / 100
-				detailWidth = m.width - sidebarWidth - contentWidth
-			}
-		}
-	} else {
-		// Two-column layout
-		contentWidth = m.width - sidebarWidth - gap
-		if contentWidth < minContentWidth {
-			sidebarWidth = m.width - minContentWidth - gap
-			if sidebarWidth < 10 {
-				sidebarWidth = 10
-				gap = 0
-				contentWidth = m.width - sidebarWidth
-			} else {
-				contentWidth = minContentWidth
-			}
-		}
-	}
-
-	if sidebarWidth < 1 {
-		sidebarWidth = 1
-	}
-	if contentWidth < 1 {
-		contentWidth = 1
-	}
-
-	sidebarHeight := (availableHeight / 3) * 3
-
-	m.sidebar = m.sidebar.SetSize(sidebarWidth, sidebarHeight)
-	m.content = m.content.SetSize(contentWidth, sidebarHeight)
-	if m.detailVisible && detailWidth > 0 {
-		m.detailPane = m.detailPane.SetSize(detailWidth, sidebarHeight)
+// loadComments loads the comments on a task for the detail pane
+func (m Model) loadComments(taskID int64) tea.Cmd {
+	return func() tea.Msg {
+		comments, err := m.app.ListComments.Execute(taskID)
+		return commentsLoadedMsg{taskID: taskID, comments: comments, err: err}
 	}
-	m.gap = gap
-
-	return m
 }
 
 // openDetailFieldModal opens the appropriate modal for the currently focused field
@@ -1327,7 +1658,7 @@ func (m Model) openDetailFieldModal() (tea.Model, tea.Cmd) {
 // loadDataAfterTagUpdate reloads tags and current tasks
 func (m Model) loadDataAfterTagUpdate() tea.Msg {
 	// Reload tags list (may have new tags)
-	tags, err := m.app.ListTags.Execute()
+	tags, err := m.app.Tasks.Tags()
 	if err != nil {
 		return loadDataMsg{err: err}
 	}
@@ -1343,7 +1674,7 @@ func (m Model) loadDataAfterTagUpdate() tea.Msg {
 	opts := m.buildListOptions(item)
 	opts.Sort = sortOpts
 
-	tasks, err := m.app.ListTasks.Execute(opts)
+	tasks, err := m.app.Tasks.List(opts)
 	if err != nil {
 		return loadDataMsg{err: err}
 	}
@@ -1380,6 +1711,10 @@ func (m Model) View() string {
 	// Determine which help keys to show based on current state
 	var helpView string
 	switch {
+	case m.planningView.Active():
+		helpView = m.help.View(planningKeys)
+	case m.focusView.Active():
+		helpView = m.help.View(focusKeys)
 	case m.addModal.Active():
 		helpView = m.help.View(addKeys)
 	case m.renameModal.Active():
@@ -1398,6 +1733,8 @@ func (m Model) View() string {
 		helpView = m.help.View(descriptionKeys)
 	case m.confirmModal.Active():
 		helpView = m.help.View(confirmKeys)
+	case m.quickAdd.Active():
+		helpView = m.help.View(quickAddKeys)
 	case m.createProjectModal.Active():
 		helpView = m.help.View(createProjectKeys)
 	case m.createAreaModal.Active():
@@ -1417,8 +1754,21 @@ func (m Model) View() string {
 	default:
 		helpView = m.help.View(contentKeys)
 	}
+	if m.status != "" {
+		helpView = m.styles.Theme.Muted.Render(truncate(m.status, m.width))
+	}
 	helpView = lipgloss.PlaceHorizontal(m.width, lipgloss.Center, helpView)
 
+	// Render planning view full-screen when active
+	if m.planningView.Active() {
+		return lipgloss.JoinVertical(lipgloss.Left, m.planningView.View(), helpView)
+	}
+
+	// Render focus view full-screen when active
+	if m.focusView.Active() {
+		return lipgloss.JoinVertical(lipgloss.Left, m.focusView.View(), helpView)
+	}
+
 	// Render modal if active (with help bar below)
 	if m.addModal.Active() {
 		return lipgloss.JoinVertical(lipgloss.Left, m.addModal.View(), helpView)
@@ -1448,18 +1798,6 @@ func (m Model) View() string {
 		return lipgloss.JoinVertical(lipgloss.Left, m.createAreaModal.View(), helpView)
 	}
 
-	// Render sidebar and content side by side (gap can be 0 for tight layouts)
-	contentView := lipgloss.NewStyle().MarginLeft(m.gap).Render(m.content.View())
-	var mainView string
-	if m.detailVisible {
-		// Three-column layout: sidebar | content | detail
-		detailView := lipgloss.NewStyle().MarginLeft(m.gap).Render(m.detailPane.View())
-		mainView = lipgloss.JoinHorizontal(lipgloss.Top, m.sidebar.View(), contentView, detailView)
-	} else {
-		// Two-column layout: sidebar | content
-		mainView = lipgloss.JoinHorizontal(lipgloss.Top, m.sidebar.View(), contentView)
-	}
-
 	// Combine main view with help bar at the bottom
-	return lipgloss.JoinVertical(lipgloss.Left, mainView, helpView)
+	return lipgloss.JoinVertical(lipgloss.Left, m.mainView(), helpView)
 }
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/devbydaniel/tt/internal/testutil"
+)
+
+func TestApplyConfigKeepsOverridesAndRewiresSchedule(t *testing.T) {
+	running := &config.Config{Database: "/tmp/other.db", Accessible: true}
+	running.Theme.NoColor = true
+	m := NewModel(app.New(testutil.NewTestDB(t)), output.DefaultTheme(), running)
+
+	reloaded := &config.Config{Database: "/from/file.db", UpcomingDays: 7, DayRolloverHour: 4}
+	m = m.applyConfig(reloaded)
+
+	if m.config.Database != "/tmp/other.db" {
+		t.Errorf("Database = %q, want the running %q", m.config.Database, "/tmp/other.db")
+	}
+	if !m.config.Accessible || !m.config.Theme.NoColor {
+		t.Errorf("Accessible = %v, NoColor = %v; want both kept", m.config.Accessible, m.config.Theme.NoColor)
+	}
+	if got := m.app.Schedule; got.UpcomingDays != 7 || got.DayRolloverHour != 4 {
+		t.Errorf("app schedule = %+v, want the reloaded settings", got)
+	}
+	if m.app.ListTasks.Schedule.UpcomingDays != 7 {
+		t.Errorf("ListTasks.Schedule = %+v, want the reloaded settings", m.app.ListTasks.Schedule)
+	}
+}
+
+func TestLayoutModes(t *testing.T) {
+	application := app.New(testutil.NewTestDB(t))
+	today := time.Now()
+	application.CreateTask.Execute("Write report", &task.CreateOptions{PlannedDate: &today})
+
+	var m tea.Model = NewModel(application, output.DefaultTheme(), &config.Config{})
+	m, _ = m.Update(m.(Model).loadData())
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+
+	compact := m.(Model)
+	if compact.layout != layoutCompact || compact.showSidebar() {
+		t.Fatalf("layout = %v, showSidebar() = %v; want compact without the sidebar", compact.layout, compact.showSidebar())
+	}
+	if got := lipgloss.Width(compact.mainView()); got > 60 {
+		t.Errorf("compact view is %d columns wide, want at most 60", got)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(`\`)})
+	if m.(Model).focusArea != FocusSidebar {
+		t.Error(`\ in the compact layout doesn't switch to the sidebar`)
+	}
+
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 180, Height: 30})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	wide := m.(Model)
+	if wide.layout != layoutWide || !wide.showDetail() {
+		t.Fatalf("layout = %v, showDetail() = %v; want wide with the detail pane", wide.layout, wide.showDetail())
+	}
+	if view := wide.mainView(); !strings.Contains(view, "Details") || lipgloss.Width(view) > 180 {
+		t.Errorf("wide view doesn't fit the detail pane for the selected task:\n%s", view)
+	}
+}
+
+func TestQuickAdd(t *testing.T) {
+	application := app.New(testutil.NewTestDB(t))
+
+	var m tea.Model = NewModel(application, output.DefaultTheme(), &config.Config{})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m, _ = m.Update(m.(Model).loadData())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Buy milk")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter in the quick-add input doesn't add a task")
+	}
+	m, _ = m.Update(cmd())
+	if !m.(Model).quickAdd.Active() {
+		t.Error("quick-add input closed after adding a task")
+	}
+
+	tasks, err := application.ListTasks.Execute(&task.ListOptions{Schedule: "today"})
+	if err != nil {
+		t.Fatalf("ListTasks error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Buy milk" {
+		t.Errorf("Today = %v, want the quick-added task", tasks)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.(Model).quickAdd.Active() || m.(Model).content.input != "" {
+		t.Error("enter on an empty quick-add input doesn't close it")
+	}
+}
+
+func TestScheduleGroupsUseScheduleSort(t *testing.T) {
+	application := app.New(testutil.NewTestDB(t))
+	today := time.Now()
+	application.CreateTask.Execute("Write report", &task.CreateOptions{PlannedDate: &today})
+	application.CreateTask.Execute("Answer mail", &task.CreateOptions{PlannedDate: &today})
+	application.CreateProject.Execute("Home", nil)
+	application.CreateTask.Execute("Water plants", &task.CreateOptions{ProjectName: "Home"})
+	application.CreateTask.Execute("Book flights", &task.CreateOptions{ProjectName: "Home"})
+
+	cfg := &config.Config{ScheduleSort: map[string]string{"today": "title"}}
+	m := NewModel(application, output.DefaultTheme(), cfg)
+	msg := m.loadScheduleGroups(SidebarItem{Type: "static", Key: "all"}, "All", "created", false).(scheduleTasksLoadedMsg)
+	if msg.err != nil {
+		t.Fatalf("loadScheduleGroups error = %v", msg.err)
+	}
+
+	titles := func(tasks []task.Task) []string {
+		var out []string
+		for _, t := range tasks {
+			out = append(out, t.Title)
+		}
+		return out
+	}
+	if got := titles(msg.groups.Today); strings.Join(got, ",") != "Answer mail,Write report" {
+		t.Errorf("Today = %v, want sorted by title", got)
+	}
+	if got := titles(msg.groups.Anytime); strings.Join(got, ",") != "Water plants,Book flights" {
+		t.Errorf("Anytime = %v, want the view's sort", got)
+	}
+}
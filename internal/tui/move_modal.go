@@ -13,7 +13,7 @@ import (
 
 // MoveItem represents a selectable destination
 type MoveItem struct {
-	Type  string // "project" or "area"
+	Type  string // "project", "area", or "new" for a project to create
 	Name  string // actual name for the service call
 	Label string // display text for filtering and rendering
 }
@@ -27,8 +27,14 @@ type MoveModal struct {
 	taskID   int64
 	active   bool
 	styles   *Styles
-	width    int
-	height   int
+
+	// Creating a project to move the task into: its name, while its area
+	// is picked from areas
+	forProject bool // moving a project, which only goes into areas
+	newProject string
+	areas      []MoveItem
+	width      int
+	height     int
 }
 
 // MoveResult represents the outcome of the move modal
@@ -37,6 +43,11 @@ type MoveResult struct {
 	ItemType string // "project" or "area"
 	Name     string
 	Canceled bool
+
+	// NewProject asks to create the project Name, in area AreaName if set,
+	// before moving the task into it
+	NewProject bool
+	AreaName   string
 }
 
 // NewMoveModal creates a new move modal
@@ -56,7 +67,13 @@ func (m MoveModal) Open(taskID int64, projects []task.Task, areas []area.Area) M
 	m.active = true
 	m.taskID = taskID
 	m.selected = 0
+	m.forProject = false
+	m.newProject = ""
 	m.allItems = m.buildItems(projects, areas)
+	m.areas = []MoveItem{{Type: "none", Label: "No area"}}
+	for _, a := range areas {
+		m.areas = append(m.areas, MoveItem{Type: "area", Name: a.Name, Label: a.Name})
+	}
 	m.filtered = m.allItems
 	m.input.SetValue("")
 	m.input.Focus()
@@ -68,6 +85,8 @@ func (m MoveModal) OpenForProject(taskID int64, areas []area.Area) MoveModal {
 	m.active = true
 	m.taskID = taskID
 	m.selected = 0
+	m.forProject = true
+	m.newProject = ""
 	var items []MoveItem
 	for _, a := range areas {
 		items = append(items, MoveItem{
@@ -145,8 +164,15 @@ func (m MoveModal) filterItems() []MoveItem {
 
 	// Return matched items in order of match quality
 	result := make([]MoveItem, len(matches))
+	exact := false
 	for i, match := range matches {
 		result[i] = m.allItems[match.Index]
+		exact = exact || (result[i].Type == "project" && strings.EqualFold(result[i].Name, query))
+	}
+
+	// Offer to create a project by that name, like tags are created
+	if !m.forProject && !exact {
+		result = append(result, MoveItem{Type: "new", Name: query, Label: "Create project '" + query + "'"})
 	}
 
 	return result
@@ -160,6 +186,10 @@ func (m MoveModal) Update(msg tea.Msg) (MoveModal, *MoveResult) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.newProject != "" {
+			return m.updateArea(msg)
+		}
+
 		switch msg.Type {
 		case tea.KeyEscape:
 			m = m.Close()
@@ -168,6 +198,17 @@ func (m MoveModal) Update(msg tea.Msg) (MoveModal, *MoveResult) {
 		case tea.KeyEnter:
 			if len(m.filtered) > 0 && m.selected < len(m.filtered) {
 				item := m.filtered[m.selected]
+				if item.Type == "new" {
+					if len(m.areas) == 1 {
+						// No areas to choose from
+						m = m.Close()
+						return m, &MoveResult{TaskID: m.taskID, ItemType: "project", Name: item.Name, NewProject: true}
+					}
+					m.newProject = item.Name
+					m.selected = 0
+					m.input.Blur()
+					return m, nil
+				}
 				m = m.Close()
 				return m, &MoveResult{
 					TaskID:   m.taskID,
@@ -206,6 +247,33 @@ func (m MoveModal) Update(msg tea.Msg) (MoveModal, *MoveResult) {
 	return m, nil
 }
 
+// updateArea handles keys while picking the area of a new project. Escape
+// goes back to the destinations.
+func (m MoveModal) updateArea(msg tea.KeyMsg) (MoveModal, *MoveResult) {
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.newProject = ""
+		m.selected = len(m.filtered) - 1
+		m.input.Focus()
+	case tea.KeyEnter:
+		result := &MoveResult{TaskID: m.taskID, ItemType: "project", Name: m.newProject, NewProject: true}
+		if item := m.areas[m.selected]; item.Type == "area" {
+			result.AreaName = item.Name
+		}
+		m = m.Close()
+		return m, result
+	case tea.KeyUp:
+		if m.selected > 0 {
+			m.selected--
+		}
+	case tea.KeyDown:
+		if m.selected < len(m.areas)-1 {
+			m.selected++
+		}
+	}
+	return m, nil
+}
+
 // View renders the modal
 func (m MoveModal) View() string {
 	if !m.active {
@@ -214,7 +282,12 @@ func (m MoveModal) View() string {
 
 	title := m.styles.ModalTitle.Render("Move")
 	input := m.input.View()
-	list := m.renderList()
+	list := m.renderList(m.filtered)
+	if m.newProject != "" {
+		title = m.styles.ModalTitle.Render("Area for '" + m.newProject + "'")
+		input = ""
+		list = m.renderList(m.areas)
+	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left, title, input, "", list)
 	modal := m.styles.ModalBorder.Render(content)
@@ -226,8 +299,8 @@ func (m MoveModal) View() string {
 	)
 }
 
-// renderList renders the filtered list of items with fixed height
-func (m MoveModal) renderList() string {
+// renderList renders a list of items with fixed height
+func (m MoveModal) renderList(items []MoveItem) string {
 	const maxVisible = 10
 
 	// Calculate offset for scrolling
@@ -239,15 +312,15 @@ func (m MoveModal) renderList() string {
 	lines := make([]string, maxVisible)
 	for i := 0; i < maxVisible; i++ {
 		idx := offset + i
-		if idx < len(m.filtered) {
-			item := m.filtered[idx]
+		if idx < len(items) {
+			item := items[idx]
 
 			if idx == m.selected {
 				lines[i] = m.styles.SelectedItem.Render("> " + item.Label)
 			} else {
 				lines[i] = "  " + item.Label
 			}
-		} else if i == 0 && len(m.filtered) == 0 {
+		} else if i == 0 && len(items) == 0 {
 			lines[i] = m.styles.Theme.Muted.Render("No matches")
 		} else {
 			lines[i] = "" // Empty line to maintain height
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/devbydaniel/tt/internal/testutil"
+)
+
+func TestMoveModalCreatesProject(t *testing.T) {
+	application := app.New(testutil.NewTestDB(t))
+	application.CreateArea.Execute("Home")
+	created, _ := application.CreateTask.Execute("Paint fence", nil)
+	areas, _ := application.Areas.List()
+
+	mm := NewMoveModal(NewStyles(output.DefaultTheme(), nil)).SetSize(80, 30).Open(created.ID, nil, areas)
+	mm, _ = mm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Garden")})
+	mm, result := mm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if result != nil {
+		t.Fatalf("enter on the create option returned %+v before an area was picked", result)
+	}
+	mm, _ = mm.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, result = mm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if result == nil || !result.NewProject || result.Name != "Garden" || result.AreaName != "Home" {
+		t.Fatalf("result = %+v, want a new project Garden in Home", result)
+	}
+
+	m := NewModel(application, output.DefaultTheme(), &config.Config{})
+	msg := m.moveToNewProject(result.TaskID, result.Name, result.AreaName)().(taskMovedMsg)
+	if msg.err != nil {
+		t.Fatalf("moveToNewProject() error = %v", msg.err)
+	}
+	projects, _ := application.Projects.ListWithArea()
+	if len(projects) != 1 || projects[0].AreaName == nil || *projects[0].AreaName != "Home" {
+		t.Fatalf("projects = %+v, want Garden in Home", projects)
+	}
+	if msg.task.ParentID == nil || *msg.task.ParentID != projects[0].ID {
+		t.Errorf("task project = %v, want Garden (#%d)", msg.task.ParentID, projects[0].ID)
+	}
+}
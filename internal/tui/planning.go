@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// PlanningView is a full-screen weekly planner: unscheduled tasks on the left,
+// the next seven days on the right. Pressing 1-7 plans the selected task on that day.
+type PlanningView struct {
+	active   bool
+	backlog  []task.Task // inbox + anytime tasks
+	week     *task.Week
+	selected int
+	styles   *Styles
+	width    int
+	height   int
+}
+
+// PlanningResult represents an action taken in the planning view
+type PlanningResult struct {
+	TaskID int64
+	Date   time.Time
+	Closed bool
+}
+
+// NewPlanningView creates a new planning view
+func NewPlanningView(styles *Styles) PlanningView {
+	return PlanningView{styles: styles}
+}
+
+// Open shows the planning view
+func (p PlanningView) Open() PlanningView {
+	p.active = true
+	p.selected = 0
+	return p
+}
+
+// Close hides the planning view
+func (p PlanningView) Close() PlanningView {
+	p.active = false
+	return p
+}
+
+// SetData updates the backlog and the days shown, keeping the selection in range
+func (p PlanningView) SetData(backlog []task.Task, week *task.Week) PlanningView {
+	p.backlog = backlog
+	p.week = week
+	if p.selected >= len(p.backlog) {
+		p.selected = len(p.backlog) - 1
+	}
+	if p.selected < 0 {
+		p.selected = 0
+	}
+	return p
+}
+
+// SetSize updates the view dimensions
+func (p PlanningView) SetSize(width, height int) PlanningView {
+	p.width = width
+	p.height = height
+	return p
+}
+
+// Update handles key events
+func (p PlanningView) Update(msg tea.Msg) (PlanningView, *PlanningResult) {
+	if !p.active {
+		return p, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "P":
+		p = p.Close()
+		return p, &PlanningResult{Closed: true}
+	case "k", "up":
+		if p.selected > 0 {
+			p.selected--
+		}
+	case "j", "down":
+		if p.selected < len(p.backlog)-1 {
+			p.selected++
+		}
+	case "1", "2", "3", "4", "5", "6", "7":
+		if p.week == nil || len(p.backlog) == 0 {
+			return p, nil
+		}
+		day := int(keyMsg.String()[0] - '1')
+		return p, &PlanningResult{
+			TaskID: p.backlog[p.selected].ID,
+			Date:   p.week.Start.AddDate(0, 0, day),
+		}
+	}
+
+	return p, nil
+}
+
+// View renders the planning view
+func (p PlanningView) View() string {
+	if !p.active {
+		return ""
+	}
+
+	card := NewCard(p.styles)
+	leftWidth := p.width * 45 / 100
+	rightWidth := p.width - leftWidth - 1
+
+	left := card.Render("Unscheduled", p.renderBacklog(leftWidth-4, p.height-4), leftWidth, p.height, true)
+	right := card.Render("Next 7 days", p.renderDays(), rightWidth, p.height, false)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, " ", right)
+}
+
+// renderBacklog renders the selectable list of unscheduled tasks,
+// scrolled so the selection stays visible
+func (p PlanningView) renderBacklog(width, height int) string {
+	theme := p.styles.Theme
+	if len(p.backlog) == 0 {
+		return theme.Muted.Render("Nothing left to plan")
+	}
+
+	start := 0
+	if height > 0 && p.selected >= height {
+		start = p.selected - height + 1
+	}
+
+	var lines []string
+	for i := start; i < len(p.backlog); i++ {
+		if height > 0 && len(lines) >= height {
+			break
+		}
+		t := p.backlog[i]
+		line := theme.ID.Render(fmt.Sprintf("%d", t.ID)) + "  " + strings.TrimSpace(t.Title)
+		if t.ParentName != nil {
+			line += " " + theme.Scope.Render(*t.ParentName)
+		} else if t.AreaName != nil {
+			line += " " + theme.Scope.Render(*t.AreaName)
+		}
+		if i == p.selected {
+			line = p.styles.SelectedItem.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderDays renders each day with its key, date, and planned task titles
+func (p PlanningView) renderDays() string {
+	theme := p.styles.Theme
+	if p.week == nil {
+		return theme.Muted.Render("Loading...")
+	}
+
+	var lines []string
+	for i, tasks := range p.week.Days {
+		date := p.week.Start.AddDate(0, 0, i)
+		header := theme.Accent.Render(fmt.Sprintf("%d", i+1)) + "  " +
+			theme.Header.Render(date.Format("Mon Jan 2")) +
+			theme.Muted.Render(fmt.Sprintf("  (%d)", len(tasks)))
+		lines = append(lines, header)
+		for _, t := range tasks {
+			lines = append(lines, "   "+theme.Muted.Render("·")+" "+strings.TrimSpace(t.Title))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Active returns whether the planning view is shown
+func (p PlanningView) Active() bool {
+	return p.active
+}
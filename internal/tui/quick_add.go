@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// QuickAdd is a one-line title input at the bottom of the task list. It
+// stays open after each task, so several can be captured in a row.
+type QuickAdd struct {
+	input  textinput.Model
+	active bool
+}
+
+// QuickAddResult represents a title entered in the quick-add input, or the
+// input being closed
+type QuickAddResult struct {
+	Title  string
+	Closed bool
+}
+
+// NewQuickAdd creates a new quick-add input
+func NewQuickAdd(styles *Styles) QuickAdd {
+	ti := textinput.New()
+	ti.Prompt = "+ "
+	ti.PromptStyle = styles.Theme.Muted
+	ti.Placeholder = "New task, enter to add, esc when done"
+	ti.CharLimit = 500
+
+	return QuickAdd{input: ti}
+}
+
+// Open shows the input, empty
+func (q QuickAdd) Open(width int) QuickAdd {
+	q.active = true
+	q.input.Width = max(width-len(q.input.Prompt)-1, 1)
+	q.input.SetValue("")
+	q.input.Focus()
+	return q
+}
+
+// Close hides the input
+func (q QuickAdd) Close() QuickAdd {
+	q.active = false
+	q.input.Blur()
+	return q
+}
+
+// Active returns whether the input is open
+func (q QuickAdd) Active() bool {
+	return q.active
+}
+
+// Update handles input events, returns the updated input and optional result
+func (q QuickAdd) Update(msg tea.Msg) (QuickAdd, *QuickAddResult) {
+	if !q.active {
+		return q, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyEscape:
+			return q.Close(), &QuickAddResult{Closed: true}
+
+		case tea.KeyEnter:
+			title := strings.TrimSpace(q.input.Value())
+			if title == "" {
+				// Enter on an empty line is done, too
+				return q.Close(), &QuickAddResult{Closed: true}
+			}
+			q.input.SetValue("")
+			return q, &QuickAddResult{Title: title}
+		}
+	}
+
+	var cmd tea.Cmd
+	q.input, cmd = q.input.Update(msg)
+	_ = cmd // We handle commands synchronously in the parent
+	return q, nil
+}
+
+// View renders the input line, empty when closed
+func (q QuickAdd) View() string {
+	if !q.active {
+		return ""
+	}
+	return q.input.View()
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// sessionFile keeps where the TUI was left, in the data directory
+const sessionFile = "tui-state.json"
+
+// Session is where the TUI was left, so the next start can return there
+type Session struct {
+	View   string `json:"view"`             // sidebar item as "type:key", e.g. "project:Work"
+	TaskID int64  `json:"task,omitempty"`   // selected task
+	Offset int    `json:"offset,omitempty"` // first visible line of the task list
+	Focus  string `json:"focus,omitempty"`  // "sidebar" (default) or "content"
+}
+
+// staticViews are the lists a view names without a prefix
+var staticViews = []string{"inbox", "today", "upcoming", "anytime", "someday"}
+
+// ParseView reads a view given on the command line or in the config, such
+// as "today", "project:Work", "area:Home" or "tag:urgent", into a session
+// that opens it with the task list focused
+func ParseView(view string) (*Session, error) {
+	kind, name, prefixed := strings.Cut(strings.TrimSpace(view), ":")
+	name = strings.TrimSpace(name)
+	switch {
+	case !prefixed && slices.Contains(staticViews, kind):
+		return &Session{View: "static:" + kind, Focus: "content"}, nil
+	case prefixed && name != "" && (kind == "project" || kind == "area" || kind == "tag"):
+		return &Session{View: kind + ":" + name, Focus: "content"}, nil
+	}
+	return nil, fmt.Errorf("invalid view %q: use %s, or project:, area: or tag: followed by a name", view, strings.Join(staticViews, ", "))
+}
+
+// item splits View into the sidebar item's type and key
+func (s Session) item() (itemType, key string, ok bool) {
+	return strings.Cut(s.View, ":")
+}
+
+// loadSession reads the session saved in dir. A missing or unreadable file
+// starts afresh at Today.
+func loadSession(dir string) *Session {
+	data, err := os.ReadFile(filepath.Join(dir, sessionFile))
+	if err != nil {
+		return nil
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+	return &s
+}
+
+// saveSession writes s to dir
+func saveSession(dir string, s Session) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, sessionFile), append(data, '\n'), 0o644)
+}
+
+// Session returns where the TUI is now
+func (m Model) Session() Session {
+	item := m.sidebar.SelectedItem()
+	s := Session{View: item.Type + ":" + item.Key, Offset: m.content.ScrollOffset(), Focus: "sidebar"}
+	if m.focusArea != FocusSidebar {
+		s.Focus = "content"
+	}
+	if t := m.content.SelectedTask(); t != nil {
+		s.TaskID = t.ID
+	}
+	return s
+}
+
+// WithSession makes the model return to s once its data has loaded
+func (m Model) WithSession(s *Session) Model {
+	m.restore = s
+	return m
+}
+
+// restoreView selects the saved sidebar item, reporting whether it differs
+// from Today, which is loaded at startup anyway
+func (m Model) restoreView() (Model, bool) {
+	if m.restore == nil {
+		return m, false
+	}
+	itemType, key, ok := m.restore.item()
+	if !ok || (itemType == "static" && key == "today") {
+		return m, false
+	}
+	sidebar, ok := m.sidebar.Select(itemType, key)
+	if !ok {
+		return m, false
+	}
+	m.sidebar = sidebar
+	return m, true
+}
+
+// restoreSelection returns focus, the selected task and the scroll
+// position to where they were, once the saved view's tasks are shown
+func (m Model) restoreSelection() Model {
+	s := m.restore
+	if s == nil {
+		return m
+	}
+	m.restore = nil
+
+	if s.Focus == "content" {
+		m.focusArea = FocusContent
+		m.sidebar = m.sidebar.SetFocused(false)
+		m.content = m.content.SetFocused(true)
+	}
+	if s.TaskID != 0 {
+		m.content, _ = m.content.SelectTask(s.TaskID)
+	}
+	m.content = m.content.SetScrollOffset(s.Offset)
+	return m
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/devbydaniel/tt/config"
+	"github.com/devbydaniel/tt/internal/app"
+	"github.com/devbydaniel/tt/internal/domain/task"
+	"github.com/devbydaniel/tt/internal/output"
+	"github.com/devbydaniel/tt/internal/testutil"
+)
+
+func TestSessionRestore(t *testing.T) {
+	application := app.New(testutil.NewTestDB(t))
+	application.CreateProject.Execute("Work", nil)
+	application.CreateTask.Execute("Write report", &task.CreateOptions{ProjectName: "Work"})
+	second, _ := application.CreateTask.Execute("Send invoice", &task.CreateOptions{ProjectName: "Work"})
+
+	saved := Session{View: "project:Work", TaskID: second.ID, Focus: "content"}
+	dir := t.TempDir()
+	if err := saveSession(dir, saved); err != nil {
+		t.Fatalf("saveSession() error = %v", err)
+	}
+
+	var m tea.Model = NewModel(application, output.DefaultTheme(), &config.Config{}).WithSession(loadSession(dir))
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m, cmd := m.Update(m.(Model).loadData())
+	if cmd == nil {
+		t.Fatal("restoring a project view doesn't load its tasks")
+	}
+	m, _ = m.Update(cmd())
+
+	if got := m.(Model).Session(); got != saved {
+		t.Errorf("Session() = %+v, want %+v", got, saved)
+	}
+}
+
+func TestSessionMissingView(t *testing.T) {
+	application := app.New(testutil.NewTestDB(t))
+
+	var m tea.Model = NewModel(application, output.DefaultTheme(), &config.Config{}).WithSession(&Session{View: "project:Gone", Focus: "content"})
+	m, cmd := m.Update(m.(Model).loadData())
+	if cmd != nil {
+		t.Error("restoring a deleted project loads tasks")
+	}
+	if got := m.(Model).Session().View; got != "static:today" {
+		t.Errorf("View = %q, want Today", got)
+	}
+	if loadSession(t.TempDir()) != nil {
+		t.Error("loadSession() without a file returned a session")
+	}
+}
+
+func TestParseView(t *testing.T) {
+	tests := []struct {
+		view string
+		want string // Session.View, "" for an error
+	}{
+		{"today", "static:today"},
+		{"someday", "static:someday"},
+		{"project:Work", "project:Work"},
+		{"area: Home ", "area:Home"},
+		{"tag:urgent", "tag:urgent"},
+		{"tomorrow", ""},
+		{"project:", ""},
+		{"folder:Work", ""},
+	}
+	for _, tt := range tests {
+		s, err := ParseView(tt.view)
+		switch {
+		case tt.want == "" && err == nil:
+			t.Errorf("ParseView(%q) = %+v, want an error", tt.view, s)
+		case tt.want != "" && (err != nil || s.View != tt.want || s.Focus != "content"):
+			t.Errorf("ParseView(%q) = %+v, %v; want view %q with the task list focused", tt.view, s, err, tt.want)
+		}
+	}
+}
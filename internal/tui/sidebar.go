@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/charmbracelet/lipgloss"
@@ -56,6 +57,15 @@ func (s Sidebar) SetData(areas []area.Area, projects []task.Task, tags []string)
 	return s
 }
 
+// SetWIP updates the counts shown next to areas and projects with a WIP
+// limit
+func (s Sidebar) SetWIP(counts []task.WIPCount) Sidebar {
+	if scopes, ok := s.sections[1].(*ScopesSection); ok {
+		s.sections[1] = scopes.SetWIP(counts)
+	}
+	return s
+}
+
 // SetSize updates sidebar dimensions
 func (s Sidebar) SetSize(width, height int) Sidebar {
 	s.width = width
@@ -136,6 +146,22 @@ func (s Sidebar) SelectedItem() SidebarItem {
 	return s.sections[s.activeSection].SelectedItem()
 }
 
+// Select selects the item with the given type and key and activates its
+// section, reporting false (changing nothing) if there is no such item
+func (s Sidebar) Select(itemType, key string) (Sidebar, bool) {
+	for i, section := range s.sections {
+		selected, ok := section.Select(itemType, key)
+		if !ok {
+			continue
+		}
+		s.sections[s.activeSection] = s.sections[s.activeSection].SetFocused(false)
+		s.activeSection = i
+		s.sections[i] = selected.SetFocused(true)
+		return s, true
+	}
+	return s, false
+}
+
 // IsScopesSectionActive returns true if the Scopes section is currently active
 func (s Sidebar) IsScopesSectionActive() bool {
 	return s.activeSection == 1 // Scopes section is index 1
@@ -176,6 +202,18 @@ type Section interface {
 	AtLast() bool
 	SelectFirst() Section
 	SelectLast() Section
+	// Select selects the item with the given type and key, if it has one
+	Select(itemType, key string) (Section, bool)
+}
+
+// findItem returns the index of the item with the given type and key, or -1
+func findItem(items []SidebarItem, itemType, key string) int {
+	for i, item := range items {
+		if item.Type == itemType && item.Key == key {
+			return i
+		}
+	}
+	return -1
 }
 
 // ListsSection shows static list items (Inbox, Today, etc.)
@@ -269,6 +307,15 @@ func (s *ListsSection) SelectLast() Section {
 	return s
 }
 
+func (s *ListsSection) Select(itemType, key string) (Section, bool) {
+	i := findItem(s.items, itemType, key)
+	if i < 0 {
+		return s, false
+	}
+	s.selected = i
+	return s, true
+}
+
 // ScopesSection shows areas and projects
 type ScopesSection struct {
 	items    []SidebarItem
@@ -278,6 +325,7 @@ type ScopesSection struct {
 	width    int
 	offset   int // For scrolling
 	styles   *Styles
+	wip      map[string]task.WIPCount // by "<type>:<key>" of the item
 }
 
 // NewScopesSection creates an empty scopes section
@@ -349,6 +397,16 @@ func (s *ScopesSection) SetData(areas []area.Area, projects []task.Task) *Scopes
 	return s
 }
 
+// SetWIP sets the WIP counts of areas and projects, shown as "3/10" after
+// their names
+func (s *ScopesSection) SetWIP(counts []task.WIPCount) *ScopesSection {
+	s.wip = make(map[string]task.WIPCount, len(counts))
+	for _, c := range counts {
+		s.wip[c.Scope+":"+c.Name] = c
+	}
+	return s
+}
+
 func (s *ScopesSection) View() string {
 	if len(s.items) == 0 {
 		return s.styles.Theme.Muted.Render("  No scopes")
@@ -374,6 +432,13 @@ func (s *ScopesSection) View() string {
 		if i == s.selected && s.focused {
 			line = s.styles.SelectedItem.Render("> " + item.Label)
 		}
+		if c, ok := s.wip[item.Type+":"+item.Key]; ok {
+			style := s.styles.Theme.Muted
+			if c.Count >= c.Limit {
+				style = s.styles.Theme.Warning
+			}
+			line += " " + style.Render(fmt.Sprintf("%d/%d", c.Count, c.Limit))
+		}
 		lines = append(lines, line)
 	}
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
@@ -436,6 +501,15 @@ func (s *ScopesSection) SelectLast() Section {
 	return s
 }
 
+func (s *ScopesSection) Select(itemType, key string) (Section, bool) {
+	i := findItem(s.items, itemType, key)
+	if i < 0 {
+		return s, false
+	}
+	s.selected = i
+	return s, true
+}
+
 // TagsSection shows tags
 type TagsSection struct {
 	items    []SidebarItem
@@ -553,3 +627,12 @@ func (s *TagsSection) SelectLast() Section {
 	}
 	return s
 }
+
+func (s *TagsSection) Select(itemType, key string) (Section, bool) {
+	i := findItem(s.items, itemType, key)
+	if i < 0 {
+		return s, false
+	}
+	s.selected = i
+	return s, true
+}
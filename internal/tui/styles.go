@@ -2,20 +2,27 @@ package tui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/devbydaniel/tt/config"
 	"github.com/devbydaniel/tt/internal/output"
+	datepicker "github.com/ethanefung/bubble-datepicker"
 )
 
 // Styles holds TUI-specific styles extending the base theme
 type Styles struct {
 	Theme *output.Theme
 
+	// Borderless is set when panels are drawn without borders; the focused
+	// panel is then marked by its title color instead
+	Borderless bool
+
 	// Sidebar styles
-	SidebarBorder   lipgloss.Style
-	SectionHeader   lipgloss.Style
-	SelectedItem    lipgloss.Style
-	UnselectedItem  lipgloss.Style
-	FocusedSection  lipgloss.Style
+	SidebarBorder    lipgloss.Style
+	SectionHeader    lipgloss.Style
+	SelectedItem     lipgloss.Style
+	UnselectedItem   lipgloss.Style
+	FocusedSection   lipgloss.Style
 	UnfocusedSection lipgloss.Style
+	FocusedTitle     lipgloss.Style
 
 	// Content styles
 	ContentBorder lipgloss.Style
@@ -25,25 +32,44 @@ type Styles struct {
 	// Modal styles
 	ModalBorder lipgloss.Style
 	ModalTitle  lipgloss.Style
+
+	// DatePicker styles the calendar in the date modal
+	DatePicker datepicker.Styles
 }
 
-// NewStyles creates TUI styles from the base theme
-func NewStyles(theme *output.Theme) *Styles {
+// NewStyles creates TUI styles from the base theme and the [theme.tui] settings
+func NewStyles(theme *output.Theme, cfg *config.TUIStyleConfig) *Styles {
 	if theme == nil {
 		theme = output.DefaultTheme()
 	}
+	if cfg == nil {
+		cfg = &config.TUIStyleConfig{}
+	}
 
 	// Get accent color for focused elements
 	accentColor := theme.Accent.GetForeground()
 	mutedColor := theme.Muted.GetForeground()
 	headerColor := theme.Header.GetForeground()
 
+	focusedColor := colorOr(cfg.FocusedBorder, accentColor)
+	unfocusedColor := colorOr(cfg.UnfocusedBorder, mutedColor)
+	modalColor := colorOr(cfg.ModalBorder, accentColor)
+
+	border, borderless := tuiBorder(cfg.Border)
+	bordered := func(color lipgloss.TerminalColor) lipgloss.Style {
+		if borderless {
+			return lipgloss.NewStyle()
+		}
+		return lipgloss.NewStyle().Border(border).BorderForeground(color)
+	}
+
+	selectedColor := colorOr(cfg.Selected, accentColor)
+
 	return &Styles{
-		Theme: theme,
+		Theme:      theme,
+		Borderless: borderless,
 
-		SidebarBorder: lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(mutedColor),
+		SidebarBorder: bordered(mutedColor),
 
 		SectionHeader: lipgloss.NewStyle().
 			Bold(true).
@@ -52,21 +78,18 @@ func NewStyles(theme *output.Theme) *Styles {
 
 		SelectedItem: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(accentColor),
+			Foreground(selectedColor),
 
 		UnselectedItem: lipgloss.NewStyle(),
 
-		FocusedSection: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(accentColor),
+		FocusedSection:   bordered(focusedColor),
+		UnfocusedSection: bordered(unfocusedColor),
 
-		UnfocusedSection: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(mutedColor),
+		FocusedTitle: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(focusedColor),
 
-		ContentBorder: lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(mutedColor),
+		ContentBorder: bordered(mutedColor),
 
 		ContentHeader: lipgloss.NewStyle().
 			Bold(true).
@@ -75,14 +98,51 @@ func NewStyles(theme *output.Theme) *Styles {
 
 		TaskRow: lipgloss.NewStyle(),
 
-		ModalBorder: lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(accentColor).
+		ModalBorder: bordered(modalColor).
 			Padding(0, 1),
 
 		ModalTitle: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(headerColor).
+			Foreground(colorOr(cfg.ModalTitle, headerColor)).
 			MarginBottom(1),
+
+		DatePicker: datePickerStyles(headerColor, mutedColor, selectedColor),
+	}
+}
+
+// datePickerStyles colors the calendar like the rest of the TUI: month and
+// weekday headers in the header color, other days muted, the chosen day
+// bold and the day under the cursor in the selection color
+func datePickerStyles(header, muted, selected lipgloss.TerminalColor) datepicker.Styles {
+	s := datepicker.DefaultStyles()
+	s.HeaderText = lipgloss.NewStyle().Bold(true).Foreground(header)
+	s.Text = lipgloss.NewStyle().Foreground(muted)
+	s.SelectedText = lipgloss.NewStyle().Bold(true)
+	s.FocusedText = lipgloss.NewStyle().Bold(true).Reverse(true).Foreground(selected)
+	return s
+}
+
+// tuiBorder maps a border name to a lipgloss border. "none" reports borderless;
+// unknown names fall back to rounded.
+func tuiBorder(name string) (lipgloss.Border, bool) {
+	switch name {
+	case "normal":
+		return lipgloss.NormalBorder(), false
+	case "thick":
+		return lipgloss.ThickBorder(), false
+	case "double":
+		return lipgloss.DoubleBorder(), false
+	case "none":
+		return lipgloss.Border{}, true
+	default:
+		return lipgloss.RoundedBorder(), false
+	}
+}
+
+// colorOr parses a configured color, falling back when it is empty
+func colorOr(s string, fallback lipgloss.TerminalColor) lipgloss.TerminalColor {
+	if s == "" {
+		return fallback
 	}
+	return output.ParseColor(s)
 }
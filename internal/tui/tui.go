@@ -1,16 +1,57 @@
 package tui
 
 import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/devbydaniel/tt/config"
 	"github.com/devbydaniel/tt/internal/app"
 	"github.com/devbydaniel/tt/internal/output"
 )
 
-// Run starts the TUI application
-func Run(application *app.App, theme *output.Theme, cfg *config.Config) error {
-	model := NewModel(application, theme, cfg)
+// Run starts the TUI application at view, or where it was left if view is nil
+func Run(application *app.App, theme *output.Theme, cfg *config.Config, view *Session) error {
+	if view == nil {
+		view = loadSession(cfg.DataDir)
+	}
+	state := &crashState{}
+	model := guard{model: NewModel(application, theme, cfg).WithSession(view), state: state}
 	p := tea.NewProgram(model, tea.WithAltScreen())
-	_, err := p.Run()
-	return err
+	state.quit = p.Quit
+
+	// Reload the config on SIGHUP, same as pressing ctrl+r
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-hup:
+				p.Send(reloadConfig())
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	final, err := p.Run()
+	if state.crash == nil {
+		if g, ok := final.(guard); ok && err == nil && cfg.DataDir != "" {
+			if m, ok := g.model.(Model); ok {
+				// Losing the session only means starting at Today next time
+				_ = saveSession(cfg.DataDir, m.Session())
+			}
+		}
+		return err
+	}
+	path, writeErr := state.crash.writeReport(cfg.DataDir)
+	if writeErr != nil {
+		return fmt.Errorf("tt crashed: %v (saving the crash report failed: %v)", state.crash.value, writeErr)
+	}
+	return fmt.Errorf("tt crashed: %v\nA crash report was saved to %s; please include it in a bug report", state.crash.value, path)
 }
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+// defaultYankTemplate is what Y copies unless yank_template is set
+const defaultYankTemplate = `#{{.ID}} {{.Title}}{{with .Due}} — due {{.}}{{end}}{{with .URL}} — {{.}}{{end}}`
+
+// urlPattern finds the first link in a task's description
+var urlPattern = regexp.MustCompile(`https?://[^\s<>()"]+`)
+
+// yankFields are the values a yank template can use
+type yankFields struct {
+	ID      int64
+	Title   string
+	Scope   string // "Area > Project", whichever are set
+	Planned string // e.g. "Jul 3", empty if unset
+	Due     string
+	Tags    string // comma-separated
+	URL     string // first link in the description
+}
+
+// ParseYankTemplate parses a yank template, the default one if tmpl is empty
+func ParseYankTemplate(tmpl string) (*template.Template, error) {
+	if tmpl == "" {
+		tmpl = defaultYankTemplate
+	}
+	return template.New("yank").Option("missingkey=error").Parse(tmpl)
+}
+
+// yankText renders a task with the yank template
+func yankText(tmpl string, t *task.Task) (string, error) {
+	parsed, err := ParseYankTemplate(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	fields := yankFields{ID: t.ID, Title: t.Title, Tags: strings.Join(t.Tags, ", ")}
+	var scope []string
+	if t.AreaName != nil {
+		scope = append(scope, *t.AreaName)
+	}
+	if t.ParentName != nil {
+		scope = append(scope, *t.ParentName)
+	}
+	fields.Scope = strings.Join(scope, " > ")
+	if t.PlannedDate != nil {
+		fields.Planned = t.PlannedDate.Format("Jan 2")
+	}
+	if t.DueDate != nil {
+		fields.Due = t.DueDate.Format("Jan 2")
+	}
+	if t.Description != nil {
+		// Punctuation after a link ends the sentence, not the link
+		fields.URL = strings.TrimRight(urlPattern.FindString(*t.Description), ".,;:!?")
+	}
+
+	var b strings.Builder
+	if err := parsed.Execute(&b, fields); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// yankedMsg reports the text copied to the clipboard
+type yankedMsg struct {
+	text string
+	err  error
+}
+
+// yankTask copies a task to the system clipboard. Without a clipboard tool,
+// e.g. over SSH, it asks the terminal to copy it instead.
+func (m Model) yankTask(t *task.Task) tea.Cmd {
+	tmpl := m.config.YankTemplate
+	return func() tea.Msg {
+		text, err := yankText(tmpl, t)
+		if err != nil {
+			return yankedMsg{err: err}
+		}
+		if err := clipboard.WriteAll(text); err != nil {
+			if _, err := osc52.New(text).WriteTo(os.Stderr); err != nil {
+				return yankedMsg{err: err}
+			}
+		}
+		return yankedMsg{text: text}
+	}
+}
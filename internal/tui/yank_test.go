@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devbydaniel/tt/internal/domain/task"
+)
+
+func TestYankText(t *testing.T) {
+	due := time.Date(2026, 7, 3, 0, 0, 0, 0, time.Local)
+	desc := "Draft in https://docs.example.com/q3, then review"
+	project := "Reports"
+	tk := &task.Task{ID: 12, Title: "Write report", DueDate: &due, Description: &desc, ParentName: &project, Tags: []string{"work", "q3"}}
+
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{"", "#12 Write report — due Jul 3 — https://docs.example.com/q3"},
+		{"{{.Title}} ({{.Scope}}; {{.Tags}})", "Write report (Reports; work, q3)"},
+	}
+	for _, tt := range tests {
+		got, err := yankText(tt.tmpl, tk)
+		if err != nil {
+			t.Fatalf("yankText(%q) error = %v", tt.tmpl, err)
+		}
+		if got != tt.want {
+			t.Errorf("yankText(%q) = %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+
+	if _, err := ParseYankTemplate("{{.Title"); err == nil {
+		t.Error("ParseYankTemplate() accepted an unclosed action")
+	}
+}